@@ -0,0 +1,248 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/config"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// What happens to the quizzes the user created when the account is erased
+const (
+	QuizHandlingTransfer  = "transfer"
+	QuizHandlingDelete    = "delete"
+	QuizHandlingAnonymize = "anonymize"
+)
+
+// The confirmation link has to be used within a day
+const erasureConfirmationLifetime = 24 * time.Hour
+
+// How often the worker looks for accounts past their grace period
+const erasureWorkerInterval = 10 * time.Minute
+
+var erasureGracePeriod = config.Default().Erasure.GracePeriod
+
+// The account endpoints and the erasure worker with what they read and write
+type Handlers struct {
+	repository Repository
+	users      auth.UserRepository
+	auditLog   audit.Log
+}
+
+func New(repository Repository, users auth.UserRepository, auditLog audit.Log) *Handlers {
+	return &Handlers{repository: repository, users: users, auditLog: auditLog}
+}
+
+type Erasure struct {
+	UserId       string
+	Token        string
+	QuizHandling string
+	TransferTo   *string
+	RequestedAt  time.Time
+	ConfirmedAt  *time.Time
+	EraseAfter   *time.Time
+}
+
+type ErasureRequestBody struct {
+	QuizHandling string `json:"quizHandling"`
+	// Email of the user receiving the quizzes when they are transferred
+	TransferTo string `json:"transferTo"`
+}
+
+type ConfirmErasureBody struct {
+	Token string `json:"token"`
+}
+
+type ErasureResponse struct {
+	QuizHandling    string     `json:"quizHandling"`
+	TransferToEmail string     `json:"transferToEmail,omitempty"`
+	RequestedAt     time.Time  `json:"requestedAt"`
+	ConfirmedAt     *time.Time `json:"confirmedAt"`
+	EraseAfter      *time.Time `json:"eraseAfter"`
+}
+
+// Sets the grace period between the confirmation and the erasure
+func InitErasureConfig(cfg config.Erasure) {
+	erasureGracePeriod = cfg.GracePeriod
+}
+
+func (h *Handlers) mapErasure(ctx context.Context, erasure *Erasure) ErasureResponse {
+	response := ErasureResponse{
+		QuizHandling: erasure.QuizHandling,
+		RequestedAt:  erasure.RequestedAt,
+		ConfirmedAt:  erasure.ConfirmedAt,
+		EraseAfter:   erasure.EraseAfter,
+	}
+	if erasure.TransferTo != nil {
+		if recipient, err := h.users.GetUserById(ctx, *erasure.TransferTo); err == nil {
+			response.TransferToEmail = recipient.Email
+		}
+	}
+	return response
+}
+
+// Returns the pending erasure of the current user
+func (h *Handlers) GetErasureEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	erasure, err := h.repository.GetErasure(ctx, auth.CurrentUserId(c))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NotFound("no erasure requested")
+		}
+		return apperror.Internal(err)
+	}
+	return c.JSON(http.StatusOK, h.mapErasure(ctx, erasure))
+}
+
+// Starts the erasure of the current user's account. Nothing happens until the
+// link in the confirmation email is opened, and the account is only erased
+// after the grace period.
+func (h *Handlers) RequestErasureEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := auth.CurrentUser(c)
+	var request = ErasureRequestBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+
+	erasure := Erasure{
+		UserId:       user.Id,
+		Token:        uuid.NewString(),
+		QuizHandling: request.QuizHandling,
+	}
+	switch request.QuizHandling {
+	case QuizHandlingDelete, QuizHandlingAnonymize:
+	case QuizHandlingTransfer:
+		recipient, err := h.users.GetUserByEmail(ctx, strings.TrimSpace(request.TransferTo))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperror.BadRequest("no user with this email")
+			}
+			return apperror.Internal(err)
+		}
+		if recipient.Id == user.Id {
+			return apperror.BadRequest("you cannot transfer the quizzes to yourself")
+		}
+		if !recipient.EmailVerified || recipient.DisabledAt != nil {
+			return apperror.BadRequest("the quizzes can only be transferred to an active account")
+		}
+		erasure.TransferTo = &recipient.Id
+	default:
+		return apperror.BadRequest("quiz handling must be transfer, delete or anonymize")
+	}
+
+	if err := h.repository.UpsertErasure(ctx, &erasure); err != nil {
+		return apperror.Internal(err)
+	}
+	gracePeriodDays := int(erasureGracePeriod.Hours() / 24)
+	err := auth.GetEmailVerificationService().SendErasureConfirmationEmail(user.Email, user.Name, erasure.Token, gracePeriodDays)
+	if err != nil {
+		return apperror.Internalf("failed to send confirmation email: %w", err)
+	}
+	auth.Audit(c, h.auditLog, audit.Event{
+		Action:     audit.ErasureRequested,
+		TargetType: audit.TargetUser,
+		TargetId:   user.Id,
+		Diff:       audit.Details(map[string]any{"quizHandling": erasure.QuizHandling, "transferTo": erasure.TransferTo}),
+	})
+	return c.JSON(http.StatusOK, h.mapErasure(ctx, &erasure))
+}
+
+// Confirms the erasure with the token of the email, the grace period starts now
+func (h *Handlers) ConfirmErasureEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	var request = ConfirmErasureBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+	if request.Token == "" {
+		return apperror.BadRequest("token is required")
+	}
+	erasure, err := h.repository.ConfirmErasure(ctx, request.Token, time.Now().Add(-erasureConfirmationLifetime), erasureGracePeriod)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NotFound("invalid or expired confirmation link")
+		}
+		return apperror.Internal(err)
+	}
+	auth.Audit(c, h.auditLog, audit.Event{
+		ActorId:    &erasure.UserId,
+		Action:     audit.ErasureConfirmed,
+		TargetType: audit.TargetUser,
+		TargetId:   erasure.UserId,
+		Diff:       audit.Details(map[string]any{"eraseAfter": erasure.EraseAfter}),
+	})
+	return c.JSON(http.StatusOK, h.mapErasure(ctx, erasure))
+}
+
+// Cancels the erasure, possible until the grace period is over
+func (h *Handlers) CancelErasureEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := auth.CurrentUserId(c)
+	deleted, err := h.repository.DeleteErasure(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if deleted == 0 {
+		return apperror.NotFound("no erasure requested")
+	}
+	auth.Audit(c, h.auditLog, audit.Event{Action: audit.ErasureCancelled, TargetType: audit.TargetUser, TargetId: userId})
+	return c.NoContent(http.StatusOK)
+}
+
+// Erases the accounts past their grace period every few minutes until the context ends
+func (h *Handlers) RunErasureWorker(ctx context.Context) {
+	ticker := time.NewTicker(erasureWorkerInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := h.EraseDueAccounts(ctx); err != nil {
+			slog.Error("failed to erase accounts", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Erases every account whose grace period is over and returns how many were
+// erased. Each account is erased in its own transaction, rows are locked so
+// several backend instances can run this at the same time. Cancelling ctx
+// stops after the account being erased, that one is still finished.
+func (h *Handlers) EraseDueAccounts(ctx context.Context) (int, error) {
+	erased := 0
+	work := context.WithoutCancel(ctx)
+	for ctx.Err() == nil {
+		userId, err := h.repository.EraseNextDueAccount(work)
+		if err != nil {
+			return erased, err
+		}
+		if userId == "" {
+			return erased, nil
+		}
+		erased++
+		// The actor stays in the log as a bare id, the trail has to outlive the account
+		err = audit.Record(work, h.auditLog, &audit.Event{
+			ActorId:    &userId,
+			Action:     audit.AccountDeleted,
+			TargetType: audit.TargetUser,
+			TargetId:   userId,
+		})
+		if err != nil {
+			slog.Error("failed to record erasure", "userId", userId, "error", err)
+		}
+	}
+	return erased, nil
+}
@@ -0,0 +1,117 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// A file of the export with the rows it holds, $1 is the id of the user.
+// Secrets like password hashes, token hashes and TOTP secrets are left out.
+type exportFile struct {
+	name   string
+	query  string
+	single bool
+}
+
+var exportFiles = []exportFile{
+	{name: "profile.json", single: true, query: `
+		SELECT id, name, email, email_verified, plan, role, disabled_at FROM users WHERE id=$1`},
+	{name: "quizzes.json", query: `
+		SELECT q.id, q.name, q.description,
+			(SELECT json_agg(s) FROM single_choice_questions s WHERE s.quizid=q.id) AS single_choice_questions,
+			(SELECT json_agg(m) FROM multiple_choice_questions m WHERE m.quizid=q.id) AS multiple_choice_questions,
+			(SELECT json_agg(t) FROM true_or_false_questions t WHERE t.quizid=q.id) AS true_or_false_questions
+		FROM quizzes q WHERE q.creatorid=$1`},
+	{name: "question_revisions.json", query: `
+		SELECT question_id, question_type, revision, author_id, snapshot, restored_from, created_at
+		FROM question_revisions WHERE author_id=$1 OR question_id IN (
+			SELECT s.uuid FROM single_choice_questions s JOIN quizzes q ON q.id=s.quizid WHERE q.creatorid=$1
+			UNION ALL SELECT m.uuid FROM multiple_choice_questions m JOIN quizzes q ON q.id=m.quizid WHERE q.creatorid=$1
+			UNION ALL SELECT t.uuid FROM true_or_false_questions t JOIN quizzes q ON q.id=t.quizid WHERE q.creatorid=$1)
+		ORDER BY question_id, revision`},
+	{name: "quiz_accesses.json", query: `
+		SELECT quizid AS quiz_id, roleid AS role_id FROM quiz_accesses WHERE userid=$1`},
+	{name: "learn_list.json", query: `
+		SELECT quiz_id FROM learn_list_added_items WHERE user_id=$1`},
+	{name: "quiz_sessions.json", query: `
+		SELECT id, quiz_id, started_at, finished_at, closes_at FROM quiz_sessions WHERE user_id=$1`},
+	{name: "answers/single_choice.json", query: `
+		SELECT a.* FROM single_choice_answers a JOIN quiz_sessions s ON s.id=a.session_id WHERE s.user_id=$1`},
+	{name: "answers/multiple_choice.json", query: `
+		SELECT a.* FROM multiple_choice_answers a JOIN quiz_sessions s ON s.id=a.session_id WHERE s.user_id=$1`},
+	{name: "answers/true_or_false.json", query: `
+		SELECT a.* FROM true_or_false_answers a JOIN quiz_sessions s ON s.id=a.session_id WHERE s.user_id=$1`},
+	{name: "quiz_results.json", query: `
+		SELECT r.*, (SELECT json_agg(sc) FROM answer_scores sc WHERE sc.quiz_result_id=r.id) AS answer_scores
+		FROM quiz_results r JOIN quiz_sessions s ON s.id=r.session_id WHERE s.user_id=$1`},
+	{name: "review_items.json", query: `
+		SELECT id, single_choice_question_id, multiple_choice_question_id, true_or_false_question_id,
+			ease_factor, difficulty, streak, next_review_date, interval_in_minutes
+		FROM review_items WHERE user_id=$1`},
+	{name: "question_generations.json", query: `
+		SELECT quiz_id, question_type, prompt_chars, latency_ms, success, created_at FROM llm_usage WHERE user_id=$1`},
+	{name: "login_sessions.json", query: `
+		SELECT public_id AS id, created_at, last_seen_at, valid_until, remember_me, user_agent, ip_address
+		FROM sessions WHERE user_id=$1 AND impersonator_id IS NULL`},
+	{name: "identities.json", query: `
+		SELECT provider, subject, email, created_at, last_login_at FROM identities WHERE user_id=$1`},
+	{name: "api_tokens.json", query: `
+		SELECT name, token_prefix, scopes, created_at, last_used_at, expires_at, revoked_at FROM api_tokens WHERE user_id=$1`},
+	{name: "two_factor.json", query: `
+		SELECT confirmed_at FROM totp_credentials WHERE user_id=$1`},
+	{name: "activity.json", query: `
+		SELECT action, target_type, target_id, ip_address, diff, created_at FROM audit_events
+		WHERE actor_id=$1 OR (target_type='user' AND target_id=$1::text) ORDER BY created_at`},
+}
+
+// Returns a zip of every record tied to the current user, one JSON file per kind of record
+func (h *Handlers) ExportDataEndpoint(c echo.Context) error {
+	userId := auth.CurrentUserId(c)
+	archive, err := h.buildExport(c.Request().Context(), userId)
+	if err != nil {
+		return apperror.Internalf("exporting the data of %s: %w", userId, err)
+	}
+	auth.Audit(c, h.auditLog, audit.Event{Action: audit.DataExported, TargetType: audit.TargetUser, TargetId: userId})
+
+	filename := fmt.Sprintf("spacedace-export-%s.zip", time.Now().UTC().Format("2006-01-02"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Blob(http.StatusOK, "application/zip", archive)
+}
+
+// Zips the files of the export, the JSON is indented for the people reading it
+func (h *Handlers) buildExport(ctx context.Context, userId string) ([]byte, error) {
+	files, err := h.repository.ExportFiles(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	archive := zip.NewWriter(buffer)
+	for _, file := range files {
+		indented := new(bytes.Buffer)
+		if err := json.Indent(indented, file.Content, "", "  "); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		writer, err := archive.Create(file.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(indented.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,176 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/quiz"
+	"spaced-ace-backend/store"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// A file of the export with its rows as JSON
+type ExportFile struct {
+	Name    string
+	Content json.RawMessage
+}
+
+// The erasures and the data of the exports, PostgresRepository on the server
+// and an in-memory fake in the handler tests
+type Repository interface {
+	GetErasure(ctx context.Context, userId string) (*Erasure, error)
+	// A new request replaces the previous one, the old confirmation link stops working
+	UpsertErasure(ctx context.Context, erasure *Erasure) error
+	// Confirms the unconfirmed erasure requested after requestedAfter, it is
+	// due once the grace period is over
+	ConfirmErasure(ctx context.Context, token string, requestedAfter time.Time, gracePeriod time.Duration) (*Erasure, error)
+	DeleteErasure(ctx context.Context, userId string) (int64, error)
+	// Returns every file of the export of the user, read in one snapshot so
+	// the files agree with each other
+	ExportFiles(ctx context.Context, userId string) ([]ExportFile, error)
+	// Erases the account whose grace period is over first and returns its
+	// id, empty when no account is due
+	EraseNextDueAccount(ctx context.Context) (string, error)
+}
+
+type PostgresRepository struct {
+	store *store.Store
+}
+
+func NewPostgresRepository(s *store.Store) *PostgresRepository {
+	return &PostgresRepository{store: s}
+}
+
+func (r *PostgresRepository) GetErasure(ctx context.Context, userId string) (*Erasure, error) {
+	erasure, err := r.store.GetErasure(ctx, userId)
+	return mapDBErasure(erasure), err
+}
+
+func (r *PostgresRepository) UpsertErasure(ctx context.Context, erasure *Erasure) error {
+	upserted, err := r.store.UpsertErasure(ctx, db.UpsertErasureParams{
+		UserID:       erasure.UserId,
+		Token:        erasure.Token,
+		QuizHandling: erasure.QuizHandling,
+		TransferTo:   erasure.TransferTo,
+	})
+	if err != nil {
+		return err
+	}
+	*erasure = *mapDBErasure(upserted)
+	return nil
+}
+
+func (r *PostgresRepository) ConfirmErasure(ctx context.Context, token string, requestedAfter time.Time, gracePeriod time.Duration) (*Erasure, error) {
+	erasure, err := r.store.ConfirmErasure(ctx, db.ConfirmErasureParams{
+		Token:              token,
+		GracePeriodSeconds: gracePeriod.Seconds(),
+		RequestedAfter:     store.Timestamptz(&requestedAfter),
+	})
+	return mapDBErasure(erasure), err
+}
+
+func (r *PostgresRepository) DeleteErasure(ctx context.Context, userId string) (int64, error) {
+	return r.store.DeleteErasure(ctx, userId)
+}
+
+func (r *PostgresRepository) ExportFiles(ctx context.Context, userId string) ([]ExportFile, error) {
+	tx, err := r.store.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	files := make([]ExportFile, 0, len(exportFiles))
+	for _, file := range exportFiles {
+		query := "SELECT coalesce(json_agg(t), '[]'::json) FROM (" + file.query + ") t"
+		if file.single {
+			query = "SELECT row_to_json(t) FROM (" + file.query + ") t"
+		}
+		var content []byte
+		if err := tx.QueryRow(ctx, query, userId).Scan(&content); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.name, err)
+		}
+		files = append(files, ExportFile{Name: file.name, Content: content})
+	}
+	return files, nil
+}
+
+// Rows are locked so several backend instances can erase at the same time
+func (r *PostgresRepository) EraseNextDueAccount(ctx context.Context) (string, error) {
+	userId := ""
+	err := r.store.WithTx(ctx, func(tx *store.Tx) error {
+		erasure, err := tx.LockNextDueErasure(ctx)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		if err := eraseAccount(ctx, tx, mapDBErasure(erasure)); err != nil {
+			return fmt.Errorf("erasing %s: %w", erasure.UserID, err)
+		}
+		userId = erasure.UserID
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return userId, nil
+}
+
+// Deletes the user with everything tied to the account. The quizzes the user
+// created are handed to the recipient, deleted, or kept without a creator.
+func eraseAccount(ctx context.Context, tx *store.Tx, erasure *Erasure) error {
+	switch {
+	case erasure.QuizHandling == QuizHandlingTransfer && erasure.TransferTo != nil:
+		err := tx.TransferQuizAccesses(ctx, db.TransferQuizAccessesParams{
+			UserID:      erasure.UserId,
+			RecipientID: *erasure.TransferTo,
+			Roleid:      int16(quiz.QUIZ_OWNER_ACCESS_ID),
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.TransferQuizzes(ctx, db.TransferQuizzesParams{UserID: erasure.UserId, RecipientID: *erasure.TransferTo})
+		if err != nil {
+			return err
+		}
+	case erasure.QuizHandling == QuizHandlingDelete:
+		// Other users took these quizzes too, their sessions and answers go with
+		// the quizzes. The revisions of the questions have no foreign key.
+		if err := tx.DeleteQuestionRevisionsOfCreator(ctx, erasure.UserId); err != nil {
+			return err
+		}
+		if err := tx.DeleteQuizzesOfCreator(ctx, &erasure.UserId); err != nil {
+			return err
+		}
+	default:
+		// Anonymized quizzes keep working for everyone who has them, the
+		// creator is cleared by the foreign key when the user is deleted
+	}
+
+	if err := tx.DeleteLoginFailuresOfUser(ctx, erasure.UserId); err != nil {
+		return err
+	}
+	// Sessions, answers, results, review items and the rest cascade from the user
+	return tx.DeleteUser(ctx, erasure.UserId)
+}
+
+func mapDBErasure(erasure *db.AccountErasure) *Erasure {
+	if erasure == nil {
+		return &Erasure{}
+	}
+	return &Erasure{
+		UserId:       erasure.UserID,
+		Token:        erasure.Token,
+		QuizHandling: erasure.QuizHandling,
+		TransferTo:   erasure.TransferTo,
+		RequestedAt:  erasure.RequestedAt.Time,
+		ConfirmedAt:  store.TimePtr(erasure.ConfirmedAt),
+		EraseAfter:   store.TimePtr(erasure.EraseAfter),
+	}
+}
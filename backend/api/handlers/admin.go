@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const adminQuizzesPageSize = 50
+
+// Searches every quiz by name or creator email, `q` is the query and `page` starts at 1
+func (h *Handlers) AdminSearchQuizzesEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	// One extra row tells whether there is a next page
+	quizzes, err := h.deps.Quizzes.SearchQuizzes(ctx, c.QueryParam("q"), adminQuizzesPageSize+1, (page-1)*adminQuizzesPageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	response := models.AdminQuizzesResponse{
+		Quizzes: []models.AdminQuizInfo{},
+		Page:    page,
+		HasMore: len(quizzes) > adminQuizzesPageSize,
+	}
+	for i, q := range quizzes {
+		if i == adminQuizzesPageSize {
+			break
+		}
+		creatorName := q.CreatorName.String
+		if !q.CreatorName.Valid {
+			creatorName = "Deleted"
+		}
+		response.Quizzes = append(response.Quizzes, models.AdminQuizInfo{
+			QuizInfo: models.QuizInfo{
+				Id:          q.Id,
+				Title:       q.Name,
+				Description: q.Description.String,
+				CreatorId:   q.CreatorId.String,
+				CreatorName: creatorName,
+				Status:      q.Status,
+			},
+			CreatorEmail: q.CreatorEmail.String,
+		})
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// Deletes the quiz of any user
+func (h *Handlers) AdminDeleteQuizEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	quizId := c.Param("id")
+	if _, err := uuid.Parse(quizId); err != nil {
+		return apperror.NotFound("quiz not found")
+	}
+	deleted, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NotFound("quiz not found")
+		}
+		return apperror.Internal(err)
+	}
+	if err := h.deleteQuiz(c, quizId); err != nil {
+		return err
+	}
+	h.auditQuiz(c, audit.AdminQuizDeleted, quizId, deleted, nil)
+	return c.NoContent(http.StatusOK)
+}
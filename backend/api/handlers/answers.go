@@ -3,17 +3,16 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"golang.org/x/net/context"
 	"io"
-	"log"
 	"net/http"
 	"slices"
 	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
 	"spaced-ace-backend/db"
-	"spaced-ace-backend/utils"
 	"time"
 )
 
@@ -34,270 +33,295 @@ type TrueOrFalseAnswerRequestBody struct {
 	Answer *bool `json:"answer"`
 }
 
-func PutCreateOrUpdateAnswer(c echo.Context) error {
+func (h *Handlers) PutCreateOrUpdateAnswer(c echo.Context) error {
 	quizSessionId := c.Param("quizSessionId")
 	if quizSessionId == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing path param quizSessionId")
+		return apperror.BadRequest("missing path param quizSessionId")
 	}
 
 	bodyBytes, err := io.ReadAll(c.Request().Body)
 	if err != nil {
-		log.Default().Println(err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("bad request: %s", err.Error()))
+		return apperror.BadRequest("invalid request body").WithCause(err)
 	}
 	c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
 	var answerRequestBody AnswerRequestBody
 	err = json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&answerRequestBody)
 	if err != nil {
-		log.Default().Println(err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("bad request: %s", err.Error()))
+		return apperror.BadRequest("invalid request body").WithCause(err)
 	}
 
 	if answerRequestBody.AnswerType == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing body param answerType")
+		return apperror.BadRequest("missing body param answerType")
 	} else if !slices.Contains([]string{"single-choice", "multiple-choice", "true-or-false"}, answerRequestBody.AnswerType) {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid questionType: `%s`", answerRequestBody.AnswerType))
+		return apperror.BadRequest(fmt.Sprintf("invalid questionType: `%s`", answerRequestBody.AnswerType))
 	}
 	if answerRequestBody.QuestionId == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing body param questionId")
+		return apperror.BadRequest("missing body param questionId")
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	quizSession, err := sqlcQuerier.GetQuizSession(ctx, quizSessionId)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("quizSession not found: %s", err))
-	}
-	if quizSession.FinishedAt.Valid {
-		return echo.NewHTTPError(http.StatusForbidden, "modifying answer for a submitted quiz is not allowed")
+	if _, err := h.authorizeQuizSession(ctx, c, quizSessionId); err != nil {
+		return err
 	}
 
+	// Writes the answer, run with the session locked
+	var write func(tx Dependencies) (any, error)
 	switch answerRequestBody.AnswerType {
 	case "single-choice":
 		var requestBody SingleChoiceAnswerRequestBody
 		if err := json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&requestBody); err != nil {
-			log.Default().Println(err.Error())
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("error parsing single-choice answer: %s", err.Error()))
+			return apperror.BadRequest("invalid single-choice answer").WithCause(err)
 		}
 
 		if !slices.Contains([]string{"A", "B", "C", "D"}, requestBody.Answer) {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid answer: `%s` for single-choice question", requestBody.Answer))
+			return apperror.BadRequest(fmt.Sprintf("invalid answer: `%s` for single-choice question", requestBody.Answer))
 		}
 
-		oldAnswer, err := sqlcQuerier.GetSingleChoiceAnswerBySessionAndQuestionId(
-			ctx,
-			db.GetSingleChoiceAnswerBySessionAndQuestionIdParams{
-				SessionID:  quizSessionId,
-				QuestionID: requestBody.QuestionId,
-			},
-		)
-
-		var answer *db.SingleChoiceAnswer
-		var dbError error
-
-		if err == nil {
-			answer, dbError = sqlcQuerier.UpdateSingleChoiceAnswerBySessionAndQuestionId(
+		write = func(tx Dependencies) (any, error) {
+			oldAnswer, err := tx.Answers.GetSingleChoiceAnswerBySessionAndQuestionId(
 				ctx,
-				db.UpdateSingleChoiceAnswerBySessionAndQuestionIdParams{
-					SessionID:  oldAnswer.SessionID,
-					QuestionID: oldAnswer.QuestionID,
-					Answer:     []string{requestBody.Answer},
-				},
-			)
-		} else {
-			answer, dbError = sqlcQuerier.CreateSingleChoiceAnswer(
-				ctx,
-				db.CreateSingleChoiceAnswerParams{
-					ID:         uuid.NewString(),
+				db.GetSingleChoiceAnswerBySessionAndQuestionIdParams{
 					SessionID:  quizSessionId,
 					QuestionID: requestBody.QuestionId,
-					Answer:     []string{requestBody.Answer},
 				},
 			)
-		}
 
-		if dbError != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("db error: %s", dbError))
-		}
+			var answer *db.SingleChoiceAnswer
+			var dbError error
+
+			if err == nil {
+				answer, dbError = tx.Answers.UpdateSingleChoiceAnswerBySessionAndQuestionId(
+					ctx,
+					db.UpdateSingleChoiceAnswerBySessionAndQuestionIdParams{
+						SessionID:  oldAnswer.SessionID,
+						QuestionID: oldAnswer.QuestionID,
+						Answer:     []string{requestBody.Answer},
+					},
+				)
+			} else {
+				answer, dbError = tx.Answers.CreateSingleChoiceAnswer(
+					ctx,
+					db.CreateSingleChoiceAnswerParams{
+						ID:         uuid.NewString(),
+						SessionID:  quizSessionId,
+						QuestionID: requestBody.QuestionId,
+						Answer:     []string{requestBody.Answer},
+					},
+				)
+			}
 
-		result, err := models.MapSingleChoiceAnswer(answer)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err)
+			if dbError != nil {
+				return nil, dbError
+			}
+
+			result, err := models.MapSingleChoiceAnswer(answer)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
 		}
-		return c.JSON(http.StatusOK, result)
 	case "multiple-choice":
 		var requestBody MultipleChoiceAnswerRequestBody
 		if err := json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&requestBody); err != nil {
-			log.Default().Println(err.Error())
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("error parsing multiple-choice answer: %s", err.Error()))
+			return apperror.BadRequest("invalid multiple-choice answer").WithCause(err)
 		}
 
 		seen := make(map[string]bool)
 		for _, answer := range requestBody.Answers {
 			if !slices.Contains([]string{"A", "B", "C", "D"}, answer) {
-				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid answer: `%s` for multiple-choice question", answer))
+				return apperror.BadRequest(fmt.Sprintf("invalid answer: `%s` for multiple-choice question", answer))
 			}
 			if seen[answer] {
-				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("duplicated value: ``%s", answer))
+				return apperror.BadRequest(fmt.Sprintf("duplicated answer: `%s`", answer))
 			}
 			seen[answer] = true
 		}
 
-		oldAnswer, err := sqlcQuerier.GetMultipleChoiceAnswerBySessionAndQuestionId(
-			ctx,
-			db.GetMultipleChoiceAnswerBySessionAndQuestionIdParams{
-				SessionID:  quizSessionId,
-				QuestionID: requestBody.QuestionId,
-			},
-		)
-
-		var answer *db.MultipleChoiceAnswer
-		var dbError error
-
-		if err == nil {
-			answer, dbError = sqlcQuerier.UpdateMultipleChoiceAnswerBySessionAndQuestionId(
+		write = func(tx Dependencies) (any, error) {
+			oldAnswer, err := tx.Answers.GetMultipleChoiceAnswerBySessionAndQuestionId(
 				ctx,
-				db.UpdateMultipleChoiceAnswerBySessionAndQuestionIdParams{
-					SessionID:  oldAnswer.SessionID,
-					QuestionID: oldAnswer.QuestionID,
-					Answers:    requestBody.Answers,
-				},
-			)
-		} else {
-			answer, dbError = sqlcQuerier.CreateMultipleChoiceAnswer(
-				ctx,
-				db.CreateMultipleChoiceAnswerParams{
-					ID:         uuid.NewString(),
+				db.GetMultipleChoiceAnswerBySessionAndQuestionIdParams{
 					SessionID:  quizSessionId,
 					QuestionID: requestBody.QuestionId,
-					Answers:    requestBody.Answers,
 				},
 			)
-		}
 
-		if dbError != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("db error: %s", dbError))
-		}
+			var answer *db.MultipleChoiceAnswer
+			var dbError error
+
+			if err == nil {
+				answer, dbError = tx.Answers.UpdateMultipleChoiceAnswerBySessionAndQuestionId(
+					ctx,
+					db.UpdateMultipleChoiceAnswerBySessionAndQuestionIdParams{
+						SessionID:  oldAnswer.SessionID,
+						QuestionID: oldAnswer.QuestionID,
+						Answers:    requestBody.Answers,
+					},
+				)
+			} else {
+				answer, dbError = tx.Answers.CreateMultipleChoiceAnswer(
+					ctx,
+					db.CreateMultipleChoiceAnswerParams{
+						ID:         uuid.NewString(),
+						SessionID:  quizSessionId,
+						QuestionID: requestBody.QuestionId,
+						Answers:    requestBody.Answers,
+					},
+				)
+			}
 
-		result, err := models.MapMultipleChoiceAnswer(answer)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err)
+			if dbError != nil {
+				return nil, dbError
+			}
+
+			result, err := models.MapMultipleChoiceAnswer(answer)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
 		}
-		return c.JSON(http.StatusOK, result)
 
 	case "true-or-false":
 		var requestBody TrueOrFalseAnswerRequestBody
 		if err := json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&requestBody); err != nil {
-			log.Default().Println(err.Error())
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("error parsing true-or-false answer: %s", err.Error()))
+			return apperror.BadRequest("invalid true-or-false answer").WithCause(err)
 		}
 
-		oldAnswer, err := sqlcQuerier.GetTrueOrFalseAnswerBySessionAndQuestionId(
-			ctx,
-			db.GetTrueOrFalseAnswerBySessionAndQuestionIdParams{
-				SessionID:  quizSessionId,
-				QuestionID: requestBody.QuestionId,
-			},
-		)
-
-		var answer *db.TrueOrFalseAnswer
-		var dbError error
-
-		if err == nil {
-			answer, dbError = sqlcQuerier.UpdateTrueOrFalseAnswerBySessionAndQuestionId(
+		write = func(tx Dependencies) (any, error) {
+			oldAnswer, err := tx.Answers.GetTrueOrFalseAnswerBySessionAndQuestionId(
 				ctx,
-				db.UpdateTrueOrFalseAnswerBySessionAndQuestionIdParams{
-					SessionID:  oldAnswer.SessionID,
-					QuestionID: oldAnswer.QuestionID,
-					Answer:     requestBody.Answer,
-				},
-			)
-		} else {
-			answer, dbError = sqlcQuerier.CreateTrueOrFalseAnswer(
-				ctx,
-				db.CreateTrueOrFalseAnswerParams{
-					ID:         uuid.NewString(),
+				db.GetTrueOrFalseAnswerBySessionAndQuestionIdParams{
 					SessionID:  quizSessionId,
 					QuestionID: requestBody.QuestionId,
-					Answer:     requestBody.Answer,
 				},
 			)
-		}
 
-		if dbError != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("db error: %s", dbError))
+			var answer *db.TrueOrFalseAnswer
+			var dbError error
+
+			if err == nil {
+				answer, dbError = tx.Answers.UpdateTrueOrFalseAnswerBySessionAndQuestionId(
+					ctx,
+					db.UpdateTrueOrFalseAnswerBySessionAndQuestionIdParams{
+						SessionID:  oldAnswer.SessionID,
+						QuestionID: oldAnswer.QuestionID,
+						Answer:     requestBody.Answer,
+					},
+				)
+			} else {
+				answer, dbError = tx.Answers.CreateTrueOrFalseAnswer(
+					ctx,
+					db.CreateTrueOrFalseAnswerParams{
+						ID:         uuid.NewString(),
+						SessionID:  quizSessionId,
+						QuestionID: requestBody.QuestionId,
+						Answer:     requestBody.Answer,
+					},
+				)
+			}
+
+			if dbError != nil {
+				return nil, dbError
+			}
+
+			result, err := models.MapTrueOrFalseAnswer(answer)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
 		}
+	default:
+		return apperror.Internalf("unknown answer type %q", answerRequestBody.AnswerType)
+	}
 
-		result, err := models.MapTrueOrFalseAnswer(answer)
+	// The session stays locked until the answer is written, so an answer
+	// racing with the submit either lands before the result is scored or is
+	// refused
+	var result any
+	err = h.deps.WithTx(ctx, func(tx Dependencies) error {
+		quizSession, err := tx.QuizSessions.LockQuizSession(ctx, quizSessionId)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err)
+			return err
+		}
+		if quizSession.FinishedAt.Valid {
+			return apperror.Forbidden("modifying answer for a submitted quiz is not allowed")
 		}
-		return c.JSON(http.StatusOK, result)
+		result, err = write(tx)
+		return err
+	})
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.Internalf("saving answer of quiz session %s: %w", quizSessionId, err)
 	}
-
-	return echo.NewHTTPError(http.StatusInternalServerError, "unreachable code")
+	return c.JSON(http.StatusOK, result)
 }
 
-func GetAnswers(c echo.Context) error {
+func (h *Handlers) GetAnswers(c echo.Context) error {
 	quizSessionId := c.Param("quizSessionId")
 	if quizSessionId == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing path param quizSessionId")
+		return apperror.BadRequest("missing path param quizSessionId")
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	dbSingleChoiceAnswers, err := sqlcQuerier.GetSingleChoiceAnswers(
+	if _, err := h.authorizeQuizSession(ctx, c, quizSessionId); err != nil {
+		return err
+	}
+
+	dbSingleChoiceAnswers, err := h.deps.Answers.GetSingleChoiceAnswers(
 		ctx,
 		quizSessionId,
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("error getting single choice answers: %s", err))
+		return apperror.Internalf("getting single choice answers: %w", err)
 	}
 
 	singleChoiceAnswers := make([]models.SingleChoiceAnswer, len(dbSingleChoiceAnswers))
 	for i, dbAnswer := range dbSingleChoiceAnswers {
 		answer, err := models.MapSingleChoiceAnswer(dbAnswer)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error parsing a single choice answer: %s", err))
+			return apperror.Internalf("parsing a single choice answer: %w", err)
 		}
 		singleChoiceAnswers[i] = *answer
 	}
 
-	dbMultipleChoiceAnswers, err := sqlcQuerier.GetMultipleChoiceAnswers(
+	dbMultipleChoiceAnswers, err := h.deps.Answers.GetMultipleChoiceAnswers(
 		ctx,
 		quizSessionId,
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("error getting multiple choice answers: %s", err))
+		return apperror.Internalf("getting multiple choice answers: %w", err)
 	}
 
 	multipleChoiceAnswers := make([]models.MultipleChoiceAnswer, len(dbMultipleChoiceAnswers))
 	for i, dbAnswer := range dbMultipleChoiceAnswers {
 		answer, err := models.MapMultipleChoiceAnswer(dbAnswer)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error parsing a multiple choice answer: %s", err))
+			return apperror.Internalf("parsing a multiple choice answer: %w", err)
 		}
 		multipleChoiceAnswers[i] = *answer
 	}
 
-	dbTrueOrFalseAnswers, err := sqlcQuerier.GetTrueOrFalseAnswers(
+	dbTrueOrFalseAnswers, err := h.deps.Answers.GetTrueOrFalseAnswers(
 		ctx,
 		quizSessionId,
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("error getting true or false answers: %s", err))
+		return apperror.Internalf("getting true or false answers: %w", err)
 	}
 
 	trueOrFalseAnswers := make([]models.TrueOrFalseAnswer, len(dbTrueOrFalseAnswers))
 	for i, dbAnswer := range dbTrueOrFalseAnswers {
 		answer, err := models.MapTrueOrFalseAnswer(dbAnswer)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error parsing a true or false answer: %s", err))
+			return apperror.Internalf("parsing a true or false answer: %w", err)
 		}
 		trueOrFalseAnswers[i] = *answer
 	}
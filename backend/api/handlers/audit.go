@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/quiz"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const auditEventsPageSize = 100
+
+// Records a change of the quiz, before is nil for created quizzes and after for deleted ones
+func (h *Handlers) auditQuiz(c echo.Context, action string, quizId string, before *quiz.DBQuiz, after *quiz.DBQuiz) {
+	auth.Audit(c, h.deps.Audit, audit.Event{
+		Action:     action,
+		TargetType: audit.TargetQuiz,
+		TargetId:   quizId,
+		Diff:       audit.Diff(quizAuditFields(before), quizAuditFields(after)),
+	})
+}
+
+func quizAuditFields(q *quiz.DBQuiz) *Quiz {
+	if q == nil {
+		return nil
+	}
+	return &Quiz{Id: q.Id, Name: q.Name, CreatorId: q.CreatorId.String, Description: q.Description.String, Status: q.Status}
+}
+
+// Records a change of the question, before is nil for created questions and after for deleted ones
+func (h *Handlers) auditQuestion(c echo.Context, action string, questionId string, before any, after any) {
+	auth.Audit(c, h.deps.Audit, audit.Event{
+		Action:     action,
+		TargetType: audit.TargetQuestion,
+		TargetId:   questionId,
+		Diff:       audit.Diff(before, after),
+	})
+}
+
+// Lists the audit log newest first. Filters are the `actor`, `action`,
+// `targetType` and `targetId` query params and the RFC 3339 `since` and
+// `until` times, `page` starts at 1.
+func (h *Handlers) AdminGetAuditEventsEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	filter := audit.Filter{
+		ActorId:    c.QueryParam("actor"),
+		Action:     c.QueryParam("action"),
+		TargetType: c.QueryParam("targetType"),
+		TargetId:   c.QueryParam("targetId"),
+	}
+	if filter.Since, err = parseAuditTime(c.QueryParam("since")); err != nil {
+		return apperror.BadRequest("invalid since")
+	}
+	if filter.Until, err = parseAuditTime(c.QueryParam("until")); err != nil {
+		return apperror.BadRequest("invalid until")
+	}
+	if filter.ActorId != "" {
+		if _, err := uuid.Parse(filter.ActorId); err != nil {
+			return apperror.BadRequest("invalid actor")
+		}
+	}
+
+	// One extra row tells whether there is a next page
+	events, err := h.deps.Audit.Search(c.Request().Context(), filter, auditEventsPageSize+1, (page-1)*auditEventsPageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	response := models.AuditEventsResponse{
+		Events:  []models.AuditEvent{},
+		Page:    page,
+		HasMore: len(events) > auditEventsPageSize,
+	}
+	actorEmails := map[string]string{}
+	for i, event := range events {
+		if i == auditEventsPageSize {
+			break
+		}
+		result := models.AuditEvent{
+			Id:             event.Id,
+			ActorId:        event.ActorId,
+			ImpersonatorId: event.ImpersonatorId,
+			Action:         event.Action,
+			TargetType:     event.TargetType,
+			TargetId:       event.TargetId,
+			IpAddress:      event.IpAddress,
+			Diff:           []byte(event.Diff),
+			CreatedAt:      event.CreatedAt,
+		}
+		if event.ActorId != nil {
+			email, known := actorEmails[*event.ActorId]
+			if !known {
+				// Deleted actors keep their id in the log but have no email anymore
+				if actor, err := h.deps.Users.GetUserById(ctx, *event.ActorId); err == nil {
+					email = actor.Email
+				}
+				actorEmails[*event.ActorId] = email
+			}
+			result.ActorEmail = email
+		}
+		response.Events = append(response.Events, result)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func parseAuditTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/quiz"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Returns the access of the current user to the quiz, or an echo error when
+// the user has none. Quizzes the user cannot see are reported as not found.
+func (h *Handlers) authorizeQuiz(c echo.Context, quizId string) (int, error) {
+	ctx := c.Request().Context()
+	if _, err := uuid.Parse(quizId); err != nil {
+		return 0, apperror.NotFound("quiz not found")
+	}
+	access, err := h.deps.Quizzes.GetQuizAccess(ctx, auth.CurrentUserId(c), quizId)
+	if err != nil {
+		return 0, apperror.Internal(err)
+	}
+	if access == 0 {
+		// Moderators and admins can see every quiz, but only owners can modify them
+		if auth.IsStaff(auth.CurrentUser(c)) {
+			return quiz.QUIZ_VIEWER_ACCESS_ID, nil
+		}
+		return 0, apperror.NotFound("quiz not found")
+	}
+	return access, nil
+}
+
+// Returns an echo error unless the current user can view the quiz
+func (h *Handlers) authorizeQuizViewer(c echo.Context, quizId string) error {
+	_, err := h.authorizeQuiz(c, quizId)
+	return err
+}
+
+// Returns an echo error unless the current user owns the quiz
+func (h *Handlers) authorizeQuizOwner(c echo.Context, quizId string) error {
+	access, err := h.authorizeQuiz(c, quizId)
+	if err != nil {
+		return err
+	}
+	if access != quiz.QUIZ_OWNER_ACCESS_ID {
+		return apperror.Forbidden("only the owner can modify the quiz")
+	}
+	return nil
+}
+
+// Returns the quiz session if it belongs to the current user
+func (h *Handlers) authorizeQuizSession(ctx context.Context, c echo.Context, quizSessionId string) (*db.QuizSession, error) {
+	quizSession, err := h.deps.QuizSessions.GetQuizSession(ctx, quizSessionId)
+	if err != nil {
+		return nil, apperror.NotFound("quiz session not found")
+	}
+	if quizSession.UserID != auth.CurrentUserId(c) {
+		return nil, apperror.NotFound("quiz session not found")
+	}
+	return quizSession, nil
+}
+
+// Returns the review item if it belongs to the current user
+func (h *Handlers) authorizeReviewItem(ctx context.Context, c echo.Context, reviewItemId string) (*db.GetReviewItemRow, error) {
+	reviewItem, err := h.deps.ReviewItems.GetReviewItem(ctx, reviewItemId)
+	if err != nil {
+		return nil, apperror.NotFound("review item not found")
+	}
+	if reviewItem.UserID != auth.CurrentUserId(c) {
+		return nil, apperror.NotFound("review item not found")
+	}
+	return reviewItem, nil
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/question"
+	"spaced-ace-backend/quiz"
+	"spaced-ace-backend/store"
+	"spaced-ace-backend/usage"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// The quiz sessions with their results and scores
+type QuizSessionRepository interface {
+	GetQuizSession(ctx context.Context, id string) (*db.QuizSession, error)
+	GetQuizSessionsByQuizIdAndUserId(ctx context.Context, arg db.GetQuizSessionsByQuizIdAndUserIdParams) ([]*db.QuizSession, error)
+	GetQuizSessionsByUserId(ctx context.Context, userID string) ([]*db.QuizSession, error)
+	CreateQuizSession(ctx context.Context, arg db.CreateQuizSessionParams) (*db.QuizSession, error)
+	HasOpenQuizSession(ctx context.Context, arg db.HasOpenQuizSessionParams) (bool, error)
+	// Locks the session until the end of the transaction
+	LockQuizSession(ctx context.Context, id string) (*db.QuizSession, error)
+	UpdateQuizSessionFinishedAt(ctx context.Context, arg db.UpdateQuizSessionFinishedAtParams) (*db.QuizSession, error)
+	GetQuizResultByQuizSessionId(ctx context.Context, sessionID string) (*db.QuizResult, error)
+	GetQuizResultsByUserID(ctx context.Context, userID string) ([]*db.GetQuizResultsByUserIDRow, error)
+	CreateQuizResult(ctx context.Context, arg db.CreateQuizResultParams) (*db.QuizResult, error)
+	UpdateQuizResultScores(ctx context.Context, arg db.UpdateQuizResultScoresParams) (*db.QuizResult, error)
+	GetAnswerScores(ctx context.Context, quizResultID string) ([]*db.AnswerScore, error)
+	CreateSingleChoiceAnswerScore(ctx context.Context, arg db.CreateSingleChoiceAnswerScoreParams) (*db.AnswerScore, error)
+	CreateMultipleChoiceAnswerScore(ctx context.Context, arg db.CreateMultipleChoiceAnswerScoreParams) (*db.AnswerScore, error)
+	CreateTrueOrFalseAnswerScore(ctx context.Context, arg db.CreateTrueOrFalseAnswerScoreParams) (*db.AnswerScore, error)
+}
+
+// The answers given in quiz sessions
+type AnswerRepository interface {
+	GetSingleChoiceAnswers(ctx context.Context, sessionID string) ([]*db.SingleChoiceAnswer, error)
+	GetMultipleChoiceAnswers(ctx context.Context, sessionID string) ([]*db.MultipleChoiceAnswer, error)
+	GetTrueOrFalseAnswers(ctx context.Context, sessionID string) ([]*db.TrueOrFalseAnswer, error)
+	CreateSingleChoiceAnswer(ctx context.Context, arg db.CreateSingleChoiceAnswerParams) (*db.SingleChoiceAnswer, error)
+	CreateMultipleChoiceAnswer(ctx context.Context, arg db.CreateMultipleChoiceAnswerParams) (*db.MultipleChoiceAnswer, error)
+	CreateTrueOrFalseAnswer(ctx context.Context, arg db.CreateTrueOrFalseAnswerParams) (*db.TrueOrFalseAnswer, error)
+	GetSingleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.GetSingleChoiceAnswerBySessionAndQuestionIdParams) (*db.SingleChoiceAnswer, error)
+	GetMultipleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.GetMultipleChoiceAnswerBySessionAndQuestionIdParams) (*db.MultipleChoiceAnswer, error)
+	GetTrueOrFalseAnswerBySessionAndQuestionId(ctx context.Context, arg db.GetTrueOrFalseAnswerBySessionAndQuestionIdParams) (*db.TrueOrFalseAnswer, error)
+	UpdateSingleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.UpdateSingleChoiceAnswerBySessionAndQuestionIdParams) (*db.SingleChoiceAnswer, error)
+	UpdateMultipleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.UpdateMultipleChoiceAnswerBySessionAndQuestionIdParams) (*db.MultipleChoiceAnswer, error)
+	UpdateTrueOrFalseAnswerBySessionAndQuestionId(ctx context.Context, arg db.UpdateTrueOrFalseAnswerBySessionAndQuestionIdParams) (*db.TrueOrFalseAnswer, error)
+}
+
+// The review items of the spaced repetition and the learn lists they come from
+type ReviewItemRepository interface {
+	GetReviewItem(ctx context.Context, id string) (*db.GetReviewItemRow, error)
+	GetReviewItems(ctx context.Context, userID string) ([]*db.GetReviewItemsRow, error)
+	GetReviewItemCounts(ctx context.Context, userID string) (*db.GetReviewItemCountsRow, error)
+	GetQuizOptions(ctx context.Context, userid string) ([]*db.GetQuizOptionsRow, error)
+	UpdateReviewItem(ctx context.Context, arg db.UpdateReviewItemParams) error
+	ResetReviewItemsOfQuestion(ctx context.Context, arg db.ResetReviewItemsOfQuestionParams) (int64, error)
+	CreateSingleChoiceReviewItem(ctx context.Context, arg db.CreateSingleChoiceReviewItemParams) (string, error)
+	CreateMultipleChoiceReviewItem(ctx context.Context, arg db.CreateMultipleChoiceReviewItemParams) (string, error)
+	CreateTrueOrFalseReviewItem(ctx context.Context, arg db.CreateTrueOrFalseReviewItemParams) (string, error)
+	CreateMissingReviewItemsOfQuiz(ctx context.Context, arg db.CreateMissingReviewItemsOfQuizParams) (int64, error)
+	DeleteReviewItemsByQuizID(ctx context.Context, arg db.DeleteReviewItemsByQuizIDParams) error
+	GetAddedLearnListItems(ctx context.Context, userID string) ([]*db.LearnListAddedItem, error)
+	AddQuizToLearnList(ctx context.Context, arg db.AddQuizToLearnListParams) error
+	RemoveQuizFromLearnList(ctx context.Context, arg db.RemoveQuizFromLearnListParams) error
+}
+
+// Every version of the questions with who wrote it, newest first
+type QuestionRevisionRepository interface {
+	LockSingleChoiceQuestion(ctx context.Context, uuid string) error
+	LockMultipleChoiceQuestion(ctx context.Context, uuid string) error
+	LockTrueOrFalseQuestion(ctx context.Context, uuid string) error
+	CreateQuestionRevision(ctx context.Context, arg db.CreateQuestionRevisionParams) (*db.QuestionRevision, error)
+	DeleteQuestionRevisions(ctx context.Context, questionID string) error
+	DeleteQuestionRevisionsOfQuiz(ctx context.Context, quizID string) error
+	GetQuestionRevisions(ctx context.Context, questionID string) ([]*db.GetQuestionRevisionsRow, error)
+	GetQuestionRevision(ctx context.Context, arg db.GetQuestionRevisionParams) (*db.GetQuestionRevisionRow, error)
+}
+
+// Everything the handlers read and write, injected by main with New
+type Dependencies struct {
+	Users        auth.UserRepository
+	Quizzes      quiz.Repository
+	Questions    question.Repository
+	Revisions    QuestionRevisionRepository
+	QuizSessions QuizSessionRepository
+	Answers      AnswerRepository
+	ReviewItems  ReviewItemRepository
+	Llm          LlmClient
+	// The audit log and the usage ledger are written outside of the
+	// transactions, the dependencies of a transaction share them
+	Audit audit.Log
+	Usage usage.Ledger
+	// Runs f with repositories that are committed together when f returns
+	// nil and rolled back otherwise
+	WithTx func(ctx context.Context, f func(tx Dependencies) error) error
+}
+
+// The handlers of the quizzes, questions, sessions and reviews with what they
+// read and write
+type Handlers struct {
+	deps Dependencies
+	// The chunks of the prompts by prompt, questions are generated from them in turn
+	cache map[string]cacheEntry
+	// Held while the cache is read and written, questions are generated concurrently
+	cacheMu sync.Mutex
+}
+
+func New(dependencies Dependencies) *Handlers {
+	return &Handlers{
+		deps:  dependencies,
+		cache: map[string]cacheEntry{},
+	}
+}
+
+// Returns the dependencies backed by the sqlc queries of the store
+func NewPostgresDependencies(s *store.Store, llm LlmClient) Dependencies {
+	dependencies := newQueriesDependencies(s.Queries, llm)
+	dependencies.Audit = audit.NewPostgresLog(s.Queries)
+	dependencies.Usage = usage.NewPostgresLedger(s)
+	dependencies.WithTx = func(ctx context.Context, f func(tx Dependencies) error) error {
+		return s.WithTx(ctx, func(tx *store.Tx) error {
+			txDependencies := newQueriesDependencies(tx.Queries, llm)
+			txDependencies.Audit = dependencies.Audit
+			txDependencies.Usage = dependencies.Usage
+			// The transaction is already open, nested calls join it
+			txDependencies.WithTx = func(ctx context.Context, f func(tx Dependencies) error) error {
+				return f(txDependencies)
+			}
+			return f(txDependencies)
+		})
+	}
+	return dependencies
+}
+
+func newQueriesDependencies(queries *db.Queries, llm LlmClient) Dependencies {
+	return Dependencies{
+		Users:        auth.NewPostgresRepository(queries),
+		Quizzes:      quiz.NewPostgresRepository(queries),
+		Questions:    question.NewPostgresRepository(queries),
+		Revisions:    queries,
+		QuizSessions: queries,
+		Answers:      queries,
+		ReviewItems:  queries,
+		Llm:          llm,
+	}
+}
+
+// The deadline of the queries of a request. They stay in the trace of the
+// request but are not cancelled when the client goes away, so a submission
+// is not rolled back halfway.
+func requestContext(c echo.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(c.Request().Context()), timeout)
+}
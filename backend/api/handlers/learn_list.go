@@ -9,12 +9,11 @@ import (
 	"net/http"
 	"slices"
 	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
 	"spaced-ace-backend/auth"
 	"spaced-ace-backend/constants"
 	"spaced-ace-backend/db"
-	"spaced-ace-backend/question"
 	"spaced-ace-backend/quiz"
-	"spaced-ace-backend/utils"
 	"time"
 )
 
@@ -26,33 +25,24 @@ type ReviewItemsRequestBody struct {
 	Query      string `json:"query"`
 }
 
-func GetLearnList(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+func (h *Handlers) GetLearnList(c echo.Context) error {
+	ctx, cancel := requestContext(c, 15*time.Second)
+	defer cancel()
+	sessionUserID := auth.CurrentUserId(c)
 
-	quizAccesses, err := quiz.GetQuizAccessesOfUser(sessionUserID)
+	quizAccesses, err := h.deps.Quizzes.GetQuizAccessesOfUser(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("error getting quiz accesses for user with ID `%s`: %w", sessionUserID, err))
+		return apperror.Internalf("getting quiz accesses for user with ID `%s`: %w", sessionUserID, err)
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-
-	addedQuizzes, err := sqlcQuerier.GetAddedLearnListItems(ctx, sessionUserID)
+	addedQuizzes, err := h.deps.ReviewItems.GetAddedLearnListItems(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("error getting added quizes for user with ID `%s`: %w", sessionUserID, err))
+		return apperror.Internalf("getting added quizes for user with ID `%s`: %w", sessionUserID, err)
 	}
 
-	available, selected, err := buildLearnListItems(*quizAccesses, addedQuizzes)
+	available, selected, err := h.buildLearnListItems(ctx, *quizAccesses, addedQuizzes)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
 
 	return c.JSON(http.StatusOK, models.LearnListResponseBody{
@@ -60,27 +50,24 @@ func GetLearnList(c echo.Context) error {
 		SelectedItems:  selected,
 	})
 }
-func PostAddQuizToLearnList(c echo.Context) error {
+func (h *Handlers) PostAddQuizToLearnList(c echo.Context) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
+	defer cancel()
 	quizID := c.Param("quizID")
 	if quizID == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("missing path param quizID"))
+		return apperror.BadRequest("missing path param quizID")
 	}
 
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	sessionUserID := auth.CurrentUserId(c)
+	if err := h.authorizeQuizViewer(c, quizID); err != nil {
+		return err
 	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	if err := h.requirePublishedQuiz(ctx, quizID); err != nil {
+		return err
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
 	// Add the quiz to the user's learn list
-	err = sqlcQuerier.AddQuizToLearnList(
+	err := h.deps.ReviewItems.AddQuizToLearnList(
 		ctx,
 		db.AddQuizToLearnListParams{
 			UserID: sessionUserID,
@@ -88,31 +75,31 @@ func PostAddQuizToLearnList(c echo.Context) error {
 		},
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("add quiz with ID %q to learn list for user with ID %q: %w", quizID, sessionUserID, err))
+		return apperror.Internalf("add quiz with ID %q to learn list for user with ID %q: %w", quizID, sessionUserID, err)
 	}
 
 	// Create review items for the quiz's questions
-	_, err = createAndStoreReviewItems(ctx, sessionUserID, quizID)
+	_, err = h.createAndStoreReviewItems(ctx, sessionUserID, quizID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("creating the review items for quiz with ID %q: %w", quizID, err))
+		return apperror.Internalf("creating the review items for quiz with ID %q: %w", quizID, err)
 	}
 
 	// Fetch the quizzes that user has access to
-	quizAccesses, err := quiz.GetQuizAccessesOfUser(sessionUserID)
+	quizAccesses, err := h.deps.Quizzes.GetQuizAccessesOfUser(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("error getting quiz accesses for user with ID `%s`: %w", sessionUserID, err))
+		return apperror.Internalf("getting quiz accesses for user with ID `%s`: %w", sessionUserID, err)
 	}
 
 	// Fetch the quizzes that are already added to the user's learn list
-	addedQuizzes, err := sqlcQuerier.GetAddedLearnListItems(ctx, sessionUserID)
+	addedQuizzes, err := h.deps.ReviewItems.GetAddedLearnListItems(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("error getting added quizes for user with ID `%s`: %w", sessionUserID, err))
+		return apperror.Internalf("getting added quizes for user with ID `%s`: %w", sessionUserID, err)
 	}
 
 	// Build the learn list
-	available, selected, err := buildLearnListItems(*quizAccesses, addedQuizzes)
+	available, selected, err := h.buildLearnListItems(ctx, *quizAccesses, addedQuizzes)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
 
 	return c.JSON(http.StatusOK, models.LearnListResponseBody{
@@ -120,27 +107,19 @@ func PostAddQuizToLearnList(c echo.Context) error {
 		SelectedItems:  selected,
 	})
 }
-func PostRemoveQuizFromLearnList(c echo.Context) error {
+func (h *Handlers) PostRemoveQuizFromLearnList(c echo.Context) error {
 	quizID := c.Param("quizID")
 	if quizID == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("missing path param quizID"))
+		return apperror.BadRequest("missing path param quizID")
 	}
 
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+	sessionUserID := auth.CurrentUserId(c)
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
 	// Remove the quiz from the user's learn list
-	err = sqlcQuerier.RemoveQuizFromLearnList(
+	err := h.deps.ReviewItems.RemoveQuizFromLearnList(
 		ctx,
 		db.RemoveQuizFromLearnListParams{
 			UserID: sessionUserID,
@@ -148,31 +127,31 @@ func PostRemoveQuizFromLearnList(c echo.Context) error {
 		},
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("removing quiz with ID %q to learn list for user with ID %q: %w", quizID, sessionUserID, err))
+		return apperror.Internalf("removing quiz with ID %q to learn list for user with ID %q: %w", quizID, sessionUserID, err)
 	}
 
 	// Delete the affected review items
-	err = deleteReviewItems(ctx, sessionUserID, quizID)
+	err = h.deleteReviewItems(ctx, sessionUserID, quizID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("deleting the review items for quiz with ID %q: %w", quizID, err))
+		return apperror.Internalf("deleting the review items for quiz with ID %q: %w", quizID, err)
 	}
 
 	// Fetch the quizzes that user has access to
-	quizAccesses, err := quiz.GetQuizAccessesOfUser(sessionUserID)
+	quizAccesses, err := h.deps.Quizzes.GetQuizAccessesOfUser(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("getting quiz accesses for user with ID `%s`: %w", sessionUserID, err))
+		return apperror.Internalf("getting quiz accesses for user with ID `%s`: %w", sessionUserID, err)
 	}
 
 	// Fetch the quizzes that are already added to the user's learn list
-	addedQuizzes, err := sqlcQuerier.GetAddedLearnListItems(ctx, sessionUserID)
+	addedQuizzes, err := h.deps.ReviewItems.GetAddedLearnListItems(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("getting added quizes for user with ID `%s`: %w", sessionUserID, err))
+		return apperror.Internalf("getting added quizes for user with ID `%s`: %w", sessionUserID, err)
 	}
 
 	// Build the learn list
-	available, selected, err := buildLearnListItems(*quizAccesses, addedQuizzes)
+	available, selected, err := h.buildLearnListItems(ctx, *quizAccesses, addedQuizzes)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
 
 	return c.JSON(http.StatusOK, models.LearnListResponseBody{
@@ -181,54 +160,54 @@ func PostRemoveQuizFromLearnList(c echo.Context) error {
 	})
 }
 
-func createAndStoreReviewItems(ctx context.Context, userID, quizID string) ([]*models.ReviewItem, error) {
-	dbSingleChoiceQuestions, err := question.GetSingleChoiceQuestions(quizID)
+// Creates the review items of the published questions of the quiz
+func (h *Handlers) createAndStoreReviewItems(ctx context.Context, userID, quizID string) ([]*models.ReviewItem, error) {
+	dbSingleChoiceQuestions, err := h.deps.Questions.GetPublishedSingleChoiceQuestions(ctx, quizID)
 	if err != nil {
-		return nil, fmt.Errorf("getting single choice questions for quiz with ID %q\n", quizID)
+		return nil, fmt.Errorf("getting single choice questions for quiz with ID %q", quizID)
 	}
 
 	reviewItems := make([]*models.ReviewItem, 0, len(dbSingleChoiceQuestions))
 
 	for _, dbQuestion := range dbSingleChoiceQuestions {
-		reviewItem, err := createSingleChoiceReviewItem(ctx, userID, dbQuestion.UUID)
+		reviewItem, err := h.createSingleChoiceReviewItem(ctx, userID, dbQuestion.UUID)
 		if err != nil {
-			return nil, fmt.Errorf("creating review item for single choice question with ID %q: %w\n", dbQuestion.UUID, err)
+			return nil, fmt.Errorf("creating review item for single choice question with ID %q: %w", dbQuestion.UUID, err)
 		}
 		reviewItems = append(reviewItems, reviewItem)
 	}
 
-	dbMultipleChoiceQuestions, err := question.GetMultipleChoiceQuestions(quizID)
+	dbMultipleChoiceQuestions, err := h.deps.Questions.GetPublishedMultipleChoiceQuestions(ctx, quizID)
 	if err != nil {
-		return nil, fmt.Errorf("getting multiple choice questions for quiz with ID %q\n", quizID)
+		return nil, fmt.Errorf("getting multiple choice questions for quiz with ID %q", quizID)
 	}
 
 	for _, dbQuestion := range dbMultipleChoiceQuestions {
-		reviewItem, err := createMultipleChoiceReviewItem(ctx, userID, dbQuestion.UUID)
+		reviewItem, err := h.createMultipleChoiceReviewItem(ctx, userID, dbQuestion.UUID)
 		if err != nil {
-			return nil, fmt.Errorf("creating review item for multiple choice question with ID %q: %w\n", dbQuestion.UUID, err)
+			return nil, fmt.Errorf("creating review item for multiple choice question with ID %q: %w", dbQuestion.UUID, err)
 		}
 		reviewItems = append(reviewItems, reviewItem)
 	}
 
-	dbTrueOrFalseQuestions, err := question.GetTrueOrFalseQuestions(quizID)
+	dbTrueOrFalseQuestions, err := h.deps.Questions.GetPublishedTrueOrFalseQuestions(ctx, quizID)
 	if err != nil {
-		return nil, fmt.Errorf("getting true or false questions for quiz with ID %q\n", quizID)
+		return nil, fmt.Errorf("getting true or false questions for quiz with ID %q", quizID)
 	}
 
 	for _, dbQuestion := range dbTrueOrFalseQuestions {
-		reviewItem, err := createTrueOrFalseReviewItem(ctx, userID, dbQuestion.UUID)
+		reviewItem, err := h.createTrueOrFalseReviewItem(ctx, userID, dbQuestion.UUID)
 		if err != nil {
-			return nil, fmt.Errorf("creating review item for true or false question with ID %q: %w\n", dbQuestion.UUID, err)
+			return nil, fmt.Errorf("creating review item for true or false question with ID %q: %w", dbQuestion.UUID, err)
 		}
 		reviewItems = append(reviewItems, reviewItem)
 	}
 
 	return reviewItems, nil
 }
-func createSingleChoiceReviewItem(ctx context.Context, userID, questionID string) (*models.ReviewItem, error) {
-	sqlcQuerier := utils.GetQuerier()
+func (h *Handlers) createSingleChoiceReviewItem(ctx context.Context, userID, questionID string) (*models.ReviewItem, error) {
 
-	reviewItemID, err := sqlcQuerier.CreateSingleChoiceReviewItem(
+	reviewItemID, err := h.deps.ReviewItems.CreateSingleChoiceReviewItem(
 		ctx,
 		db.CreateSingleChoiceReviewItemParams{
 			ID:                     uuid.NewString(),
@@ -246,25 +225,24 @@ func createSingleChoiceReviewItem(ctx context.Context, userID, questionID string
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("creating review item for single choice question with ID %q: %w\n", questionID, err)
+		return nil, fmt.Errorf("creating review item for single choice question with ID %q: %w", questionID, err)
 	}
 
-	dbReviewItem, err := sqlcQuerier.GetReviewItem(ctx, reviewItemID)
+	dbReviewItem, err := h.deps.ReviewItems.GetReviewItem(ctx, reviewItemID)
 	if err != nil {
-		return nil, fmt.Errorf("getting review item with ID %q: %w\n", reviewItemID, err)
+		return nil, fmt.Errorf("getting review item with ID %q: %w", reviewItemID, err)
 	}
 
 	reviewItem, err := models.MapReviewItem(dbReviewItem)
 	if err != nil {
-		return nil, fmt.Errorf("mapping review item: %w\n", err)
+		return nil, fmt.Errorf("mapping review item: %w", err)
 	}
 
 	return reviewItem, nil
 }
-func createMultipleChoiceReviewItem(ctx context.Context, userID, questionID string) (*models.ReviewItem, error) {
-	sqlcQuerier := utils.GetQuerier()
+func (h *Handlers) createMultipleChoiceReviewItem(ctx context.Context, userID, questionID string) (*models.ReviewItem, error) {
 
-	reviewItemID, err := sqlcQuerier.CreateMultipleChoiceReviewItem(
+	reviewItemID, err := h.deps.ReviewItems.CreateMultipleChoiceReviewItem(
 		ctx,
 		db.CreateMultipleChoiceReviewItemParams{
 			ID:                       uuid.NewString(),
@@ -282,25 +260,24 @@ func createMultipleChoiceReviewItem(ctx context.Context, userID, questionID stri
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("creating review item for multiple choice question with ID %q: %w\n", questionID, err)
+		return nil, fmt.Errorf("creating review item for multiple choice question with ID %q: %w", questionID, err)
 	}
 
-	dbReviewItem, err := sqlcQuerier.GetReviewItem(ctx, reviewItemID)
+	dbReviewItem, err := h.deps.ReviewItems.GetReviewItem(ctx, reviewItemID)
 	if err != nil {
-		return nil, fmt.Errorf("getting review item with ID %q: %w\n", reviewItemID, err)
+		return nil, fmt.Errorf("getting review item with ID %q: %w", reviewItemID, err)
 	}
 
 	reviewItem, err := models.MapReviewItem(dbReviewItem)
 	if err != nil {
-		return nil, fmt.Errorf("mapping review item: %w\n", err)
+		return nil, fmt.Errorf("mapping review item: %w", err)
 	}
 
 	return reviewItem, nil
 }
-func createTrueOrFalseReviewItem(ctx context.Context, userID, questionID string) (*models.ReviewItem, error) {
-	sqlcQuerier := utils.GetQuerier()
+func (h *Handlers) createTrueOrFalseReviewItem(ctx context.Context, userID, questionID string) (*models.ReviewItem, error) {
 
-	reviewItemID, err := sqlcQuerier.CreateTrueOrFalseReviewItem(
+	reviewItemID, err := h.deps.ReviewItems.CreateTrueOrFalseReviewItem(
 		ctx,
 		db.CreateTrueOrFalseReviewItemParams{
 			ID:                    uuid.NewString(),
@@ -318,26 +295,25 @@ func createTrueOrFalseReviewItem(ctx context.Context, userID, questionID string)
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("creating review item for true or false question with ID %q: %w\n", questionID, err)
+		return nil, fmt.Errorf("creating review item for true or false question with ID %q: %w", questionID, err)
 	}
 
-	dbReviewItem, err := sqlcQuerier.GetReviewItem(ctx, reviewItemID)
+	dbReviewItem, err := h.deps.ReviewItems.GetReviewItem(ctx, reviewItemID)
 	if err != nil {
-		return nil, fmt.Errorf("getting review item with ID %q: %w\n", reviewItemID, err)
+		return nil, fmt.Errorf("getting review item with ID %q: %w", reviewItemID, err)
 	}
 
 	reviewItem, err := models.MapReviewItem(dbReviewItem)
 	if err != nil {
-		return nil, fmt.Errorf("mapping review item: %w\n", err)
+		return nil, fmt.Errorf("mapping review item: %w", err)
 	}
 
 	return reviewItem, nil
 }
 
-func deleteReviewItems(ctx context.Context, userID, quizID string) error {
-	sqlcQuerier := utils.GetQuerier()
+func (h *Handlers) deleteReviewItems(ctx context.Context, userID, quizID string) error {
 
-	return sqlcQuerier.DeleteReviewItemsByQuizID(
+	return h.deps.ReviewItems.DeleteReviewItemsByQuizID(
 		ctx,
 		db.DeleteReviewItemsByQuizIDParams{
 			UserID: userID,
@@ -346,7 +322,7 @@ func deleteReviewItems(ctx context.Context, userID, quizID string) error {
 	)
 }
 
-func buildLearnListItems(quizAccesses []quiz.DBQuizAccess, addedQuizzes []*db.LearnListAddedItem) (available, selected []models.LearnListItem, err error) {
+func (h *Handlers) buildLearnListItems(ctx context.Context, quizAccesses []quiz.DBQuizAccess, addedQuizzes []*db.LearnListAddedItem) (available, selected []models.LearnListItem, err error) {
 	available = make([]models.LearnListItem, 0, len(quizAccesses)-len(addedQuizzes))
 	selected = make([]models.LearnListItem, 0, len(addedQuizzes))
 
@@ -356,9 +332,9 @@ func buildLearnListItems(quizAccesses []quiz.DBQuizAccess, addedQuizzes []*db.Le
 	}
 
 	for _, access := range quizAccesses {
-		dbQuiz, err := quiz.GetQuizById(access.QuizId)
+		dbQuiz, err := h.deps.Quizzes.GetQuizById(ctx, access.QuizId)
 		if err != nil {
-			return nil, nil, fmt.Errorf("getting quiz with ID %q: %w\n", access.QuizId, err)
+			return nil, nil, fmt.Errorf("getting quiz with ID %q: %w", access.QuizId, err)
 		}
 
 		item := models.LearnListItem{
@@ -368,7 +344,8 @@ func buildLearnListItems(quizAccesses []quiz.DBQuizAccess, addedQuizzes []*db.Le
 
 		if _, isAdded := addedQuizMap[access.QuizId]; isAdded {
 			selected = append(selected, item)
-		} else {
+		} else if dbQuiz.Status == quiz.STATUS_PUBLISHED {
+			// Only published quizzes can be added
 			available = append(available, item)
 		}
 	}
@@ -378,11 +355,11 @@ func buildLearnListItems(quizAccesses []quiz.DBQuizAccess, addedQuizzes []*db.Le
 
 func validateReviewItemsRequest(request ReviewItemsRequestBody) (*ReviewItemsRequestBody, error) {
 	if !slices.Contains([]string{"", "easy", "medium", "hard"}, request.Difficulty) {
-		return nil, fmt.Errorf("invalid difficulty value %q\n", request.Difficulty)
+		return nil, fmt.Errorf("invalid difficulty value %q", request.Difficulty)
 	}
 
 	if !slices.Contains([]string{"", "due", "not-due"}, request.Status) {
-		return nil, fmt.Errorf("invalid status value %q\n", request.Status)
+		return nil, fmt.Errorf("invalid status value %q", request.Status)
 	}
 
 	if request.Page < 1 {
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"spaced-ace-backend/metrics"
+	"spaced-ace-backend/tracing"
+	"time"
+)
+
+type TextChunk struct {
+	Id   string `json:"id"`
+	Text string `json:"chunk"`
+}
+
+type prompt struct {
+	Prompt string `json:"prompt"`
+}
+
+// The llm api that chunks prompts and generates questions from the chunks
+type LlmClient interface {
+	// Splits the prompt into chunks small enough to generate a question from
+	Chunk(ctx context.Context, prompt string) ([]TextChunk, error)
+	// Generates a question of the type from the text and decodes it into generated
+	Generate(ctx context.Context, questionType string, text string, generated any) error
+}
+
+type HttpLlmClient struct {
+	baseUrl string
+	client  *http.Client
+}
+
+func NewLlmClient(baseUrl string) *HttpLlmClient {
+	return &HttpLlmClient{baseUrl: baseUrl, client: &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)}}
+}
+
+func (l *HttpLlmClient) Chunk(ctx context.Context, userPrompt string) ([]TextChunk, error) {
+	chunks := []TextChunk{}
+	if err := l.post(ctx, "chunk", "/chunk", userPrompt, &chunks); err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+func (l *HttpLlmClient) Generate(ctx context.Context, questionType string, text string, generated any) error {
+	return l.post(ctx, questionType, "/"+questionType+"/create", text, generated)
+}
+
+// Checks that the llm api is up, for the readiness probe
+func (l *HttpLlmClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.baseUrl+"/healthz", nil)
+	if err != nil {
+		return err
+	}
+	res, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("llm api responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// Posts the text and records the call in the metrics by the operation and the
+// provider the llm api reports in its response
+func (l *HttpLlmClient) post(ctx context.Context, operation string, path string, text string, response any) (err error) {
+	body, err := json.Marshal(prompt{Prompt: text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.baseUrl+path, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	started := time.Now()
+	provider := ""
+	defer func() {
+		metrics.ObserveLlmCall(operation, provider, time.Since(started), err)
+	}()
+	res, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	provider = res.Header.Get("X-LLM-Provider")
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("llm api responded with status %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(response)
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/constants"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/question"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// The names of the question types in the revisions, the ones of the routes
+var questionTypeNames = map[models.QuestionType]string{
+	models.SingleChoice:   "single-choice",
+	models.MultipleChoice: "multiple-choice",
+	models.TrueOrFalse:    "true-or-false",
+}
+
+// Lists the revisions of a question newest first. Questions that were never
+// changed since the history is kept have none.
+func (h *Handlers) GetQuestionRevisionsEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	current, err := findQuestion(ctx, h.deps.Questions, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.authorizeQuizViewer(c, current.quizId); err != nil {
+		return err
+	}
+	rows, err := h.deps.Revisions.GetQuestionRevisions(c.Request().Context(), current.id)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	revisions := []models.QuestionRevision{}
+	for _, row := range rows {
+		revisions = append(revisions, mapQuestionRevision(db.GetQuestionRevisionRow(*row)))
+	}
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// Compares the revisions in the `from` and `to` query params, `to` is the
+// latest one when it is missing
+func (h *Handlers) GetQuestionRevisionDiffEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	current, err := findQuestion(ctx, h.deps.Questions, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.authorizeQuizViewer(c, current.quizId); err != nil {
+		return err
+	}
+	from, err := strconv.Atoi(c.QueryParam("from"))
+	if err != nil {
+		return apperror.BadRequest("invalid from")
+	}
+	to := 0
+	if c.QueryParam("to") != "" {
+		if to, err = strconv.Atoi(c.QueryParam("to")); err != nil {
+			return apperror.BadRequest("invalid to")
+		}
+	} else {
+		rows, err := h.deps.Revisions.GetQuestionRevisions(ctx, current.id)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if len(rows) > 0 {
+			to = int(rows[0].Revision)
+		}
+	}
+	fromRevision, err := h.getQuestionRevision(ctx, current.id, from)
+	if err != nil {
+		return err
+	}
+	toRevision, err := h.getQuestionRevision(ctx, current.id, to)
+	if err != nil {
+		return err
+	}
+
+	var before, after map[string]any
+	if err := json.Unmarshal(fromRevision.Snapshot, &before); err != nil {
+		return apperror.Internal(err)
+	}
+	if err := json.Unmarshal(toRevision.Snapshot, &after); err != nil {
+		return apperror.Internal(err)
+	}
+	diff := models.QuestionRevisionDiff{From: from, To: to, Changes: map[string]models.QuestionChange{}}
+	for key, value := range after {
+		// The review status is not part of the revisions
+		if key == "id" || key == "quizid" || key == "status" || key == "published" {
+			continue
+		}
+		if !reflect.DeepEqual(before[key], value) {
+			diff.Changes[key] = models.QuestionChange{From: before[key], To: value}
+		}
+	}
+	beforeQuestion, err := decodeSnapshot(current.questionType, fromRevision.Snapshot)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	afterQuestion, err := decodeSnapshot(current.questionType, toRevision.Snapshot)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	diff.CorrectAnswerChanged = !slices.Equal(correctAnswerOf(beforeQuestion), correctAnswerOf(afterQuestion))
+	return c.JSON(http.StatusOK, diff)
+}
+
+// Makes the question what it was in the revision, as a new revision. With
+// `resetReviewItems=true` the review items of the question start over when
+// its correct answer changes.
+func (h *Handlers) RestoreQuestionRevisionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	current, err := findQuestion(ctx, h.deps.Questions, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.authorizeQuizOwner(c, current.quizId); err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		return apperror.BadRequest("invalid revision")
+	}
+	revision, err := h.getQuestionRevision(c.Request().Context(), current.id, number)
+	if err != nil {
+		return err
+	}
+	restored, err := decodeSnapshot(current.questionType, revision.Snapshot)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	restoredFrom := revision.Revision
+	switch q := restored.(type) {
+	case *models.SingleChoiceQuestion:
+		dbQuestion := question.DBSingleChoiceQuestion{UUID: current.id, QuizID: current.quizId, Question: q.Question, Answers: q.Answers, CorrectAnswer: q.CorrectAnswer, Status: current.status, Published: current.published}
+		result := dbQuestion.MapToModel()
+		err = h.saveQuestionChange(c, current.model, result, &restoredFrom, func(tx Dependencies) error {
+			return tx.Questions.UpdateSingleChoiceQuestion(ctx, &dbQuestion)
+		})
+		restored = result
+	case *models.MultipleChoiceQuestion:
+		dbQuestion := question.DBMultipleChoiceQuestion{UUID: current.id, QuizID: current.quizId, Question: q.Question, Answers: q.Answers, CorrectAnswers: q.CorrectAnswers, Status: current.status, Published: current.published}
+		result := dbQuestion.MapToModel()
+		err = h.saveQuestionChange(c, current.model, result, &restoredFrom, func(tx Dependencies) error {
+			return tx.Questions.UpdateMultipleChoiceQuestion(ctx, &dbQuestion)
+		})
+		restored = result
+	case *models.TrueOrFalseQuestion:
+		dbQuestion := question.DBTrueOrFalseQuestion{UUID: current.id, QuizID: current.quizId, Question: q.Question, CorrectAnswer: q.CorrectAnswer, Status: current.status, Published: current.published}
+		result := dbQuestion.MapToModel()
+		err = h.saveQuestionChange(c, current.model, result, &restoredFrom, func(tx Dependencies) error {
+			return tx.Questions.UpdateTrueOrFalseQuestion(ctx, &dbQuestion)
+		})
+		restored = result
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, restored)
+}
+
+// The current state of a question of any type
+type currentQuestion struct {
+	id           string
+	quizId       string
+	questionType models.QuestionType
+	status       string
+	published    bool
+	model        any
+}
+
+// Looks for the question in the tables of every type, the errors are *apperror.Error
+func findQuestion(ctx context.Context, questions question.Repository, id string) (currentQuestion, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return currentQuestion{}, apperror.BadRequest("invalid id")
+	}
+	single, err := questions.GetSingleChoiceQuestion(ctx, id)
+	if err == nil {
+		return currentQuestion{id: id, quizId: single.QuizID, questionType: models.SingleChoice, status: single.Status, published: single.Published, model: single.MapToModel()}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return currentQuestion{}, apperror.Internal(err)
+	}
+	multiple, err := questions.GetMultipleChoiceQuestion(ctx, id)
+	if err == nil {
+		return currentQuestion{id: id, quizId: multiple.QuizID, questionType: models.MultipleChoice, status: multiple.Status, published: multiple.Published, model: multiple.MapToModel()}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return currentQuestion{}, apperror.Internal(err)
+	}
+	trueOrFalse, err := questions.GetTrueOrFalseQuestion(ctx, id)
+	if err == nil {
+		return currentQuestion{id: id, quizId: trueOrFalse.QuizID, questionType: models.TrueOrFalse, status: trueOrFalse.Status, published: trueOrFalse.Published, model: trueOrFalse.MapToModel()}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return currentQuestion{}, apperror.Internal(err)
+	}
+	return currentQuestion{}, apperror.NotFound("question not found")
+}
+
+// The errors are *apperror.Error
+func (h *Handlers) getQuestionRevision(ctx context.Context, questionId string, number int) (*db.GetQuestionRevisionRow, error) {
+	revision, err := h.deps.Revisions.GetQuestionRevision(ctx, db.GetQuestionRevisionParams{QuestionID: questionId, Revision: int32(number)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperror.NotFound(fmt.Sprintf("revision %d not found", number))
+	}
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return revision, nil
+}
+
+// Saves a change of a question made by update, with its revision. With
+// `resetReviewItems=true` the review items of the question start over when
+// the correct answer changed, so learners are not scheduled by what they
+// knew of the old question. The error is an *apperror.Error.
+func (h *Handlers) saveQuestionChange(c echo.Context, before any, after any, restoredFrom *int32, update func(tx Dependencies) error) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
+	defer cancel()
+	questionId := questionIdOf(after)
+	resetReviewItems := c.QueryParam("resetReviewItems") == "true" &&
+		!slices.Equal(correctAnswerOf(before), correctAnswerOf(after))
+
+	var reset int64
+	err := h.deps.WithTx(ctx, func(tx Dependencies) error {
+		if err := update(tx); err != nil {
+			return err
+		}
+		if err := recordRevision(ctx, c, tx, before, after, restoredFrom); err != nil {
+			return err
+		}
+		if !resetReviewItems {
+			return nil
+		}
+		var err error
+		reset, err = tx.ReviewItems.ResetReviewItemsOfQuestion(ctx, db.ResetReviewItemsOfQuestionParams{
+			QuestionID:        questionId,
+			EaseFactor:        constants.EASE_FACTOR_DEFAULT,
+			Difficulty:        constants.REVIEW_ITEM_DIFFICULTY_DEFAULT,
+			Streak:            constants.REVIEW_ITEM_STREAK_DEFAULT,
+			NextReviewDate:    pgtype.Timestamptz{Time: time.Now().UTC(), InfinityModifier: pgtype.Finite, Valid: true},
+			IntervalInMinutes: constants.REVIEW_ITEM_INTERVAL_IN_MINUTES_DEFAULT,
+		})
+		return err
+	})
+	if err != nil {
+		return apperror.Internalf("saving question %s: %w", questionId, err)
+	}
+
+	if restoredFrom != nil {
+		h.auditQuestion(c, audit.QuestionRestored, questionId, before, after)
+	} else {
+		h.auditQuestion(c, audit.QuestionUpdated, questionId, before, after)
+	}
+	if reset > 0 {
+		auth.Audit(c, h.deps.Audit, audit.Event{
+			Action:     audit.ReviewItemsReset,
+			TargetType: audit.TargetQuestion,
+			TargetId:   questionId,
+			Diff:       audit.Details(map[string]int64{"reviewItems": reset}),
+		})
+	}
+	return nil
+}
+
+// Stores after as the next revision of the question, written by the current
+// user. Questions from before the history was kept first get before as their
+// initial revision, without an author. Pass nil as before for created questions.
+// The question stays locked until tx ends, so concurrent changes are numbered
+// one after the other.
+func recordRevision(ctx context.Context, c echo.Context, tx Dependencies, before any, after any, restoredFrom *int32) error {
+	if err := lockQuestion(ctx, tx, after); err != nil {
+		return err
+	}
+	if before != nil {
+		revisions, err := tx.Revisions.GetQuestionRevisions(ctx, questionIdOf(before))
+		if err != nil {
+			return err
+		}
+		if len(revisions) == 0 {
+			if err := createRevision(ctx, tx, nil, before, nil); err != nil {
+				return err
+			}
+		}
+	}
+	var authorId *string
+	if userId := auth.CurrentUserId(c); userId != "" {
+		authorId = &userId
+	}
+	return createRevision(ctx, tx, authorId, after, restoredFrom)
+}
+
+func lockQuestion(ctx context.Context, tx Dependencies, q any) error {
+	switch questionTypeOf(q) {
+	case models.SingleChoice:
+		return tx.Revisions.LockSingleChoiceQuestion(ctx, questionIdOf(q))
+	case models.MultipleChoice:
+		return tx.Revisions.LockMultipleChoiceQuestion(ctx, questionIdOf(q))
+	case models.TrueOrFalse:
+		return tx.Revisions.LockTrueOrFalseQuestion(ctx, questionIdOf(q))
+	}
+	return fmt.Errorf("question %s has no revisions", questionIdOf(q))
+}
+
+func createRevision(ctx context.Context, tx Dependencies, authorId *string, snapshot any, restoredFrom *int32) error {
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Revisions.CreateQuestionRevision(ctx, db.CreateQuestionRevisionParams{
+		ID:           uuid.NewString(),
+		QuestionID:   questionIdOf(snapshot),
+		QuestionType: questionTypeNames[questionTypeOf(snapshot)],
+		AuthorID:     authorId,
+		Snapshot:     encoded,
+		RestoredFrom: restoredFrom,
+	})
+	return err
+}
+
+func mapQuestionRevision(row db.GetQuestionRevisionRow) models.QuestionRevision {
+	revision := models.QuestionRevision{
+		Id:         row.ID,
+		QuestionId: row.QuestionID,
+		Revision:   int(row.Revision),
+		AuthorId:   row.AuthorID,
+		AuthorName: row.AuthorName,
+		CreatedAt:  row.CreatedAt.Time,
+		Question:   row.Snapshot,
+	}
+	for questionType, name := range questionTypeNames {
+		if name == row.QuestionType {
+			revision.QuestionType = questionType
+		}
+	}
+	if row.RestoredFrom != nil {
+		restoredFrom := int(*row.RestoredFrom)
+		revision.RestoredFrom = &restoredFrom
+	}
+	return revision
+}
+
+func decodeSnapshot(questionType models.QuestionType, snapshot []byte) (any, error) {
+	var decoded any
+	switch questionType {
+	case models.SingleChoice:
+		decoded = &models.SingleChoiceQuestion{}
+	case models.MultipleChoice:
+		decoded = &models.MultipleChoiceQuestion{}
+	case models.TrueOrFalse:
+		decoded = &models.TrueOrFalseQuestion{}
+	default:
+		return nil, fmt.Errorf("question type %d has no revisions", questionType)
+	}
+	if err := json.Unmarshal(snapshot, decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func questionIdOf(q any) string {
+	switch q := q.(type) {
+	case *models.SingleChoiceQuestion:
+		return q.ID
+	case *models.MultipleChoiceQuestion:
+		return q.ID
+	case *models.TrueOrFalseQuestion:
+		return q.ID
+	}
+	return ""
+}
+
+func questionTypeOf(q any) models.QuestionType {
+	switch q.(type) {
+	case *models.SingleChoiceQuestion:
+		return models.SingleChoice
+	case *models.MultipleChoiceQuestion:
+		return models.MultipleChoice
+	case *models.TrueOrFalseQuestion:
+		return models.TrueOrFalse
+	}
+	return models.OpenEnded
+}
+
+// The correct answer as learners see it, the letters with the text of their
+// options, so rewording a correct option changes it too
+func correctAnswerOf(q any) []string {
+	option := func(answers []string, letter string) string {
+		index := slices.Index(answerLetters, letter)
+		if index < 0 || index >= len(answers) {
+			return letter
+		}
+		return letter + ": " + answers[index]
+	}
+	switch q := q.(type) {
+	case *models.SingleChoiceQuestion:
+		return []string{option(q.Answers, q.CorrectAnswer)}
+	case *models.MultipleChoiceQuestion:
+		correct := []string{}
+		for _, letter := range q.CorrectAnswers {
+			correct = append(correct, option(q.Answers, letter))
+		}
+		slices.Sort(correct)
+		return correct
+	case *models.TrueOrFalseQuestion:
+		return []string{strconv.FormatBool(q.CorrectAnswer)}
+	}
+	return nil
+}
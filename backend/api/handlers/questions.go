@@ -1,20 +1,26 @@
 package handlers
 
 import (
-	"bytes"
+	"context"
 	"crypto/sha256"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
 	"spaced-ace-backend/auth"
-	"spaced-ace-backend/constants"
+	"spaced-ace-backend/logging"
+	"spaced-ace-backend/metrics"
 	"spaced-ace-backend/question"
-	"spaced-ace-backend/quiz"
+	"spaced-ace-backend/usage"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 )
 
@@ -38,272 +44,364 @@ type trueOrFalseResponse struct {
 
 // #####
 
-type textChunk struct {
-	Id   string `json:"id"`
-	Text string `json:"chunk"`
-}
-
-type prompt struct {
-	Prompt string `json:"prompt"`
-}
-
 type cacheEntry struct {
-	chunks        *[]textChunk
+	chunks        []TextChunk
 	IndexLastUsed int
 }
 
-type quizAccess struct {
-	userId string
-	quizId string
-	access int
+// The details of the quota_exceeded error
+type QuotaExceededDetails struct {
+	Period   string    `json:"period"`
+	Limit    int       `json:"limit"`
+	Used     int       `json:"used"`
+	ResetsAt time.Time `json:"resetsAt"`
 }
 
-var cache = make(map[string]cacheEntry)
+// Asks the llm api for a question of the given type and decodes it into generated.
+// The call takes its place in the quota of the user before it is made, so
+// concurrent generations cannot go over it, and gives it back when it fails.
+func (h *Handlers) generateQuestion(c echo.Context, request models.QuestionCreationRequestBody, questionType string, generated any) error {
+	ctx := c.Request().Context()
+	user := auth.CurrentUser(c)
+
+	call, err := usage.Reserve(ctx, h.deps.Usage, usage.Call{
+		UserId:       user.Id,
+		QuizId:       request.QuizId,
+		QuestionType: questionType,
+	}, user.Plan)
+	if err != nil {
+		var quotaErr *usage.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return apperror.New(http.StatusTooManyRequests, apperror.CodeQuotaExceeded, quotaErr.Error()).WithDetails(QuotaExceededDetails{
+				Period:   quotaErr.Period.Name,
+				Limit:    quotaErr.Period.Limit,
+				Used:     quotaErr.Period.Used,
+				ResetsAt: quotaErr.Period.ResetsAt,
+			})
+		}
+		return apperror.Internalf("reserving the quota: %w", err)
+	}
+	// Failed until the llm answered, a failed call gives its place back
+	call.Success = false
+	defer func() {
+		// Record even when the client went away, the llm api was still used
+		if err := usage.Record(context.WithoutCancel(ctx), h.deps.Usage, call); err != nil {
+			logging.Logger(ctx).Error("failed to record llm usage", "error", err)
+		}
+	}()
 
-func CreateMultipleChoiceQuestionEndpoint(c echo.Context) error {
-	var request = models.QuestionCreationRequestBody{}
-	err := json.NewDecoder(c.Request().Body).Decode(&request)
+	chunkToUse, err := h.manageChunking(ctx, request.Prompt)
 	if err != nil {
-		fmt.Println(err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
-	}
-	quizAccess, err := accessControlQuiz(c, request.QuizId)
-	if quizAccess.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+		return err
 	}
+	call.PromptChars = len(chunkToUse.Text)
 
-	chunkToUse, err := manageChunking(request.Prompt)
+	started := time.Now()
+	err = h.deps.Llm.Generate(ctx, questionType, chunkToUse.Text, generated)
+	call.Latency = time.Since(started)
 	if err != nil {
-		fmt.Println(err.Error())
-		return c.JSON(http.StatusInternalServerError, "error chunking prompt")
+		return apperror.Internalf("generating a %s question: %w", questionType, err)
 	}
-	promptJson, err := json.Marshal(prompt{Prompt: chunkToUse.Text})
+	call.Success = true
+	return nil
+}
+
+// Stores a new question with create together with its first revision. The
+// error is an *apperror.Error.
+func (h *Handlers) createQuestion(c echo.Context, created any, create func(ctx context.Context, tx Dependencies) error) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
+	defer cancel()
+	err := h.deps.WithTx(ctx, func(tx Dependencies) error {
+		if err := create(ctx, tx); err != nil {
+			return err
+		}
+		return recordRevision(ctx, c, tx, nil, created, nil)
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error marshalling prompt")
+		return apperror.Internalf("creating question %s: %w", questionIdOf(created), err)
 	}
-	res, err := http.Post(
-		constants.LLM_API_URL+"/multiple-choice/create",
-		"application/json",
-		bytes.NewBuffer(promptJson),
-	)
+	return nil
+}
+
+// Generates a multiple choice question with the llm, it waits in the review queue as
+// a draft until the owner approves it
+func (h *Handlers) CreateMultipleChoiceQuestionEndpoint(c echo.Context) error {
+	var request = models.QuestionCreationRequestBody{}
+	err := json.NewDecoder(c.Request().Body).Decode(&request)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error during question generation")
+		return apperror.BadRequest("bad request").WithCause(err)
+	}
+	if err := h.authorizeQuizOwner(c, request.QuizId); err != nil {
+		return err
 	}
-	defer res.Body.Close()
+
 	generated := multipleChoiceResponse{}
-	err = json.NewDecoder(res.Body).Decode(&generated)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error decoding response")
+	if err := h.generateQuestion(c, request, "multiple-choice", &generated); err != nil {
+		return err
 	}
 
 	dbQuestion := question.DBMultipleChoiceQuestion{
 		UUID:           uuid.New().String(),
-		QuizID:         quizAccess.quizId,
+		QuizID:         request.QuizId,
 		Question:       generated.Question,
 		Answers:        generated.Options,
 		CorrectAnswers: generated.CorrectOptions,
+		Status:         question.STATUS_DRAFT,
 	}
-	err = question.CreateMultipleChoiceQuestion(&dbQuestion)
+	err = h.createQuestion(c, dbQuestion.MapToModel(), func(ctx context.Context, tx Dependencies) error {
+		return tx.Questions.CreateMultipleChoiceQuestion(ctx, &dbQuestion)
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	result := models.MultipleChoiceQuestion{
-		ID:             dbQuestion.UUID,
-		QuizID:         quizAccess.quizId,
-		QuestionType:   models.MultipleChoice,
-		Question:       generated.Question,
-		Answers:        generated.Options,
-		CorrectAnswers: generated.CorrectOptions,
-	}
-	return c.JSON(http.StatusOK, &result)
+	h.auditQuestion(c, audit.QuestionCreated, dbQuestion.UUID, nil, dbQuestion.MapToModel())
+	return c.JSON(http.StatusOK, dbQuestion.MapToModel())
 }
 
-func CreateSingleChoiceQuestionEndpoint(c echo.Context) error {
+// Generates a single choice question with the llm, it waits in the review queue as
+// a draft until the owner approves it
+func (h *Handlers) CreateSingleChoiceQuestionEndpoint(c echo.Context) error {
 	var request = models.QuestionCreationRequestBody{}
 	err := json.NewDecoder(c.Request().Body).Decode(&request)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
-	quizAccess, err := accessControlQuiz(c, request.QuizId)
-	if quizAccess.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+	if err := h.authorizeQuizOwner(c, request.QuizId); err != nil {
+		return err
 	}
 
-	chunkToUse, err := manageChunking(request.Prompt)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error chunking prompt")
-	}
-	promptJson, err := json.Marshal(prompt{Prompt: chunkToUse.Text})
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error marshalling prompt")
-	}
-	res, err := http.Post(
-		constants.LLM_API_URL+"/single-choice/create",
-		"application/json",
-		bytes.NewBuffer(promptJson),
-	)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error during question generation")
-	}
-	defer res.Body.Close()
 	generated := singleChoiceResponse{}
-	err = json.NewDecoder(res.Body).Decode(&generated)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error decoding response")
+	if err := h.generateQuestion(c, request, "single-choice", &generated); err != nil {
+		return err
 	}
 
 	dbQuestion := question.DBSingleChoiceQuestion{
 		UUID:          uuid.New().String(),
-		QuizID:        quizAccess.quizId,
+		QuizID:        request.QuizId,
 		Question:      generated.Question,
 		Answers:       generated.Options,
 		CorrectAnswer: generated.CorrectOption,
+		Status:        question.STATUS_DRAFT,
 	}
-	err = question.CreateSingleChoiceQuestion(&dbQuestion)
+	err = h.createQuestion(c, dbQuestion.MapToModel(), func(ctx context.Context, tx Dependencies) error {
+		return tx.Questions.CreateSingleChoiceQuestion(ctx, &dbQuestion)
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	result := models.SingleChoiceQuestion{
-		ID:            dbQuestion.UUID,
-		QuizID:        quizAccess.quizId,
-		QuestionType:  models.SingleChoice,
-		Question:      generated.Question,
-		Answers:       generated.Options,
-		CorrectAnswer: generated.CorrectOption,
-	}
-	return c.JSON(http.StatusOK, &result)
+	h.auditQuestion(c, audit.QuestionCreated, dbQuestion.UUID, nil, dbQuestion.MapToModel())
+	return c.JSON(http.StatusOK, dbQuestion.MapToModel())
 }
 
-func CreateTrueOrFalseQuestionEndpoint(c echo.Context) error {
+// Generates a true or false question with the llm, it waits in the review queue as
+// a draft until the owner approves it
+func (h *Handlers) CreateTrueOrFalseQuestionEndpoint(c echo.Context) error {
 	var request = models.QuestionCreationRequestBody{}
 	err := json.NewDecoder(c.Request().Body).Decode(&request)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
-	quizAccess, err := accessControlQuiz(c, request.QuizId)
-	if quizAccess.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+	if err := h.authorizeQuizOwner(c, request.QuizId); err != nil {
+		return err
 	}
 
-	chunkToUse, err := manageChunking(request.Prompt)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error chunking prompt")
-	}
-	promptJson, err := json.Marshal(prompt{Prompt: chunkToUse.Text})
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error marshalling prompt")
-	}
-	res, err := http.Post(
-		constants.LLM_API_URL+"/true-or-false/create",
-		"application/json",
-		bytes.NewBuffer(promptJson),
-	)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error during question generation")
-	}
-	defer res.Body.Close()
 	generated := trueOrFalseResponse{}
-	err = json.NewDecoder(res.Body).Decode(&generated)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "error decoding response")
+	if err := h.generateQuestion(c, request, "true-or-false", &generated); err != nil {
+		return err
 	}
 
 	dbQuestion := question.DBTrueOrFalseQuestion{
 		UUID:          uuid.New().String(),
-		QuizID:        quizAccess.quizId,
+		QuizID:        request.QuizId,
 		Question:      generated.Question,
 		CorrectAnswer: generated.CorrectAnswer,
+		Status:        question.STATUS_DRAFT,
 	}
-	err = question.CreateTrueOrFalseQuestion(&dbQuestion)
+	err = h.createQuestion(c, dbQuestion.MapToModel(), func(ctx context.Context, tx Dependencies) error {
+		return tx.Questions.CreateTrueOrFalseQuestion(ctx, &dbQuestion)
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	result := models.TrueOrFalseQuestion{
-		ID:            dbQuestion.UUID,
-		QuizID:        quizAccess.quizId,
-		QuestionType:  models.TrueOrFalse,
-		Question:      generated.Question,
-		CorrectAnswer: generated.CorrectAnswer,
+	h.auditQuestion(c, audit.QuestionCreated, dbQuestion.UUID, nil, dbQuestion.MapToModel())
+	return c.JSON(http.StatusOK, dbQuestion.MapToModel())
+}
+
+// Creates a multiple choice question written by the user instead of the llm,
+// so it does not count against the quota
+func (h *Handlers) CreateManualMultipleChoiceQuestionEndpoint(c echo.Context) error {
+	var request = models.MultipleChoiceUpdateRequestBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request").WithCause(err)
 	}
-	return c.JSON(http.StatusOK, &result)
+	if err := h.authorizeQuizOwner(c, request.QuizId); err != nil {
+		return err
+	}
+	if err := validateMultipleChoice(request.Question, request.Answers, request.CorrectAnswers); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	dbQuestion := question.DBMultipleChoiceQuestion{
+		UUID:           uuid.New().String(),
+		QuizID:         request.QuizId,
+		Question:       request.Question,
+		Answers:        request.Answers,
+		CorrectAnswers: request.CorrectAnswers,
+		Status:         question.STATUS_APPROVED,
+	}
+	result := dbQuestion.MapToModel()
+	err := h.createQuestion(c, result, func(ctx context.Context, tx Dependencies) error {
+		return tx.Questions.CreateMultipleChoiceQuestion(ctx, &dbQuestion)
+	})
+	if err != nil {
+		return err
+	}
+	h.auditQuestion(c, audit.QuestionCreated, dbQuestion.UUID, nil, result)
+	return c.JSON(http.StatusOK, result)
 }
 
-func GetMultipleChoiceEndpoint(c echo.Context) error {
-	_, err := c.Cookie("session")
+// Creates a single choice question written by the user instead of the llm,
+// so it does not count against the quota
+func (h *Handlers) CreateManualSingleChoiceQuestionEndpoint(c echo.Context) error {
+	var request = models.SingleChoiceUpdateRequestBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request").WithCause(err)
+	}
+	if err := h.authorizeQuizOwner(c, request.QuizId); err != nil {
+		return err
+	}
+	if err := validateSingleChoice(request.Question, request.Answers, request.CorrectAnswer); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	dbQuestion := question.DBSingleChoiceQuestion{
+		UUID:          uuid.New().String(),
+		QuizID:        request.QuizId,
+		Question:      request.Question,
+		Answers:       request.Answers,
+		CorrectAnswer: request.CorrectAnswer,
+		Status:        question.STATUS_APPROVED,
+	}
+	result := dbQuestion.MapToModel()
+	err := h.createQuestion(c, result, func(ctx context.Context, tx Dependencies) error {
+		return tx.Questions.CreateSingleChoiceQuestion(ctx, &dbQuestion)
+	})
+	if err != nil {
+		return err
+	}
+	h.auditQuestion(c, audit.QuestionCreated, dbQuestion.UUID, nil, result)
+	return c.JSON(http.StatusOK, result)
+}
+
+// Creates a true or false question written by the user instead of the llm,
+// so it does not count against the quota
+func (h *Handlers) CreateManualTrueOrFalseQuestionEndpoint(c echo.Context) error {
+	var request = models.TrueOrFalseUpdateRequestBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request").WithCause(err)
+	}
+	if err := h.authorizeQuizOwner(c, request.QuizId); err != nil {
+		return err
+	}
+	if err := validateTrueOrFalse(request.Question); err != nil {
+		return apperror.BadRequest(err.Error())
+	}
+
+	dbQuestion := question.DBTrueOrFalseQuestion{
+		UUID:          uuid.New().String(),
+		QuizID:        request.QuizId,
+		Question:      request.Question,
+		CorrectAnswer: request.CorrectAnswer,
+		Status:        question.STATUS_APPROVED,
+	}
+	result := dbQuestion.MapToModel()
+	err := h.createQuestion(c, result, func(ctx context.Context, tx Dependencies) error {
+		return tx.Questions.CreateTrueOrFalseQuestion(ctx, &dbQuestion)
+	})
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+		return err
 	}
+	h.auditQuestion(c, audit.QuestionCreated, dbQuestion.UUID, nil, result)
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *Handlers) GetMultipleChoiceEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId := c.Param("id")
-	q, err := question.GetMultipleChoiceQuestion(questionId)
+	q, err := h.deps.Questions.GetMultipleChoiceQuestion(ctx, questionId)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusNotFound, "question not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("question not found")
 		}
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return apperror.Internal(err)
+	}
+	if err := h.authorizeQuizViewer(c, q.QuizID); err != nil {
+		return err
 	}
 	result := q.MapToModel()
 	return c.JSON(http.StatusOK, &result)
 }
 
-func GetSingleChoiceEndpoint(c echo.Context) error {
-	_, err := c.Cookie("session")
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
+func (h *Handlers) GetSingleChoiceEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId := c.Param("id")
-	q, err := question.GetSingleChoiceQuestion(questionId)
+	q, err := h.deps.Questions.GetSingleChoiceQuestion(ctx, questionId)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusNotFound, "question not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("question not found")
 		}
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return apperror.Internal(err)
+	}
+	if err := h.authorizeQuizViewer(c, q.QuizID); err != nil {
+		return err
 	}
 	result := q.MapToModel()
 	return c.JSON(http.StatusOK, result)
 }
 
-func GetTrueOrFalseEndpoint(c echo.Context) error {
-	_, err := c.Cookie("session")
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
+func (h *Handlers) GetTrueOrFalseEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId := c.Param("id")
-	q, err := question.GetTrueOrFalseQuestion(questionId)
+	q, err := h.deps.Questions.GetTrueOrFalseQuestion(ctx, questionId)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusNotFound, "question not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("question not found")
 		}
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return apperror.Internal(err)
+	}
+	if err := h.authorizeQuizViewer(c, q.QuizID); err != nil {
+		return err
 	}
 	result := q.MapToModel()
 	return c.JSON(http.StatusOK, result)
 }
 
-func UpdateMultipleChoiceQuestionEndpoint(c echo.Context) error {
+// Changes the given fields and keeps the change as a revision. With
+// `resetReviewItems=true` the review items start over when the correct answer changes.
+// The owner is the reviewer, so a published question stays published and
+// learners get the change at once.
+func (h *Handlers) UpdateMultipleChoiceQuestionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid id")
+		return apperror.BadRequest("invalid id")
 	}
 	request := models.MultipleChoiceUpdateRequestBody{}
 	err = json.NewDecoder(c.Request().Body).Decode(&request)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
-	access, err := accessControlQuiz(c, request.QuizId)
+	questionToUpdate, err := h.deps.Questions.GetMultipleChoiceQuestion(ctx, questionId.String())
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
-	if access.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
-
-	questionToUpdate, err := question.GetMultipleChoiceQuestion(questionId.String())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusNotFound, "question not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("question not found")
 		}
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return apperror.Internal(err)
+	}
+	if err := h.authorizeQuizOwner(c, questionToUpdate.QuizID); err != nil {
+		return err
 	}
+	before := questionToUpdate.MapToModel()
 	if request.Question != "" {
 		questionToUpdate.Question = request.Question
 	}
@@ -313,41 +411,45 @@ func UpdateMultipleChoiceQuestionEndpoint(c echo.Context) error {
 	if len(request.CorrectAnswers) > 0 {
 		questionToUpdate.CorrectAnswers = request.CorrectAnswers
 	}
-	fmt.Println(questionToUpdate)
-	err = question.UpdateMultipleChoiceQuestion(&questionToUpdate)
-
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+	if err := validateMultipleChoice(questionToUpdate.Question, questionToUpdate.Answers, questionToUpdate.CorrectAnswers); err != nil {
+		return apperror.BadRequest(err.Error())
 	}
 	result := questionToUpdate.MapToModel()
+	err = h.saveQuestionChange(c, before, result, nil, func(tx Dependencies) error {
+		return tx.Questions.UpdateMultipleChoiceQuestion(ctx, &questionToUpdate)
+	})
+	if err != nil {
+		return err
+	}
 	return c.JSON(http.StatusOK, result)
 }
 
-func UpdateSingleChoiceQuestionEndpoint(c echo.Context) error {
+// Changes the given fields and keeps the change as a revision. With
+// `resetReviewItems=true` the review items start over when the correct answer changes.
+// The owner is the reviewer, so a published question stays published and
+// learners get the change at once.
+func (h *Handlers) UpdateSingleChoiceQuestionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid id")
+		return apperror.BadRequest("invalid id")
 	}
 	request := models.SingleChoiceUpdateRequestBody{}
 	err = json.NewDecoder(c.Request().Body).Decode(&request)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
-	access, err := accessControlQuiz(c, request.QuizId)
+	questionToUpdate, err := h.deps.Questions.GetSingleChoiceQuestion(ctx, questionId.String())
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
-	if access.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
-
-	questionToUpdate, err := question.GetSingleChoiceQuestion(questionId.String())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusNotFound, "question not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("question not found")
 		}
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return apperror.Internal(err)
+	}
+	if err := h.authorizeQuizOwner(c, questionToUpdate.QuizID); err != nil {
+		return err
 	}
+	before := questionToUpdate.MapToModel()
 	if request.Question != "" {
 		questionToUpdate.Question = request.Question
 	}
@@ -357,183 +459,252 @@ func UpdateSingleChoiceQuestionEndpoint(c echo.Context) error {
 	if request.CorrectAnswer != "" {
 		questionToUpdate.CorrectAnswer = request.CorrectAnswer
 	}
-	err = question.UpdateSingleChoiceQuestion(&questionToUpdate)
-
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+	if err := validateSingleChoice(questionToUpdate.Question, questionToUpdate.Answers, questionToUpdate.CorrectAnswer); err != nil {
+		return apperror.BadRequest(err.Error())
 	}
 	result := questionToUpdate.MapToModel()
+	err = h.saveQuestionChange(c, before, result, nil, func(tx Dependencies) error {
+		return tx.Questions.UpdateSingleChoiceQuestion(ctx, &questionToUpdate)
+	})
+	if err != nil {
+		return err
+	}
 	return c.JSON(http.StatusOK, &result)
 }
 
-func UpdateTrueOrFalseQuestionEndpoint(c echo.Context) error {
+// Changes the given fields and keeps the change as a revision. With
+// `resetReviewItems=true` the review items start over when the correct answer changes.
+// The owner is the reviewer, so a published question stays published and
+// learners get the change at once.
+func (h *Handlers) UpdateTrueOrFalseQuestionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid id")
+		return apperror.BadRequest("invalid id")
 	}
 	request := models.TrueOrFalseUpdateRequestBody{}
 	err = json.NewDecoder(c.Request().Body).Decode(&request)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
-	access, err := accessControlQuiz(c, request.QuizId)
+	questionToUpdate, err := h.deps.Questions.GetTrueOrFalseQuestion(ctx, questionId.String())
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
-	if access.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
-	}
-
-	questionToUpdate, err := question.GetTrueOrFalseQuestion(questionId.String())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusNotFound, "question not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("question not found")
 		}
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return apperror.Internal(err)
 	}
+	if err := h.authorizeQuizOwner(c, questionToUpdate.QuizID); err != nil {
+		return err
+	}
+	before := questionToUpdate.MapToModel()
 	if request.Question != "" {
 		questionToUpdate.Question = request.Question
 	}
 	questionToUpdate.CorrectAnswer = request.CorrectAnswer
-	err = question.UpdateTrueOrFalseQuestion(&questionToUpdate)
-
+	result := questionToUpdate.MapToModel()
+	err = h.saveQuestionChange(c, before, result, nil, func(tx Dependencies) error {
+		return tx.Questions.UpdateTrueOrFalseQuestion(ctx, &questionToUpdate)
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	result := questionToUpdate.MapToModel()
 	return c.JSON(http.StatusOK, result)
 }
 
-func DeleteMultipleChoiceQuestionEndpoint(c echo.Context) error {
+// Deletes the question with delete together with its revisions. The error
+// is an *apperror.Error.
+func (h *Handlers) deleteQuestion(c echo.Context, questionId string, delete func(tx Dependencies) error) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
+	defer cancel()
+	err := h.deps.WithTx(ctx, func(tx Dependencies) error {
+		if err := delete(tx); err != nil {
+			return err
+		}
+		return tx.Revisions.DeleteQuestionRevisions(ctx, questionId)
+	})
+	if err != nil {
+		return apperror.Internalf("deleting question %s: %w", questionId, err)
+	}
+	return nil
+}
+
+func (h *Handlers) DeleteMultipleChoiceQuestionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid question id")
+		return apperror.BadRequest("invalid question id")
 	}
 	quizId, err := uuid.Parse(c.Param("quizId"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid quiz id")
+		return apperror.BadRequest("invalid quiz id")
 	}
-	access, err := accessControlQuiz(c, quizId.String())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusUnauthorized, "unauthorized")
-		}
-		return c.JSON(http.StatusInternalServerError, "internal server error")
+	if err := h.authorizeQuizOwner(c, quizId.String()); err != nil {
+		return err
 	}
-	if access.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+	questionToDelete, err := h.deps.Questions.GetMultipleChoiceQuestion(ctx, questionId.String())
+	if err != nil || questionToDelete.QuizID != quizId.String() {
+		return apperror.NotFound("question not found")
 	}
-	err = question.DeleteMultipleChoiceQuestion(questionId.String())
+	err = h.deleteQuestion(c, questionId.String(), func(tx Dependencies) error {
+		return tx.Questions.DeleteMultipleChoiceQuestion(ctx, questionId.String())
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
+	h.auditQuestion(c, audit.QuestionDeleted, questionToDelete.UUID, questionToDelete.MapToModel(), nil)
 	return c.JSON(http.StatusOK, "question deleted")
 }
 
-func DeleteSingleChoiceQuestionEndpoint(c echo.Context) error {
+func (h *Handlers) DeleteSingleChoiceQuestionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid question id")
+		return apperror.BadRequest("invalid question id")
 	}
 	quizId, err := uuid.Parse(c.Param("quizId"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid quiz id")
+		return apperror.BadRequest("invalid quiz id")
 	}
-	access, err := accessControlQuiz(c, quizId.String())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusUnauthorized, "unauthorized")
-		}
-		return c.JSON(http.StatusInternalServerError, "internal server error")
+	if err := h.authorizeQuizOwner(c, quizId.String()); err != nil {
+		return err
 	}
-	if access.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+	questionToDelete, err := h.deps.Questions.GetSingleChoiceQuestion(ctx, questionId.String())
+	if err != nil || questionToDelete.QuizID != quizId.String() {
+		return apperror.NotFound("question not found")
 	}
-	err = question.DeleteSingleChoiceQuestion(questionId.String())
+	err = h.deleteQuestion(c, questionId.String(), func(tx Dependencies) error {
+		return tx.Questions.DeleteSingleChoiceQuestion(ctx, questionId.String())
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
+	h.auditQuestion(c, audit.QuestionDeleted, questionToDelete.UUID, questionToDelete.MapToModel(), nil)
 	return c.JSON(http.StatusOK, "question deleted")
 }
 
-func DeleteTrueOrFalseQuestionEndpoint(c echo.Context) error {
+func (h *Handlers) DeleteTrueOrFalseQuestionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	questionId, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid question id")
+		return apperror.BadRequest("invalid question id")
 	}
 	quizId, err := uuid.Parse(c.Param("quizId"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, "invalid quiz id")
+		return apperror.BadRequest("invalid quiz id")
 	}
-	access, err := accessControlQuiz(c, quizId.String())
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusUnauthorized, "unauthorized")
-		}
-		return c.JSON(http.StatusInternalServerError, "internal server error")
+	if err := h.authorizeQuizOwner(c, quizId.String()); err != nil {
+		return err
 	}
-	if access.access != 1 {
-		return c.JSON(http.StatusUnauthorized, "unauthorized")
+	questionToDelete, err := h.deps.Questions.GetTrueOrFalseQuestion(ctx, questionId.String())
+	if err != nil || questionToDelete.QuizID != quizId.String() {
+		return apperror.NotFound("question not found")
 	}
-	err = question.DeleteTrueOrFalseQuestion(questionId.String())
+	err = h.deleteQuestion(c, questionId.String(), func(tx Dependencies) error {
+		return tx.Questions.DeleteTrueOrFalseQuestion(ctx, questionId.String())
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return err
 	}
+	h.auditQuestion(c, audit.QuestionDeleted, questionToDelete.UUID, questionToDelete.MapToModel(), nil)
 	return c.JSON(http.StatusOK, "question deleted")
 }
 
-func accessControlQuiz(c echo.Context, quizId string) (*quizAccess, error) {
-	_, err := uuid.Parse(quizId)
-	if err != nil {
-		fmt.Println(err.Error())
-		return nil, err
+var answerLetters = []string{"A", "B", "C", "D"}
+
+// The checks of the questions written or changed by users, so they can be
+// shown and answered the way the generated ones are
+func validateSingleChoice(q string, answers []string, correctAnswer string) error {
+	if err := validateOptions(q, answers); err != nil {
+		return err
 	}
-	session, err := c.Cookie("session")
-	if err != nil {
-		fmt.Println(err.Error())
-		return nil, err
+	if !slices.Contains(answerLetters, correctAnswer) {
+		return errors.New("the correct answer has to be one of A, B, C and D")
 	}
-	userId, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		fmt.Println(err.Error())
-		return nil, err
+	return nil
+}
+
+func validateMultipleChoice(q string, answers []string, correctAnswers []string) error {
+	if err := validateOptions(q, answers); err != nil {
+		return err
 	}
-	access, err := quiz.GetQuizAccess(userId, quizId)
-	if err != nil {
-		fmt.Println(err.Error())
-		return nil, err
+	if len(correctAnswers) == 0 {
+		return errors.New("needs a correct answer")
+	}
+	for _, answer := range correctAnswers {
+		if !slices.Contains(answerLetters, answer) {
+			return errors.New("the correct answers have to be A, B, C or D")
+		}
+	}
+	return nil
+}
+
+func validateTrueOrFalse(q string) error {
+	if strings.TrimSpace(q) == "" {
+		return errors.New("the question is empty")
+	}
+	return nil
+}
+
+func validateOptions(q string, answers []string) error {
+	if err := validateTrueOrFalse(q); err != nil {
+		return err
 	}
-	return &quizAccess{userId: userId, quizId: quizId, access: access}, nil
+	if len(answers) != len(answerLetters) {
+		return fmt.Errorf("needs %d answers", len(answerLetters))
+	}
+	for _, answer := range answers {
+		if strings.TrimSpace(answer) == "" {
+			return errors.New("the answers cannot be empty")
+		}
+	}
+	return nil
 }
 
-func manageChunking(userPrompt string) (*textChunk, error) {
+// Returns the next chunk of the prompt, the errors are *apperror.Error
+func (h *Handlers) manageChunking(ctx context.Context, userPrompt string) (*TextChunk, error) {
 	promptLength := len(userPrompt)
 	if promptLength == 0 || promptLength > 100_000 {
-		return nil, errors.New("prompt must be between 1 and 100,000 characters")
+		return nil, apperror.BadRequest("prompt must be between 1 and 100,000 characters")
 	}
 	hash := hashPrompt(userPrompt)
-	existingCacheEntry, ok := cache[hash]
+	h.cacheMu.Lock()
+	_, ok := h.cache[hash]
+	h.cacheMu.Unlock()
+	metrics.ObserveChunkCacheLookup(ok)
 	if !ok {
-		chunks, err := chunkPrompt(userPrompt)
+		// Chunking calls the llm, the lock is not held during it so other
+		// prompts are not kept waiting
+		chunks, err := h.deps.Llm.Chunk(ctx, userPrompt)
 		if err != nil {
-			fmt.Println(err.Error())
-			return nil, err
+			return nil, apperror.Internalf("chunking the prompt: %w", err)
 		}
-		existingCacheEntry = cacheEntry{
-			chunks:        chunks,
-			IndexLastUsed: -1,
+		h.cacheMu.Lock()
+		// A concurrent request for the same prompt may have stored it first
+		if _, stored := h.cache[hash]; !stored {
+			h.cache[hash] = cacheEntry{
+				chunks:        chunks,
+				IndexLastUsed: -1,
+			}
 		}
-		cache[hash] = existingCacheEntry
+		h.cacheMu.Unlock()
+	}
+
+	h.cacheMu.Lock()
+	defer h.cacheMu.Unlock()
+	existingCacheEntry := h.cache[hash]
+	if len(existingCacheEntry.chunks) == 0 {
+		return nil, apperror.Internalf("prompt was split into no chunks")
 	}
-	var chunkToUse textChunk
-	if existingCacheEntry.IndexLastUsed < len(*existingCacheEntry.chunks)-1 {
-		chunkToUse = (*existingCacheEntry.chunks)[existingCacheEntry.IndexLastUsed+1]
+	var chunkToUse TextChunk
+	if existingCacheEntry.IndexLastUsed < len(existingCacheEntry.chunks)-1 {
+		chunkToUse = existingCacheEntry.chunks[existingCacheEntry.IndexLastUsed+1]
 		existingCacheEntry.IndexLastUsed++
 	} else {
-		chunkToUse = (*existingCacheEntry.chunks)[0]
+		chunkToUse = existingCacheEntry.chunks[0]
 		existingCacheEntry.IndexLastUsed = 0
 	}
-	cache[hash] = existingCacheEntry
+	h.cache[hash] = existingCacheEntry
 	return &chunkToUse, nil
 }
 
@@ -542,24 +713,3 @@ func hashPrompt(userPrompt string) string {
 	hash.Write([]byte(userPrompt))
 	return string(hash.Sum(nil))
 }
-
-func chunkPrompt(userPrompt string) (*[]textChunk, error) {
-	promptRequest := prompt{Prompt: userPrompt}
-	bodyjson, err := json.Marshal(promptRequest)
-	if err != nil {
-		return nil, err
-	}
-	bodyBuffer := bytes.NewBuffer(bodyjson)
-
-	resp, err := http.Post(constants.LLM_API_URL+"/chunk", "application/json", bodyBuffer)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	result := []textChunk{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
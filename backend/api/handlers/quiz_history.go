@@ -1,57 +1,46 @@
 package handlers
 
 import (
-	"fmt"
 	"github.com/labstack/echo/v4"
-	"golang.org/x/net/context"
 	"net/http"
 	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
 	"spaced-ace-backend/auth"
 	"spaced-ace-backend/db"
-	"spaced-ace-backend/quiz"
-	"spaced-ace-backend/utils"
 	"time"
 )
 
-func GetQuizHistoryEntries(c echo.Context) error {
+func (h *Handlers) GetQuizHistoryEntries(c echo.Context) error {
 	userID := c.QueryParam("userID")
 	if userID == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "missing query param userID")
+		return apperror.BadRequest("missing query param userID")
 	}
 
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+	sessionUserID := auth.CurrentUserId(c)
 
 	if userID != sessionUserID {
-		return echo.NewHTTPError(http.StatusForbidden, "cannot get quiz results for another user")
+		return apperror.Forbidden("cannot get quiz results for another user")
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := requestContext(c, 15*time.Second)
 	defer cancel()
 
-	quizSessions, err := sqlcQuerier.GetQuizSessionsByUserId(ctx, userID)
+	quizSessions, err := h.deps.QuizSessions.GetQuizSessionsByUserId(ctx, userID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("error getting quiz sessions for user: %w", err))
+		return apperror.Internalf("getting quiz sessions for user: %w", err)
 	}
 
-	quizResults, err := sqlcQuerier.GetQuizResultsByUserID(ctx, userID)
+	quizResults, err := h.deps.QuizSessions.GetQuizResultsByUserID(ctx, userID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("error getting quiz results for user: %w", err))
+		return apperror.Internalf("getting quiz results for user: %w", err)
 	}
 
 	quizNameMap := make(map[string]string)
 	for _, result := range quizResults {
 		if quizNameMap[result.QuizID] == "" {
-			dbQuiz, err := quiz.GetQuizById(result.QuizID)
+			dbQuiz, err := h.deps.Quizzes.GetQuizById(ctx, result.QuizID)
 			if err != nil {
-				return echo.NewHTTPError(http.StatusNotFound, fmt.Errorf("quiz not found with ID `%s`: %w", result.QuizID, err))
+				return apperror.Internalf("getting quiz %s of a result: %w", result.QuizID, err)
 			}
 			quizNameMap[result.QuizID] = dbQuiz.Name
 		}
@@ -82,9 +71,9 @@ func GetQuizHistoryEntries(c echo.Context) error {
 			percentage := 0.0
 
 			if result == nil {
-				quizResult, err := calculateAndStoreQuizResult(ctx, quizSession.ID, quizSession.QuizID)
+				quizResult, err := h.submitQuizSession(ctx, quizSession.ID)
 				if err != nil {
-					return echo.NewHTTPError(http.StatusInternalServerError, "error calculating the quiz result: "+err.Error())
+					return apperror.Internalf("calculating the quiz result: %w", err)
 				}
 
 				timeSpent = quizSession.FinishedAt.Time.Sub(quizSession.StartedAt.Time)
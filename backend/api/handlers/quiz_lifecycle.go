@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/constants"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/question"
+	"spaced-ace-backend/quiz"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Approves or rejects a question of the review queue. Rejecting a published
+// question takes it from learners at once, approving one adds it with the
+// next publishing of the quiz.
+func (h *Handlers) UpdateQuestionStatusEndpoint(c echo.Context) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
+	defer cancel()
+	var request models.QuestionStatusRequestBody
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request").WithCause(err)
+	}
+	if !slices.Contains([]string{question.STATUS_APPROVED, question.STATUS_REJECTED}, request.Status) {
+		return apperror.BadRequest("the status has to be approved or rejected")
+	}
+	current, err := findQuestion(ctx, h.deps.Questions, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.authorizeQuizOwner(c, current.quizId); err != nil {
+		return err
+	}
+
+	// Locked like the edits, so a change saved at the same time cannot
+	// overwrite the status or be reviewed before it is visible
+	var updated currentQuestion
+	err = h.deps.WithTx(ctx, func(tx Dependencies) error {
+		if err := lockQuestion(ctx, tx, current.model); err != nil {
+			return err
+		}
+		var err error
+		switch current.questionType {
+		case models.SingleChoice:
+			err = tx.Questions.UpdateSingleChoiceQuestionStatus(ctx, current.id, request.Status)
+		case models.MultipleChoice:
+			err = tx.Questions.UpdateMultipleChoiceQuestionStatus(ctx, current.id, request.Status)
+		case models.TrueOrFalse:
+			err = tx.Questions.UpdateTrueOrFalseQuestionStatus(ctx, current.id, request.Status)
+		}
+		if err != nil {
+			return err
+		}
+		updated, err = findQuestion(ctx, tx.Questions, current.id)
+		return err
+	})
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.Internalf("reviewing question %s: %w", current.id, err)
+	}
+	h.auditQuestion(c, audit.QuestionReviewed, current.id, current.model, updated.model)
+	return c.JSON(http.StatusOK, updated.model)
+}
+
+// Makes the approved questions of the quiz the ones learners get and gives
+// the learners who already have the quiz in their learn list the review items
+// of the new ones. Publishing an archived quiz takes it back into use.
+func (h *Handlers) PublishQuizEndpoint(c echo.Context) error {
+	ctx, cancel := requestContext(c, 10*time.Second)
+	defer cancel()
+	quizId := c.Param("id")
+	if err := h.authorizeQuizOwner(c, quizId); err != nil {
+		return err
+	}
+	before, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	err = h.deps.WithTx(ctx, func(tx Dependencies) error {
+		if err := tx.Questions.PublishQuestionsOfQuiz(ctx, quizId); err != nil {
+			return err
+		}
+		// Counted after publishing, the published questions stay locked until
+		// the commit, so a question rejected meanwhile cannot empty the quiz
+		published, err := publishedQuestionIds(ctx, tx.Questions, quizId)
+		if err != nil {
+			return err
+		}
+		if len(published) == 0 {
+			return apperror.Conflict("approve a question before publishing the quiz")
+		}
+		if err := tx.Quizzes.UpdateQuizStatus(ctx, quizId, quiz.STATUS_PUBLISHED); err != nil {
+			return err
+		}
+		_, err = tx.ReviewItems.CreateMissingReviewItemsOfQuiz(ctx, db.CreateMissingReviewItemsOfQuizParams{
+			QuizID:            quizId,
+			EaseFactor:        constants.EASE_FACTOR_DEFAULT,
+			Difficulty:        constants.REVIEW_ITEM_DIFFICULTY_DEFAULT,
+			Streak:            constants.REVIEW_ITEM_STREAK_DEFAULT,
+			NextReviewDate:    pgtype.Timestamptz{Time: time.Now().UTC(), InfinityModifier: pgtype.Finite, Valid: true},
+			IntervalInMinutes: constants.REVIEW_ITEM_INTERVAL_IN_MINUTES_DEFAULT,
+		})
+		return err
+	})
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.Internalf("publishing quiz %s: %w", quizId, err)
+	}
+	after, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	h.auditQuiz(c, audit.QuizPublished, quizId, before, after)
+	return h.GetQuizEndpoint(c)
+}
+
+// Retires the quiz, it takes no new sessions or learners. The review items
+// of its learners stay.
+func (h *Handlers) ArchiveQuizEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	quizId := c.Param("id")
+	if err := h.authorizeQuizOwner(c, quizId); err != nil {
+		return err
+	}
+	before, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if err := h.deps.Quizzes.UpdateQuizStatus(ctx, quizId, quiz.STATUS_ARCHIVED); err != nil {
+		return apperror.Internal(err)
+	}
+	after, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	h.auditQuiz(c, audit.QuizArchived, quizId, before, after)
+	return h.GetQuizEndpoint(c)
+}
+
+// Returns a conflict unless learners can start the quiz, the error is an *apperror.Error
+func (h *Handlers) requirePublishedQuiz(ctx context.Context, quizId string) error {
+	dbQuiz, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperror.NotFound("quiz not found")
+	}
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	switch dbQuiz.Status {
+	case quiz.STATUS_PUBLISHED:
+		return nil
+	case quiz.STATUS_ARCHIVED:
+		return apperror.Conflict("the quiz is archived")
+	default:
+		return apperror.Conflict("the quiz is not published yet")
+	}
+}
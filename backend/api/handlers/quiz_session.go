@@ -3,17 +3,21 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
-	"log"
 	"net/http"
+	"slices"
 	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
 	"spaced-ace-backend/auth"
 	"spaced-ace-backend/db"
+	"spaced-ace-backend/logging"
+	"spaced-ace-backend/metrics"
 	"spaced-ace-backend/question"
-	"spaced-ace-backend/utils"
 	"strings"
 	"time"
 )
@@ -26,28 +30,24 @@ type GetQuizSessionRequestBody struct {
 	UserId string `json:"userId"`
 }
 
-func StartQuizSession(c echo.Context) error {
+func (h *Handlers) StartQuizSession(c echo.Context) error {
+	ctx, cancel := requestContext(c, 10*time.Second)
+	defer cancel()
 	var request StartQuizSessionRequestBody
 	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
-		fmt.Println(err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return apperror.BadRequest("invalid request body").WithCause(err)
 	}
 
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	userId := auth.CurrentUserId(c)
+	if err := h.authorizeQuizViewer(c, request.QuizId); err != nil {
+		return err
 	}
-	userId, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	if err := h.requirePublishedQuiz(ctx, request.QuizId); err != nil {
+		return err
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	// List quiz sessions
-	openQuizSessions, err := sqlcQuerier.GetQuizSessionsByQuizIdAndUserId(
+	openQuizSessions, err := h.deps.QuizSessions.GetQuizSessionsByQuizIdAndUserId(
 		ctx,
 		db.GetQuizSessionsByQuizIdAndUserIdParams{
 			QuizID: request.QuizId,
@@ -55,7 +55,7 @@ func StartQuizSession(c echo.Context) error {
 		},
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "error getting open quiz sessions: "+err.Error())
+		return apperror.Internalf("getting the open quiz sessions: %w", err)
 	}
 
 	// Close the open quiz sessions
@@ -65,7 +65,7 @@ func StartQuizSession(c echo.Context) error {
 			continue
 		}
 
-		_, err := sqlcQuerier.UpdateQuizSessionFinishedAt(
+		_, err := h.deps.QuizSessions.UpdateQuizSessionFinishedAt(
 			ctx,
 			db.UpdateQuizSessionFinishedAtParams{
 				ID: openQuizSession.ID,
@@ -77,13 +77,17 @@ func StartQuizSession(c echo.Context) error {
 			},
 		)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error closing quiz session: %s", openQuizSession.ID))
+			return apperror.Internalf("closing quiz session %s: %w", openQuizSession.ID, err)
 		}
-		log.Default().Printf("quiz session closed: %s\n", openQuizSession.ID)
+		logging.Logger(ctx).Info("quiz session closed", "quizSessionId", openQuizSession.ID)
 	}
 
-	// Start a new quiz session
-	dbQuizSession, err := sqlcQuerier.CreateQuizSession(
+	// Start a new quiz session with the questions published now
+	questionIds, err := publishedQuestionIds(ctx, h.deps.Questions, request.QuizId)
+	if err != nil {
+		return apperror.Internalf("getting the published questions: %w", err)
+	}
+	dbQuizSession, err := h.deps.QuizSessions.CreateQuizSession(
 		ctx,
 		db.CreateQuizSessionParams{
 			ID:     uuid.NewString(),
@@ -94,30 +98,65 @@ func StartQuizSession(c echo.Context) error {
 				InfinityModifier: pgtype.Finite,
 				Valid:            true,
 			},
+			QuestionIds: questionIds,
 		},
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "error starting a new quiz session")
+		return apperror.Internalf("starting a new quiz session: %w", err)
 	}
-	log.Default().Printf("quiz session started: %s", dbQuizSession.ID)
+	logging.Logger(ctx).Info("quiz session started", "quizSessionId", dbQuizSession.ID)
 
 	quizSession, err := models.MapQuizSession(dbQuizSession)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
 	return c.JSON(http.StatusOK, quizSession)
 }
 
-func GetQuizSessions(c echo.Context) error {
-	userId := c.QueryParam("userId")
+func publishedQuestionIds(ctx context.Context, questions question.Repository, quizId string) ([]string, error) {
+	questionIds := []string{}
+	singleChoice, err := questions.GetPublishedSingleChoiceQuestions(ctx, quizId)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range singleChoice {
+		questionIds = append(questionIds, q.UUID)
+	}
+	multipleChoice, err := questions.GetPublishedMultipleChoiceQuestions(ctx, quizId)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range multipleChoice {
+		questionIds = append(questionIds, q.UUID)
+	}
+	trueOrFalse, err := questions.GetPublishedTrueOrFalseQuestions(ctx, quizId)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range trueOrFalse {
+		questionIds = append(questionIds, q.UUID)
+	}
+	return questionIds, nil
+}
+
+// Whether the question is scored in the session, the ones published when it
+// started. Sessions from before these were kept score the published questions.
+func scoredInSession(session *db.QuizSession, questionId string, published bool) bool {
+	if session.QuestionIds == nil {
+		return published
+	}
+	return slices.Contains(session.QuestionIds, questionId)
+}
+
+func (h *Handlers) GetQuizSessions(c echo.Context) error {
+	userId := auth.CurrentUserId(c)
 	quizId := c.QueryParam("quizId")
 	open := c.QueryParam("open")
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	dbQuizSessions, err := sqlcQuerier.GetQuizSessionsByQuizIdAndUserId(
+	dbQuizSessions, err := h.deps.QuizSessions.GetQuizSessionsByQuizIdAndUserId(
 		ctx,
 		db.GetQuizSessionsByQuizIdAndUserIdParams{
 			QuizID: quizId,
@@ -125,7 +164,7 @@ func GetQuizSessions(c echo.Context) error {
 		},
 	)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
 
 	filter := func(s *db.QuizSession) bool {
@@ -153,7 +192,7 @@ func GetQuizSessions(c echo.Context) error {
 
 		quizSession, err := models.MapQuizSession(dbQuizSession)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err)
+			return apperror.Internal(err)
 		}
 		quizSessions[nextIndex] = *quizSession
 		nextIndex++
@@ -166,131 +205,144 @@ func GetQuizSessions(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-func GetQuizSession(c echo.Context) error {
+func (h *Handlers) GetQuizSession(c echo.Context) error {
 	quizSessionId := c.Param("quizSessionId")
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	dbQuizSession, err := sqlcQuerier.GetQuizSession(ctx, quizSessionId)
+	dbQuizSession, err := h.authorizeQuizSession(ctx, c, quizSessionId)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, err)
+		return err
 	}
 
 	quizSession, err := models.MapQuizSession(dbQuizSession)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
 
 	return c.JSON(http.StatusOK, quizSession)
 }
 
-func PostSubmitQuiz(c echo.Context) error {
+func (h *Handlers) PostSubmitQuiz(c echo.Context) error {
 	quizSessionId := c.Param("quizSessionId")
 
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	userId, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Hour)
+	ctx, cancel := requestContext(c, 15*time.Second)
 	defer cancel()
 
-	quizSession, err := sqlcQuerier.GetQuizSession(
-		ctx,
-		quizSessionId,
-	)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("quiz session '%s' is not found", quizSessionId))
-	}
-
-	if quizSession.FinishedAt.Valid {
-		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("quiz session '%s' is already submitted", quizSessionId))
-	}
-
-	if quizSession.UserID != userId {
-		return echo.NewHTTPError(http.StatusForbidden, "cannot submit other user's quiz session")
+	if _, err := h.authorizeQuizSession(ctx, c, quizSessionId); err != nil {
+		return err
 	}
 
-	_, err = sqlcQuerier.UpdateQuizSessionFinishedAt(
-		ctx,
-		db.UpdateQuizSessionFinishedAtParams{
-			ID: quizSessionId,
-			FinishedAt: pgtype.Timestamp{
-				Time:             time.Now(),
-				InfinityModifier: pgtype.Finite,
-				Valid:            true,
-			},
-		},
-	)
+	quizResult, err := h.submitQuizSession(ctx, quizSessionId)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error submitting quiz session: %s", quizSessionId))
+		return apperror.Internalf("submitting quiz session %s: %w", quizSessionId, err)
 	}
-	log.Default().Printf("quiz session submitted: %s", quizSessionId)
+	logging.Logger(ctx).Info("quiz session submitted", "quizSessionId", quizSessionId)
 
-	dbQuizResult, err := sqlcQuerier.GetQuizResultByQuizSessionId(ctx, quizSessionId)
-	if err != nil {
-		quizResult, err := calculateAndStoreQuizResult(ctx, quizSessionId, quizSession.QuizID)
+	return c.JSON(http.StatusOK, quizResult)
+}
+
+// Finishes the session and stores its result in one transaction. Submitting
+// again returns the stored result, so the client can retry a submit that timed
+// out. The session row stays locked until the result is stored, a submit racing
+// with another waits for it and then finds its result.
+func (h *Handlers) submitQuizSession(ctx context.Context, quizSessionId string) (*models.QuizResult, error) {
+	var quizResult *models.QuizResult
+	scored := false
+	err := h.deps.WithTx(ctx, func(tx Dependencies) error {
+		quizSession, err := tx.QuizSessions.LockQuizSession(ctx, quizSessionId)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "error calculating the quiz result: "+err.Error())
+			return err
+		}
+
+		dbQuizResult, err := tx.QuizSessions.GetQuizResultByQuizSessionId(ctx, quizSessionId)
+		if err == nil {
+			quizResult, err = loadQuizResult(ctx, tx.QuizSessions, dbQuizResult)
+			return err
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
 		}
-		return c.JSON(http.StatusOK, quizResult)
-	}
 
+		if !quizSession.FinishedAt.Valid {
+			_, err = tx.QuizSessions.UpdateQuizSessionFinishedAt(
+				ctx,
+				db.UpdateQuizSessionFinishedAtParams{
+					ID: quizSessionId,
+					FinishedAt: pgtype.Timestamp{
+						Time:             time.Now(),
+						InfinityModifier: pgtype.Finite,
+						Valid:            true,
+					},
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("error finishing quiz session: %w", err)
+			}
+		}
+		quizResult, err = calculateAndStoreQuizResult(ctx, tx, quizSession)
+		scored = err == nil
+		return err
+	})
+	if err == nil && scored {
+		metrics.QuizzesSubmitted.Inc()
+	}
+	return quizResult, err
+}
+
+func loadQuizResult(ctx context.Context, quizSessions QuizSessionRepository, dbQuizResult *db.QuizResult) (*models.QuizResult, error) {
 	quizResult, err := models.MapQuizResult(dbQuizResult)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error parsing quiz result: %s", dbQuizResult.ID))
+		return nil, fmt.Errorf("error parsing quiz result `%s`: %w", dbQuizResult.ID, err)
 	}
 
-	dbAnswerScores, err := sqlcQuerier.GetAnswerScores(ctx, dbQuizResult.ID)
+	dbAnswerScores, err := quizSessions.GetAnswerScores(ctx, dbQuizResult.ID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("error getting answer scores for the quiz result: %s", dbQuizResult.ID))
+		return nil, fmt.Errorf("error getting answer scores for the quiz result `%s`: %w", dbQuizResult.ID, err)
 	}
 
 	answerScores := make([]models.AnswerScore, len(dbAnswerScores))
 	for i, dbScore := range dbAnswerScores {
 		score, err := models.MapAnswerScore(dbScore)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error parsing answer score: %s", dbScore.ID))
+			return nil, fmt.Errorf("error parsing answer score `%s`: %w", dbScore.ID, err)
 		}
 		answerScores[i] = *score
 	}
 
 	quizResult.AnswerScores = answerScores
-	return c.JSON(http.StatusOK, quizResult)
+	return quizResult, nil
 }
 
-func GetQuizResult(c echo.Context) error {
+func (h *Handlers) GetQuizResult(c echo.Context) error {
 	quizSessionId := c.Param("quizSessionId")
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := requestContext(c, 15*time.Second)
 	defer cancel()
 
-	dbQuizResult, err := sqlcQuerier.GetQuizResultByQuizSessionId(ctx, quizSessionId)
+	if _, err := h.authorizeQuizSession(ctx, c, quizSessionId); err != nil {
+		return err
+	}
+
+	dbQuizResult, err := h.deps.QuizSessions.GetQuizResultByQuizSessionId(ctx, quizSessionId)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("quiz result is not found for ID `%s`, error: %s", quizSessionId, err.Error()))
+		return apperror.NotFound("quiz result not found").WithCause(err)
 	}
 	quizResult, err := models.MapQuizResult(dbQuizResult)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error mapping quiz result: %s", err.Error()))
+		return apperror.Internalf("mapping quiz result: %w", err)
 	}
 
-	dbAnswerScores, err := sqlcQuerier.GetAnswerScores(ctx, quizResult.ID)
+	dbAnswerScores, err := h.deps.QuizSessions.GetAnswerScores(ctx, quizResult.ID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("error finding answer scores for quiz result with ID `%s`, error: %s", quizResult.ID, err))
+		return apperror.Internalf("getting the answer scores of quiz result %s: %w", quizResult.ID, err)
 	}
 	answerScores := make([]models.AnswerScore, 0, len(dbAnswerScores))
 	for _, dbAnswerScore := range dbAnswerScores {
 		answerScore, err := models.MapAnswerScore(dbAnswerScore)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("error mapping answer score with ID `%s`", dbAnswerScore.ID))
+			return apperror.Internalf("mapping answer score %s: %w", dbAnswerScore.ID, err)
 		}
 		answerScores = append(answerScores, *answerScore)
 	}
@@ -299,11 +351,12 @@ func GetQuizResult(c echo.Context) error {
 	return c.JSON(http.StatusOK, quizResult)
 }
 
-func calculateAndStoreQuizResult(ctx context.Context, sessionID, quizID string) (*models.QuizResult, error) {
-	sqlcQuerier := utils.GetQuerier()
-
+// Creates the result with a score for every question of the session, questions
+// left unanswered get an empty answer. Runs in the transaction of submitQuizSession.
+func calculateAndStoreQuizResult(ctx context.Context, tx Dependencies, session *db.QuizSession) (*models.QuizResult, error) {
+	sessionID := session.ID
 	// Create a quiz result record with initial scores
-	dbQuizResult, err := sqlcQuerier.CreateQuizResult(
+	dbQuizResult, err := tx.QuizSessions.CreateQuizResult(
 		ctx,
 		db.CreateQuizResultParams{
 			ID:        uuid.NewString(),
@@ -317,19 +370,19 @@ func calculateAndStoreQuizResult(ctx context.Context, sessionID, quizID string)
 	}
 
 	// Calculate the scores for the single choice questions
-	singleChoiceAnswerScores, err := calculateSingleChoiceQuestionScores(ctx, dbQuizResult.ID, sessionID, quizID)
+	singleChoiceAnswerScores, err := calculateSingleChoiceQuestionScores(ctx, tx, dbQuizResult.ID, session)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate single choice scores: %w", err)
 	}
 
 	// Calculate the scores for the multiple choice questions
-	multipleChoiceAnswerScores, err := calculateMultipleChoiceQuestionScores(ctx, dbQuizResult.ID, sessionID, quizID)
+	multipleChoiceAnswerScores, err := calculateMultipleChoiceQuestionScores(ctx, tx, dbQuizResult.ID, session)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate multiple choice scores: %w", err)
 	}
 
 	// Calculate the scores for the true or false questions
-	trueOrFalseAnswerScores, err := calculateTrueOrFalseQuestionScores(ctx, dbQuizResult.ID, sessionID, quizID)
+	trueOrFalseAnswerScores, err := calculateTrueOrFalseQuestionScores(ctx, tx, dbQuizResult.ID, session)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate true or false scores: %w", err)
 	}
@@ -348,7 +401,7 @@ func calculateAndStoreQuizResult(ctx context.Context, sessionID, quizID string)
 	}
 
 	// Update quiz result with the calculated scores
-	updatedDbQuizResult, err := sqlcQuerier.UpdateQuizResultScores(
+	updatedDbQuizResult, err := tx.QuizSessions.UpdateQuizResultScores(
 		ctx,
 		db.UpdateQuizResultScoresParams{
 			ID:       dbQuizResult.ID,
@@ -370,15 +423,17 @@ func calculateAndStoreQuizResult(ctx context.Context, sessionID, quizID string)
 	return quizResult, nil
 }
 
-func calculateSingleChoiceQuestionScores(ctx context.Context, quizResultID, sessionID, quizID string) ([]models.AnswerScore, error) {
-	sqlcQuerier := utils.GetQuerier()
-
-	questions, err := question.GetSingleChoiceQuestions(quizID)
+func calculateSingleChoiceQuestionScores(ctx context.Context, tx Dependencies, quizResultID string, session *db.QuizSession) ([]models.AnswerScore, error) {
+	sessionID := session.ID
+	quizQuestions, err := tx.Questions.GetSingleChoiceQuestions(ctx, session.QuizID)
 	if err != nil {
 		return []models.AnswerScore{}, err
 	}
+	questions := slices.DeleteFunc(quizQuestions, func(q question.DBSingleChoiceQuestion) bool {
+		return !scoredInSession(session, q.UUID, q.Published)
+	})
 
-	answers, err := sqlcQuerier.GetSingleChoiceAnswers(ctx, sessionID)
+	answers, err := tx.Answers.GetSingleChoiceAnswers(ctx, sessionID)
 	if err != nil {
 		return []models.AnswerScore{}, err
 	}
@@ -388,8 +443,8 @@ func calculateSingleChoiceQuestionScores(ctx context.Context, quizResultID, sess
 	for i, q := range questions {
 		userAnswer, err := findSingleChoiceAnswer(answers, q.UUID)
 		if err != nil {
-			log.Default().Printf("user answer not found for question with ID `%s`, trying to create a new one", q.UUID)
-			emptyAnswer, err := sqlcQuerier.CreateSingleChoiceAnswer(
+			logging.Logger(ctx).Debug("question left unanswered, storing an empty answer", "questionId", q.UUID)
+			emptyAnswer, err := tx.Answers.CreateSingleChoiceAnswer(
 				ctx,
 				db.CreateSingleChoiceAnswerParams{
 					ID:         uuid.NewString(),
@@ -413,7 +468,7 @@ func calculateSingleChoiceQuestionScores(ctx context.Context, quizResultID, sess
 			score = 1.0
 		}
 
-		dbAnswerScore, err := sqlcQuerier.CreateSingleChoiceAnswerScore(
+		dbAnswerScore, err := tx.QuizSessions.CreateSingleChoiceAnswerScore(
 			ctx,
 			db.CreateSingleChoiceAnswerScoreParams{
 				ID:                   uuid.NewString(),
@@ -440,15 +495,17 @@ func calculateSingleChoiceQuestionScores(ctx context.Context, quizResultID, sess
 
 	return answerScores, nil
 }
-func calculateMultipleChoiceQuestionScores(ctx context.Context, quizResultID, sessionID, quizID string) ([]models.AnswerScore, error) {
-	sqlcQuerier := utils.GetQuerier()
-
-	questions, err := question.GetMultipleChoiceQuestions(quizID)
+func calculateMultipleChoiceQuestionScores(ctx context.Context, tx Dependencies, quizResultID string, session *db.QuizSession) ([]models.AnswerScore, error) {
+	sessionID := session.ID
+	quizQuestions, err := tx.Questions.GetMultipleChoiceQuestions(ctx, session.QuizID)
 	if err != nil {
 		return []models.AnswerScore{}, err
 	}
+	questions := slices.DeleteFunc(quizQuestions, func(q question.DBMultipleChoiceQuestion) bool {
+		return !scoredInSession(session, q.UUID, q.Published)
+	})
 
-	answers, err := sqlcQuerier.GetMultipleChoiceAnswers(ctx, sessionID)
+	answers, err := tx.Answers.GetMultipleChoiceAnswers(ctx, sessionID)
 	if err != nil {
 		return []models.AnswerScore{}, err
 	}
@@ -467,8 +524,8 @@ func calculateMultipleChoiceQuestionScores(ctx context.Context, quizResultID, se
 
 		userAnswer, err := findMultipleChoiceAnswer(answers, q.UUID)
 		if err != nil {
-			log.Default().Printf("user answer not found for question with ID `%s`, trying to create a new one", q.UUID)
-			emptyAnswer, err := sqlcQuerier.CreateMultipleChoiceAnswer(
+			logging.Logger(ctx).Debug("question left unanswered, storing an empty answer", "questionId", q.UUID)
+			emptyAnswer, err := tx.Answers.CreateMultipleChoiceAnswer(
 				ctx,
 				db.CreateMultipleChoiceAnswerParams{
 					ID:         uuid.NewString(),
@@ -502,7 +559,7 @@ func calculateMultipleChoiceQuestionScores(ctx context.Context, quizResultID, se
 			score = 0
 		}
 
-		dbAnswerScore, err := sqlcQuerier.CreateMultipleChoiceAnswerScore(
+		dbAnswerScore, err := tx.QuizSessions.CreateMultipleChoiceAnswerScore(
 			ctx,
 			db.CreateMultipleChoiceAnswerScoreParams{
 				ID:                     uuid.NewString(),
@@ -529,15 +586,17 @@ func calculateMultipleChoiceQuestionScores(ctx context.Context, quizResultID, se
 
 	return answerScores, nil
 }
-func calculateTrueOrFalseQuestionScores(ctx context.Context, quizResultID, sessionID, quizID string) ([]models.AnswerScore, error) {
-	sqlcQuerier := utils.GetQuerier()
-
-	questions, err := question.GetTrueOrFalseQuestions(quizID)
+func calculateTrueOrFalseQuestionScores(ctx context.Context, tx Dependencies, quizResultID string, session *db.QuizSession) ([]models.AnswerScore, error) {
+	sessionID := session.ID
+	quizQuestions, err := tx.Questions.GetTrueOrFalseQuestions(ctx, session.QuizID)
 	if err != nil {
 		return []models.AnswerScore{}, err
 	}
+	questions := slices.DeleteFunc(quizQuestions, func(q question.DBTrueOrFalseQuestion) bool {
+		return !scoredInSession(session, q.UUID, q.Published)
+	})
 
-	answers, err := sqlcQuerier.GetTrueOrFalseAnswers(ctx, sessionID)
+	answers, err := tx.Answers.GetTrueOrFalseAnswers(ctx, sessionID)
 	if err != nil {
 		return []models.AnswerScore{}, err
 	}
@@ -555,8 +614,8 @@ func calculateTrueOrFalseQuestionScores(ctx context.Context, quizResultID, sessi
 
 		userAnswer, err := findTrueOrFalseAnswer(answers, q.UUID)
 		if err != nil {
-			log.Default().Printf("user answer not found for question with ID `%s`, trying to create a new one", q.UUID)
-			emptyAnswer, err := sqlcQuerier.CreateTrueOrFalseAnswer(
+			logging.Logger(ctx).Debug("question left unanswered, storing an empty answer", "questionId", q.UUID)
+			emptyAnswer, err := tx.Answers.CreateTrueOrFalseAnswer(
 				ctx,
 				db.CreateTrueOrFalseAnswerParams{
 					ID:         uuid.NewString(),
@@ -580,7 +639,7 @@ func calculateTrueOrFalseQuestionScores(ctx context.Context, quizResultID, sessi
 			score = 1.0
 		}
 
-		dbAnswerScore, err := sqlcQuerier.CreateTrueOrFalseAnswerScore(
+		dbAnswerScore, err := tx.QuizSessions.CreateTrueOrFalseAnswerScore(
 			ctx,
 			db.CreateTrueOrFalseAnswerScoreParams{
 				ID:                  uuid.NewString(),
@@ -645,15 +704,14 @@ func findTrueOrFalseAnswer(answers []*db.TrueOrFalseAnswer, questionID string) (
 	return nil, fmt.Errorf("answer not found for question with ID `%s`", questionID)
 }
 
-func HasOpenQuizSession(c echo.Context) error {
-	userId := c.QueryParam("userId")
+func (h *Handlers) HasOpenQuizSession(c echo.Context) error {
+	userId := auth.CurrentUserId(c)
 	quizId := c.QueryParam("quizId")
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	hasOpenSession, err := sqlcQuerier.HasOpenQuizSession(
+	hasOpenSession, err := h.deps.QuizSessions.HasOpenQuizSession(
 		ctx,
 		db.HasOpenQuizSessionParams{
 			QuizID: quizId,
@@ -661,10 +719,8 @@ func HasOpenQuizSession(c echo.Context) error {
 		},
 	)
 	if err != nil {
-		log.Default().Printf("error during HasOpenQuizSession call: %f\n", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+		return apperror.Internal(err)
 	}
-	log.Default().Printf("hasOpenQuizSession: %t\n", hasOpenSession)
 
 	if hasOpenSession {
 		return c.NoContent(http.StatusOK)
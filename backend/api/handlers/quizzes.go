@@ -1,15 +1,20 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"net/http"
 	models "spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
 	"spaced-ace-backend/auth"
 	"spaced-ace-backend/question"
-	quiz "spaced-ace-backend/quiz"
+	"spaced-ace-backend/quiz"
+	"time"
 )
 
 type QuizzesResponse struct {
@@ -27,215 +32,296 @@ type Quiz struct {
 	Name        string `json:"name"`
 	CreatorId   string `json:"creatorid"`
 	Description string `json:"description"`
+	Status      string `json:"status"`
 }
 
 func init() {
 }
 
-func CreateQuizEndpoint(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+func (h *Handlers) CreateQuizEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	uid := auth.CurrentUserId(c)
+	user := auth.CurrentUser(c)
+	var request = QuizRequestBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
 	}
-	uid, err := auth.GetUserIdBySession(session.Value)
+	createdQuiz, err := h.deps.Quizzes.CreateQuiz(ctx, uid, request.Name, request.Description)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return apperror.Internal(err)
 	}
-	user, err := auth.GetUserById(uid)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	h.auditQuiz(c, audit.QuizCreated, createdQuiz.Id, nil, createdQuiz)
+	return c.JSON(http.StatusOK, models.QuizInfo{Id: createdQuiz.Id, Title: createdQuiz.Name, Description: createdQuiz.Description.String, Status: createdQuiz.Status, CreatorName: user.Name, CreatorId: user.Id})
+}
+
+const maxImportedQuestions = 500
+
+// Creates a quiz with questions written elsewhere, e.g. exported with the
+// command-line client. Either the quiz and all of its questions are created
+// or nothing is. The questions are approved, the quiz is a draft until it is
+// published.
+func (h *Handlers) ImportQuizEndpoint(c echo.Context) error {
+	user := auth.CurrentUser(c)
+	var request models.QuizImportRequestBody
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request").WithCause(err)
 	}
-	var request = QuizRequestBody{}
-	if err = json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+	if request.Name == "" {
+		return apperror.BadRequest("the quiz needs a name")
 	}
-	createdQuiz, err := quiz.CreateQuiz(uid, request.Name, request.Description)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	if len(request.Questions) > maxImportedQuestions {
+		return apperror.BadRequest(fmt.Sprintf("a quiz can have at most %d questions", maxImportedQuestions))
+	}
+	questions := make([]any, 0, len(request.Questions))
+	for i, raw := range request.Questions {
+		imported, err := parseImportedQuestion(raw)
+		if err != nil {
+			return apperror.BadRequest(fmt.Sprintf("question %d: %s", i+1, err))
+		}
+		questions = append(questions, imported)
 	}
-	return c.JSON(http.StatusOK, models.QuizInfo{Id: createdQuiz.Id, Title: createdQuiz.Name, Description: createdQuiz.Description.String, CreatorName: user.Name, CreatorId: user.Id})
-}
 
-func GetQuizEndpoint(c echo.Context) error {
-	session, err := c.Cookie("session")
+	ctx, cancel := requestContext(c, 10*time.Second)
+	defer cancel()
+
+	var createdQuiz *quiz.DBQuiz
+	var created []any
+	err := h.deps.WithTx(ctx, func(tx Dependencies) error {
+		var err error
+		createdQuiz, err = tx.Quizzes.CreateQuiz(ctx, user.Id, request.Name, request.Description)
+		if err != nil {
+			return err
+		}
+		for _, imported := range questions {
+			var snapshot any
+			switch q := imported.(type) {
+			case models.SingleChoiceQuestion:
+				dbQuestion := question.DBSingleChoiceQuestion{UUID: uuid.New().String(), QuizID: createdQuiz.Id, Question: q.Question, Answers: q.Answers, CorrectAnswer: q.CorrectAnswer, Status: question.STATUS_APPROVED}
+				err = tx.Questions.CreateSingleChoiceQuestion(ctx, &dbQuestion)
+				created = append(created, dbQuestion)
+				snapshot = dbQuestion.MapToModel()
+			case models.MultipleChoiceQuestion:
+				dbQuestion := question.DBMultipleChoiceQuestion{UUID: uuid.New().String(), QuizID: createdQuiz.Id, Question: q.Question, Answers: q.Answers, CorrectAnswers: q.CorrectAnswers, Status: question.STATUS_APPROVED}
+				err = tx.Questions.CreateMultipleChoiceQuestion(ctx, &dbQuestion)
+				created = append(created, dbQuestion)
+				snapshot = dbQuestion.MapToModel()
+			case models.TrueOrFalseQuestion:
+				dbQuestion := question.DBTrueOrFalseQuestion{UUID: uuid.New().String(), QuizID: createdQuiz.Id, Question: q.Question, CorrectAnswer: q.CorrectAnswer, Status: question.STATUS_APPROVED}
+				err = tx.Questions.CreateTrueOrFalseQuestion(ctx, &dbQuestion)
+				created = append(created, dbQuestion)
+				snapshot = dbQuestion.MapToModel()
+			}
+			if err != nil {
+				return err
+			}
+			if err := recordRevision(ctx, c, tx, nil, snapshot, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return apperror.Internalf("importing a quiz: %w", err)
 	}
-	uid, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+
+	h.auditQuiz(c, audit.QuizCreated, createdQuiz.Id, nil, createdQuiz)
+	for _, dbQuestion := range created {
+		switch q := dbQuestion.(type) {
+		case question.DBSingleChoiceQuestion:
+			h.auditQuestion(c, audit.QuestionCreated, q.UUID, nil, q.MapToModel())
+		case question.DBMultipleChoiceQuestion:
+			h.auditQuestion(c, audit.QuestionCreated, q.UUID, nil, q.MapToModel())
+		case question.DBTrueOrFalseQuestion:
+			h.auditQuestion(c, audit.QuestionCreated, q.UUID, nil, q.MapToModel())
+		}
 	}
+	return c.JSON(http.StatusOK, models.QuizInfo{Id: createdQuiz.Id, Title: createdQuiz.Name, Description: createdQuiz.Description.String, Status: createdQuiz.Status, CreatorName: user.Name, CreatorId: user.Id})
+}
+
+// Decodes the question by its questionType and checks that it can be shown
+// the way the generated ones are
+func parseImportedQuestion(raw json.RawMessage) (any, error) {
+	var common struct {
+		QuestionType *models.QuestionType `json:"questionType"`
+	}
+	if err := json.Unmarshal(raw, &common); err != nil {
+		return nil, errors.New("not a question")
+	}
+	if common.QuestionType == nil {
+		return nil, errors.New("the questionType is missing")
+	}
+	switch *common.QuestionType {
+	case models.SingleChoice:
+		var q models.SingleChoiceQuestion
+		if err := json.Unmarshal(raw, &q); err != nil {
+			return nil, errors.New("not a single choice question")
+		}
+		return q, validateSingleChoice(q.Question, q.Answers, q.CorrectAnswer)
+	case models.MultipleChoice:
+		var q models.MultipleChoiceQuestion
+		if err := json.Unmarshal(raw, &q); err != nil {
+			return nil, errors.New("not a multiple choice question")
+		}
+		return q, validateMultipleChoice(q.Question, q.Answers, q.CorrectAnswers)
+	case models.TrueOrFalse:
+		var q models.TrueOrFalseQuestion
+		if err := json.Unmarshal(raw, &q); err != nil {
+			return nil, errors.New("not a true or false question")
+		}
+		return q, validateTrueOrFalse(q.Question)
+	default:
+		return nil, fmt.Errorf("unknown questionType %d", *common.QuestionType)
+	}
+}
+
+// Returns the quiz with its questions. The owner gets every question with
+// its review status, everyone else only the published ones.
+func (h *Handlers) GetQuizEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	quizId := c.Param("id")
-	_, err = quiz.GetQuizAccess(uid, quizId)
+	access, err := h.authorizeQuiz(c, quizId)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusNotFound, "quiz not found")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return err
 	}
-	quiz, err := quiz.GetQuizById(quizId)
+	dbQuiz, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusNotFound, "quiz not found")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("quiz not found")
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 
+	getSingleChoiceQuestions := h.deps.Questions.GetPublishedSingleChoiceQuestions
+	getMultipleChoiceQuestions := h.deps.Questions.GetPublishedMultipleChoiceQuestions
+	getTrueOrFalseQuestions := h.deps.Questions.GetPublishedTrueOrFalseQuestions
+	if access == quiz.QUIZ_OWNER_ACCESS_ID {
+		getSingleChoiceQuestions = h.deps.Questions.GetSingleChoiceQuestions
+		getMultipleChoiceQuestions = h.deps.Questions.GetMultipleChoiceQuestions
+		getTrueOrFalseQuestions = h.deps.Questions.GetTrueOrFalseQuestions
+	}
 	var questions []models.Question
-	singleChoiceQuestions, _ := question.GetSingleChoiceQuestions(quizId)
+	singleChoiceQuestions, _ := getSingleChoiceQuestions(ctx, quizId)
 	for _, q := range singleChoiceQuestions {
-		questions = append(questions, models.SingleChoiceQuestion{
-			ID:            q.UUID,
-			QuizID:        q.QuizID,
-			QuestionType:  models.SingleChoice,
-			Question:      q.Question,
-			Answers:       q.Answers,
-			CorrectAnswer: q.CorrectAnswer,
-		})
-	}
-	multipleChoiceQuestions, _ := question.GetMultipleChoiceQuestions(quizId)
+		questions = append(questions, q.MapToModel())
+	}
+	multipleChoiceQuestions, _ := getMultipleChoiceQuestions(ctx, quizId)
 	for _, q := range multipleChoiceQuestions {
-		questions = append(questions, models.MultipleChoiceQuestion{
-			ID:             q.UUID,
-			QuizID:         q.QuizID,
-			QuestionType:   models.MultipleChoice,
-			Question:       q.Question,
-			Answers:        q.Answers,
-			CorrectAnswers: q.CorrectAnswers,
-		})
-	}
-	trueOrFalseQuestions, _ := question.GetTrueOrFalseQuestions(quizId)
+		questions = append(questions, q.MapToModel())
+	}
+	trueOrFalseQuestions, _ := getTrueOrFalseQuestions(ctx, quizId)
 	for _, q := range trueOrFalseQuestions {
-		questions = append(questions, models.TrueOrFalseQuestion{
-			ID:            q.UUID,
-			QuizID:        q.QuizID,
-			QuestionType:  models.TrueOrFalse,
-			Question:      q.Question,
-			CorrectAnswer: q.CorrectAnswer,
-		})
+		questions = append(questions, q.MapToModel())
 	}
 
-	userinfo, err := auth.GetUserById(uid)
-	if err != nil {
-		return c.JSON(http.StatusOK, models.Quiz{
-			QuizInfo: models.QuizInfo{
-				Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: "Deleted",
-			},
-			Questions: questions,
-		})
-	}
-	return c.JSON(http.StatusOK, models.Quiz{
+	result := models.Quiz{
 		QuizInfo: models.QuizInfo{
-			Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: userinfo.Name, CreatorId: userinfo.Id,
+			Id: dbQuiz.Id, Title: dbQuiz.Name, Description: dbQuiz.Description.String, Status: dbQuiz.Status, CreatorName: "Deleted",
 		},
-		Questions: questions,
-	})
-}
-
-func GetQuizzesOfUserEndpoint(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		PublishedAt: dbQuiz.PublishedAt,
+		Questions:   questions,
 	}
-	uid, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	// Staff can view quizzes of others, so the creator is not the current user
+	if userinfo, err := h.deps.Users.GetUserById(ctx, dbQuiz.CreatorId.String); err == nil {
+		result.CreatorName = userinfo.Name
+		result.CreatorId = userinfo.Id
 	}
-	quizAccesses, err := quiz.GetQuizAccessesOfUser(uid)
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *Handlers) GetQuizzesOfUserEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	uid := auth.CurrentUserId(c)
+	quizAccesses, err := h.deps.Quizzes.GetQuizAccessesOfUser(ctx, uid)
 	if err != nil {
-		fmt.Println("failed to get quiz accesses of user")
-		fmt.Println(err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internalf("getting the quiz accesses of the user: %w", err)
 	}
 	var quizzes []models.QuizInfo
 	for _, acc := range *quizAccesses {
-		quiz, err := quiz.GetQuizById(acc.QuizId)
+		quiz, err := h.deps.Quizzes.GetQuizById(ctx, acc.QuizId)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+			return apperror.Internal(err)
 		}
-		creator, err := auth.GetUserById(quiz.CreatorId.String)
+		creator, err := h.deps.Users.GetUserById(ctx, quiz.CreatorId.String)
 		if err != nil {
-			fmt.Println(err)
-			quizzes = append(quizzes, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: "Deleted"})
+			quizzes = append(quizzes, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, Status: quiz.Status, CreatorName: "Deleted"})
 		} else {
-			quizzes = append(quizzes, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: creator.Name})
+			quizzes = append(quizzes, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, Status: quiz.Status, CreatorName: creator.Name})
 		}
 	}
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 	return c.JSON(http.StatusOK, QuizzesResponse{Quizzes: quizzes, Length: len(quizzes)})
 }
 
-func UpdateQuizEndpoint(c echo.Context) error {
+func (h *Handlers) UpdateQuizEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	request := QuizRequestBody{}
 	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
-	}
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
-	}
-	uid, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return apperror.BadRequest("bad request")
 	}
 	quizId := c.Param("id")
-	role, err := quiz.GetQuizAccess(uid, quizId)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusNotFound, "unauthorized")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	if err := h.authorizeQuizOwner(c, quizId); err != nil {
+		return err
 	}
-	if role != 1 {
-		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	before, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
+	if err != nil {
+		return apperror.Internal(err)
 	}
-	err = quiz.UpdateQuiz(quizId, request.Name, request.Description)
+	err = h.deps.Quizzes.UpdateQuiz(ctx, quizId, request.Name, request.Description)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
-	quiz, err := quiz.GetQuizById(quizId)
+	quiz, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
+	h.auditQuiz(c, audit.QuizUpdated, quizId, before, quiz)
 	if !quiz.CreatorId.Valid {
-		return c.JSON(http.StatusOK, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: "Deleted"})
+		return c.JSON(http.StatusOK, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, Status: quiz.Status, CreatorName: "Deleted"})
 	}
-	creator, err := auth.GetUserById(quiz.CreatorId.String)
+	creator, err := h.deps.Users.GetUserById(ctx, quiz.CreatorId.String)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.JSON(http.StatusOK, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: "Deleted"})
+		if err == pgx.ErrNoRows {
+			return c.JSON(http.StatusOK, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, Status: quiz.Status, CreatorName: "Deleted"})
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
-	return c.JSON(http.StatusOK, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, CreatorName: creator.Name, CreatorId: creator.Id})
+	return c.JSON(http.StatusOK, models.QuizInfo{Id: quiz.Id, Title: quiz.Name, Description: quiz.Description.String, Status: quiz.Status, CreatorName: creator.Name, CreatorId: creator.Id})
 }
 
-func DeleteQuizEndpoint(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+func (h *Handlers) DeleteQuizEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	quizId := c.Param("id")
+	if err := h.authorizeQuizOwner(c, quizId); err != nil {
+		return err
 	}
-	uid, err := auth.GetUserIdBySession(session.Value)
+	deleted, err := h.deps.Quizzes.GetQuizById(ctx, quizId)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return apperror.Internal(err)
 	}
-	quizId := c.Param("id")
-	role, err := quiz.GetQuizAccess(uid, quizId)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusNotFound, "unauthorized")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	if err := h.deleteQuiz(c, quizId); err != nil {
+		return err
 	}
-	if role != 1 {
-		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
-	}
-	err = quiz.DeleteQuiz(quizId)
+	h.auditQuiz(c, audit.QuizDeleted, quizId, deleted, nil)
+	return c.JSON(http.StatusOK, "quiz deleted")
+}
+
+// Deletes the quiz with its questions and their revisions. The error is an
+// *apperror.Error.
+func (h *Handlers) deleteQuiz(c echo.Context, quizId string) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
+	defer cancel()
+	err := h.deps.WithTx(ctx, func(tx Dependencies) error {
+		// The questions go with the quiz, their revisions do not
+		if err := tx.Revisions.DeleteQuestionRevisionsOfQuiz(ctx, quizId); err != nil {
+			return err
+		}
+		return tx.Quizzes.DeleteQuiz(ctx, quizId)
+	})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internalf("deleting quiz %s: %w", quizId, err)
 	}
-	return c.JSON(http.StatusOK, "quiz deleted")
+	return nil
 }
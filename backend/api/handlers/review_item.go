@@ -6,54 +6,52 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/net/context"
-	"log"
+	"log/slog"
 	"math"
 	"net/http"
 	"slices"
 	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/apperror"
 	"spaced-ace-backend/auth"
-	"spaced-ace-backend/constants"
+	"spaced-ace-backend/config"
 	"spaced-ace-backend/db"
-	"spaced-ace-backend/question"
-	"spaced-ace-backend/utils"
+	"spaced-ace-backend/metrics"
 	"strings"
 	"time"
 )
 
-func GetReviewItems(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+var reviewItemConfig = config.Default().ReviewItems
+
+func InitReviewItemConfig(cfg config.ReviewItems) {
+	reviewItemConfig = cfg
+}
+
+func (h *Handlers) GetReviewItems(c echo.Context) error {
+	sessionUserID := auth.CurrentUserId(c)
 
 	var request = ReviewItemsRequestBody{}
-	if err = json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("parsing request body: %w\n", err))
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("invalid request body").WithCause(err)
 	}
 
 	filter, err := validateReviewItemsRequest(request)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("validationg the request body: %w\n", err))
+		return apperror.BadRequest(err.Error())
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	dbReviewItems, err := sqlcQuerier.GetReviewItems(ctx, sessionUserID)
+	dbReviewItems, err := h.deps.ReviewItems.GetReviewItems(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("getting review item for user with ID %q: %w\n", sessionUserID, err))
+		return apperror.Internalf("getting review item for user with ID %q: %w", sessionUserID, err)
 	}
 
 	reviewItems := make([]*models.ReviewItem, 0, len(dbReviewItems))
 	for _, dbReviewItem := range dbReviewItems {
 		reviewItem, err := models.MapReviewItemFromReviewItemsRow(dbReviewItem)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("mapping review item: %w\n", err))
+			return apperror.Internalf("mapping review item: %w", err)
 		}
 		reviewItems = append(reviewItems, reviewItem)
 	}
@@ -94,9 +92,9 @@ func GetReviewItems(c echo.Context) error {
 
 	reviewItemCountForFilter := len(filteredReviewItem)
 
-	lowerIndex := (filter.Page - 1) * constants.REVIEW_ITEM_PAGE_SIZE
-	upperIndex := filter.Page * constants.REVIEW_ITEM_PAGE_SIZE
-	reviewItemsOnPage := make([]*models.ReviewItem, 0, constants.REVIEW_ITEM_PAGE_SIZE)
+	lowerIndex := (filter.Page - 1) * reviewItemConfig.PageSize
+	upperIndex := filter.Page * reviewItemConfig.PageSize
+	reviewItemsOnPage := make([]*models.ReviewItem, 0, reviewItemConfig.PageSize)
 	for i, item := range filteredReviewItem {
 		if i >= lowerIndex && i <= upperIndex {
 			reviewItemsOnPage = append(reviewItemsOnPage, item)
@@ -109,23 +107,15 @@ func GetReviewItems(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, response)
 }
-func GetQuizOptions(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+func (h *Handlers) GetQuizOptions(c echo.Context) error {
+	sessionUserID := auth.CurrentUserId(c)
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	dbQuizOptions, err := sqlcQuerier.GetQuizOptions(ctx, sessionUserID)
+	dbQuizOptions, err := h.deps.ReviewItems.GetQuizOptions(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Errorf("getting quiz options for user with ID %q: %w\n", sessionUserID, err))
+		return apperror.Internalf("getting quiz options for user with ID %q: %w", sessionUserID, err)
 	}
 
 	quizOptions := make([]*models.Option, 0, len(dbQuizOptions))
@@ -140,23 +130,15 @@ func GetQuizOptions(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, models.QuizOptionsResponseBody{QuizOptions: quizOptions})
 }
-func GetReviewItemCounts(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+func (h *Handlers) GetReviewItemCounts(c echo.Context) error {
+	sessionUserID := auth.CurrentUserId(c)
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
-	dbCounts, err := sqlcQuerier.GetReviewItemCounts(ctx, sessionUserID)
+	dbCounts, err := h.deps.ReviewItems.GetReviewItemCounts(ctx, sessionUserID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("getting item counts for user with ID %q: %w\n", sessionUserID, err))
+		return apperror.Internalf("getting item counts for user with ID %q: %w", sessionUserID, err)
 	}
 
 	return c.JSON(
@@ -168,37 +150,32 @@ func GetReviewItemCounts(c echo.Context) error {
 	)
 }
 
-func GetReviewItemQuestion(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
+func (h *Handlers) GetReviewItemQuestion(c echo.Context) error {
+	sessionUserID := auth.CurrentUserId(c)
 
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
 	reviewItemID := c.Param("reviewItemID")
 
 	var reviewItem *models.ReviewItem
 	if reviewItemID != "" {
-		dbReviewItem, err := sqlcQuerier.GetReviewItem(ctx, reviewItemID)
+		dbReviewItem, err := h.authorizeReviewItem(ctx, c, reviewItemID)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("getting review item with ID %q: %w\n", reviewItemID, err))
+			return err
 		}
 
 		reviewItem, err = models.MapReviewItem(dbReviewItem)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("mapping review item: %w\n", err))
+			return apperror.Internalf("mapping review item: %w", err)
 		}
 	} else {
-		dbReviewItems, err := sqlcQuerier.GetReviewItems(ctx, sessionUserID)
-		if err != nil || len(dbReviewItems) < 1 {
-			return echo.NewHTTPError(http.StatusNotFound, fmt.Errorf("getting enough review items for user with ID %q: %w\n", sessionUserID, err))
+		dbReviewItems, err := h.deps.ReviewItems.GetReviewItems(ctx, sessionUserID)
+		if err != nil {
+			return apperror.Internalf("getting review items for user with ID %q: %w", sessionUserID, err)
+		}
+		if len(dbReviewItems) < 1 {
+			return apperror.NotFound("no review items")
 		}
 
 		var reviewItemToDue *db.GetReviewItemsRow
@@ -212,20 +189,20 @@ func GetReviewItemQuestion(c echo.Context) error {
 		}
 
 		if reviewItemToDue == nil {
-			return echo.NewHTTPError(http.StatusNotFound, fmt.Errorf("getting review item to due"))
+			return apperror.NotFound("no review item is due")
 		}
 
 		reviewItem, err = models.MapReviewItemFromReviewItemsRow(reviewItemToDue)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("mapping review item: %w\n", err))
+			return apperror.Internalf("mapping review item: %w", err)
 		}
 	}
 
 	var singleChoiceQuestion *models.SingleChoiceQuestion
 	if reviewItem.SingleChoiceQuestionID != nil {
-		dbQuestion, err := question.GetSingleChoiceQuestion(*reviewItem.SingleChoiceQuestionID)
+		dbQuestion, err := h.deps.Questions.GetSingleChoiceQuestion(ctx, *reviewItem.SingleChoiceQuestionID)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("getting single choice question with ID %q: %w\n", reviewItem.SingleChoiceQuestionID, err))
+			return apperror.Internalf("getting single choice question with ID %q: %w", *reviewItem.SingleChoiceQuestionID, err)
 		}
 
 		singleChoiceQuestion = &models.SingleChoiceQuestion{
@@ -240,9 +217,9 @@ func GetReviewItemQuestion(c echo.Context) error {
 
 	var multipleChoiceQuestion *models.MultipleChoiceQuestion
 	if reviewItem.MultipleChoiceQuestionID != nil {
-		dbQuestion, err := question.GetMultipleChoiceQuestion(*reviewItem.MultipleChoiceQuestionID)
+		dbQuestion, err := h.deps.Questions.GetMultipleChoiceQuestion(ctx, *reviewItem.MultipleChoiceQuestionID)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("getting multiple choice question with ID %q: %w\n", reviewItem.MultipleChoiceQuestionID, err))
+			return apperror.Internalf("getting multiple choice question with ID %q: %w", *reviewItem.MultipleChoiceQuestionID, err)
 		}
 
 		multipleChoiceQuestion = &models.MultipleChoiceQuestion{
@@ -257,9 +234,9 @@ func GetReviewItemQuestion(c echo.Context) error {
 
 	var trueOrFalseQuestion *models.TrueOrFalseQuestion
 	if reviewItem.TrueOrFalseQuestionID != nil {
-		dbQuestion, err := question.GetTrueOrFalseQuestion(*reviewItem.TrueOrFalseQuestionID)
+		dbQuestion, err := h.deps.Questions.GetTrueOrFalseQuestion(ctx, *reviewItem.TrueOrFalseQuestionID)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("getting true or false question with ID %q: %w\n", reviewItem.TrueOrFalseQuestionID, err))
+			return apperror.Internalf("getting true or false question with ID %q: %w", *reviewItem.TrueOrFalseQuestionID, err)
 		}
 
 		trueOrFalseQuestion = &models.TrueOrFalseQuestion{
@@ -279,96 +256,83 @@ func GetReviewItemQuestion(c echo.Context) error {
 	}
 	return c.JSON(200, response)
 }
-func PostSubmitReviewItemQuestion(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-	sessionUserID, err := auth.GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err)
-	}
-
-	sqlcQuerier := utils.GetQuerier()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (h *Handlers) PostSubmitReviewItemQuestion(c echo.Context) error {
+	ctx, cancel := requestContext(c, 5*time.Second)
 	defer cancel()
 
 	reviewItemID := c.Param("reviewItemID")
 
 	answers := new(models.SubmitReviewItemQuestionRequestBody)
-	if err = json.NewDecoder(c.Request().Body).Decode(answers); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("parsing submit review item question request body: %w\n", err))
+	if err := json.NewDecoder(c.Request().Body).Decode(answers); err != nil {
+		return apperror.BadRequest("invalid request body").WithCause(err)
 	}
 
-	dbReviewItem, err := sqlcQuerier.GetReviewItem(ctx, reviewItemID)
+	dbReviewItem, err := h.authorizeReviewItem(ctx, c, reviewItemID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("getting review item with ID %q: %w\n", reviewItemID, err))
-	}
-
-	if dbReviewItem.UserID != sessionUserID {
-		return echo.NewHTTPError(http.StatusForbidden, fmt.Errorf("cannot submit other user's review item question"))
+		return err
 	}
 
 	reviewItem, err := models.MapReviewItem(dbReviewItem)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("mapping review item with ID %q: %w\n", reviewItemID, err))
+		return apperror.Internalf("mapping review item with ID %q: %w", reviewItemID, err)
 	}
 
-	score, err := calculateReviewItemScore(reviewItem, answers)
+	score, err := h.calculateReviewItemScore(ctx, reviewItem, answers)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("calculating score for review item with ID %q: %w\n", reviewItemID, err))
+		return apperror.Internalf("calculating score for review item with ID %q: %w", reviewItemID, err)
 	}
 
-	updatedReviewItem, err := applySpacedRepetitionAndStore(ctx, reviewItem, score)
+	updatedReviewItem, err := h.applySpacedRepetitionAndStore(ctx, reviewItem, score)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("applying spaced repetition on review item with ID %q: %w\n", reviewItemID, err))
+		return apperror.Internalf("applying spaced repetition on review item with ID %q: %w", reviewItemID, err)
 	}
+	metrics.ReviewsSubmitted.Inc()
 
 	return c.JSON(http.StatusOK, updatedReviewItem)
 }
 
-func calculateReviewItemScore(reviewItem *models.ReviewItem, answers *models.SubmitReviewItemQuestionRequestBody) (float64, error) {
+func (h *Handlers) calculateReviewItemScore(ctx context.Context, reviewItem *models.ReviewItem, answers *models.SubmitReviewItemQuestionRequestBody) (float64, error) {
 	if reviewItem.SingleChoiceQuestionID != nil {
-		dbQuestion, err := question.GetSingleChoiceQuestion(*reviewItem.SingleChoiceQuestionID)
+		dbQuestion, err := h.deps.Questions.GetSingleChoiceQuestion(ctx, *reviewItem.SingleChoiceQuestionID)
 		if err != nil {
-			return 0, fmt.Errorf("getting single choice question with ID %q: %w\n", *reviewItem.SingleChoiceQuestionID, err)
+			return 0, fmt.Errorf("getting single choice question with ID %q: %w", *reviewItem.SingleChoiceQuestionID, err)
 		}
 		modelQuestion := dbQuestion.MapToModel()
 
 		score, err := calculateReviewItemSingleChoiceQuestionScore(*modelQuestion, answers.SingleChoiceValue)
 		if err != nil {
-			return 0, fmt.Errorf("calculating single choice question score for review item with ID %q: %w\n", reviewItem.ID, err)
+			return 0, fmt.Errorf("calculating single choice question score for review item with ID %q: %w", reviewItem.ID, err)
 		}
 		return score, nil
 	}
 	if reviewItem.MultipleChoiceQuestionID != nil {
-		dbQuestion, err := question.GetMultipleChoiceQuestion(*reviewItem.MultipleChoiceQuestionID)
+		dbQuestion, err := h.deps.Questions.GetMultipleChoiceQuestion(ctx, *reviewItem.MultipleChoiceQuestionID)
 		if err != nil {
-			return 0, fmt.Errorf("getting multiple choice question with ID %q: %w\n", *reviewItem.MultipleChoiceQuestionID, err)
+			return 0, fmt.Errorf("getting multiple choice question with ID %q: %w", *reviewItem.MultipleChoiceQuestionID, err)
 		}
 		modelQuestion := dbQuestion.MapToModel()
 
 		score, err := calculateReviewItemMultipleChoiceQuestionScore(*modelQuestion, answers.MultipleChoiceValue)
 		if err != nil {
-			return 0, fmt.Errorf("calculating multiple choice question score for review item with ID %q: %w\n", reviewItem.ID, err)
+			return 0, fmt.Errorf("calculating multiple choice question score for review item with ID %q: %w", reviewItem.ID, err)
 		}
 		return score, nil
 	}
 	if reviewItem.TrueOrFalseQuestionID != nil {
-		dbQuestion, err := question.GetTrueOrFalseQuestion(*reviewItem.TrueOrFalseQuestionID)
+		dbQuestion, err := h.deps.Questions.GetTrueOrFalseQuestion(ctx, *reviewItem.TrueOrFalseQuestionID)
 		if err != nil {
-			return 0, fmt.Errorf("getting true or false question with ID %q: %w\n", *reviewItem.TrueOrFalseQuestionID, err)
+			return 0, fmt.Errorf("getting true or false question with ID %q: %w", *reviewItem.TrueOrFalseQuestionID, err)
 		}
 		modelQuestion := dbQuestion.MapToModel()
 
 		score, err := calculateReviewItemTrueOrFalseQuestionScore(*modelQuestion, answers.TrueOrFalseValue)
 		if err != nil {
-			return 0, fmt.Errorf("calculating true or false question score for review item with ID %q: %w\n", reviewItem.ID, err)
+			return 0, fmt.Errorf("calculating true or false question score for review item with ID %q: %w", reviewItem.ID, err)
 		}
 		return score, nil
 	}
 
-	log.Default().Printf("none of the questions were actually answered, additional check may be needed")
+	slog.Warn("review item has no question", "reviewItemId", reviewItem.ID)
 	return 0, nil
 }
 func calculateReviewItemSingleChoiceQuestionScore(singleChoiceQuestion models.SingleChoiceQuestion, answer string) (float64, error) {
@@ -401,7 +365,7 @@ func calculateReviewItemTrueOrFalseQuestionScore(trueOrFalseQuestion models.True
 	return 0, nil
 }
 
-func applySpacedRepetitionAndStore(ctx context.Context, reviewItem *models.ReviewItem, percentage float64) (*models.ReviewItem, error) {
+func (h *Handlers) applySpacedRepetitionAndStore(ctx context.Context, reviewItem *models.ReviewItem, percentage float64) (*models.ReviewItem, error) {
 	// score is the user's performance rating, where:
 	// 5 = perfect recall, 4 = correct with minor hesitation, 3 = correct but difficult,
 	// 2 = incorrect, but partially remembered, 1 = completely incorrect
@@ -433,8 +397,7 @@ func applySpacedRepetitionAndStore(ctx context.Context, reviewItem *models.Revie
 		Time: time.Now().Add(time.Duration(reviewItem.IntervalInMinutes) * time.Minute),
 	}
 
-	sqlcQuerier := utils.GetQuerier()
-	err := sqlcQuerier.UpdateReviewItem(
+	err := h.deps.ReviewItems.UpdateReviewItem(
 		ctx,
 		db.UpdateReviewItemParams{
 			ID:         reviewItem.ID,
@@ -450,7 +413,7 @@ func applySpacedRepetitionAndStore(ctx context.Context, reviewItem *models.Revie
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("storing updated review item with ID %q: %w\n", reviewItem.ID, err)
+		return nil, fmt.Errorf("storing updated review item with ID %q: %w", reviewItem.ID, err)
 	}
 
 	return reviewItem, nil
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/usage"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Returns the plan of the current user with the generations used in the
+// current day and month
+func (h *Handlers) GetUsageEndpoint(c echo.Context) error {
+	user := auth.CurrentUser(c)
+	summary, err := usage.GetSummary(c.Request().Context(), h.deps.Usage, user.Id, user.Plan)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(http.StatusOK, summary)
+}
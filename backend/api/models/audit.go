@@ -0,0 +1,25 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEvent struct {
+	Id             string          `json:"id"`
+	ActorId        *string         `json:"actorId"`
+	ActorEmail     string          `json:"actorEmail,omitempty"`
+	ImpersonatorId *string         `json:"impersonatorId"`
+	Action         string          `json:"action"`
+	TargetType     string          `json:"targetType"`
+	TargetId       string          `json:"targetId"`
+	IpAddress      string          `json:"ipAddress"`
+	Diff           json.RawMessage `json:"diff"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+type AuditEventsResponse struct {
+	Events  []AuditEvent `json:"events"`
+	Page    int          `json:"page"`
+	HasMore bool         `json:"hasMore"`
+}
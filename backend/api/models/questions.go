@@ -1,5 +1,10 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type QuestionType int
 
 const (
@@ -16,6 +21,8 @@ type MultipleChoiceQuestion struct {
 	Question       string       `json:"question"`
 	Answers        []string     `json:"answers"`
 	CorrectAnswers []string     `json:"correctAnswers"`
+	Status         string       `json:"status"`
+	Published      bool         `json:"published"`
 }
 type SingleChoiceQuestion struct {
 	ID            string       `json:"id"`
@@ -24,6 +31,8 @@ type SingleChoiceQuestion struct {
 	Question      string       `json:"question"`
 	Answers       []string     `json:"answers"`
 	CorrectAnswer string       `json:"correctAnswer"`
+	Status        string       `json:"status"`
+	Published     bool         `json:"published"`
 }
 type TrueOrFalseQuestion struct {
 	ID            string       `json:"id"`
@@ -31,6 +40,8 @@ type TrueOrFalseQuestion struct {
 	QuestionType  QuestionType `json:"questionType"`
 	Question      string       `json:"question"`
 	CorrectAnswer bool         `json:"correct_answer"`
+	Status        string       `json:"status"`
+	Published     bool         `json:"published"`
 }
 
 type SingleChoiceUpdateRequestBody struct {
@@ -53,7 +64,38 @@ type TrueOrFalseUpdateRequestBody struct {
 	CorrectAnswer bool   `json:"correctAnswer"`
 }
 
+// Moves a question through the review, to approved or rejected
+type QuestionStatusRequestBody struct {
+	Status string `json:"status"`
+}
+
 type QuestionCreationRequestBody struct {
 	QuizId string `json:"quizId"`
 	Prompt string `json:"prompt"`
 }
+
+// A stored version of a question, Question is the question as it was then
+type QuestionRevision struct {
+	Id           string          `json:"id"`
+	QuestionId   string          `json:"questionId"`
+	QuestionType QuestionType    `json:"questionType"`
+	Revision     int             `json:"revision"`
+	AuthorId     *string         `json:"authorId"`
+	AuthorName   *string         `json:"authorName"`
+	RestoredFrom *int            `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	Question     json.RawMessage `json:"question"`
+}
+
+// The fields that differ between two revisions of a question
+type QuestionRevisionDiff struct {
+	From                 int                       `json:"from"`
+	To                   int                       `json:"to"`
+	Changes              map[string]QuestionChange `json:"changes"`
+	CorrectAnswerChanged bool                      `json:"correctAnswerChanged"`
+}
+
+type QuestionChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
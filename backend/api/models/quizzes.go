@@ -1,5 +1,10 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type Question interface{}
 
 type QuizInfo struct {
@@ -8,9 +13,30 @@ type QuizInfo struct {
 	Description string `json:"description"`
 	CreatorId   string `json:"creatorId"`
 	CreatorName string `json:"creatorName"`
+	Status      string `json:"status"`
 }
 
 type Quiz struct {
 	QuizInfo
-	Questions []Question
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	Questions   []Question `json:"questions"`
+}
+
+// A quiz with its questions as exported from GET /quizzes/:id, the ids of
+// the questions are ignored and new ones are given
+type QuizImportRequestBody struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Questions   []json.RawMessage `json:"questions"`
+}
+
+type AdminQuizInfo struct {
+	QuizInfo
+	CreatorEmail string `json:"creatorEmail"`
+}
+
+type AdminQuizzesResponse struct {
+	Quizzes []AdminQuizInfo `json:"quizzes"`
+	Page    int             `json:"page"`
+	HasMore bool            `json:"hasMore"`
 }
@@ -0,0 +1,151 @@
+// Package apperror is the error model of the api. Handlers return an *Error,
+// or any other error, and ErrorHandler answers every failed request with the
+// same JSON body:
+//
+//	{"code": "not_found", "message": "quiz not found", "requestId": "..."}
+//
+// The message is shown to the user by the frontend, so the cause of internal
+// errors is only logged, never sent.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	CodeBadRequest      = "bad_request"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodeExpired         = "expired"
+	CodeTooManyRequests = "too_many_requests"
+	CodeQuotaExceeded   = "quota_exceeded"
+	CodeInternal        = "internal_error"
+	CodeUnavailable     = "unavailable"
+)
+
+type Error struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Extra fields of the error for the client, e.g. the quota that ran out
+	Details   any    `json:"details,omitempty"`
+	RequestId string `json:"requestId,omitempty"`
+	cause     error
+}
+
+func New(status int, code string, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(http.StatusConflict, CodeConflict, message)
+}
+
+// The error of a failure the user cannot do anything about. The cause is
+// logged with the request id and the user only sees that it happened.
+func Internal(cause error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: CodeInternal, Message: "internal server error", cause: cause}
+}
+
+// Internal with the cause wrapped in a description of what failed
+func Internalf(format string, args ...any) *Error {
+	return Internal(fmt.Errorf(format, args...))
+}
+
+func (e *Error) WithDetails(details any) *Error {
+	e.Details = details
+	return e
+}
+
+// Keeps the cause for the log, e.g. the query that found no quiz
+func (e *Error) WithCause(cause error) *Error {
+	e.cause = cause
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Converts what a handler returned into the error sent to the client. The
+// *echo.HTTPError of echo itself and of the older handlers keeps its status,
+// but only a string message is sent on and only below 500.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) {
+		return Internal(err)
+	}
+	if httpErr.Code >= http.StatusInternalServerError {
+		return &Error{Status: httpErr.Code, Code: codeOf(httpErr.Code), Message: defaultMessage(httpErr.Code), cause: err}
+	}
+	if message, ok := httpErr.Message.(string); ok {
+		return &Error{Status: httpErr.Code, Code: codeOf(httpErr.Code), Message: message, cause: httpErr.Internal}
+	}
+	return &Error{Status: httpErr.Code, Code: codeOf(httpErr.Code), Message: defaultMessage(httpErr.Code), cause: err}
+}
+
+func codeOf(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeExpired
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+func defaultMessage(status int) string {
+	if status == http.StatusInternalServerError {
+		return "internal server error"
+	}
+	return strings.ToLower(http.StatusText(status))
+}
@@ -0,0 +1,74 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spaced-ace-backend/logging"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestErrorHandlerSendsTheSameBodyForEveryError(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(logging.Middleware(func(c echo.Context) bool { return true }))
+	e.GET("/app", func(c echo.Context) error {
+		return NotFound("quiz not found").WithCause(errors.New("no rows in result set"))
+	})
+	e.GET("/internal", func(c echo.Context) error {
+		return Internalf("getting quiz: %w", errors.New("connection refused"))
+	})
+	e.GET("/echo-string", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden, "only the owner can modify the quiz")
+	})
+	e.GET("/echo-error", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("parsing request body: %w", errors.New("unexpected EOF")))
+	})
+	e.GET("/plain", func(c echo.Context) error {
+		return errors.New("pq: relation does not exist")
+	})
+	e.GET("/quota", func(c echo.Context) error {
+		return New(http.StatusTooManyRequests, CodeQuotaExceeded, "daily quota of 5 reached").WithDetails(map[string]int{"limit": 5})
+	})
+
+	for _, test := range []struct {
+		path    string
+		status  int
+		code    string
+		message string
+		details string
+	}{
+		{"/app", http.StatusNotFound, CodeNotFound, "quiz not found", ""},
+		{"/internal", http.StatusInternalServerError, CodeInternal, "internal server error", ""},
+		{"/echo-string", http.StatusForbidden, CodeForbidden, "only the owner can modify the quiz", ""},
+		{"/echo-error", http.StatusBadRequest, CodeBadRequest, "bad request", ""},
+		{"/plain", http.StatusInternalServerError, CodeInternal, "internal server error", ""},
+		{"/quota", http.StatusTooManyRequests, CodeQuotaExceeded, "daily quota of 5 reached", `{"limit":5}`},
+		{"/unknown", http.StatusNotFound, CodeNotFound, "Not Found", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		req.Header.Set(echo.HeaderXRequestID, "frontend-request")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var body struct {
+			Code      string          `json:"code"`
+			Message   string          `json:"message"`
+			Details   json.RawMessage `json:"details"`
+			RequestId string          `json:"requestId"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: %v in %q", test.path, err, rec.Body.String())
+		}
+		if rec.Code != test.status || body.Code != test.code || body.Message != test.message || string(body.Details) != test.details {
+			t.Errorf("%s: got %d %+v, want %d %s %q %s", test.path, rec.Code, body, test.status, test.code, test.message, test.details)
+		}
+		if body.RequestId != "frontend-request" || rec.Header().Get(echo.HeaderXRequestID) != "frontend-request" {
+			t.Errorf("%s: got request id %q and header %q, want the one of the frontend", test.path, body.RequestId, rec.Header().Get(echo.HeaderXRequestID))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package apperror
+
+import (
+	"log/slog"
+	"net/http"
+	"spaced-ace-backend/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// The HTTPErrorHandler of the server. Internal errors are logged with their
+// cause, the causes of the others only at debug level.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	ctx := c.Request().Context()
+	appErr := From(err)
+	if appErr.Status >= http.StatusInternalServerError {
+		logging.Logger(ctx).Error("request failed", slog.Int("status", appErr.Status), slog.Any("error", err))
+	} else if appErr.cause != nil {
+		logging.Logger(ctx).Debug("request rejected", slog.Int("status", appErr.Status), slog.Any("error", err))
+	}
+
+	response := *appErr
+	response.RequestId = logging.RequestId(ctx)
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(response.Status)
+	} else {
+		err = c.JSON(response.Status, response)
+	}
+	if err != nil {
+		logging.Logger(ctx).Error("writing the error response failed", slog.Any("error", err))
+	}
+}
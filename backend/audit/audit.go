@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LoginSucceeded  = "login.succeeded"
+	LoginFailed     = "login.failed"
+	SessionRevoked  = "session.revoked"
+	SessionsRevoked = "session.revoked_others"
+	TotpEnabled     = "totp.enabled"
+	TotpDisabled    = "totp.disabled"
+	ApiTokenCreated = "api_token.created"
+	ApiTokenRevoked = "api_token.revoked"
+	AccountDeleted  = "user.deleted"
+
+	ErasureRequested = "user.erasure_requested"
+	ErasureConfirmed = "user.erasure_confirmed"
+	ErasureCancelled = "user.erasure_cancelled"
+	DataExported     = "user.data_exported"
+
+	QuizCreated     = "quiz.created"
+	QuizUpdated     = "quiz.updated"
+	QuizDeleted     = "quiz.deleted"
+	QuestionCreated = "question.created"
+	QuestionUpdated = "question.updated"
+	QuestionDeleted = "question.deleted"
+
+	QuestionRestored = "question.restored"
+	ReviewItemsReset = "question.review_items_reset"
+
+	QuizPublished    = "quiz.published"
+	QuizArchived     = "quiz.archived"
+	QuestionReviewed = "question.reviewed"
+
+	AdminVerificationResent = "admin.user.verification_resent"
+	AdminEmailVerified      = "admin.user.email_verified"
+	AdminUserDisabled       = "admin.user.disabled"
+	AdminUserEnabled        = "admin.user.enabled"
+	AdminRoleChanged        = "admin.user.role_changed"
+	AdminImpersonated       = "admin.user.impersonated"
+	AdminQuizDeleted        = "admin.quiz.deleted"
+)
+
+const (
+	TargetUser     = "user"
+	TargetSession  = "session"
+	TargetApiToken = "api_token"
+	TargetQuiz     = "quiz"
+	TargetQuestion = "question"
+)
+
+type Event struct {
+	Id             string
+	ActorId        *string
+	ImpersonatorId *string
+	Action         string
+	TargetType     string
+	TargetId       string
+	IpAddress      string
+	Diff           json.RawMessage
+	CreatedAt      time.Time
+}
+
+// Empty fields are not filtered on
+type Filter struct {
+	ActorId    string
+	Action     string
+	TargetType string
+	TargetId   string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// A changed field in the diff of an event, From is missing for created
+// records and To for deleted ones
+type Change struct {
+	From any `json:"from,omitempty"`
+	To   any `json:"to,omitempty"`
+}
+
+// Where the events are stored, PostgresLog on the server and an in-memory
+// fake in the handler tests
+type Log interface {
+	Record(ctx context.Context, event *Event) error
+	// Returns the matching events, newest first
+	Search(ctx context.Context, filter Filter, limit int, offset int) ([]Event, error)
+}
+
+// Appends the event to the log, the id and time are set here
+func Record(ctx context.Context, log Log, event *Event) error {
+	event.Id = uuid.NewString()
+	if len(event.Diff) == 0 {
+		event.Diff = json.RawMessage("{}")
+	}
+	return log.Record(ctx, event)
+}
+
+type PostgresLog struct {
+	queries *db.Queries
+}
+
+func NewPostgresLog(queries *db.Queries) *PostgresLog {
+	return &PostgresLog{queries: queries}
+}
+
+func (l *PostgresLog) Record(ctx context.Context, event *Event) error {
+	createdAt, err := l.queries.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		ID:             event.Id,
+		ActorID:        event.ActorId,
+		ImpersonatorID: event.ImpersonatorId,
+		Action:         event.Action,
+		TargetType:     event.TargetType,
+		TargetID:       event.TargetId,
+		IpAddress:      event.IpAddress,
+		Diff:           event.Diff,
+	})
+	event.CreatedAt = createdAt.Time
+	return err
+}
+
+func (l *PostgresLog) Search(ctx context.Context, filter Filter, limit int, offset int) ([]Event, error) {
+	rows, err := l.queries.SearchAuditEvents(ctx, db.SearchAuditEventsParams{
+		ActorID:    optional(filter.ActorId),
+		Action:     optional(filter.Action),
+		TargetType: optional(filter.TargetType),
+		TargetID:   optional(filter.TargetId),
+		Since:      store.Timestamptz(filter.Since),
+		Until:      store.Timestamptz(filter.Until),
+		Limit:      int32(limit),
+		Offset:     int32(offset),
+	})
+	events := []Event{}
+	for _, row := range rows {
+		events = append(events, Event{
+			Id:             row.ID,
+			ActorId:        row.ActorID,
+			ImpersonatorId: row.ImpersonatorID,
+			Action:         row.Action,
+			TargetType:     row.TargetType,
+			TargetId:       row.TargetID,
+			IpAddress:      row.IpAddress,
+			Diff:           row.Diff,
+			CreatedAt:      row.CreatedAt.Time,
+		})
+	}
+	return events, err
+}
+
+// Empty filter fields match everything
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// Returns the fields that differ between the JSON encodings of before and
+// after. Pass nil as before for created records and as after for deleted ones.
+func Diff(before any, after any) json.RawMessage {
+	beforeFields := jsonFields(before)
+	afterFields := jsonFields(after)
+	changes := map[string]Change{}
+	for key, from := range beforeFields {
+		to, exists := afterFields[key]
+		if !exists || !reflect.DeepEqual(from, to) {
+			changes[key] = Change{From: from, To: to}
+		}
+	}
+	for key, to := range afterFields {
+		if _, exists := beforeFields[key]; !exists {
+			changes[key] = Change{To: to}
+		}
+	}
+	return Details(changes)
+}
+
+// Returns the value as the diff of events that do not change a record, like logins
+func Details(value any) json.RawMessage {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return json.RawMessage("{}")
+	}
+	return encoded
+}
+
+func jsonFields(value any) map[string]any {
+	fields := map[string]any{}
+	if value == nil {
+		return fields
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(encoded, &fields)
+	return fields
+}
@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"strconv"
+	"time"
+
+	"spaced-ace-backend/audit"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const adminUsersPageSize = 50
+
+// Impersonation sessions do not slide, support has an hour per session
+const impersonationLifetime = time.Hour
+
+type AdminUserResponse struct {
+	Id            string     `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	EmailVerified bool       `json:"emailVerified"`
+	Role          string     `json:"role"`
+	Plan          string     `json:"plan"`
+	DisabledAt    *time.Time `json:"disabledAt"`
+}
+
+type AdminUsersResponse struct {
+	Users   []AdminUserResponse `json:"users"`
+	Page    int                 `json:"page"`
+	HasMore bool                `json:"hasMore"`
+}
+
+type SetRoleBody struct {
+	Role string `json:"role"`
+}
+
+type ImpersonationResponse struct {
+	Session    string    `json:"session"`
+	ValidUntil time.Time `json:"validUntil"`
+}
+
+func mapAdminUser(user *DBUser) AdminUserResponse {
+	return AdminUserResponse{
+		Id:            user.Id,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Role:          user.Role,
+		Plan:          user.Plan,
+		DisabledAt:    user.DisabledAt,
+	}
+}
+
+// Returns the user of the :id path param
+func (h *Handlers) adminTargetUser(c echo.Context) (*DBUser, error) {
+	ctx := c.Request().Context()
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+	user, err := h.repos.Users.GetUserById(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("user not found")
+		}
+		return nil, apperror.Internal(err)
+	}
+	return user, nil
+}
+
+func (h *Handlers) auditAdminUserChange(c echo.Context, action string, before AdminUserResponse, after AdminUserResponse) {
+	Audit(c, h.auditLog, audit.Event{
+		Action:     action,
+		TargetType: audit.TargetUser,
+		TargetId:   after.Id,
+		Diff:       audit.Diff(before, after),
+	})
+}
+
+// Searches the users by name or email, `q` is the query and `page` starts at 1
+func (h *Handlers) AdminSearchUsersEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	// One extra row tells whether there is a next page
+	users, err := h.repos.Users.SearchUsers(ctx, c.QueryParam("q"), adminUsersPageSize+1, (page-1)*adminUsersPageSize)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	response := AdminUsersResponse{
+		Users:   []AdminUserResponse{},
+		Page:    page,
+		HasMore: len(users) > adminUsersPageSize,
+	}
+	for i := range users {
+		if i == adminUsersPageSize {
+			break
+		}
+		response.Users = append(response.Users, mapAdminUser(&users[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) AdminGetUserEndpoint(c echo.Context) error {
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, mapAdminUser(user))
+}
+
+func (h *Handlers) AdminResendVerificationEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return apperror.Conflict("email already verified")
+	}
+	if user.VerificationToken == nil || *user.VerificationToken == "" {
+		token := GenerateVerificationToken()
+		user.VerificationToken = &token
+		if err := h.repos.Users.UpdateUser(ctx, user); err != nil {
+			return apperror.Internalf("failed to update user: %w", err)
+		}
+	}
+	err = GetEmailVerificationService().SendVerificationEmail(user.Email, user.Name, *user.VerificationToken)
+	if err != nil {
+		return apperror.Internalf("failed to send verification email: %w", err)
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.AdminVerificationResent, TargetType: audit.TargetUser, TargetId: user.Id})
+	return c.JSON(http.StatusOK, EmailVerificationResponse{EMAIL_VERIFICATION_SENT})
+}
+
+// Marks the email of the user verified without the link, for users who cannot receive it
+func (h *Handlers) AdminVerifyEmailEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	before := mapAdminUser(user)
+	if err := h.repos.Users.VerifyEmail(ctx, user.Id); err != nil {
+		return apperror.Internalf("failed to verify email: %w", err)
+	}
+	user.EmailVerified = true
+	h.auditAdminUserChange(c, audit.AdminEmailVerified, before, mapAdminUser(user))
+	return c.JSON(http.StatusOK, mapAdminUser(user))
+}
+
+// Disables the account and signs it out everywhere. Api tokens of disabled
+// accounts are rejected by RequireAuthentication.
+func (h *Handlers) AdminDisableUserEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	if user.Id == CurrentUserId(c) {
+		return apperror.BadRequest("you cannot disable your own account")
+	}
+	if err := h.repos.Users.SetUserDisabled(ctx, user.Id, true); err != nil {
+		return apperror.Internal(err)
+	}
+	if err := h.repos.Sessions.DeleteSessionsOfUser(ctx, user.Id); err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.AdminUserDisabled, TargetType: audit.TargetUser, TargetId: user.Id})
+	return h.AdminGetUserEndpoint(c)
+}
+
+func (h *Handlers) AdminEnableUserEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	if err := h.repos.Users.SetUserDisabled(ctx, user.Id, false); err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.AdminUserEnabled, TargetType: audit.TargetUser, TargetId: user.Id})
+	return h.AdminGetUserEndpoint(c)
+}
+
+// Removes the second factor of a user who lost it, they sign in with the
+// password alone until they enroll again
+func (h *Handlers) AdminResetTotpEndpoint(c echo.Context) error {
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	if err := h.ResetTotp(c.Request().Context(), user.Id); err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.TotpDisabled, TargetType: audit.TargetUser, TargetId: user.Id})
+	return h.AdminGetUserEndpoint(c)
+}
+
+func (h *Handlers) AdminSetRoleEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	var request SetRoleBody
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+	if !IsValidRole(request.Role) {
+		return apperror.BadRequest("invalid role")
+	}
+	// Keeps at least one admin around, another admin has to demote you
+	if user.Id == CurrentUserId(c) {
+		return apperror.BadRequest("you cannot change your own role")
+	}
+	before := mapAdminUser(user)
+	if err := h.repos.Users.SetUserRole(ctx, user.Id, request.Role); err != nil {
+		return apperror.Internal(err)
+	}
+	user.Role = request.Role
+	h.auditAdminUserChange(c, audit.AdminRoleChanged, before, mapAdminUser(user))
+	return c.JSON(http.StatusOK, mapAdminUser(user))
+}
+
+// Opens a read-only session of the user for support. The frontend keeps the
+// session of the admin aside and swaps it back when the impersonation ends.
+func (h *Handlers) AdminImpersonateEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := h.adminTargetUser(c)
+	if err != nil {
+		return err
+	}
+	if user.Id == CurrentUserId(c) {
+		return apperror.BadRequest("you cannot impersonate yourself")
+	}
+	if user.Role == RoleAdmin {
+		return apperror.Forbidden("admins cannot be impersonated")
+	}
+	if user.DisabledAt != nil {
+		return apperror.Conflict("account disabled")
+	}
+
+	impersonatorId := CurrentUserId(c)
+	validUntil := time.Now().Add(impersonationLifetime)
+	session := Session{
+		UserId:         user.Id,
+		ValidUntil:     validUntil,
+		ExpiresAt:      validUntil,
+		UserAgent:      c.Request().UserAgent(),
+		IpAddress:      c.RealIP(),
+		ImpersonatorId: &impersonatorId,
+	}
+	if len(session.UserAgent) > maxUserAgentLength {
+		session.UserAgent = session.UserAgent[:maxUserAgentLength]
+	}
+	if err := h.repos.Sessions.CreateSession(ctx, &session); err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{
+		Action:     audit.AdminImpersonated,
+		TargetType: audit.TargetUser,
+		TargetId:   user.Id,
+		Diff:       audit.Details(map[string]any{"session": session.PublicId, "validUntil": session.ValidUntil}),
+	})
+	return c.JSON(http.StatusOK, ImpersonationResponse{
+		Session:    session.Id,
+		ValidUntil: session.ValidUntil,
+	})
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/logging"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const apiTokenPrefix = "sa_"
+const apiTokenContextKey = "apiToken"
+
+// Scopes come in read and write pairs per area, write implies read
+var ApiTokenScopes = []string{
+	"quizzes:read",
+	"quizzes:write",
+	"sessions:read",
+	"sessions:write",
+	"learn:read",
+	"learn:write",
+}
+
+type CreateApiTokenBody struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expiresInDays"`
+}
+
+type ApiTokenResponse struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+}
+
+type CreatedApiTokenResponse struct {
+	ApiTokenResponse
+	Token string `json:"token"`
+}
+
+func hashApiToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(header[7:])
+	return token, token != ""
+}
+
+// Resolves the bearer token of the request once and caches it in the context
+func (h *Handlers) apiTokenOfRequest(c echo.Context) (*ApiToken, error) {
+	ctx := c.Request().Context()
+	if apiToken, ok := c.Get(apiTokenContextKey).(*ApiToken); ok {
+		return apiToken, nil
+	}
+	token, ok := bearerToken(c.Request())
+	if !ok || !strings.HasPrefix(token, apiTokenPrefix) {
+		return nil, pgx.ErrNoRows
+	}
+	apiToken, err := h.repos.ApiTokens.GetActiveApiTokenByHash(ctx, hashApiToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if err := h.repos.ApiTokens.TouchApiToken(ctx, apiToken.Id); err != nil {
+		logging.Logger(c.Request().Context()).Error("failed to update last use of api token", "error", err)
+	}
+	c.Set(apiTokenContextKey, apiToken)
+	return apiToken, nil
+}
+
+// Restricts requests authenticated with a bearer token to tokens having the
+// read (GET requests) or write scope of the area. Requests authenticated with
+// the session cookie are not affected.
+func (h *Handlers) RequireTokenScope(area string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := bearerToken(c.Request()); !ok {
+				return next(c)
+			}
+			apiToken, err := h.apiTokenOfRequest(c)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return apperror.Unauthorized("invalid token")
+				}
+				return apperror.Internal(err)
+			}
+
+			method := c.Request().Method
+			allowed := slices.Contains(apiToken.Scopes, area+":write")
+			if method == http.MethodGet || method == http.MethodHead {
+				allowed = allowed || slices.Contains(apiToken.Scopes, area+":read")
+			}
+			if !allowed {
+				return apperror.Forbidden("token is missing the " + area + " scope")
+			}
+			return next(c)
+		}
+	}
+}
+
+func mapApiToken(token *ApiToken) ApiTokenResponse {
+	return ApiTokenResponse{
+		Id:         token.Id,
+		Name:       token.Name,
+		Prefix:     token.TokenPrefix,
+		Scopes:     token.Scopes,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+	}
+}
+
+// Token management is only available with a session cookie, a token cannot mint other tokens
+func (h *Handlers) GetApiTokensEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	tokens, err := h.repos.ApiTokens.GetApiTokensOfUser(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	response := make([]ApiTokenResponse, 0, len(tokens))
+	for i := range tokens {
+		response = append(response, mapApiToken(&tokens[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) CreateApiTokenEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	var request = CreateApiTokenBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return apperror.BadRequest("name is required")
+	}
+	if len(request.Name) > 100 {
+		return apperror.BadRequest("name must be at most 100 characters long")
+	}
+	if len(request.Scopes) == 0 {
+		return apperror.BadRequest("at least one scope is required")
+	}
+	for _, scope := range request.Scopes {
+		if !slices.Contains(ApiTokenScopes, scope) {
+			return apperror.BadRequest("unknown scope: " + scope)
+		}
+	}
+	if request.ExpiresInDays < 0 || request.ExpiresInDays > 365 {
+		return apperror.BadRequest("tokens can expire in at most 365 days")
+	}
+
+	random, err := randomString(32)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	plainToken := apiTokenPrefix + random
+	apiToken := ApiToken{
+		Id:          uuid.NewString(),
+		UserId:      userId,
+		Name:        request.Name,
+		TokenHash:   hashApiToken(plainToken),
+		TokenPrefix: plainToken[:len(apiTokenPrefix)+6],
+		Scopes:      slices.Compact(slices.Sorted(slices.Values(request.Scopes))),
+		CreatedAt:   time.Now(),
+	}
+	if request.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, request.ExpiresInDays)
+		apiToken.ExpiresAt = &expiresAt
+	}
+	if err := h.repos.ApiTokens.CreateApiToken(ctx, &apiToken); err != nil {
+		return apperror.Internalf("failed to create token: %w", err)
+	}
+	Audit(c, h.auditLog, audit.Event{
+		Action:     audit.ApiTokenCreated,
+		TargetType: audit.TargetApiToken,
+		TargetId:   apiToken.Id,
+		Diff:       audit.Diff(nil, mapApiToken(&apiToken)),
+	})
+
+	return c.JSON(http.StatusCreated, CreatedApiTokenResponse{
+		ApiTokenResponse: mapApiToken(&apiToken),
+		Token:            plainToken,
+	})
+}
+
+func (h *Handlers) RevokeApiTokenEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	revoked, err := h.repos.ApiTokens.RevokeApiToken(ctx, c.Param("id"), userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if revoked == 0 {
+		return apperror.NotFound("token not found")
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.ApiTokenRevoked, TargetType: audit.TargetApiToken, TargetId: c.Param("id")})
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Appends the event to the audit log with the actor, impersonator and address
+// of the request. The actor is the current user unless the event sets one.
+// A failed write is logged, the action itself has already happened.
+func Audit(c echo.Context, log audit.Log, event audit.Event) {
+	if event.ActorId == nil {
+		if userId := CurrentUserId(c); userId != "" {
+			event.ActorId = &userId
+		}
+	}
+	if impersonatorId := CurrentImpersonatorId(c); impersonatorId != "" {
+		event.ImpersonatorId = &impersonatorId
+	}
+	event.IpAddress = c.RealIP()
+	// Recorded even when the client went away, the action went through
+	if err := audit.Record(context.WithoutCancel(c.Request().Context()), log, &event); err != nil {
+		logging.Logger(c.Request().Context()).Error("failed to record audit event", "action", event.Action, "error", err)
+	}
+}
+
+func (h *Handlers) auditLogin(c echo.Context, user *DBUser, method string) {
+	Audit(c, h.auditLog, audit.Event{
+		ActorId:    &user.Id,
+		Action:     audit.LoginSucceeded,
+		TargetType: audit.TargetUser,
+		TargetId:   user.Id,
+		Diff:       audit.Details(map[string]string{"method": method}),
+	})
+}
+
+// The user is nil when nobody has the email, the email is kept to spot
+// attempts against accounts that do not exist
+func (h *Handlers) auditLoginFailure(c echo.Context, user *DBUser, email string, method string, reason string) {
+	event := audit.Event{
+		Action:     audit.LoginFailed,
+		TargetType: audit.TargetUser,
+		Diff:       audit.Details(map[string]string{"method": method, "reason": reason}),
+	}
+	if user != nil {
+		event.TargetId = user.Id
+	} else {
+		event.Diff = audit.Details(map[string]string{"method": method, "reason": reason, "email": normalizeLoginEmail(email)})
+	}
+	Audit(c, h.auditLog, event)
+}
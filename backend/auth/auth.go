@@ -1,14 +1,13 @@
 package auth
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
-	"time"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/logging"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	bcrypt "golang.org/x/crypto/bcrypt"
 )
@@ -18,11 +17,13 @@ type User struct {
 	Name          string `json:"name"`
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
 }
 
 type LoginBody struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"rememberMe"`
 }
 
 type SignupBody struct {
@@ -34,6 +35,8 @@ type SignupBody struct {
 type AuthResponse struct {
 	Session string `json:"session"`
 	User    User   `json:"user"`
+	// The admin looking at the account, only set for impersonation sessions
+	ImpersonatedBy *User `json:"impersonatedBy,omitempty"`
 }
 
 type ResendEmailverificationRequest struct {
@@ -50,119 +53,138 @@ const EMAIL_VERIFICATION_ALREADY_VERIFIED = "Email already verified"
 const EMAIL_VERIFICATION_RESEND = "If your email is registered, a verification link has been sent"
 const EMAIL_VERIFICATION_SENT = "Email verification sent"
 
-func AuthenticateUser(c echo.Context) error {
+func (h *Handlers) AuthenticateUser(c echo.Context) error {
+	ctx := c.Request().Context()
 	var request = LoginBody{}
 	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
-	var user, err = GetUserByEmail(request.Email)
+	if err := h.checkLoginLockout(c, request.Email); err != nil {
+		return err
+	}
+	var user, err = h.repos.Users.GetUserByEmail(ctx, request.Email)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+		if err == pgx.ErrNoRows {
+			h.recordLoginFailure(c, request.Email)
+			h.auditLoginFailure(c, nil, request.Email, "password", "unknown_email")
+			return apperror.Unauthorized("unauthorized")
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+		h.recordLoginFailure(c, request.Email)
+		h.auditLoginFailure(c, user, request.Email, "password", "wrong_password")
+		return apperror.Unauthorized("unauthorized")
 	}
+	h.clearLoginFailures(c, request.Email)
 
+	if user.DisabledAt != nil {
+		h.auditLoginFailure(c, user, request.Email, "password", "account_disabled")
+		return apperror.Forbidden("account disabled")
+	}
 	if !user.EmailVerified {
-		return echo.NewHTTPError(http.StatusForbidden, "email not verified")
+		return apperror.Forbidden("email not verified")
 	}
 
-	session, err := CreateSession(user.Id)
+	totpEnabled, err := h.TotpEnabled(ctx, user.Id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error, failed to create session")
+		return apperror.Internal(err)
+	}
+	if totpEnabled {
+		challenge, err := h.repos.Totp.CreateLoginChallenge(ctx, user.Id, request.RememberMe)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		return c.JSON(http.StatusAccepted, LoginChallengeResponse{Challenge: challenge, TotpRequired: true})
 	}
-	c.SetCookie(&http.Cookie{
-		Name:     "session",
-		Value:    session,
-		HttpOnly: true,
-		Expires:  time.Now().Add(59 * time.Minute),
-	})
+
+	_, err = h.startSession(c, user.Id, request.RememberMe)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	h.auditLogin(c, user, "password")
 
 	var userResponse = User{
 		Id:            user.Id,
 		Name:          user.Name,
 		Email:         user.Email,
 		EmailVerified: user.EmailVerified,
+		Role:          user.Role,
 	}
 	return c.JSON(http.StatusOK, userResponse)
 }
 
-func Authenticated(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
-	}
-	userId, err := GetUserIdBySession(session.Value)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
-		}
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
-	}
-
-	dbUser, err := GetUserById(userId)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
-	}
-
+// Returns the user of the request, the route runs behind RequireAuthentication
+func (h *Handlers) Authenticated(c echo.Context) error {
+	ctx := c.Request().Context()
+	dbUser := CurrentUser(c)
 	authResponse := AuthResponse{
-		Session: session.Value,
+		// Requests authenticated with a token have no session
+		Session: CurrentSessionId(c),
 		User: User{
 			Id:            dbUser.Id,
 			Name:          dbUser.Name,
 			Email:         dbUser.Email,
 			EmailVerified: dbUser.EmailVerified,
+			Role:          dbUser.Role,
 		},
 	}
+	if impersonatorId := CurrentImpersonatorId(c); impersonatorId != "" {
+		impersonator, err := h.repos.Users.GetUserById(ctx, impersonatorId)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		authResponse.ImpersonatedBy = &User{
+			Id:            impersonator.Id,
+			Name:          impersonator.Name,
+			Email:         impersonator.Email,
+			EmailVerified: impersonator.EmailVerified,
+			Role:          impersonator.Role,
+		}
+	}
 	return c.JSON(http.StatusOK, authResponse)
 }
 
-func Register(c echo.Context) error {
+func (h *Handlers) Register(c echo.Context) error {
+	ctx := c.Request().Context()
 	var request = SignupBody{}
 	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
 
 	if request.Name == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "name is required")
+		return apperror.BadRequest("name is required")
 	}
 	if request.Email == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "email is required")
+		return apperror.BadRequest("email is required")
 	}
 	if request.Password == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "password is required")
+		return apperror.BadRequest("password is required")
 	}
 	if request.PasswordAgain == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "password again is required")
+		return apperror.BadRequest("password again is required")
 	}
 
-	var oldUser, err = GetUserByEmail(request.Email)
+	var oldUser, err = h.repos.Users.GetUserByEmail(ctx, request.Email)
 	if err != nil {
-		if err != sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		if err != pgx.ErrNoRows {
+			return apperror.Internal(err)
 		}
 	}
 	if oldUser.Email != "" {
-		return echo.NewHTTPError(http.StatusConflict, "user already exists with this email")
+		return apperror.Conflict("user already exists with this email")
 	}
 
 	if request.Password != request.PasswordAgain {
-		return echo.NewHTTPError(http.StatusBadRequest, "passwords do not match")
+		return apperror.BadRequest("passwords do not match")
 	}
 	if len(request.Password) < 8 {
-		return echo.NewHTTPError(http.StatusBadRequest, "password must be at least 8 characters long")
+		return apperror.BadRequest("password must be at least 8 characters long")
 	}
 	bcryptPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
-		fmt.Println(err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 
 	verificationToken := GenerateVerificationToken()
@@ -174,115 +196,93 @@ func Register(c echo.Context) error {
 		Password:          string(bcryptPassword),
 		EmailVerified:     false,
 		VerificationToken: &verificationToken,
+		Role:              RoleUser,
 	}
-	err = CreateUser(&newUser)
+	err = h.repos.Users.CreateUser(ctx, &newUser)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create user")
+		return apperror.Internalf("failed to create user: %w", err)
 	}
 
 	emailSvc := GetEmailVerificationService()
 	err = emailSvc.SendVerificationEmail(newUser.Email, newUser.Name, verificationToken)
 	if err != nil {
 		// Log the error but don't fail registration
-		fmt.Printf("Error sending verification email: %v\n", err)
+		logging.Logger(c.Request().Context()).Error("failed to send verification email", "error", err)
 	}
 
-	sessionId, err := CreateSession(newUser.Id)
+	session, err := h.startSession(c, newUser.Id, false)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create session")
+		return apperror.Internalf("failed to create session: %w", err)
 	}
-	c.SetCookie(&http.Cookie{
-		Name:     "session",
-		Value:    sessionId,
-		Path:     "/",
-		HttpOnly: true,
-		Expires:  time.Now().Add(59 * time.Minute),
-	})
 
 	authResponse := AuthResponse{
-		Session: sessionId,
+		Session: session.Id,
 		User: User{
 			Id:            newUser.Id,
 			Name:          newUser.Name,
 			Email:         newUser.Email,
 			EmailVerified: newUser.EmailVerified,
+			Role:          newUser.Role,
 		},
 	}
 
 	return c.JSON(http.StatusOK, authResponse)
 }
 
-func Logout(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
-	}
-	err = DeleteSession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
-	}
-	return c.NoContent(http.StatusOK)
-}
-
-func DeleteUserEndpoint(c echo.Context) error {
-	session, err := c.Cookie("session")
-	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "login required")
-	}
-	userid, err := GetUserIdBySession(session.Value)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
-	}
-	err = DeleteUser(userid)
+func (h *Handlers) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+	err := h.repos.Sessions.DeleteSession(ctx, CurrentSessionId(c))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 	return c.NoContent(http.StatusOK)
 }
 
-func VerifyEmailEndpoint(c echo.Context) error {
+func (h *Handlers) VerifyEmailEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	token := c.QueryParam("token")
 	if token == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "verification token is required")
+		return apperror.BadRequest("verification token is required")
 	}
 
-	user, err := GetUserByVerificationToken(token)
+	user, err := h.repos.Users.GetUserByVerificationToken(ctx, token)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return echo.NewHTTPError(http.StatusNotFound, "invalid or expired verification token")
+		if err == pgx.ErrNoRows {
+			return apperror.NotFound("invalid or expired verification token")
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 
 	if user.EmailVerified {
 		return c.JSON(http.StatusOK, EmailVerificationResponse{EMAIL_VERIFICATION_ALREADY_VERIFIED})
 	}
 
-	err = VerifyEmail(user.Id)
+	err = h.repos.Users.VerifyEmail(ctx, user.Id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to verify email")
+		return apperror.Internalf("failed to verify email: %w", err)
 	}
 
 	return c.JSON(http.StatusOK, EmailVerificationResponse{EMAIL_VERIFICATION_SUCCESS})
 }
 
-func ResendVerificationEmailEndpoint(c echo.Context) error {
+func (h *Handlers) ResendVerificationEmailEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
 	var email ResendEmailverificationRequest
 	if err := json.NewDecoder(c.Request().Body).Decode(&email); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "bad request")
+		return apperror.BadRequest("bad request")
 	}
 
 	if email.Email == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "email is required")
+		return apperror.BadRequest("email is required")
 	}
 
-	user, err := GetUserByEmail(email.Email)
+	user, err := h.repos.Users.GetUserByEmail(ctx, email.Email)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			// Don't reveal if email exists or not
 			return c.JSON(http.StatusOK, EmailVerificationResponse{EMAIL_VERIFICATION_RESEND})
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		return apperror.Internal(err)
 	}
 
 	if user.EmailVerified {
@@ -292,9 +292,9 @@ func ResendVerificationEmailEndpoint(c echo.Context) error {
 	// Generate a new verification token if needed
 	if *user.VerificationToken == "" {
 		*user.VerificationToken = GenerateVerificationToken()
-		err = UpdateUser(user)
+		err = h.repos.Users.UpdateUser(ctx, user)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "failed to update user")
+			return apperror.Internalf("failed to update user: %w", err)
 		}
 	}
 
@@ -302,7 +302,7 @@ func ResendVerificationEmailEndpoint(c echo.Context) error {
 	svc := GetEmailVerificationService()
 	err = svc.SendVerificationEmail(user.Email, user.Name, *user.VerificationToken)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to send verification email")
+		return apperror.Internalf("failed to send verification email: %w", err)
 	}
 
 	return c.JSON(http.StatusOK, EmailVerificationResponse{EMAIL_VERIFICATION_SENT})
@@ -1,8 +1,13 @@
 package auth
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
+	"net/http"
+	"net/url"
+	"spaced-ace-backend/config"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -18,14 +23,14 @@ type EmailVerificationService struct {
 	mu         *sync.Mutex
 }
 
-// Initializes the email service
+// Initializes the email service, the app base url is the base of the links in the emails
 //
 // unsafe to call concurrently
-func InitEmailService() error {
+func InitEmailService(cfg config.Email, appBaseURL string) error {
 	if emailVerificationService != nil {
 		return nil
 	}
-	svc, err := newEmailVerificationService()
+	svc, err := newEmailVerificationService(cfg, appBaseURL)
 	if err != nil {
 		return fmt.Errorf("Failed to initialize email client: %w", err)
 	}
@@ -41,32 +46,53 @@ func GetEmailVerificationService() *EmailVerificationService {
 	return emailVerificationService
 }
 
-func newEmailVerificationService() (*EmailVerificationService, error) {
-	apiKey := os.Getenv("RESEND_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("RESEND_API_KEY environment variable not set")
+func newEmailVerificationService(cfg config.Email, appBaseURL string) (*EmailVerificationService, error) {
+	if cfg.ResendApiKey == "" {
+		return nil, fmt.Errorf("email.resendApiKey (RESEND_API_KEY) is not set")
 	}
 
-	client := resend.NewClient(apiKey)
-
-	fromEmail := os.Getenv("EMAIL_FROM_ADDRESS")
-	if fromEmail == "" {
-		fromEmail = "verification@spacedace.hu"
-	}
-
-	appBaseURL := os.Getenv("APP_BASE_URL")
-	if appBaseURL == "" {
-		appBaseURL = "http://localhost" // Default for local development
+	client := resend.NewClient(cfg.ResendApiKey)
+	if cfg.ResendBaseUrl != "" {
+		parsed, err := url.Parse(cfg.ResendBaseUrl)
+		if err != nil {
+			return nil, fmt.Errorf("email.resendBaseUrl is not a valid url: %w", err)
+		}
+		client.BaseURL = parsed
 	}
 
 	return &EmailVerificationService{
 		client:     client,
-		fromEmail:  fromEmail,
-		appBaseURL: appBaseURL,
+		fromEmail:  cfg.FromAddress,
+		appBaseURL: strings.TrimRight(appBaseURL, "/"),
 		mu:         &sync.Mutex{},
 	}, nil
 }
 
+// Checks that Resend is reachable and accepts the api key, for the readiness
+// probe. Keys that may only send emails cannot list the domains, Resend
+// answering that the key is restricted proves the key is valid too.
+func (s *EmailVerificationService) Ping(ctx context.Context) error {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, "domains", nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	var resendError struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resendError); err == nil && resendError.Name == "restricted_api_key" {
+		return nil
+	}
+	return fmt.Errorf("resend responded with status %d %s", res.StatusCode, resendError.Name)
+}
+
 func GenerateVerificationToken() string {
 	return uuid.NewString()
 }
@@ -97,3 +123,33 @@ func (s *EmailVerificationService) SendVerificationEmail(email, name, token stri
 	s.mu.Unlock()
 	return err
 }
+
+// Sends the link that confirms the deletion of the account, the account is
+// only erased after the grace period
+func (s *EmailVerificationService) SendErasureConfirmationEmail(email, name, token string, gracePeriodDays int) error {
+	confirmationLink := fmt.Sprintf("%s/confirm-erasure?token=%s", s.appBaseURL, token)
+
+	s.mu.Lock()
+	params := &resend.SendEmailRequest{
+		From:    s.fromEmail,
+		To:      []string{email},
+		Subject: "Confirm the deletion of your SpacedAce account",
+		Html: fmt.Sprintf(`
+			<div style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
+				<h2>Delete your SpacedAce account</h2>
+				<p>Hi %s,</p>
+				<p>We received a request to delete your account. Please confirm it by clicking the button below:</p>
+				<p style="text-align: center;">
+					<a href="%s" style="display: inline-block; background-color: #DC2626; color: white; padding: 10px 20px; text-decoration: none; border-radius: 5px;">Delete my account</a>
+				</p>
+				<p>Your account and data are erased %d days after the confirmation, until then you can cancel the deletion on your account page.</p>
+				<p>If you didn't request this, you can safely ignore this email.</p>
+				<p>Best regards,<br>The SpacedAce Team</p>
+			</div>
+		`, name, confirmationLink, gracePeriodDays),
+	}
+
+	_, err := s.client.Emails.Send(params)
+	s.mu.Unlock()
+	return err
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/logging"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// After loginFailuresBeforeLockout failed attempts in a row the email is locked
+// for loginBaseLockout, doubling with every further failure up to loginMaxLockout
+const (
+	loginFailuresBeforeLockout = 5
+	loginBaseLockout           = 30 * time.Second
+	loginMaxLockout            = time.Hour
+	loginFailureWindow         = time.Hour
+)
+
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Returns a 429 error while the email is locked out
+func (h *Handlers) checkLoginLockout(c echo.Context, email string) error {
+	ctx := c.Request().Context()
+	failure, err := h.repos.Lockouts.GetLoginFailure(ctx, normalizeLoginEmail(email))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return apperror.Internal(err)
+	}
+	if failure.LockedUntil == nil || !failure.LockedUntil.After(time.Now()) {
+		return nil
+	}
+	seconds := int(math.Ceil(time.Until(*failure.LockedUntil).Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	return apperror.New(http.StatusTooManyRequests, apperror.CodeTooManyRequests, "too many failed logins, retry in "+strconv.Itoa(seconds)+" seconds")
+}
+
+func lockoutDuration(failures int) time.Duration {
+	if failures < loginFailuresBeforeLockout {
+		return 0
+	}
+	lockout := loginBaseLockout
+	for i := loginFailuresBeforeLockout; i < failures && lockout < loginMaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, loginMaxLockout)
+}
+
+func (h *Handlers) recordLoginFailure(c echo.Context, email string) {
+	ctx := c.Request().Context()
+	email = normalizeLoginEmail(email)
+	failure, err := h.repos.Lockouts.RecordLoginFailure(ctx, email, loginFailureWindow)
+	if err != nil {
+		logging.Logger(c.Request().Context()).Error("failed to record login failure", "error", err)
+		return
+	}
+	if lockout := lockoutDuration(failure.Failures); lockout > 0 {
+		if err := h.repos.Lockouts.LockLogin(ctx, email, time.Now().Add(lockout)); err != nil {
+			logging.Logger(c.Request().Context()).Error("failed to lock login", "error", err)
+		}
+	}
+}
+
+func (h *Handlers) clearLoginFailures(c echo.Context, email string) {
+	ctx := c.Request().Context()
+	if err := h.repos.Lockouts.DeleteLoginFailures(ctx, normalizeLoginEmail(email)); err != nil {
+		logging.Logger(c.Request().Context()).Error("failed to clear login failures", "error", err)
+	}
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/logging"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const userContextKey = "user"
+const sessionContextKey = "session"
+const impersonatorContextKey = "impersonator"
+
+// Resolves the bearer token or the session cookie of the request once, loads
+// the user into the context and rejects unauthenticated requests
+func (h *Handlers) RequireAuthentication(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var userId string
+		// Nil for requests authenticated with an api token
+		var session *Session
+		if _, ok := bearerToken(c.Request()); ok {
+			apiToken, err := h.apiTokenOfRequest(c)
+			if err != nil {
+				return authenticationError(err)
+			}
+			userId = apiToken.UserId
+		} else {
+			cookie, err := c.Cookie("session")
+			if err != nil {
+				return apperror.Unauthorized("unauthorized")
+			}
+			session, err = h.repos.Sessions.GetSession(ctx, cookie.Value)
+			if err != nil {
+				return authenticationError(err)
+			}
+			userId = session.UserId
+		}
+		user, err := h.repos.Users.GetUserById(ctx, userId)
+		if err != nil {
+			return authenticationError(err)
+		}
+
+		if user.DisabledAt != nil {
+			return apperror.Forbidden("account disabled")
+		}
+
+		c.Set(userContextKey, user)
+		if session != nil {
+			c.Set(sessionContextKey, session.Id)
+			if session.ImpersonatorId != nil {
+				c.Set(impersonatorContextKey, *session.ImpersonatorId)
+				if !impersonationAllows(c.Request()) {
+					return apperror.Forbidden("impersonation is read-only")
+				}
+			}
+			if err := h.renewSession(c, session); err != nil {
+				logging.Logger(c.Request().Context()).Error("failed to renew session", "error", err)
+			}
+		}
+		return next(c)
+	}
+}
+
+// Unknown, expired and revoked credentials are unauthorized, anything else
+// went wrong on our side
+func authenticationError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperror.Unauthorized("unauthorized")
+	}
+	return apperror.Internal(err)
+}
+
+// Rejects requests authenticated with an api token, used for account
+// management that must only happen from a logged in browser
+func RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if CurrentSessionId(c) == "" {
+			return apperror.Forbidden("login required")
+		}
+		return next(c)
+	}
+}
+
+// Rejects impersonation sessions on reads that hand out the credentials or
+// the whole data of the user, which the admin has no business taking along
+func RejectImpersonation(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if CurrentImpersonatorId(c) != "" {
+			return apperror.Forbidden("not allowed while impersonating")
+		}
+		return next(c)
+	}
+}
+
+// Returns the user loaded by RequireAuthentication
+func CurrentUser(c echo.Context) *DBUser {
+	user, _ := c.Get(userContextKey).(*DBUser)
+	return user
+}
+
+// Returns the id of the user loaded by RequireAuthentication
+func CurrentUserId(c echo.Context) string {
+	if user := CurrentUser(c); user != nil {
+		return user.Id
+	}
+	return ""
+}
+
+// Returns the id of the admin impersonating the current user, empty for regular sessions
+func CurrentImpersonatorId(c echo.Context) string {
+	impersonator, _ := c.Get(impersonatorContextKey).(string)
+	return impersonator
+}
+
+// Impersonation is read-only, the admin can only look around and end the session
+func impersonationAllows(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return r.Method == http.MethodPost && r.URL.Path == "/logout"
+}
+
+// Returns the session of the request, empty for requests authenticated with an api token
+func CurrentSessionId(c echo.Context) string {
+	session, _ := c.Get(sessionContextKey).(string)
+	return session
+}
@@ -0,0 +1,426 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/logging"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/oauth2"
+)
+
+type OidcProviderInfo struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type OidcAuthorizeResponse struct {
+	Url   string `json:"url"`
+	State string `json:"state"`
+}
+
+type OidcCallbackBody struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+type IdentityResponse struct {
+	Id          string     `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+}
+
+type oidcClaims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+}
+
+type oidcProvider struct {
+	config      config.OidcProvider
+	redirectURL string
+	mu          sync.Mutex
+	provider    *oidc.Provider
+}
+
+// Registers the configured providers, the redirects go to the callback page
+// of the frontend at the app base url
+//
+// unsafe to call concurrently
+func (h *Handlers) InitOidcProviders(providers []config.OidcProvider, appBaseURL string) {
+	for _, provider := range providers {
+		h.RegisterOidcProvider(provider, strings.TrimRight(appBaseURL, "/")+"/login/oidc/"+provider.Id+"/callback")
+	}
+}
+
+// Registers a provider, the redirect url must point to the frontend callback page
+func (h *Handlers) RegisterOidcProvider(provider config.OidcProvider, redirectURL string) {
+	if _, exists := h.oidcProviders[provider.Id]; !exists {
+		h.oidcProviderIds = append(h.oidcProviderIds, provider.Id)
+	}
+	h.oidcProviders[provider.Id] = &oidcProvider{
+		config:      provider,
+		redirectURL: redirectURL,
+	}
+}
+
+// Discovery is done lazily, so an unavailable provider does not prevent startup
+func (p *oidcProvider) discover(ctx context.Context) (*oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider != nil {
+		return p.provider, nil
+	}
+	provider, err := oidc.NewProvider(ctx, p.config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+func (p *oidcProvider) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientId,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.redirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       p.config.Scopes,
+	}
+}
+
+func (p *oidcProvider) exchange(ctx context.Context, code string, state *OidcState) (*oidcClaims, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(state.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	rawIdToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response did not contain an id_token")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.config.ClientId}).Verify(ctx, rawIdToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+
+	claims := oidcClaims{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id_token claims: %w", err)
+	}
+	if claims.Nonce != state.Nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+	return &claims, nil
+}
+
+func randomString(byteCount int) (string, error) {
+	bytes := make([]byte, byteCount)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func (h *Handlers) GetOidcProvidersEndpoint(c echo.Context) error {
+	providers := make([]OidcProviderInfo, 0, len(h.oidcProviderIds))
+	for _, id := range h.oidcProviderIds {
+		providers = append(providers, OidcProviderInfo{
+			Id:          id,
+			DisplayName: h.oidcProviders[id].config.DisplayName,
+		})
+	}
+	return c.JSON(http.StatusOK, providers)
+}
+
+func (h *Handlers) OidcAuthorizeEndpoint(c echo.Context) error {
+	p, ok := h.oidcProviders[c.Param("provider")]
+	if !ok {
+		return apperror.NotFound("unknown identity provider")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return apperror.New(http.StatusBadGateway, apperror.CodeUnavailable, "identity provider unavailable").WithCause(fmt.Errorf("oidc discovery for %s: %w", p.config.Id, err))
+	}
+
+	state, err := randomString(32)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	oidcState := OidcState{
+		State:        state,
+		Provider:     p.config.Id,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+	}
+	if err := h.repos.Identities.CreateOidcState(ctx, &oidcState); err != nil {
+		return apperror.Internal(err)
+	}
+
+	url := p.oauth2Config(provider).AuthCodeURL(
+		state,
+		oidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(oidcState.CodeVerifier),
+	)
+	return c.JSON(http.StatusOK, OidcAuthorizeResponse{Url: url, State: state})
+}
+
+func (h *Handlers) OidcCallbackEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	p, ok := h.oidcProviders[c.Param("provider")]
+	if !ok {
+		return apperror.NotFound("unknown identity provider")
+	}
+
+	var request = OidcCallbackBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+	if request.Code == "" || request.State == "" {
+		return apperror.BadRequest("code and state are required")
+	}
+
+	state, err := h.repos.Identities.ConsumeOidcState(ctx, request.State)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.BadRequest("invalid or expired login state")
+		}
+		return apperror.Internal(err)
+	}
+	if state.Provider != p.config.Id {
+		return apperror.BadRequest("invalid or expired login state")
+	}
+
+	exchangeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	claims, err := p.exchange(exchangeCtx, request.Code, state)
+	if err != nil {
+		logging.Logger(c.Request().Context()).Warn("oidc login failed", "provider", p.config.Id, "error", err)
+		return apperror.Unauthorized("unauthorized")
+	}
+
+	user, err := h.resolveOidcUser(ctx, p.config.Id, claims)
+	if err != nil {
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.Internalf("resolving the oidc user: %w", err)
+	}
+	if user.DisabledAt != nil {
+		h.auditLoginFailure(c, user, user.Email, "oidc:"+p.config.Id, "account_disabled")
+		return apperror.Forbidden("account disabled")
+	}
+	if !user.EmailVerified {
+		return apperror.Forbidden("email not verified")
+	}
+
+	totpEnabled, err := h.TotpEnabled(ctx, user.Id)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if totpEnabled {
+		challenge, err := h.repos.Totp.CreateLoginChallenge(ctx, user.Id, false)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		return c.JSON(http.StatusAccepted, LoginChallengeResponse{Challenge: challenge, TotpRequired: true})
+	}
+
+	session, err := h.startSession(c, user.Id, false)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	h.auditLogin(c, user, "oidc:"+p.config.Id)
+
+	return c.JSON(http.StatusOK, AuthResponse{
+		Session: session.Id,
+		User: User{
+			Id:            user.Id,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified,
+			Role:          user.Role,
+		},
+	})
+}
+
+// Finds the user belonging to the identity. Unknown identities are linked to the
+// account with the same email if the provider verified it, which claims the
+// account if it was never verified. Otherwise a new account is created.
+func (h *Handlers) resolveOidcUser(ctx context.Context, provider string, claims *oidcClaims) (*DBUser, error) {
+	var email *string
+	if claims.Email != "" {
+		email = &claims.Email
+	}
+
+	identity, err := h.repos.Identities.GetIdentity(ctx, provider, claims.Subject)
+	if err == nil {
+		if err := h.repos.Identities.TouchIdentity(ctx, identity.Id, email); err != nil {
+			return nil, err
+		}
+		return h.repos.Users.GetUserById(ctx, identity.UserId)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	if claims.Email == "" {
+		return nil, apperror.BadRequest("identity provider did not share an email address")
+	}
+
+	user, err := h.repos.Users.GetUserByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		if !claims.EmailVerified {
+			return nil, apperror.Conflict("user already exists with this email")
+		}
+		if !user.EmailVerified {
+			if err := h.claimUnverifiedAccount(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+	case errors.Is(err, pgx.ErrNoRows):
+		name := claims.Name
+		if name == "" {
+			name = strings.Split(claims.Email, "@")[0]
+		}
+		user = &DBUser{
+			Id:            uuid.NewString(),
+			Name:          name,
+			Email:         claims.Email,
+			Password:      "",
+			EmailVerified: claims.EmailVerified,
+			Role:          RoleUser,
+		}
+		if err := h.repos.Users.CreateUser(ctx, user); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	err = h.repos.Identities.CreateIdentity(ctx, &Identity{
+		Id:       uuid.NewString(),
+		UserId:   user.Id,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// The provider proved the email belongs to the one logging in, whoever signed
+// up with it before may not have. Their password and everything they set up
+// with it goes, so they keep no way into the account once it is verified.
+// Either all of it goes or nothing does.
+func (h *Handlers) claimUnverifiedAccount(ctx context.Context, user *DBUser) error {
+	user.Password = ""
+	user.EmailVerified = true
+	user.VerificationToken = nil
+	return h.repos.WithTx(ctx, func(tx Repositories) error {
+		if err := tx.Users.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		if err := tx.Sessions.DeleteSessionsOfUser(ctx, user.Id); err != nil {
+			return err
+		}
+		tokens, err := tx.ApiTokens.GetApiTokensOfUser(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			if _, err := tx.ApiTokens.RevokeApiToken(ctx, token.Id, user.Id); err != nil {
+				return err
+			}
+		}
+		if err := tx.Totp.DeleteTotpCredential(ctx, user.Id); err != nil {
+			return err
+		}
+		if err := tx.Totp.DeleteRecoveryCodes(ctx, user.Id); err != nil {
+			return err
+		}
+		return tx.Totp.DeleteLoginChallengesOfUser(ctx, user.Id)
+	})
+}
+
+func (h *Handlers) GetIdentitiesEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+
+	identities, err := h.repos.Identities.GetIdentitiesOfUser(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	response := make([]IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		email := ""
+		if identity.Email != nil {
+			email = *identity.Email
+		}
+		response = append(response, IdentityResponse{
+			Id:          identity.Id,
+			Provider:    identity.Provider,
+			Email:       email,
+			CreatedAt:   identity.CreatedAt,
+			LastLoginAt: identity.LastLoginAt,
+		})
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) DeleteIdentityEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+
+	user, err := h.repos.Users.GetUserById(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	identities, err := h.repos.Identities.GetIdentitiesOfUser(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if user.Password == "" && len(identities) <= 1 {
+		return apperror.Conflict("cannot unlink the only way to sign in")
+	}
+
+	deleted, err := h.repos.Identities.DeleteIdentity(ctx, c.Param("id"), userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if deleted == 0 {
+		return apperror.NotFound("identity not found")
+	}
+	return c.NoContent(http.StatusOK)
+}
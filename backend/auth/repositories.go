@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
+	"time"
+)
+
+// The auth state is behind interfaces so the handlers can be tested against
+// in-memory fakes. PostgresRepository implements all of them.
+
+type UserRepository interface {
+	GetUserByEmail(ctx context.Context, email string) (*DBUser, error)
+	GetUserById(ctx context.Context, id string) (*DBUser, error)
+	CreateUser(ctx context.Context, user *DBUser) error
+	UpdateUser(ctx context.Context, user *DBUser) error
+	GetUserByVerificationToken(ctx context.Context, token string) (*DBUser, error)
+	VerifyEmail(ctx context.Context, id string) error
+	SearchUsers(ctx context.Context, query string, limit int, offset int) ([]DBUser, error)
+	SetUserRole(ctx context.Context, id string, role string) error
+	SetUserDisabled(ctx context.Context, id string, disabled bool) error
+}
+
+type SessionRepository interface {
+	GetSession(ctx context.Context, sessionId string) (*Session, error)
+	GetSessionsOfUser(ctx context.Context, userId string) ([]Session, error)
+	CreateSession(ctx context.Context, session *Session) error
+	RenewSession(ctx context.Context, sessionId string, validUntil time.Time) error
+	DeleteSession(ctx context.Context, id string) error
+	DeleteSessionOfUser(ctx context.Context, publicId string, userId string) (int64, error)
+	DeleteOtherSessionsOfUser(ctx context.Context, userId string, keepSessionId string) (int64, error)
+	DeleteSessionsOfUser(ctx context.Context, userId string) error
+}
+
+// The identities of the users at the OIDC providers and the states of the
+// logins in progress
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, provider string, subject string) (*Identity, error)
+	GetIdentitiesOfUser(ctx context.Context, userId string) ([]Identity, error)
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	TouchIdentity(ctx context.Context, id string, email *string) error
+	DeleteIdentity(ctx context.Context, id string, userId string) (int64, error)
+	CreateOidcState(ctx context.Context, state *OidcState) error
+	ConsumeOidcState(ctx context.Context, state string) (*OidcState, error)
+}
+
+// The TOTP credentials with their recovery codes and the login challenges
+// waiting for a code
+type TotpRepository interface {
+	GetTotpCredential(ctx context.Context, userId string) (*TotpCredential, error)
+	UpsertUnconfirmedTotpCredential(ctx context.Context, userId string, secret string) (int64, error)
+	ConfirmTotpCredential(ctx context.Context, userId string, step int64) error
+	UseTotpStep(ctx context.Context, userId string, step int64) (bool, error)
+	DeleteTotpCredential(ctx context.Context, userId string) error
+	ReplaceRecoveryCodes(ctx context.Context, userId string, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userId string, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userId string) (int, error)
+	DeleteRecoveryCodes(ctx context.Context, userId string) error
+	CreateLoginChallenge(ctx context.Context, userId string, rememberMe bool) (string, error)
+	ClaimLoginChallengeAttempt(ctx context.Context, id string, maxAttempts int) (*LoginChallenge, error)
+	DeleteLoginChallenge(ctx context.Context, id string) error
+	DeleteLoginChallengesOfUser(ctx context.Context, userId string) error
+}
+
+type ApiTokenRepository interface {
+	CreateApiToken(ctx context.Context, token *ApiToken) error
+	GetActiveApiTokenByHash(ctx context.Context, tokenHash string) (*ApiToken, error)
+	GetApiTokensOfUser(ctx context.Context, userId string) ([]ApiToken, error)
+	TouchApiToken(ctx context.Context, id string) error
+	RevokeApiToken(ctx context.Context, id string, userId string) (int64, error)
+}
+
+// The failed logins per email the lockout is counted from
+type LockoutRepository interface {
+	GetLoginFailure(ctx context.Context, email string) (*LoginFailure, error)
+	RecordLoginFailure(ctx context.Context, email string, window time.Duration) (*LoginFailure, error)
+	LockLogin(ctx context.Context, email string, lockedUntil time.Time) error
+	DeleteLoginFailures(ctx context.Context, email string) error
+}
+
+// Everything the auth handlers read and write, injected by main with New
+type Repositories struct {
+	Users      UserRepository
+	Sessions   SessionRepository
+	Identities IdentityRepository
+	Totp       TotpRepository
+	ApiTokens  ApiTokenRepository
+	Lockouts   LockoutRepository
+	// Runs f with repositories that are committed together when f returns
+	// nil and rolled back otherwise
+	WithTx func(ctx context.Context, f func(tx Repositories) error) error
+}
+
+// The auth endpoints and middleware with what they read and write
+type Handlers struct {
+	repos    Repositories
+	auditLog audit.Log
+	// The registered OIDC providers by id, and their ids in the order of registration
+	oidcProviders   map[string]*oidcProvider
+	oidcProviderIds []string
+}
+
+func New(repositories Repositories, auditLog audit.Log) *Handlers {
+	return &Handlers{
+		repos:         repositories,
+		auditLog:      auditLog,
+		oidcProviders: map[string]*oidcProvider{},
+	}
+}
+
+// Returns the users, for the packages that look them up besides the handlers
+func (h *Handlers) Users() UserRepository {
+	return h.repos.Users
+}
+
+// Returns the repositories backed by the sqlc queries of the store
+func NewPostgresRepositories(s *store.Store) Repositories {
+	repositories := newQueriesRepositories(s.Queries)
+	repositories.WithTx = func(ctx context.Context, f func(tx Repositories) error) error {
+		return s.WithTx(ctx, func(tx *store.Tx) error {
+			txRepositories := newQueriesRepositories(tx.Queries)
+			// The transaction is already open, nested calls join it
+			txRepositories.WithTx = func(ctx context.Context, f func(tx Repositories) error) error {
+				return f(txRepositories)
+			}
+			return f(txRepositories)
+		})
+	}
+	return repositories
+}
+
+func newQueriesRepositories(queries *db.Queries) Repositories {
+	repository := NewPostgresRepository(queries)
+	return Repositories{
+		Users:      repository,
+		Sessions:   repository,
+		Identities: repository,
+		Totp:       repository,
+		ApiTokens:  repository,
+		Lockouts:   repository,
+	}
+}
@@ -1,112 +1,498 @@
 package auth
 
 import (
-	_ "database/sql"
-	_ "fmt"
-	_ "github.com/lib/pq"
-	"spaced-ace-backend/utils"
+	"context"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
+	"time"
 )
 
 type DBUser struct {
-	Id                string  `db:"id"`
-	Name              string  `db:"name"`
-	Email             string  `db:"email"`
-	Password          string  `db:"password"`
-	EmailVerified     bool    `db:"email_verified"`
-	VerificationToken *string `db:"verification_token"`
+	Id                string
+	Name              string
+	Email             string
+	Password          string
+	EmailVerified     bool
+	VerificationToken *string
+	Plan              string
+	Role              string
+	DisabledAt        *time.Time
+}
+
+type Identity struct {
+	Id          string
+	UserId      string
+	Provider    string
+	Subject     string
+	Email       *string
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
+
+type OidcState struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ValidUntil   time.Time
+}
+
+type TotpCredential struct {
+	UserId       string
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+}
+
+type LoginChallenge struct {
+	Id         string
+	UserId     string
+	Attempts   int
+	ValidUntil time.Time
+	RememberMe bool
+}
+
+type ApiToken struct {
+	Id          string
+	UserId      string
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scopes      []string
+	CreatedAt   time.Time
+	LastUsedAt  *time.Time
+	ExpiresAt   *time.Time
+	RevokedAt   *time.Time
+}
+
+type LoginFailure struct {
+	Email         string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
 }
 
 type Session struct {
-	Id         string `db:"id"`
-	UserId     string `db:"user_id"`
-	ValidUntil string `db:"valid_until"`
-}
-
-var schema = `
-CREATE EXTENSION IF NOT EXISTS pg_cron;
-CREATE TABLE IF NOT EXISTS users (
-	id UUID PRIMARY KEY,
-	name TEXT,
-	email TEXT,
-	password TEXT,
-	email_verified BOOLEAN DEFAULT FALSE,
-	verification_token TEXT
-);
-CREATE INDEX IF NOT EXISTS users_email ON users(email);
-CREATE INDEX IF NOT EXISTS users_verification_token ON users(verification_token);
-CREATE UNLOGGED TABLE IF NOT EXISTS sessions (
-	id UUID PRIMARY KEY,
-	user_id UUID REFERENCES users(id) ON DELETE CASCADE,
-	valid_until TIMESTAMPTZ
-);
-CREATE INDEX IF NOT EXISTS sessions_id ON sessions(id);
-CREATE INDEX IF NOT EXISTS sessions_user_id ON sessions(user_id);
-CREATE INDEX IF NOT EXISTS sessions_valid_until ON sessions(valid_until);
-SELECT cron.schedule('del_exp_sessions', '10 * * * *', $$DELETE FROM sessions WHERE valid_until < now()$$);`
-
-func InitDb() {
-	utils.DB.MustExec(schema)
-}
-
-func GetUserByEmail(email string) (*DBUser, error) {
-	user := DBUser{}
-	err := utils.DB.Get(&user, "SELECT * FROM users WHERE email=$1", email)
-	return &user, err
-}
-
-func GetUserById(id string) (*DBUser, error) {
-	user := DBUser{}
-	err := utils.DB.Get(&user, "SELECT * FROM users WHERE id=$1", id)
-	return &user, err
-}
-
-func CreateUser(user *DBUser) error {
-	_, err := utils.DB.Exec("INSERT INTO users (id, name, email, password, email_verified, verification_token) VALUES ($1, $2, $3, $4, $5, $6)",
-		user.Id, user.Name, user.Email, user.Password, user.EmailVerified, user.VerificationToken)
+	Id         string
+	UserId     string
+	ValidUntil time.Time
+	PublicId   string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RememberMe bool
+	UserAgent  string
+	IpAddress  string
+	// Set when an admin opened the session to look at the account of the user
+	ImpersonatorId *string
+}
+
+type PostgresRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresRepository(queries *db.Queries) *PostgresRepository {
+	return &PostgresRepository{queries: queries}
+}
+
+func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (*DBUser, error) {
+	user, err := r.queries.GetUserByEmail(ctx, &email)
+	return mapUser(user), err
+}
+
+func (r *PostgresRepository) GetUserById(ctx context.Context, id string) (*DBUser, error) {
+	user, err := r.queries.GetUserById(ctx, id)
+	return mapUser(user), err
+}
+
+func (r *PostgresRepository) CreateUser(ctx context.Context, user *DBUser) error {
+	return r.queries.CreateUser(ctx, db.CreateUserParams{
+		ID:                user.Id,
+		Name:              &user.Name,
+		Email:             &user.Email,
+		Password:          &user.Password,
+		EmailVerified:     &user.EmailVerified,
+		VerificationToken: user.VerificationToken,
+	})
+}
+
+func (r *PostgresRepository) UpdateUser(ctx context.Context, user *DBUser) error {
+	return r.queries.UpdateUser(ctx, db.UpdateUserParams{
+		ID:                user.Id,
+		Name:              &user.Name,
+		Email:             &user.Email,
+		Password:          &user.Password,
+		EmailVerified:     &user.EmailVerified,
+		VerificationToken: user.VerificationToken,
+	})
+}
+
+func (r *PostgresRepository) GetSession(ctx context.Context, sessionId string) (*Session, error) {
+	session, err := r.queries.GetSession(ctx, sessionId)
+	return mapSession(session), err
+}
+
+// Returns the unexpired sessions of the user, most recently used first.
+// Impersonation sessions of admins are not included.
+func (r *PostgresRepository) GetSessionsOfUser(ctx context.Context, userId string) ([]Session, error) {
+	rows, err := r.queries.GetSessionsOfUser(ctx, &userId)
+	sessions := []Session{}
+	for _, row := range rows {
+		sessions = append(sessions, *mapSession(row))
+	}
+	return sessions, err
+}
+
+// Inserts the session and fills in the generated id and public id
+func (r *PostgresRepository) CreateSession(ctx context.Context, session *Session) error {
+	created, err := r.queries.CreateSession(ctx, db.CreateSessionParams{
+		UserID:         &session.UserId,
+		ValidUntil:     store.Timestamptz(&session.ValidUntil),
+		ExpiresAt:      store.Timestamptz(&session.ExpiresAt),
+		RememberMe:     session.RememberMe,
+		UserAgent:      session.UserAgent,
+		IpAddress:      session.IpAddress,
+		ImpersonatorID: session.ImpersonatorId,
+	})
 	if err != nil {
 		return err
 	}
+	*session = *mapSession(created)
 	return nil
 }
 
-func UpdateUser(user *DBUser) error {
-	_, err := utils.DB.Exec("UPDATE users SET name=$2, email=$3, password=$4, email_verified=$5, verification_token=$6 WHERE id=$1",
-		user.Id, user.Name, user.Email, user.Password, user.EmailVerified, user.VerificationToken)
-	return err
+func (r *PostgresRepository) RenewSession(ctx context.Context, sessionId string, validUntil time.Time) error {
+	return r.queries.RenewSession(ctx, db.RenewSessionParams{
+		ID:         sessionId,
+		ValidUntil: store.Timestamptz(&validUntil),
+	})
+}
+
+func (r *PostgresRepository) DeleteSession(ctx context.Context, id string) error {
+	return r.queries.DeleteSession(ctx, id)
+}
+
+// Returns the number of deleted sessions, 0 when the session is not the user's
+func (r *PostgresRepository) DeleteSessionOfUser(ctx context.Context, publicId string, userId string) (int64, error) {
+	return r.queries.DeleteSessionOfUser(ctx, db.DeleteSessionOfUserParams{PublicID: publicId, UserID: &userId})
+}
+
+func (r *PostgresRepository) DeleteOtherSessionsOfUser(ctx context.Context, userId string, keepSessionId string) (int64, error) {
+	return r.queries.DeleteOtherSessionsOfUser(ctx, db.DeleteOtherSessionsOfUserParams{UserID: &userId, ID: keepSessionId})
+}
+
+func (r *PostgresRepository) GetUserByVerificationToken(ctx context.Context, token string) (*DBUser, error) {
+	user, err := r.queries.GetUserByVerificationToken(ctx, &token)
+	return mapUser(user), err
+}
+
+func (r *PostgresRepository) VerifyEmail(ctx context.Context, id string) error {
+	return r.queries.VerifyEmail(ctx, id)
+}
+
+func (r *PostgresRepository) GetIdentity(ctx context.Context, provider string, subject string) (*Identity, error) {
+	identity, err := r.queries.GetIdentity(ctx, db.GetIdentityParams{Provider: provider, Subject: subject})
+	return mapIdentity(identity), err
+}
+
+func (r *PostgresRepository) GetIdentitiesOfUser(ctx context.Context, userId string) ([]Identity, error) {
+	rows, err := r.queries.GetIdentitiesOfUser(ctx, userId)
+	identities := []Identity{}
+	for _, row := range rows {
+		identities = append(identities, *mapIdentity(row))
+	}
+	return identities, err
+}
+
+func (r *PostgresRepository) CreateIdentity(ctx context.Context, identity *Identity) error {
+	return r.queries.CreateIdentity(ctx, db.CreateIdentityParams{
+		ID:       identity.Id,
+		UserID:   identity.UserId,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
 }
 
-func DeleteUser(id string) error {
-	_, err := utils.DB.Exec("DELETE FROM users WHERE id=$1", id)
-	return err
+func (r *PostgresRepository) TouchIdentity(ctx context.Context, id string, email *string) error {
+	return r.queries.TouchIdentity(ctx, db.TouchIdentityParams{ID: id, Email: email})
 }
 
-func GetUserIdBySession(sessionId string) (string, error) {
-	var id string
-	err := utils.DB.Get(&id, "SELECT user_id FROM sessions WHERE id=$1", sessionId)
-	return id, err
+func (r *PostgresRepository) DeleteIdentity(ctx context.Context, id string, userId string) (int64, error) {
+	return r.queries.DeleteIdentity(ctx, db.DeleteIdentityParams{ID: id, UserID: userId})
 }
 
-func CreateSession(userId string) (string, error) {
-	var id string
-	err := utils.DB.Get(&id, "INSERT INTO sessions (id, user_id, valid_until) VALUES (gen_random_uuid(), $1, now() + interval '1 hour') RETURNING id", userId)
+func (r *PostgresRepository) CreateOidcState(ctx context.Context, state *OidcState) error {
+	return r.queries.CreateOidcState(ctx, db.CreateOidcStateParams{
+		State:        state.State,
+		Provider:     state.Provider,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+	})
+}
+
+// Deletes the state and returns it, states can only be used once
+func (r *PostgresRepository) ConsumeOidcState(ctx context.Context, state string) (*OidcState, error) {
+	oidcState, err := r.queries.ConsumeOidcState(ctx, state)
+	if err != nil {
+		return &OidcState{}, err
+	}
+	return &OidcState{
+		State:        oidcState.State,
+		Provider:     oidcState.Provider,
+		Nonce:        oidcState.Nonce,
+		CodeVerifier: oidcState.CodeVerifier,
+		ValidUntil:   oidcState.ValidUntil.Time,
+	}, nil
+}
+
+func (r *PostgresRepository) GetTotpCredential(ctx context.Context, userId string) (*TotpCredential, error) {
+	credential, err := r.queries.GetTotpCredential(ctx, userId)
 	if err != nil {
-		return "", err
+		return &TotpCredential{}, err
 	}
-	return id, nil
+	return &TotpCredential{
+		UserId:       credential.UserID,
+		Secret:       credential.Secret,
+		ConfirmedAt:  store.TimePtr(credential.ConfirmedAt),
+		LastUsedStep: credential.LastUsedStep,
+	}, nil
+}
+
+// Replaces the unconfirmed credential of the user, confirmed ones are left untouched
+func (r *PostgresRepository) UpsertUnconfirmedTotpCredential(ctx context.Context, userId string, secret string) (int64, error) {
+	return r.queries.UpsertUnconfirmedTotpCredential(ctx, db.UpsertUnconfirmedTotpCredentialParams{UserID: userId, Secret: secret})
 }
 
-func DeleteSession(id string) error {
-	_, err := utils.DB.Exec("DELETE FROM sessions WHERE id=$1", id)
-	return err
+func (r *PostgresRepository) ConfirmTotpCredential(ctx context.Context, userId string, step int64) error {
+	return r.queries.ConfirmTotpCredential(ctx, db.ConfirmTotpCredentialParams{UserID: userId, LastUsedStep: step})
 }
 
-func GetUserByVerificationToken(token string) (*DBUser, error) {
-	user := DBUser{}
-	err := utils.DB.Get(&user, "SELECT * FROM users WHERE verification_token=$1", token)
-	return &user, err
+// Moves the last used step forward, returns false if the step was already used
+func (r *PostgresRepository) UseTotpStep(ctx context.Context, userId string, step int64) (bool, error) {
+	affected, err := r.queries.UseTotpStep(ctx, db.UseTotpStepParams{UserID: userId, LastUsedStep: step})
+	return affected == 1, err
+}
+
+func (r *PostgresRepository) DeleteTotpCredential(ctx context.Context, userId string) error {
+	return r.queries.DeleteTotpCredential(ctx, userId)
+}
+
+// Deletes the recovery codes of the user and stores the new ones, run it in a
+// transaction so the user is never left without codes
+func (r *PostgresRepository) ReplaceRecoveryCodes(ctx context.Context, userId string, codeHashes []string) error {
+	if err := r.queries.DeleteRecoveryCodes(ctx, userId); err != nil {
+		return err
+	}
+	for _, codeHash := range codeHashes {
+		if err := r.queries.CreateRecoveryCode(ctx, db.CreateRecoveryCodeParams{UserID: userId, CodeHash: codeHash}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func VerifyEmail(id string) error {
-	_, err := utils.DB.Exec("UPDATE users SET email_verified=true, verification_token=NULL WHERE id=$1", id)
-	return err
+// Marks the recovery code as used, returns false if there is no unused code with the hash
+func (r *PostgresRepository) UseRecoveryCode(ctx context.Context, userId string, codeHash string) (bool, error) {
+	affected, err := r.queries.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{UserID: userId, CodeHash: codeHash})
+	return affected == 1, err
+}
+
+func (r *PostgresRepository) CountUnusedRecoveryCodes(ctx context.Context, userId string) (int, error) {
+	count, err := r.queries.CountUnusedRecoveryCodes(ctx, userId)
+	return int(count), err
+}
+
+func (r *PostgresRepository) DeleteRecoveryCodes(ctx context.Context, userId string) error {
+	return r.queries.DeleteRecoveryCodes(ctx, userId)
+}
+
+func (r *PostgresRepository) CreateLoginChallenge(ctx context.Context, userId string, rememberMe bool) (string, error) {
+	return r.queries.CreateLoginChallenge(ctx, db.CreateLoginChallengeParams{UserID: userId, RememberMe: rememberMe})
+}
+
+// Counts an attempt of the challenge, returns pgx.ErrNoRows when it expired
+// or had its attempts already
+func (r *PostgresRepository) ClaimLoginChallengeAttempt(ctx context.Context, id string, maxAttempts int) (*LoginChallenge, error) {
+	challenge, err := r.queries.ClaimLoginChallengeAttempt(ctx, db.ClaimLoginChallengeAttemptParams{ID: id, MaxAttempts: int32(maxAttempts)})
+	if err != nil {
+		return &LoginChallenge{}, err
+	}
+	return &LoginChallenge{
+		Id:         challenge.ID,
+		UserId:     challenge.UserID,
+		Attempts:   int(challenge.Attempts),
+		ValidUntil: challenge.ValidUntil.Time,
+		RememberMe: challenge.RememberMe,
+	}, nil
+}
+
+func (r *PostgresRepository) DeleteLoginChallenge(ctx context.Context, id string) error {
+	return r.queries.DeleteLoginChallenge(ctx, id)
+}
+
+func (r *PostgresRepository) DeleteLoginChallengesOfUser(ctx context.Context, userId string) error {
+	return r.queries.DeleteLoginChallengesOfUser(ctx, userId)
+}
+
+func (r *PostgresRepository) CreateApiToken(ctx context.Context, token *ApiToken) error {
+	return r.queries.CreateApiToken(ctx, db.CreateApiTokenParams{
+		ID:          token.Id,
+		UserID:      token.UserId,
+		Name:        token.Name,
+		TokenHash:   token.TokenHash,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		ExpiresAt:   store.Timestamptz(token.ExpiresAt),
+	})
+}
+
+// Returns the token with the hash if it is neither revoked nor expired
+func (r *PostgresRepository) GetActiveApiTokenByHash(ctx context.Context, tokenHash string) (*ApiToken, error) {
+	token, err := r.queries.GetActiveApiTokenByHash(ctx, tokenHash)
+	return mapApiTokenRow(token), err
+}
+
+func (r *PostgresRepository) GetApiTokensOfUser(ctx context.Context, userId string) ([]ApiToken, error) {
+	rows, err := r.queries.GetApiTokensOfUser(ctx, userId)
+	tokens := []ApiToken{}
+	for _, row := range rows {
+		tokens = append(tokens, *mapApiTokenRow(row))
+	}
+	return tokens, err
+}
+
+// Updates the last used timestamp at most once a minute to spare writes on busy tokens
+func (r *PostgresRepository) TouchApiToken(ctx context.Context, id string) error {
+	return r.queries.TouchApiToken(ctx, id)
+}
+
+func (r *PostgresRepository) RevokeApiToken(ctx context.Context, id string, userId string) (int64, error) {
+	return r.queries.RevokeApiToken(ctx, db.RevokeApiTokenParams{ID: id, UserID: userId})
+}
+
+func (r *PostgresRepository) GetLoginFailure(ctx context.Context, email string) (*LoginFailure, error) {
+	failure, err := r.queries.GetLoginFailure(ctx, email)
+	return mapLoginFailure(failure), err
+}
+
+// Counts a failed login, the count starts over when the last failure is older than the window
+func (r *PostgresRepository) RecordLoginFailure(ctx context.Context, email string, window time.Duration) (*LoginFailure, error) {
+	failure, err := r.queries.RecordLoginFailure(ctx, db.RecordLoginFailureParams{Email: email, WindowSeconds: window.Seconds()})
+	return mapLoginFailure(failure), err
+}
+
+func (r *PostgresRepository) LockLogin(ctx context.Context, email string, lockedUntil time.Time) error {
+	return r.queries.LockLogin(ctx, db.LockLoginParams{Email: email, LockedUntil: store.Timestamptz(&lockedUntil)})
+}
+
+func (r *PostgresRepository) DeleteLoginFailures(ctx context.Context, email string) error {
+	return r.queries.DeleteLoginFailures(ctx, email)
+}
+
+// Returns a page of the users whose name or email contains the query
+func (r *PostgresRepository) SearchUsers(ctx context.Context, query string, limit int, offset int) ([]DBUser, error) {
+	rows, err := r.queries.SearchUsers(ctx, db.SearchUsersParams{Query: query, Limit: int32(limit), Offset: int32(offset)})
+	users := []DBUser{}
+	for _, row := range rows {
+		users = append(users, *mapUser(row))
+	}
+	return users, err
+}
+
+func (r *PostgresRepository) SetUserRole(ctx context.Context, id string, role string) error {
+	return r.queries.SetUserRole(ctx, db.SetUserRoleParams{ID: id, Role: role})
+}
+
+// Disables or re-enables the account, disabled users cannot log in
+func (r *PostgresRepository) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	return r.queries.SetUserDisabled(ctx, db.SetUserDisabledParams{ID: id, Disabled: disabled})
+}
+
+func (r *PostgresRepository) DeleteSessionsOfUser(ctx context.Context, userId string) error {
+	return r.queries.DeleteSessionsOfUser(ctx, &userId)
+}
+
+// The mappers return an empty value for nil, like the lookups did before on
+// missing rows, so callers can keep checking only the error
+
+func mapUser(user *db.User) *DBUser {
+	if user == nil {
+		return &DBUser{}
+	}
+	return &DBUser{
+		Id:                user.ID,
+		Name:              store.Value(user.Name),
+		Email:             store.Value(user.Email),
+		Password:          store.Value(user.Password),
+		EmailVerified:     store.Value(user.EmailVerified),
+		VerificationToken: user.VerificationToken,
+		Plan:              user.Plan,
+		Role:              user.Role,
+		DisabledAt:        store.TimePtr(user.DisabledAt),
+	}
+}
+
+func mapSession(session *db.Session) *Session {
+	if session == nil {
+		return &Session{}
+	}
+	return &Session{
+		Id:             session.ID,
+		UserId:         store.Value(session.UserID),
+		ValidUntil:     session.ValidUntil.Time,
+		PublicId:       session.PublicID,
+		CreatedAt:      session.CreatedAt.Time,
+		LastSeenAt:     session.LastSeenAt.Time,
+		ExpiresAt:      session.ExpiresAt.Time,
+		RememberMe:     session.RememberMe,
+		UserAgent:      session.UserAgent,
+		IpAddress:      session.IpAddress,
+		ImpersonatorId: session.ImpersonatorID,
+	}
+}
+
+func mapIdentity(identity *db.Identity) *Identity {
+	if identity == nil {
+		return &Identity{}
+	}
+	return &Identity{
+		Id:          identity.ID,
+		UserId:      identity.UserID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt.Time,
+		LastLoginAt: store.TimePtr(identity.LastLoginAt),
+	}
+}
+
+func mapApiTokenRow(token *db.ApiToken) *ApiToken {
+	if token == nil {
+		return &ApiToken{}
+	}
+	return &ApiToken{
+		Id:          token.ID,
+		UserId:      token.UserID,
+		Name:        token.Name,
+		TokenHash:   token.TokenHash,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.Scopes,
+		CreatedAt:   token.CreatedAt.Time,
+		LastUsedAt:  store.TimePtr(token.LastUsedAt),
+		ExpiresAt:   store.TimePtr(token.ExpiresAt),
+		RevokedAt:   store.TimePtr(token.RevokedAt),
+	}
+}
+
+func mapLoginFailure(failure *db.LoginFailure) *LoginFailure {
+	if failure == nil {
+		return &LoginFailure{}
+	}
+	return &LoginFailure{
+		Email:         failure.Email,
+		Failures:      int(failure.Failures),
+		LastFailureAt: failure.LastFailureAt.Time,
+		LockedUntil:   store.TimePtr(failure.LockedUntil),
+	}
 }
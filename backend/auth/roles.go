@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"slices"
+	"spaced-ace-backend/apperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Global roles of the users. Moderators manage content, admins also manage users.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+var Roles = []string{RoleUser, RoleModerator, RoleAdmin}
+
+func IsValidRole(role string) bool {
+	return slices.Contains(Roles, role)
+}
+
+// Returns true for users who can see and delete the content of others
+func IsStaff(user *DBUser) bool {
+	return user != nil && (user.Role == RoleModerator || user.Role == RoleAdmin)
+}
+
+// Rejects requests of users without one of the roles, must run after RequireAuthentication
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := CurrentUser(c)
+			if user == nil || !slices.Contains(roles, user.Role) {
+				return apperror.Forbidden("insufficient role")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/config"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var sessionConfig = config.Default().Sessions
+
+const maxUserAgentLength = 512
+
+type SessionResponse struct {
+	Id         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IpAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ValidUntil time.Time `json:"validUntil"`
+	RememberMe bool      `json:"rememberMe"`
+	Current    bool      `json:"current"`
+}
+
+// Sets the session lifetimes
+func InitSessionConfig(cfg config.Sessions) {
+	sessionConfig = cfg
+}
+
+func sessionTimeouts(rememberMe bool) (idle time.Duration, max time.Duration) {
+	if rememberMe {
+		return sessionConfig.RememberMeIdleTimeout, sessionConfig.RememberMeMaxLifetime
+	}
+	return sessionConfig.IdleTimeout, sessionConfig.MaxLifetime
+}
+
+// Remembered sessions get a persistent cookie, others end with the browser
+func setSessionCookie(c echo.Context, session *Session) {
+	cookie := &http.Cookie{
+		Name:     "session",
+		Value:    session.Id,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if session.RememberMe {
+		cookie.Expires = session.ValidUntil
+	}
+	c.SetCookie(cookie)
+}
+
+// Creates a session for the device making the request and sets its cookie
+func (h *Handlers) startSession(c echo.Context, userId string, rememberMe bool) (*Session, error) {
+	ctx := c.Request().Context()
+	idle, max := sessionTimeouts(rememberMe)
+	now := time.Now()
+	userAgent := c.Request().UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	session := Session{
+		UserId:     userId,
+		ValidUntil: now.Add(idle),
+		ExpiresAt:  now.Add(max),
+		RememberMe: rememberMe,
+		UserAgent:  userAgent,
+		IpAddress:  c.RealIP(),
+	}
+	if err := h.repos.Sessions.CreateSession(ctx, &session); err != nil {
+		return nil, err
+	}
+	setSessionCookie(c, &session)
+	return &session, nil
+}
+
+// Slides the expiry of the session and refreshes its cookie. Sessions are
+// renewed at most once a minute to keep writes off the hot path.
+func (h *Handlers) renewSession(c echo.Context, session *Session) error {
+	ctx := c.Request().Context()
+	if time.Since(session.LastSeenAt) < time.Minute {
+		return nil
+	}
+	idle, _ := sessionTimeouts(session.RememberMe)
+	validUntil := time.Now().Add(idle)
+	if validUntil.After(session.ExpiresAt) {
+		validUntil = session.ExpiresAt
+	}
+	if err := h.repos.Sessions.RenewSession(ctx, session.Id, validUntil); err != nil {
+		return err
+	}
+	session.ValidUntil = validUntil
+	setSessionCookie(c, session)
+	return nil
+}
+
+func (h *Handlers) GetSessionsEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	sessions, err := h.repos.Sessions.GetSessionsOfUser(ctx, CurrentUserId(c))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	currentSessionId := CurrentSessionId(c)
+	response := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, SessionResponse{
+			Id:         session.PublicId,
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IpAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ValidUntil: session.ValidUntil,
+			RememberMe: session.RememberMe,
+			Current:    session.Id == currentSessionId,
+		})
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *Handlers) RevokeSessionEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		return apperror.NotFound("session not found")
+	}
+	revoked, err := h.repos.Sessions.DeleteSessionOfUser(ctx, c.Param("id"), CurrentUserId(c))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if revoked == 0 {
+		return apperror.NotFound("session not found")
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.SessionRevoked, TargetType: audit.TargetSession, TargetId: c.Param("id")})
+	return c.NoContent(http.StatusOK)
+}
+
+// Signs out every other device, the session making the request stays valid
+func (h *Handlers) RevokeOtherSessionsEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	revoked, err := h.repos.Sessions.DeleteOtherSessionsOfUser(ctx, CurrentUserId(c), CurrentSessionId(c))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{
+		Action:     audit.SessionsRevoked,
+		TargetType: audit.TargetUser,
+		TargetId:   CurrentUserId(c),
+		Diff:       audit.Details(map[string]int64{"revoked": revoked}),
+	})
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,394 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/config"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	totpDigits             = 6
+	totpPeriod             = 30
+	totpSkew               = 1
+	recoveryCodeCount      = 10
+	loginChallengeAttempts = 5
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var totpConfig = config.Default().Totp
+
+func InitTotpConfig(cfg config.Totp) {
+	totpConfig = cfg
+}
+
+type LoginChallengeResponse struct {
+	Challenge    string `json:"challenge"`
+	TotpRequired bool   `json:"totpRequired"`
+}
+
+type LoginTotpBody struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"`
+}
+
+type TotpCodeBody struct {
+	Code string `json:"code"`
+}
+
+type TotpStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+type TotpEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"provisioningUri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func generateTotpSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// Computes the RFC 6238 code of the given time step
+func totpCode(secret []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+// Returns the time step the code belongs to, allowing one step of clock skew
+func validateTotp(secret string, code string, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func totpProvisioningUri(secret string, email string) string {
+	issuer := totpConfig.Issuer
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+email) + "?" + query.Encode()
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Generates new recovery codes for the user, the previous ones stop working.
+// Pass the repository of a transaction, the old codes are deleted first.
+func regenerateRecoveryCodes(ctx context.Context, totp TotpRepository, userId string) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		random := make([]byte, 7)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(totpEncoding.EncodeToString(random))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	if err := totp.ReplaceRecoveryCodes(ctx, userId, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (h *Handlers) TotpEnabled(ctx context.Context, userId string) (bool, error) {
+	credential, err := h.repos.Totp.GetTotpCredential(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return credential.ConfirmedAt != nil, nil
+}
+
+// Checks a TOTP or recovery code of a user with confirmed TOTP, every code can only be used once
+func (h *Handlers) verifySecondFactor(ctx context.Context, userId string, code string) (bool, error) {
+	credential, err := h.repos.Totp.GetTotpCredential(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if credential.ConfirmedAt == nil {
+		return false, nil
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := validateTotp(credential.Secret, strings.ReplaceAll(code, " ", ""), time.Now()); ok {
+		return h.repos.Totp.UseTotpStep(ctx, userId, step)
+	}
+	if len(normalizeRecoveryCode(code)) == 10 {
+		return h.repos.Totp.UseRecoveryCode(ctx, userId, hashRecoveryCode(code))
+	}
+	return false, nil
+}
+
+// Removes the second factor of the user, used when an admin helps a locked out user
+func (h *Handlers) ResetTotp(ctx context.Context, userId string) error {
+	return h.repos.WithTx(ctx, func(tx Repositories) error {
+		if err := tx.Totp.DeleteTotpCredential(ctx, userId); err != nil {
+			return err
+		}
+		if err := tx.Totp.DeleteRecoveryCodes(ctx, userId); err != nil {
+			return err
+		}
+		return tx.Totp.DeleteLoginChallengesOfUser(ctx, userId)
+	})
+}
+
+func (h *Handlers) AuthenticateTotpEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	var request = LoginTotpBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+	if request.Challenge == "" || request.Code == "" {
+		return apperror.BadRequest("challenge and code are required")
+	}
+
+	challenge, err := h.repos.Totp.ClaimLoginChallengeAttempt(ctx, request.Challenge, loginChallengeAttempts)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			_ = h.repos.Totp.DeleteLoginChallenge(ctx, request.Challenge)
+			return apperror.New(http.StatusGone, apperror.CodeExpired, "login challenge expired")
+		}
+		return apperror.Internal(err)
+	}
+
+	ok, err := h.verifySecondFactor(ctx, challenge.UserId, request.Code)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		Audit(c, h.auditLog, audit.Event{
+			Action:     audit.LoginFailed,
+			TargetType: audit.TargetUser,
+			TargetId:   challenge.UserId,
+			Diff:       audit.Details(map[string]string{"method": "totp", "reason": "invalid_code"}),
+		})
+		return apperror.Unauthorized("invalid code")
+	}
+	if err := h.repos.Totp.DeleteLoginChallenge(ctx, challenge.Id); err != nil {
+		return apperror.Internal(err)
+	}
+
+	user, err := h.repos.Users.GetUserById(ctx, challenge.UserId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if user.DisabledAt != nil {
+		return apperror.Forbidden("account disabled")
+	}
+	session, err := h.startSession(c, user.Id, challenge.RememberMe)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	h.auditLogin(c, user, "totp")
+
+	return c.JSON(http.StatusOK, AuthResponse{
+		Session: session.Id,
+		User: User{
+			Id:            user.Id,
+			Name:          user.Name,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified,
+			Role:          user.Role,
+		},
+	})
+}
+
+func (h *Handlers) GetTotpStatusEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	enabled, err := h.TotpEnabled(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	remaining := 0
+	if enabled {
+		remaining, err = h.repos.Totp.CountUnusedRecoveryCodes(ctx, userId)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+	}
+	return c.JSON(http.StatusOK, TotpStatusResponse{
+		Enabled:                enabled,
+		RecoveryCodesRemaining: remaining,
+	})
+}
+
+func (h *Handlers) EnrollTotpEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	user, err := h.repos.Users.GetUserById(ctx, userId)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	secret, err := generateTotpSecret()
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	stored, err := h.repos.Totp.UpsertUnconfirmedTotpCredential(ctx, userId, secret)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if stored == 0 {
+		return apperror.Conflict("two-factor authentication is already enabled")
+	}
+
+	return c.JSON(http.StatusOK, TotpEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningUri: totpProvisioningUri(secret, user.Email),
+	})
+}
+
+func (h *Handlers) ConfirmTotpEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	var request = TotpCodeBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+
+	credential, err := h.repos.Totp.GetTotpCredential(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperror.NotFound("no pending two-factor enrollment")
+		}
+		return apperror.Internal(err)
+	}
+	if credential.ConfirmedAt != nil {
+		return apperror.Conflict("two-factor authentication is already enabled")
+	}
+	step, ok := validateTotp(credential.Secret, strings.ReplaceAll(request.Code, " ", ""), time.Now())
+	if !ok {
+		return apperror.BadRequest("invalid code")
+	}
+
+	// The credential is only confirmed together with its recovery codes
+	var codes []string
+	err = h.repos.WithTx(ctx, func(tx Repositories) error {
+		if err := tx.Totp.ConfirmTotpCredential(ctx, userId, step); err != nil {
+			return err
+		}
+		codes, err = regenerateRecoveryCodes(ctx, tx.Totp, userId)
+		return err
+	})
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.TotpEnabled, TargetType: audit.TargetUser, TargetId: userId})
+	return c.JSON(http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *Handlers) DisableTotpEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	var request = TotpCodeBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+
+	ok, err := h.verifySecondFactor(ctx, userId, request.Code)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		return apperror.BadRequest("invalid code")
+	}
+	if err := h.ResetTotp(ctx, userId); err != nil {
+		return apperror.Internal(err)
+	}
+	Audit(c, h.auditLog, audit.Event{Action: audit.TotpDisabled, TargetType: audit.TargetUser, TargetId: userId})
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handlers) RegenerateRecoveryCodesEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId := CurrentUserId(c)
+	var request = TotpCodeBody{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
+		return apperror.BadRequest("bad request")
+	}
+
+	ok, err := h.verifySecondFactor(ctx, userId, request.Code)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !ok {
+		return apperror.BadRequest("invalid code")
+	}
+	var codes []string
+	err = h.repos.WithTx(ctx, func(tx Repositories) error {
+		codes, err = regenerateRecoveryCodes(ctx, tx.Totp, userId)
+		return err
+	})
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
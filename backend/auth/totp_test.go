@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// The SHA1 vectors of RFC 6238 appendix B, cut to the six digits we use
+func TestTotpCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	for _, tc := range []struct {
+		time int64
+		want string
+	}{
+		{time: 59, want: "287082"},
+		{time: 1111111109, want: "081804"},
+		{time: 1111111111, want: "050471"},
+		{time: 1234567890, want: "005924"},
+		{time: 2000000000, want: "279037"},
+		{time: 20000000000, want: "353130"},
+	} {
+		if got := totpCode(secret, tc.time/totpPeriod); got != tc.want {
+			t.Errorf("at %d got %s, want %s", tc.time, got, tc.want)
+		}
+	}
+}
+
+func TestValidateTotp(t *testing.T) {
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+	for _, tc := range []struct {
+		name string
+		code string
+		ok   bool
+	}{
+		{name: "current step", code: "050471", ok: true},
+		{name: "previous step", code: "081804", ok: true},
+		{name: "far off step", code: "005924"},
+		{name: "wrong code", code: "000000"},
+		{name: "eight digits", code: "14050471"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, ok := validateTotp(secret, tc.code, now); ok != tc.ok {
+				t.Errorf("got %v, want %v", ok, tc.ok)
+			}
+		})
+	}
+}
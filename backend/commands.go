@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/migrations"
+	"spaced-ace-backend/store"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Runs a maintenance command instead of the server, e.g. `app reset-totp user@example.com`
+func runCommand(s *store.Store, a *app, args []string) error {
+	ctx := context.Background()
+	switch args[0] {
+	case "reset-totp":
+		if len(args) != 2 {
+			return errors.New("usage: reset-totp <email>")
+		}
+		user, err := a.auth.Users().GetUserByEmail(ctx, args[1])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("no user with email %q", args[1])
+			}
+			return err
+		}
+		if err := a.auth.ResetTotp(ctx, user.Id); err != nil {
+			return err
+		}
+		// Without an actor, whoever ran the command did not sign in
+		err = audit.Record(ctx, a.auditLog, &audit.Event{
+			Action:     audit.TotpDisabled,
+			TargetType: audit.TargetUser,
+			TargetId:   user.Id,
+			Diff:       audit.Details(map[string]string{"via": "command"}),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Two-factor authentication of %s was reset\n", user.Email)
+		return nil
+	case "set-role":
+		// Bootstraps the first admin, later ones can be promoted in the admin console
+		if len(args) != 3 || !auth.IsValidRole(args[2]) {
+			return fmt.Errorf("usage: set-role <email> <%s>", strings.Join(auth.Roles, "|"))
+		}
+		user, err := a.auth.Users().GetUserByEmail(ctx, args[1])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("no user with email %q", args[1])
+			}
+			return err
+		}
+		if err := a.auth.Users().SetUserRole(ctx, user.Id, args[2]); err != nil {
+			return err
+		}
+		fmt.Printf("%s is now %s\n", user.Email, args[2])
+		return nil
+	case "erase-due-accounts":
+		// The server does this every few minutes, the command is for cron jobs and tests
+		erased, err := a.account.EraseDueAccounts(ctx)
+		fmt.Printf("Erased %d accounts\n", erased)
+		return err
+	case "migrate":
+		return runMigrate(s, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// Usage: migrate [up | down [steps] | status]. The server migrates up on start,
+// so this is mostly for reverting and for running migrations ahead of a deploy.
+func runMigrate(s *store.Store, args []string) error {
+	ctx := context.Background()
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+	switch {
+	case action == "up" && len(args) <= 1:
+		applied, err := migrations.Up(ctx, s.Pool)
+		fmt.Printf("Applied %d migrations\n", applied)
+		return err
+	case action == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			parsed, err := strconv.Atoi(args[1])
+			if err != nil || parsed < 1 {
+				return errors.New("usage: migrate down [steps]")
+			}
+			steps = parsed
+		}
+		reverted, err := migrations.Down(ctx, s.Pool, steps)
+		fmt.Printf("Reverted %d migrations\n", reverted)
+		return err
+	case action == "status" && len(args) == 1:
+		statuses, err := migrations.GetStatus(ctx, s.Pool)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	default:
+		return errors.New("usage: migrate [up | down [steps] | status]")
+	}
+}
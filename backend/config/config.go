@@ -0,0 +1,450 @@
+// Package config loads the settings of the backend. The defaults are
+// overridden by the YAML file named by CONFIG_FILE, and that by the
+// environment variables in the env tags, so secrets can stay out of the file.
+// See config.example.yaml for every setting.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server      Server      `yaml:"server"`
+	Logging     Logging     `yaml:"logging"`
+	Metrics     Metrics     `yaml:"metrics"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Database    Database    `yaml:"database"`
+	Llm         Llm         `yaml:"llm"`
+	Email       Email       `yaml:"email"`
+	Sessions    Sessions    `yaml:"sessions"`
+	Totp        Totp        `yaml:"totp"`
+	Erasure     Erasure     `yaml:"erasure"`
+	RateLimit   RateLimit   `yaml:"rateLimit"`
+	ReviewItems ReviewItems `yaml:"reviewItems"`
+	// Overridden with OIDC_PROVIDERS, a comma separated list of ids, and
+	// OIDC_<ID>_ISSUER, OIDC_<ID>_CLIENT_ID, OIDC_<ID>_CLIENT_SECRET,
+	// OIDC_<ID>_DISPLAY_NAME and OIDC_<ID>_SCOPES
+	Oidc []OidcProvider `yaml:"oidc"`
+	// The generation quotas by plan name. Overridden with LLM_QUOTA_PLANS, a
+	// comma separated list of extra plans, and LLM_QUOTA_<PLAN>_DAILY and
+	// LLM_QUOTA_<PLAN>_MONTHLY.
+	Quotas map[string]Quota `yaml:"quotas"`
+}
+
+type Server struct {
+	// dev or production, dev lets the database be reached without credentials
+	Environment string `yaml:"environment" env:"ENVIRONMENT"`
+	Port        int    `yaml:"port" env:"PORT"`
+	// Where users reach the frontend, the base of the links in emails and of the oidc redirects
+	AppBaseUrl string `yaml:"appBaseUrl" env:"APP_BASE_URL"`
+	// How long in-flight requests and background work get to finish after SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// The requests and errors are logged to stdout, one line per entry
+type Logging struct {
+	// debug, info, warn or error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// json for the log collector, text for reading it in a terminal
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// Prometheus scrapes /metrics on its own port, which is not published like the api
+type Metrics struct {
+	Port int `yaml:"port" env:"METRICS_PORT"`
+}
+
+// Traces are exported over OTLP/HTTP, e.g. to a local collector on
+// http://localhost:4318. Tracing is off while the endpoint is empty.
+type Tracing struct {
+	Endpoint string `yaml:"endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	// The share of the traces started here that are kept, between 0 and 1.
+	// Requests that come with a sampled trace are always traced.
+	SampleRatio float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type Database struct {
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASS" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+}
+
+type Llm struct {
+	ApiUrl string `yaml:"apiUrl" env:"LLM_API_URL"`
+}
+
+type Email struct {
+	// Required by the server, the commands do not send emails
+	ResendApiKey string `yaml:"resendApiKey" env:"RESEND_API_KEY" secret:"true"`
+	// Empty for the Resend api, the integration tests point it at a local mailbox
+	ResendBaseUrl string `yaml:"resendBaseUrl" env:"RESEND_BASE_URL"`
+	FromAddress   string `yaml:"fromAddress" env:"EMAIL_FROM_ADDRESS"`
+}
+
+// Sessions slide forward by the idle timeout on use, but never past the max lifetime
+type Sessions struct {
+	IdleTimeout           time.Duration `yaml:"idleTimeout" env:"SESSION_IDLE_TIMEOUT"`
+	MaxLifetime           time.Duration `yaml:"maxLifetime" env:"SESSION_MAX_LIFETIME"`
+	RememberMeIdleTimeout time.Duration `yaml:"rememberMeIdleTimeout" env:"SESSION_REMEMBER_ME_IDLE_TIMEOUT"`
+	RememberMeMaxLifetime time.Duration `yaml:"rememberMeMaxLifetime" env:"SESSION_REMEMBER_ME_MAX_LIFETIME"`
+}
+
+type Totp struct {
+	// Shown next to the account in authenticator apps
+	Issuer string `yaml:"issuer" env:"TOTP_ISSUER"`
+}
+
+type Erasure struct {
+	// How long a confirmed erasure can be cancelled before the account is erased
+	GracePeriod time.Duration `yaml:"gracePeriod" env:"ACCOUNT_ERASURE_GRACE_PERIOD"`
+}
+
+type RateLimit struct {
+	// memory or postgres, use postgres when more than one backend instance is running
+	Store string `yaml:"store" env:"RATE_LIMIT_STORE"`
+}
+
+type ReviewItems struct {
+	PageSize int `yaml:"pageSize" env:"REVIEW_ITEM_PAGE_SIZE"`
+}
+
+type OidcProvider struct {
+	Id string `yaml:"id"`
+	// Defaults to the id
+	DisplayName  string   `yaml:"displayName"`
+	Issuer       string   `yaml:"issuer"`
+	ClientId     string   `yaml:"clientId"`
+	ClientSecret string   `yaml:"clientSecret" secret:"true"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+// A limit of 0 means the plan is not limited in that period
+type Quota struct {
+	Daily   int `yaml:"daily"`
+	Monthly int `yaml:"monthly"`
+}
+
+const DefaultPlan = "free"
+
+var defaultOidcScopes = []string{"openid", "profile", "email"}
+
+// Returns the settings used when nothing is configured, they suit local development
+func Default() *Config {
+	return &Config{
+		Server:   Server{Environment: "production", Port: 9000, AppBaseUrl: "http://localhost", ShutdownTimeout: 30 * time.Second},
+		Metrics:  Metrics{Port: 9100},
+		Logging:  Logging{Level: "info", Format: "json"},
+		Tracing:  Tracing{SampleRatio: 1},
+		Database: Database{Host: "localhost", Port: 5432, Name: "postgres"},
+		Llm:      Llm{ApiUrl: "http://localhost:8000"},
+		Email:    Email{FromAddress: "verification@spacedace.hu"},
+		Sessions: Sessions{
+			IdleTimeout:           time.Hour,
+			MaxLifetime:           24 * time.Hour,
+			RememberMeIdleTimeout: 30 * 24 * time.Hour,
+			RememberMeMaxLifetime: 90 * 24 * time.Hour,
+		},
+		Totp:        Totp{Issuer: "SpacedAce"},
+		Erasure:     Erasure{GracePeriod: 14 * 24 * time.Hour},
+		RateLimit:   RateLimit{Store: "memory"},
+		ReviewItems: ReviewItems{PageSize: 10},
+		Oidc:        []OidcProvider{},
+		Quotas: map[string]Quota{
+			"free": {Daily: 20, Monthly: 200},
+			"pro":  {Daily: 200, Monthly: 3000},
+		},
+	}
+}
+
+// Loads the defaults, the file of CONFIG_FILE when it is set and the
+// environment, and validates the result
+func Load() (*Config, error) {
+	config := Default()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := config.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.readEnv(); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Settings missing from the file keep their defaults, unknown keys are an
+// error so typos do not go unnoticed
+func (c *Config) readFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	defer file.Close()
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) readEnv() error {
+	problems := []string{}
+	readEnvFields(reflect.ValueOf(c).Elem(), &problems)
+
+	for _, id := range splitList(os.Getenv("OIDC_PROVIDERS")) {
+		id = strings.ToLower(id)
+		if c.oidcProvider(id) == nil {
+			c.Oidc = append(c.Oidc, OidcProvider{Id: id})
+		}
+	}
+	for i := range c.Oidc {
+		provider := &c.Oidc[i]
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(provider.Id, "-", "_")) + "_"
+		readEnvString(prefix+"DISPLAY_NAME", &provider.DisplayName)
+		readEnvString(prefix+"ISSUER", &provider.Issuer)
+		readEnvString(prefix+"CLIENT_ID", &provider.ClientId)
+		readEnvString(prefix+"CLIENT_SECRET", &provider.ClientSecret)
+		if scopes, exists := os.LookupEnv(prefix + "SCOPES"); exists && scopes != "" {
+			provider.Scopes = splitList(scopes)
+		}
+	}
+
+	for _, name := range splitList(os.Getenv("LLM_QUOTA_PLANS")) {
+		name = strings.ToLower(name)
+		if _, exists := c.Quotas[name]; !exists {
+			c.Quotas[name] = Quota{}
+		}
+	}
+	for name, quota := range c.Quotas {
+		prefix := "LLM_QUOTA_" + strings.ToUpper(name)
+		readEnvInt(prefix+"_DAILY", &quota.Daily, &problems)
+		readEnvInt(prefix+"_MONTHLY", &quota.Monthly, &problems)
+		c.Quotas[name] = quota
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid environment:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// Sets the fields with an env tag from the variable when it is set and not empty
+func readEnvFields(value reflect.Value, problems *[]string) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		env := value.Type().Field(i).Tag.Get("env")
+		switch {
+		case field.Kind() == reflect.Struct:
+			readEnvFields(field, problems)
+		case env == "":
+		case field.Type() == reflect.TypeOf(time.Duration(0)):
+			raw, exists := os.LookupEnv(env)
+			if !exists || raw == "" {
+				continue
+			}
+			duration, err := time.ParseDuration(raw)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s must be a duration like 90m or 720h, got %q", env, raw))
+				continue
+			}
+			field.SetInt(int64(duration))
+		case field.Kind() == reflect.Float64:
+			raw, exists := os.LookupEnv(env)
+			if !exists || raw == "" {
+				continue
+			}
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s must be a number, got %q", env, raw))
+				continue
+			}
+			field.SetFloat(number)
+		case field.Kind() == reflect.Int:
+			number := int(field.Int())
+			readEnvInt(env, &number, problems)
+			field.SetInt(int64(number))
+		case field.Kind() == reflect.String:
+			readEnvString(env, field.Addr().Interface().(*string))
+		}
+	}
+}
+
+func readEnvString(env string, value *string) {
+	if raw, exists := os.LookupEnv(env); exists && raw != "" {
+		*value = raw
+	}
+}
+
+func readEnvInt(env string, value *int, problems *[]string) {
+	raw, exists := os.LookupEnv(env)
+	if !exists || raw == "" {
+		return
+	}
+	number, err := strconv.Atoi(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s must be a whole number, got %q", env, raw))
+		return
+	}
+	*value = number
+}
+
+// Reports every problem at once, named by the setting of the file and its variable
+func (c *Config) Validate() error {
+	problems := []string{}
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Server.Environment == "dev" || c.Server.Environment == "production", "server.environment (ENVIRONMENT) must be dev or production, got %q", c.Server.Environment)
+	check(validPort(c.Server.Port), "server.port (PORT) must be between 1 and 65535, got %d", c.Server.Port)
+	check(validUrl(c.Server.AppBaseUrl), "server.appBaseUrl (APP_BASE_URL) must be an absolute http url, got %q", c.Server.AppBaseUrl)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout (SHUTDOWN_TIMEOUT) must be positive, got %s", c.Server.ShutdownTimeout)
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, c.Logging.Level), "logging.level (LOG_LEVEL) must be debug, info, warn or error, got %q", c.Logging.Level)
+	check(c.Logging.Format == "json" || c.Logging.Format == "text", "logging.format (LOG_FORMAT) must be json or text, got %q", c.Logging.Format)
+
+	check(validPort(c.Metrics.Port), "metrics.port (METRICS_PORT) must be between 1 and 65535, got %d", c.Metrics.Port)
+	check(c.Metrics.Port != c.Server.Port, "metrics.port (METRICS_PORT) cannot be the port of the api")
+
+	check(c.Tracing.Endpoint == "" || validUrl(c.Tracing.Endpoint), "tracing.endpoint (TRACING_OTLP_ENDPOINT) must be an absolute http url, got %q", c.Tracing.Endpoint)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio (TRACING_SAMPLE_RATIO) must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	check(c.Database.Host != "", "database.host (DB_HOST) is required")
+	check(validPort(c.Database.Port), "database.port (DB_PORT) must be between 1 and 65535, got %d", c.Database.Port)
+	dev := c.Server.Environment == "dev"
+	check(dev || c.Database.User != "", "database.user (DB_USER) is required outside dev")
+	check(dev || c.Database.Password != "", "database.password (DB_PASS) is required outside dev")
+	check(c.Database.Name != "", "database.name (DB_NAME) is required")
+
+	check(validUrl(c.Llm.ApiUrl), "llm.apiUrl (LLM_API_URL) must be an absolute http url, got %q", c.Llm.ApiUrl)
+
+	check(c.Email.ResendBaseUrl == "" || validUrl(c.Email.ResendBaseUrl), "email.resendBaseUrl (RESEND_BASE_URL) must be an absolute http url, got %q", c.Email.ResendBaseUrl)
+	check(strings.Contains(c.Email.FromAddress, "@"), "email.fromAddress (EMAIL_FROM_ADDRESS) must be an email address, got %q", c.Email.FromAddress)
+
+	sessions := []struct {
+		name  string
+		env   string
+		value time.Duration
+	}{
+		{"idleTimeout", "SESSION_IDLE_TIMEOUT", c.Sessions.IdleTimeout},
+		{"maxLifetime", "SESSION_MAX_LIFETIME", c.Sessions.MaxLifetime},
+		{"rememberMeIdleTimeout", "SESSION_REMEMBER_ME_IDLE_TIMEOUT", c.Sessions.RememberMeIdleTimeout},
+		{"rememberMeMaxLifetime", "SESSION_REMEMBER_ME_MAX_LIFETIME", c.Sessions.RememberMeMaxLifetime},
+	}
+	for _, setting := range sessions {
+		check(setting.value > 0, "sessions.%s (%s) must be positive, got %s", setting.name, setting.env, setting.value)
+	}
+	check(c.Sessions.IdleTimeout <= c.Sessions.MaxLifetime, "sessions.idleTimeout cannot be longer than sessions.maxLifetime")
+	check(c.Sessions.RememberMeIdleTimeout <= c.Sessions.RememberMeMaxLifetime, "sessions.rememberMeIdleTimeout cannot be longer than sessions.rememberMeMaxLifetime")
+
+	check(c.Totp.Issuer != "", "totp.issuer (TOTP_ISSUER) is required")
+	check(c.Erasure.GracePeriod >= 0, "erasure.gracePeriod (ACCOUNT_ERASURE_GRACE_PERIOD) cannot be negative, got %s", c.Erasure.GracePeriod)
+	check(c.RateLimit.Store == "memory" || c.RateLimit.Store == "postgres", "rateLimit.store (RATE_LIMIT_STORE) must be memory or postgres, got %q", c.RateLimit.Store)
+	check(c.ReviewItems.PageSize > 0, "reviewItems.pageSize (REVIEW_ITEM_PAGE_SIZE) must be positive, got %d", c.ReviewItems.PageSize)
+
+	seen := map[string]bool{}
+	for _, provider := range c.Oidc {
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(provider.Id, "-", "_")) + "_"
+		check(provider.Id != "", "oidc providers need an id")
+		check(!seen[provider.Id], "oidc provider %q is configured twice", provider.Id)
+		check(provider.Issuer != "" && provider.ClientId != "", "oidc provider %q needs an issuer (%sISSUER) and a clientId (%sCLIENT_ID)", provider.Id, prefix, prefix)
+		seen[provider.Id] = true
+	}
+
+	_, hasDefaultPlan := c.Quotas[DefaultPlan]
+	check(hasDefaultPlan, "quotas need the %s plan, users without a plan get it", DefaultPlan)
+	for name, quota := range c.Quotas {
+		prefix := "LLM_QUOTA_" + strings.ToUpper(name)
+		check(quota.Daily >= 0, "quotas.%s.daily (%s_DAILY) cannot be negative, got %d", name, prefix, quota.Daily)
+		check(quota.Monthly >= 0, "quotas.%s.monthly (%s_MONTHLY) cannot be negative, got %d", name, prefix, quota.Monthly)
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// Returns the providers with their defaults filled in
+func (c *Config) OidcProviders() []OidcProvider {
+	providers := []OidcProvider{}
+	for _, provider := range c.Oidc {
+		if provider.DisplayName == "" {
+			provider.DisplayName = provider.Id
+		}
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = defaultOidcScopes
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+func (c *Config) oidcProvider(id string) *OidcProvider {
+	for i := range c.Oidc {
+		if c.Oidc[i].Id == id {
+			return &c.Oidc[i]
+		}
+	}
+	return nil
+}
+
+// Returns the configuration as YAML with the secrets redacted, for the startup log
+func (c *Config) String() string {
+	redacted := *c
+	redacted.Oidc = append([]OidcProvider{}, c.Oidc...)
+	redact(reflect.ValueOf(&redacted).Elem())
+	out, err := yaml.Marshal(&redacted)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+func redact(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if value.Type().Field(i).Tag.Get("secret") == "true" && field.String() != "" {
+				field.SetString("[redacted]")
+				continue
+			}
+			redact(field)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			redact(value.Index(i))
+		}
+	}
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func validUrl(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func splitList(raw string) []string {
+	return strings.Fields(strings.ReplaceAll(raw, ",", " "))
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+}
+
+func TestEnvironmentOverridesFile(t *testing.T) {
+	writeConfigFile(t, `
+server:
+  port: 8080
+database:
+  host: db
+  user: app
+  password: from-file
+sessions:
+  idleTimeout: 30m
+quotas:
+  team:
+    daily: 50
+`)
+	t.Setenv("PORT", "9090")
+	t.Setenv("LLM_QUOTA_TEAM_MONTHLY", "500")
+	t.Setenv("OIDC_PROVIDERS", "google")
+	t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+	t.Setenv("OIDC_GOOGLE_CLIENT_ID", "client")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 9090 || cfg.Database.Host != "db" || cfg.Database.Password != "from-file" {
+		t.Errorf("server and database: got %+v %+v", cfg.Server, cfg.Database)
+	}
+	if cfg.Sessions.IdleTimeout != 30*time.Minute || cfg.Sessions.MaxLifetime != Default().Sessions.MaxLifetime {
+		t.Errorf("sessions: got %+v", cfg.Sessions)
+	}
+	if cfg.Quotas["team"] != (Quota{Daily: 50, Monthly: 500}) || cfg.Quotas[DefaultPlan] != Default().Quotas[DefaultPlan] {
+		t.Errorf("quotas: got %+v", cfg.Quotas)
+	}
+	providers := cfg.OidcProviders()
+	if len(providers) != 1 || providers[0].DisplayName != "google" || len(providers[0].Scopes) == 0 {
+		t.Errorf("oidc providers: got %+v", providers)
+	}
+}
+
+func TestUnknownKeysAreRejected(t *testing.T) {
+	writeConfigFile(t, "server:\n  prot: 8080\n")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "prot") {
+		t.Errorf("got %v, want an error naming the key", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	t.Setenv("PORT", "0")
+	t.Setenv("RATE_LIMIT_STORE", "redis")
+	t.Setenv("SESSION_IDLE_TIMEOUT", "-1h")
+	_, err := Load()
+	if err == nil {
+		t.Fatal("invalid configuration was accepted")
+	}
+	for _, setting := range []string{"PORT", "RATE_LIMIT_STORE", "SESSION_IDLE_TIMEOUT"} {
+		if !strings.Contains(err.Error(), "("+setting+")") {
+			t.Errorf("%s is not reported in %q", setting, err)
+		}
+	}
+}
+
+func TestStringRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "hunter2"
+	cfg.Email.ResendApiKey = "re_secret"
+	cfg.Oidc = []OidcProvider{{Id: "google", ClientSecret: "client-secret"}}
+	out := cfg.String()
+	for _, secret := range []string{"hunter2", "re_secret", "client-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%q is logged:\n%s", secret, out)
+		}
+	}
+	if cfg.Oidc[0].ClientSecret != "client-secret" {
+		t.Error("redacting changed the configuration")
+	}
+}
+
+func TestDatabaseCredentialsRequiredOutsideDev(t *testing.T) {
+	_, err := Load()
+	if err == nil {
+		t.Fatal("missing database credentials were accepted")
+	}
+	for _, setting := range []string{"DB_USER", "DB_PASS"} {
+		if !strings.Contains(err.Error(), "("+setting+")") {
+			t.Errorf("%s is not reported in %q", setting, err)
+		}
+	}
+
+	t.Setenv("ENVIRONMENT", "dev")
+	if _, err := Load(); err != nil {
+		t.Errorf("dev without database credentials: %v", err)
+	}
+}
+
+// The example documents the defaults, so it has to stay in sync with them.
+// The defaults are not valid outside dev, so the file is read without Load.
+func TestExampleMatchesDefaults(t *testing.T) {
+	cfg := Default()
+	if err := cfg.readFile("../config.example.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.String() != Default().String() {
+		t.Errorf("got\n%s\nwant\n%s", cfg, Default())
+	}
+}
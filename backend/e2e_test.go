@@ -0,0 +1,292 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"spaced-ace-backend/account"
+	"spaced-ace-backend/api/handlers"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/fake"
+	"spaced-ace-backend/integration"
+	"spaced-ace-backend/usage"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	cfg      *config.Config
+	database *integration.Postgres
+	mailbox  *integration.Mailbox
+)
+
+func TestMain(m *testing.M) {
+	mailbox = integration.NewMailbox()
+	os.Setenv("RESEND_API_KEY", "test")
+	os.Setenv("RESEND_BASE_URL", mailbox.Url)
+	// Confirmed erasures are due right away, so the scenarios can run them
+	os.Setenv("ACCOUNT_ERASURE_GRACE_PERIOD", "0s")
+	// The scenarios connect to the database of integration.Start, not the configured one
+	os.Setenv("ENVIRONMENT", "dev")
+	var err error
+	cfg, err = config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := auth.InitEmailService(cfg.Email, cfg.Server.AppBaseUrl); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	auth.InitSessionConfig(cfg.Sessions)
+	usage.InitQuotaConfig(cfg.Quotas)
+	account.InitErasureConfig(cfg.Erasure)
+
+	database, err = integration.Start(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	database.Stop()
+	mailbox.Close()
+	os.Exit(code)
+}
+
+// A client of its own server on a fresh schema. The scenarios run in
+// parallel and share the mailbox, each signs up with its own emails.
+type apiClient struct {
+	t       *testing.T
+	app     *app
+	server  *echo.Echo
+	session string
+}
+
+func newApiClient(t *testing.T) *apiClient {
+	s := database.NewStore(t)
+	llm := &fake.Llm{}
+	llm.Respond("multiple-choice", map[string]any{
+		"question":        "Which countries border Hungary?",
+		"options":         []string{"Austria", "Slovakia", "Poland", "Germany"},
+		"correct_options": []string{"A", "B"},
+	})
+	llm.Respond("single-choice", map[string]any{
+		"question":       "Since when is Hungary a parliamentary republic?",
+		"options":        []string{"1956", "1989", "2004", "1920"},
+		"correct_option": "B",
+	})
+	llm.Respond("true-or-false", map[string]any{
+		"question":       "Hungary is in the Carpathian Basin.",
+		"correct_option": true,
+	})
+	a := newApp(auth.NewPostgresRepositories(s), account.NewPostgresRepository(s), handlers.NewPostgresDependencies(s, llm))
+	return &apiClient{t: t, app: a, server: newServer(a, unlimited{})}
+}
+
+// Sends the request with the session of the client, fails the test unless
+// the response has the status and decodes it into response when it is not nil
+func (c *apiClient) do(method string, path string, body any, status int, response any) {
+	c.t.Helper()
+	encoded := []byte{}
+	if body != nil {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(encoded))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if c.session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: c.session})
+	}
+	rec := httptest.NewRecorder()
+	c.server.ServeHTTP(rec, req)
+	if rec.Code != status {
+		c.t.Fatalf("%s %s: got %d, want %d: %s", method, path, rec.Code, status, rec.Body)
+	}
+	if response != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), response); err != nil {
+			c.t.Fatalf("%s %s: decoding %s: %v", method, path, rec.Body, err)
+		}
+	}
+}
+
+// Registers and verifies the user, later requests are sent with its session
+func (c *apiClient) signUp(name string, email string) auth.User {
+	c.t.Helper()
+	var registered auth.AuthResponse
+	c.do("POST", "/create-user", map[string]string{"name": name, "email": email, "password": "123456789", "passwordAgain": "123456789"}, 200, &registered)
+	c.session = registered.Session
+	token := mailbox.Token(email)
+	if token == "" {
+		c.t.Fatalf("no verification email to %s", email)
+	}
+	c.do("GET", "/verify-email?token="+token, nil, 200, nil)
+	return registered.User
+}
+
+const prompt = "Magyarország állam Közép-Európában, a Kárpát-medence közepén. 1989 óta parlamentáris köztársaság."
+
+func TestAccountAndQuizLifecycle(t *testing.T) {
+	t.Parallel()
+	c := newApiClient(t)
+	c.do("GET", "/authenticated", nil, 401, nil)
+	alice := c.signUp("Alice", "alice@example.com")
+	c.do("GET", "/authenticated", nil, 200, nil)
+
+	var quiz struct {
+		Id          string `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	c.do("POST", "/quizzes/create", map[string]string{"name": "test-quiz", "description": "test-desc"}, 200, &quiz)
+	c.do("GET", "/quizzes/"+quiz.Id, nil, 200, nil)
+
+	updates := []struct {
+		body        map[string]string
+		title       string
+		description string
+	}{
+		{map[string]string{"name": "test-quiz-modified"}, "test-quiz-modified", "test-desc"},
+		{map[string]string{"description": "test-desc-modified"}, "test-quiz-modified", "test-desc-modified"},
+		{map[string]string{"name": "test-name-mod2", "description": "test-desc-mod2"}, "test-name-mod2", "test-desc-mod2"},
+	}
+	for _, update := range updates {
+		c.do("PATCH", "/quizzes/"+quiz.Id, update.body, 200, nil)
+		c.do("GET", "/quizzes/"+quiz.Id, nil, 200, &quiz)
+		if quiz.Title != update.title || quiz.Description != update.description {
+			t.Errorf("after updating %v: got %q %q", update.body, quiz.Title, quiz.Description)
+		}
+	}
+
+	var quizzes handlers.QuizzesResponse
+	c.do("GET", "/quizzes/user/"+alice.Id, nil, 200, &quizzes)
+	if quizzes.Length != 1 {
+		t.Errorf("quizzes of alice: got %+v", quizzes)
+	}
+
+	changes := map[string]map[string]any{
+		"multiple-choice": {"quizId": quiz.Id, "question": "Modified, whatever it was", "answers": []string{"CABD", "ABCD"}, "correctAnswers": []string{"A", "B"}},
+		"single-choice":   {"quizId": quiz.Id, "question": "Modified, whatever it was", "answers": []string{"CABD", "ABCD"}, "correctAnswer": "A"},
+		"true-or-false":   {"quizId": quiz.Id, "question": "Modified, whatever it was", "correctAnswer": false},
+	}
+	for _, questionType := range []string{"multiple-choice", "single-choice", "true-or-false"} {
+		var question struct {
+			Id       string `json:"id"`
+			Question string `json:"question"`
+		}
+		c.do("POST", "/questions/"+questionType, map[string]string{"quizId": quiz.Id, "prompt": prompt}, 200, &question)
+		c.do("GET", "/questions/"+questionType+"/"+question.Id, nil, 200, nil)
+		c.do("PATCH", "/questions/"+questionType+"/"+question.Id, changes[questionType], 200, nil)
+		c.do("GET", "/questions/"+questionType+"/"+question.Id, nil, 200, &question)
+		if question.Question != "Modified, whatever it was" {
+			t.Errorf("modified %s question: got %q", questionType, question.Question)
+		}
+		c.do("DELETE", "/questions/"+questionType+"/"+quiz.Id+"/"+question.Id, nil, 200, nil)
+		c.do("GET", "/questions/"+questionType+"/"+question.Id, nil, 404, nil)
+	}
+
+	c.do("DELETE", "/quizzes/"+quiz.Id, nil, 200, nil)
+	c.do("GET", "/quizzes/"+quiz.Id, nil, 404, nil)
+
+	// The account is deleted through the erasure confirmed by email
+	c.do("POST", "/me/erasure", map[string]string{"quizHandling": "delete"}, 200, nil)
+	token := mailbox.Token("alice@example.com")
+	c.do("POST", "/erasure/confirm", map[string]string{"token": token}, 200, nil)
+	if erased, err := c.app.account.EraseDueAccounts(context.Background()); err != nil || erased != 1 {
+		t.Fatalf("erasing due accounts: got %d, %v", erased, err)
+	}
+	c.do("GET", "/authenticated", nil, 401, nil)
+}
+
+func TestQuizSession(t *testing.T) {
+	t.Parallel()
+	c := newApiClient(t)
+	alice := c.signUp("Alice", "alice.sessions@example.com")
+
+	var quiz struct {
+		Id string `json:"id"`
+	}
+	c.do("POST", "/quizzes/create", map[string]string{"name": "Hungary"}, 200, &quiz)
+	var question struct {
+		Id string `json:"id"`
+	}
+	c.do("POST", "/questions/single-choice", map[string]string{"quizId": quiz.Id, "prompt": prompt}, 200, &question)
+	// Generated questions wait in review and learners only get published quizzes
+	c.do("POST", "/quiz-sessions/start", map[string]string{"quizId": quiz.Id}, 409, nil)
+	c.do("PUT", "/questions/"+question.Id+"/status", map[string]string{"status": "approved"}, 200, nil)
+	c.do("POST", "/quizzes/"+quiz.Id+"/publish", nil, 200, nil)
+
+	var session struct {
+		Id string `json:"id"`
+	}
+	c.do("POST", "/quiz-sessions/start", map[string]string{"quizId": quiz.Id}, 200, &session)
+	c.do("GET", "/quiz-sessions/has-open?quizId="+quiz.Id, nil, 200, nil)
+	answer := map[string]string{"questionId": question.Id, "answerType": "single-choice", "answer": "A"}
+	c.do("PUT", "/quiz-sessions/"+session.Id+"/answers", answer, 200, nil)
+	answer["answer"] = "B"
+	c.do("PUT", "/quiz-sessions/"+session.Id+"/answers", answer, 200, nil)
+
+	type result struct {
+		Id       string  `json:"id"`
+		MaxScore float64 `json:"maxScore"`
+		Score    float64 `json:"score"`
+	}
+	var submitted, retried, stored result
+	c.do("POST", "/quiz-sessions/"+session.Id+"/submit", nil, 200, &submitted)
+	if submitted.Score != 1 || submitted.MaxScore != 1 {
+		t.Errorf("score of the correct answer: got %+v", submitted)
+	}
+	// A retried submit gets the stored result instead of scoring again
+	c.do("POST", "/quiz-sessions/"+session.Id+"/submit", nil, 200, &retried)
+	c.do("GET", "/quiz-sessions/"+session.Id+"/result", nil, 200, &stored)
+	if retried != submitted || stored != submitted {
+		t.Errorf("results of the session: submitted %+v, retried %+v, stored %+v", submitted, retried, stored)
+	}
+
+	c.do("GET", "/quiz-sessions/has-open?quizId="+quiz.Id, nil, 404, nil)
+	c.do("PUT", "/quiz-sessions/"+session.Id+"/answers", answer, 403, nil)
+	c.do("GET", "/quiz-history?userID="+alice.Id, nil, 200, nil)
+}
+
+// Whoever signs up with an email they do not own must lose the account once
+// its owner logs in with a provider that verified the email
+func TestOidcLoginClaimsUnverifiedAccount(t *testing.T) {
+	t.Parallel()
+	c := newApiClient(t)
+	issuer := integration.NewIssuer(t)
+	c.app.auth.RegisterOidcProvider(config.OidcProvider{
+		Id:       "mock",
+		Issuer:   issuer.Url,
+		ClientId: integration.IssuerClientId,
+		Scopes:   []string{"openid", "email", "profile"},
+	}, "http://localhost/login/oidc/mock/callback")
+
+	var squatted auth.AuthResponse
+	c.do("POST", "/create-user", map[string]string{"name": "Mallory", "email": "bob@example.com", "password": "123456789", "passwordAgain": "123456789"}, 200, &squatted)
+
+	var authorize auth.OidcAuthorizeResponse
+	c.do("GET", "/oidc/mock/authorize", nil, 200, &authorize)
+	code, state := issuer.Authorize(t, authorize.Url, map[string]any{"sub": "bob", "email": "bob@example.com", "email_verified": true, "name": "Bob"})
+	var login auth.AuthResponse
+	c.do("POST", "/oidc/mock/callback", auth.OidcCallbackBody{Code: code, State: state}, 200, &login)
+	if login.User.Id != squatted.User.Id || !login.User.EmailVerified {
+		t.Errorf("oidc login: got %+v, want the verified account %s", login.User, squatted.User.Id)
+	}
+
+	// The session and the password of the sign up are gone
+	c.session = squatted.Session
+	c.do("GET", "/authenticated", nil, 401, nil)
+	c.session = ""
+	c.do("POST", "/authenticate-user", map[string]string{"email": "bob@example.com", "password": "123456789"}, 401, nil)
+	c.session = login.Session
+	c.do("GET", "/authenticated", nil, 200, nil)
+}
@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"spaced-ace-backend/account"
+	"sync"
+	"time"
+)
+
+// In-memory account.Repository. The export has only the profile and the
+// erasure deletes only the user with the sessions, the quizzes are left alone.
+type Account struct {
+	mu       sync.Mutex
+	erasures table[account.Erasure]
+	users    *Users
+	sessions *Sessions
+}
+
+func (a *Account) GetErasure(_ context.Context, userId string) (*account.Erasure, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	erasure, err := a.erasures.get(func(erasure *account.Erasure) bool { return erasure.UserId == userId })
+	return &erasure, err
+}
+
+func (a *Account) UpsertErasure(_ context.Context, erasure *account.Erasure) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.erasures.delete(func(row *account.Erasure) bool { return row.UserId == erasure.UserId })
+	erasure.RequestedAt = time.Now()
+	erasure.ConfirmedAt = nil
+	erasure.EraseAfter = nil
+	a.erasures.insert(*erasure)
+	return nil
+}
+
+func (a *Account) ConfirmErasure(_ context.Context, token string, requestedAfter time.Time, gracePeriod time.Duration) (*account.Erasure, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	erasure, err := a.erasures.find(func(erasure *account.Erasure) bool {
+		return erasure.Token == token && erasure.ConfirmedAt == nil && erasure.RequestedAt.After(requestedAfter)
+	})
+	if err != nil {
+		return &account.Erasure{}, err
+	}
+	now := time.Now()
+	eraseAfter := now.Add(gracePeriod)
+	erasure.ConfirmedAt = &now
+	erasure.EraseAfter = &eraseAfter
+	confirmed := *erasure
+	return &confirmed, nil
+}
+
+func (a *Account) DeleteErasure(_ context.Context, userId string) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int64(a.erasures.delete(func(erasure *account.Erasure) bool { return erasure.UserId == userId })), nil
+}
+
+func (a *Account) ExportFiles(ctx context.Context, userId string) ([]account.ExportFile, error) {
+	user, err := a.users.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := json.Marshal(map[string]any{"id": user.Id, "name": user.Name, "email": user.Email})
+	if err != nil {
+		return nil, err
+	}
+	return []account.ExportFile{{Name: "profile.json", Content: profile}}, nil
+}
+
+func (a *Account) EraseNextDueAccount(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	due := func(erasure *account.Erasure) bool {
+		return erasure.EraseAfter != nil && !erasure.EraseAfter.After(time.Now())
+	}
+	erasure, err := a.erasures.get(due)
+	if err != nil {
+		// No account is due
+		return "", nil
+	}
+	a.erasures.delete(func(row *account.Erasure) bool { return row.UserId == erasure.UserId })
+	a.users.remove(erasure.UserId)
+	if err := a.sessions.DeleteSessionsOfUser(ctx, erasure.UserId); err != nil {
+		return "", err
+	}
+	return erasure.UserId, nil
+}
@@ -0,0 +1,128 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/db"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// In-memory handlers.AnswerRepository
+type Answers struct {
+	mu             sync.Mutex
+	singleChoice   table[db.SingleChoiceAnswer]
+	multipleChoice table[db.MultipleChoiceAnswer]
+	trueOrFalse    table[db.TrueOrFalseAnswer]
+}
+
+func (a *Answers) GetSingleChoiceAnswers(ctx context.Context, sessionID string) ([]*db.SingleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.singleChoice.filter(func(row *db.SingleChoiceAnswer) bool { return row.SessionID == sessionID }), nil
+}
+
+func (a *Answers) GetMultipleChoiceAnswers(ctx context.Context, sessionID string) ([]*db.MultipleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.multipleChoice.filter(func(row *db.MultipleChoiceAnswer) bool { return row.SessionID == sessionID }), nil
+}
+
+func (a *Answers) GetTrueOrFalseAnswers(ctx context.Context, sessionID string) ([]*db.TrueOrFalseAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.trueOrFalse.filter(func(row *db.TrueOrFalseAnswer) bool { return row.SessionID == sessionID }), nil
+}
+
+func (a *Answers) CreateSingleChoiceAnswer(ctx context.Context, arg db.CreateSingleChoiceAnswerParams) (*db.SingleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer := *a.singleChoice.insert(db.SingleChoiceAnswer(arg))
+	return &answer, nil
+}
+
+func (a *Answers) CreateMultipleChoiceAnswer(ctx context.Context, arg db.CreateMultipleChoiceAnswerParams) (*db.MultipleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer := *a.multipleChoice.insert(db.MultipleChoiceAnswer(arg))
+	return &answer, nil
+}
+
+func (a *Answers) CreateTrueOrFalseAnswer(ctx context.Context, arg db.CreateTrueOrFalseAnswerParams) (*db.TrueOrFalseAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer := *a.trueOrFalse.insert(db.TrueOrFalseAnswer(arg))
+	return &answer, nil
+}
+
+func (a *Answers) GetSingleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.GetSingleChoiceAnswerBySessionAndQuestionIdParams) (*db.SingleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer, err := a.singleChoice.get(func(row *db.SingleChoiceAnswer) bool {
+		return row.SessionID == arg.SessionID && row.QuestionID == arg.QuestionID
+	})
+	return &answer, err
+}
+
+func (a *Answers) GetMultipleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.GetMultipleChoiceAnswerBySessionAndQuestionIdParams) (*db.MultipleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer, err := a.multipleChoice.get(func(row *db.MultipleChoiceAnswer) bool {
+		return row.SessionID == arg.SessionID && row.QuestionID == arg.QuestionID
+	})
+	return &answer, err
+}
+
+func (a *Answers) GetTrueOrFalseAnswerBySessionAndQuestionId(ctx context.Context, arg db.GetTrueOrFalseAnswerBySessionAndQuestionIdParams) (*db.TrueOrFalseAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer, err := a.trueOrFalse.get(func(row *db.TrueOrFalseAnswer) bool {
+		return row.SessionID == arg.SessionID && row.QuestionID == arg.QuestionID
+	})
+	return &answer, err
+}
+
+// The updates return the updated row, so they fail on missing rows like
+// UPDATE ... RETURNING does
+
+func (a *Answers) UpdateSingleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.UpdateSingleChoiceAnswerBySessionAndQuestionIdParams) (*db.SingleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer, err := a.singleChoice.find(func(row *db.SingleChoiceAnswer) bool {
+		return row.SessionID == arg.SessionID && row.QuestionID == arg.QuestionID
+	})
+	if err != nil {
+		return nil, pgx.ErrNoRows
+	}
+	answer.Answer = arg.Answer
+	copied := *answer
+	return &copied, nil
+}
+
+func (a *Answers) UpdateMultipleChoiceAnswerBySessionAndQuestionId(ctx context.Context, arg db.UpdateMultipleChoiceAnswerBySessionAndQuestionIdParams) (*db.MultipleChoiceAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer, err := a.multipleChoice.find(func(row *db.MultipleChoiceAnswer) bool {
+		return row.SessionID == arg.SessionID && row.QuestionID == arg.QuestionID
+	})
+	if err != nil {
+		return nil, pgx.ErrNoRows
+	}
+	answer.Answers = arg.Answers
+	copied := *answer
+	return &copied, nil
+}
+
+func (a *Answers) UpdateTrueOrFalseAnswerBySessionAndQuestionId(ctx context.Context, arg db.UpdateTrueOrFalseAnswerBySessionAndQuestionIdParams) (*db.TrueOrFalseAnswer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	answer, err := a.trueOrFalse.find(func(row *db.TrueOrFalseAnswer) bool {
+		return row.SessionID == arg.SessionID && row.QuestionID == arg.QuestionID
+	})
+	if err != nil {
+		return nil, pgx.ErrNoRows
+	}
+	answer.Answer = arg.Answer
+	copied := *answer
+	return &copied, nil
+}
@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"spaced-ace-backend/auth"
+	"sync"
+	"time"
+)
+
+// In-memory auth.ApiTokenRepository, revoked tokens are kept but not returned
+type ApiTokens struct {
+	mu     sync.Mutex
+	tokens table[auth.ApiToken]
+}
+
+func (a *ApiTokens) CreateApiToken(_ context.Context, token *auth.ApiToken) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	created := *token
+	created.CreatedAt = time.Now()
+	a.tokens.insert(created)
+	return nil
+}
+
+func (a *ApiTokens) GetActiveApiTokenByHash(_ context.Context, tokenHash string) (*auth.ApiToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	token, err := a.tokens.get(func(token *auth.ApiToken) bool {
+		return token.TokenHash == tokenHash && token.RevokedAt == nil && (token.ExpiresAt == nil || token.ExpiresAt.After(time.Now()))
+	})
+	return &token, err
+}
+
+func (a *ApiTokens) GetApiTokensOfUser(_ context.Context, userId string) ([]auth.ApiToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tokens := values(a.tokens.filter(func(token *auth.ApiToken) bool { return token.UserId == userId && token.RevokedAt == nil }))
+	slices.Reverse(tokens)
+	return tokens, nil
+}
+
+func (a *ApiTokens) TouchApiToken(_ context.Context, id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if token, err := a.tokens.find(func(token *auth.ApiToken) bool { return token.Id == id }); err == nil {
+		now := time.Now()
+		token.LastUsedAt = &now
+	}
+	return nil
+}
+
+func (a *ApiTokens) RevokeApiToken(_ context.Context, id string, userId string) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	token, err := a.tokens.find(func(token *auth.ApiToken) bool {
+		return token.Id == id && token.UserId == userId && token.RevokedAt == nil
+	})
+	if err != nil {
+		return 0, nil
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	return 1, nil
+}
@@ -0,0 +1,66 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"spaced-ace-backend/audit"
+	"sync"
+	"time"
+)
+
+// In-memory audit.Log
+type AuditLog struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (l *AuditLog) Record(ctx context.Context, event *audit.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	event.CreatedAt = time.Now()
+	l.events = append(l.events, *event)
+	return nil
+}
+
+func (l *AuditLog) Search(ctx context.Context, filter audit.Filter, limit int, offset int) ([]audit.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := []audit.Event{}
+	for _, event := range slices.Backward(l.events) {
+		if matches(event, filter) {
+			events = append(events, event)
+		}
+	}
+	return page(events, limit, offset), nil
+}
+
+// Returns the recorded events with the action, oldest first
+func (l *AuditLog) Events(action string) []audit.Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	events := []audit.Event{}
+	for _, event := range l.events {
+		if event.Action == action {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func matches(event audit.Event, filter audit.Filter) bool {
+	switch {
+	case filter.ActorId != "" && (event.ActorId == nil || *event.ActorId != filter.ActorId):
+		return false
+	case filter.Action != "" && event.Action != filter.Action:
+		return false
+	case filter.TargetType != "" && event.TargetType != filter.TargetType:
+		return false
+	case filter.TargetId != "" && event.TargetId != filter.TargetId:
+		return false
+	case filter.Since != nil && event.CreatedAt.Before(*filter.Since):
+		return false
+	case filter.Until != nil && !event.CreatedAt.Before(*filter.Until):
+		return false
+	}
+	return true
+}
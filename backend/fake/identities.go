@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/auth"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// In-memory auth.IdentityRepository with the states of the OIDC logins
+type Identities struct {
+	mu         sync.Mutex
+	identities table[auth.Identity]
+	states     table[auth.OidcState]
+}
+
+func (i *Identities) GetIdentity(_ context.Context, provider string, subject string) (*auth.Identity, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	identity, err := i.identities.get(func(identity *auth.Identity) bool {
+		return identity.Provider == provider && identity.Subject == subject
+	})
+	return &identity, err
+}
+
+func (i *Identities) GetIdentitiesOfUser(_ context.Context, userId string) ([]auth.Identity, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return values(i.identities.filter(func(identity *auth.Identity) bool { return identity.UserId == userId })), nil
+}
+
+func (i *Identities) CreateIdentity(_ context.Context, identity *auth.Identity) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, err := i.identities.find(func(row *auth.Identity) bool {
+		return row.Provider == identity.Provider && row.Subject == identity.Subject
+	}); err == nil {
+		return errUniqueViolation
+	}
+	now := time.Now()
+	created := *identity
+	created.CreatedAt = now
+	created.LastLoginAt = &now
+	i.identities.insert(created)
+	return nil
+}
+
+func (i *Identities) TouchIdentity(_ context.Context, id string, email *string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if identity, err := i.identities.find(func(identity *auth.Identity) bool { return identity.Id == id }); err == nil {
+		now := time.Now()
+		identity.Email = email
+		identity.LastLoginAt = &now
+	}
+	return nil
+}
+
+func (i *Identities) DeleteIdentity(_ context.Context, id string, userId string) (int64, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return int64(i.identities.delete(func(identity *auth.Identity) bool {
+		return identity.Id == id && identity.UserId == userId
+	})), nil
+}
+
+func (i *Identities) CreateOidcState(_ context.Context, state *auth.OidcState) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	created := *state
+	created.ValidUntil = time.Now().Add(10 * time.Minute)
+	i.states.insert(created)
+	return nil
+}
+
+func (i *Identities) ConsumeOidcState(_ context.Context, state string) (*auth.OidcState, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	match := func(row *auth.OidcState) bool { return row.State == state }
+	consumed, err := i.states.get(match)
+	if err != nil {
+		return &auth.OidcState{}, err
+	}
+	i.states.delete(match)
+	if !consumed.ValidUntil.After(time.Now()) {
+		return &auth.OidcState{}, pgx.ErrNoRows
+	}
+	return &consumed, nil
+}
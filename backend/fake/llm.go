@@ -0,0 +1,55 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"spaced-ace-backend/api/handlers"
+	"sync"
+)
+
+// A handlers.LlmClient answering with canned questions. Every prompt is one
+// chunk, Generate answers with the response set for the question type.
+type Llm struct {
+	mu        sync.Mutex
+	responses map[string]any
+	// The question types Generate was called with, in order
+	Calls []string
+}
+
+// Sets the response to generated questions of the type, encoded like the llm
+// api encodes it
+func (l *Llm) Respond(questionType string, response any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.responses == nil {
+		l.responses = map[string]any{}
+	}
+	l.responses[questionType] = response
+}
+
+// Makes Generate fail for the question type until it gets a response again
+func (l *Llm) Fail(questionType string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.responses, questionType)
+}
+
+func (l *Llm) Chunk(ctx context.Context, prompt string) ([]handlers.TextChunk, error) {
+	return []handlers.TextChunk{{Id: "0", Text: prompt}}, nil
+}
+
+func (l *Llm) Generate(ctx context.Context, questionType string, text string, generated any) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Calls = append(l.Calls, questionType)
+	response, ok := l.responses[questionType]
+	if !ok {
+		return fmt.Errorf("no response for %s questions", questionType)
+	}
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, generated)
+}
@@ -0,0 +1,55 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/auth"
+	"sync"
+	"time"
+)
+
+// In-memory auth.LockoutRepository
+type Lockouts struct {
+	mu       sync.Mutex
+	failures table[auth.LoginFailure]
+}
+
+func (l *Lockouts) GetLoginFailure(_ context.Context, email string) (*auth.LoginFailure, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	failure, err := l.failures.get(func(failure *auth.LoginFailure) bool { return failure.Email == email })
+	return &failure, err
+}
+
+func (l *Lockouts) RecordLoginFailure(_ context.Context, email string, window time.Duration) (*auth.LoginFailure, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	failure, err := l.failures.find(func(failure *auth.LoginFailure) bool { return failure.Email == email })
+	if err != nil {
+		failure = l.failures.insert(auth.LoginFailure{Email: email})
+	}
+	if failure.LastFailureAt.Before(now.Add(-window)) {
+		failure.Failures = 1
+	} else {
+		failure.Failures++
+	}
+	failure.LastFailureAt = now
+	recorded := *failure
+	return &recorded, nil
+}
+
+func (l *Lockouts) LockLogin(_ context.Context, email string, lockedUntil time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if failure, err := l.failures.find(func(failure *auth.LoginFailure) bool { return failure.Email == email }); err == nil {
+		failure.LockedUntil = &lockedUntil
+	}
+	return nil
+}
+
+func (l *Lockouts) DeleteLoginFailures(_ context.Context, email string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.failures.delete(func(failure *auth.LoginFailure) bool { return failure.Email == email })
+	return nil
+}
@@ -0,0 +1,234 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/question"
+	"sync"
+)
+
+// In-memory question.Repository
+type Questions struct {
+	mu             sync.Mutex
+	singleChoice   table[question.DBSingleChoiceQuestion]
+	multipleChoice table[question.DBMultipleChoiceQuestion]
+	trueOrFalse    table[question.DBTrueOrFalseQuestion]
+}
+
+func (q *Questions) CreateMultipleChoiceQuestion(_ context.Context, created *question.DBMultipleChoiceQuestion) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.multipleChoice.insert(*created)
+	return nil
+}
+
+func (q *Questions) CreateSingleChoiceQuestion(_ context.Context, created *question.DBSingleChoiceQuestion) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.singleChoice.insert(*created)
+	return nil
+}
+
+func (q *Questions) CreateTrueOrFalseQuestion(_ context.Context, created *question.DBTrueOrFalseQuestion) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.trueOrFalse.insert(*created)
+	return nil
+}
+
+func (q *Questions) GetMultipleChoiceQuestions(_ context.Context, quizID string) ([]question.DBMultipleChoiceQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return values(q.multipleChoice.filter(func(row *question.DBMultipleChoiceQuestion) bool { return row.QuizID == quizID })), nil
+}
+
+func (q *Questions) GetPublishedMultipleChoiceQuestions(_ context.Context, quizID string) ([]question.DBMultipleChoiceQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return values(q.multipleChoice.filter(func(row *question.DBMultipleChoiceQuestion) bool { return row.QuizID == quizID && row.Published })), nil
+}
+
+func (q *Questions) GetMultipleChoiceQuestion(_ context.Context, uuid string) (question.DBMultipleChoiceQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.multipleChoice.get(func(row *question.DBMultipleChoiceQuestion) bool { return row.UUID == uuid })
+}
+
+func (q *Questions) GetSingleChoiceQuestions(_ context.Context, quizID string) ([]question.DBSingleChoiceQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return values(q.singleChoice.filter(func(row *question.DBSingleChoiceQuestion) bool { return row.QuizID == quizID })), nil
+}
+
+func (q *Questions) GetPublishedSingleChoiceQuestions(_ context.Context, quizID string) ([]question.DBSingleChoiceQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return values(q.singleChoice.filter(func(row *question.DBSingleChoiceQuestion) bool { return row.QuizID == quizID && row.Published })), nil
+}
+
+func (q *Questions) GetSingleChoiceQuestion(_ context.Context, id string) (question.DBSingleChoiceQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.singleChoice.get(func(row *question.DBSingleChoiceQuestion) bool { return row.UUID == id })
+}
+
+func (q *Questions) GetTrueOrFalseQuestions(_ context.Context, quizID string) ([]question.DBTrueOrFalseQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return values(q.trueOrFalse.filter(func(row *question.DBTrueOrFalseQuestion) bool { return row.QuizID == quizID })), nil
+}
+
+func (q *Questions) GetPublishedTrueOrFalseQuestions(_ context.Context, quizID string) ([]question.DBTrueOrFalseQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return values(q.trueOrFalse.filter(func(row *question.DBTrueOrFalseQuestion) bool { return row.QuizID == quizID && row.Published })), nil
+}
+
+func (q *Questions) GetTrueOrFalseQuestion(_ context.Context, id string) (question.DBTrueOrFalseQuestion, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.trueOrFalse.get(func(row *question.DBTrueOrFalseQuestion) bool { return row.UUID == id })
+}
+
+func (q *Questions) DeleteMultipleChoiceQuestion(_ context.Context, uuid string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.multipleChoice.delete(func(row *question.DBMultipleChoiceQuestion) bool { return row.UUID == uuid })
+	return nil
+}
+
+func (q *Questions) DeleteSingleChoiceQuestion(_ context.Context, uuid string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.singleChoice.delete(func(row *question.DBSingleChoiceQuestion) bool { return row.UUID == uuid })
+	return nil
+}
+
+func (q *Questions) DeleteTrueOrFalseQuestion(_ context.Context, uuid string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.trueOrFalse.delete(func(row *question.DBTrueOrFalseQuestion) bool { return row.UUID == uuid })
+	return nil
+}
+
+func (q *Questions) UpdateMultipleChoiceQuestion(_ context.Context, updated *question.DBMultipleChoiceQuestion) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.multipleChoice.find(func(row *question.DBMultipleChoiceQuestion) bool { return row.UUID == updated.UUID }); err == nil {
+		row.Question = updated.Question
+		row.Answers = updated.Answers
+		row.CorrectAnswers = updated.CorrectAnswers
+	}
+	return nil
+}
+
+func (q *Questions) UpdateSingleChoiceQuestion(_ context.Context, updated *question.DBSingleChoiceQuestion) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.singleChoice.find(func(row *question.DBSingleChoiceQuestion) bool { return row.UUID == updated.UUID }); err == nil {
+		row.Question = updated.Question
+		row.Answers = updated.Answers
+		row.CorrectAnswer = updated.CorrectAnswer
+	}
+	return nil
+}
+
+func (q *Questions) UpdateTrueOrFalseQuestion(_ context.Context, updated *question.DBTrueOrFalseQuestion) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.trueOrFalse.find(func(row *question.DBTrueOrFalseQuestion) bool { return row.UUID == updated.UUID }); err == nil {
+		row.Question = updated.Question
+		row.CorrectAnswer = updated.CorrectAnswer
+	}
+	return nil
+}
+
+func (q *Questions) UpdateMultipleChoiceQuestionStatus(_ context.Context, uuid string, status string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.multipleChoice.find(func(row *question.DBMultipleChoiceQuestion) bool { return row.UUID == uuid }); err == nil {
+		row.Status = status
+		row.Published = row.Published && status == question.STATUS_APPROVED
+	}
+	return nil
+}
+
+func (q *Questions) UpdateSingleChoiceQuestionStatus(_ context.Context, uuid string, status string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.singleChoice.find(func(row *question.DBSingleChoiceQuestion) bool { return row.UUID == uuid }); err == nil {
+		row.Status = status
+		row.Published = row.Published && status == question.STATUS_APPROVED
+	}
+	return nil
+}
+
+func (q *Questions) UpdateTrueOrFalseQuestionStatus(_ context.Context, uuid string, status string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.trueOrFalse.find(func(row *question.DBTrueOrFalseQuestion) bool { return row.UUID == uuid }); err == nil {
+		row.Status = status
+		row.Published = row.Published && status == question.STATUS_APPROVED
+	}
+	return nil
+}
+
+func (q *Questions) PublishQuestionsOfQuiz(_ context.Context, quizID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, row := range q.singleChoice.rows {
+		if row.QuizID == quizID {
+			row.Published = row.Status == question.STATUS_APPROVED
+		}
+	}
+	for _, row := range q.multipleChoice.rows {
+		if row.QuizID == quizID {
+			row.Published = row.Status == question.STATUS_APPROVED
+		}
+	}
+	for _, row := range q.trueOrFalse.rows {
+		if row.QuizID == quizID {
+			row.Published = row.Status == question.STATUS_APPROVED
+		}
+	}
+	return nil
+}
+
+// The question of the id of any type, for the joins of the review items
+func (q *Questions) quizAndText(id string) (quizID string, text string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.singleChoice.find(func(row *question.DBSingleChoiceQuestion) bool { return row.UUID == id }); err == nil {
+		return row.QuizID, row.Question
+	}
+	if row, err := q.multipleChoice.find(func(row *question.DBMultipleChoiceQuestion) bool { return row.UUID == id }); err == nil {
+		return row.QuizID, row.Question
+	}
+	if row, err := q.trueOrFalse.find(func(row *question.DBTrueOrFalseQuestion) bool { return row.UUID == id }); err == nil {
+		return row.QuizID, row.Question
+	}
+	return "", ""
+}
+
+// Whether the question of the id of any type is in the published set
+func (q *Questions) isPublished(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if row, err := q.singleChoice.find(func(row *question.DBSingleChoiceQuestion) bool { return row.UUID == id }); err == nil {
+		return row.Published
+	}
+	if row, err := q.multipleChoice.find(func(row *question.DBMultipleChoiceQuestion) bool { return row.UUID == id }); err == nil {
+		return row.Published
+	}
+	if row, err := q.trueOrFalse.find(func(row *question.DBTrueOrFalseQuestion) bool { return row.UUID == id }); err == nil {
+		return row.Published
+	}
+	return false
+}
+
+func values[T any](rows []*T) []T {
+	result := make([]T, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	return result
+}
@@ -0,0 +1,178 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/db"
+	"sync"
+)
+
+// In-memory handlers.QuizSessionRepository. There are no row locks, the
+// transactions of the fake store run one at a time instead.
+type QuizSessions struct {
+	mu       sync.Mutex
+	sessions table[db.QuizSession]
+	results  table[db.QuizResult]
+	scores   table[db.AnswerScore]
+}
+
+func (q *QuizSessions) GetQuizSession(ctx context.Context, id string) (*db.QuizSession, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	session, err := q.sessions.get(func(row *db.QuizSession) bool { return row.ID == id })
+	return &session, err
+}
+
+func (q *QuizSessions) GetQuizSessionsByQuizIdAndUserId(ctx context.Context, arg db.GetQuizSessionsByQuizIdAndUserIdParams) ([]*db.QuizSession, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sessions.filter(func(row *db.QuizSession) bool {
+		return row.QuizID == arg.QuizID && row.UserID == arg.UserID
+	}), nil
+}
+
+func (q *QuizSessions) GetQuizSessionsByUserId(ctx context.Context, userID string) ([]*db.QuizSession, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sessions.filter(func(row *db.QuizSession) bool { return row.UserID == userID }), nil
+}
+
+func (q *QuizSessions) CreateQuizSession(ctx context.Context, arg db.CreateQuizSessionParams) (*db.QuizSession, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	session := q.sessions.insert(db.QuizSession{
+		ID:          arg.ID,
+		UserID:      arg.UserID,
+		QuizID:      arg.QuizID,
+		StartedAt:   arg.StartedAt,
+		ClosesAt:    arg.ClosesAt,
+		QuestionIds: arg.QuestionIds,
+	})
+	copied := *session
+	return &copied, nil
+}
+
+func (q *QuizSessions) HasOpenQuizSession(ctx context.Context, arg db.HasOpenQuizSessionParams) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, err := q.sessions.find(func(row *db.QuizSession) bool {
+		return row.QuizID == arg.QuizID && row.UserID == arg.UserID && !row.FinishedAt.Valid
+	})
+	return err == nil, nil
+}
+
+func (q *QuizSessions) LockQuizSession(ctx context.Context, id string) (*db.QuizSession, error) {
+	return q.GetQuizSession(ctx, id)
+}
+
+func (q *QuizSessions) UpdateQuizSessionFinishedAt(ctx context.Context, arg db.UpdateQuizSessionFinishedAtParams) (*db.QuizSession, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	session, err := q.sessions.find(func(row *db.QuizSession) bool { return row.ID == arg.ID })
+	if err != nil {
+		return nil, err
+	}
+	session.FinishedAt = arg.FinishedAt
+	copied := *session
+	return &copied, nil
+}
+
+func (q *QuizSessions) GetQuizResultByQuizSessionId(ctx context.Context, sessionID string) (*db.QuizResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	result, err := q.results.get(func(row *db.QuizResult) bool { return row.SessionID == sessionID })
+	return &result, err
+}
+
+func (q *QuizSessions) GetQuizResultsByUserID(ctx context.Context, userID string) ([]*db.GetQuizResultsByUserIDRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	rows := []*db.GetQuizResultsByUserIDRow{}
+	for _, result := range q.results.rows {
+		session, err := q.sessions.find(func(row *db.QuizSession) bool { return row.ID == result.SessionID })
+		if err != nil || session.UserID != userID {
+			continue
+		}
+		rows = append(rows, &db.GetQuizResultsByUserIDRow{
+			ID:         result.ID,
+			SessionID:  result.SessionID,
+			MaxScore:   result.MaxScore,
+			Score:      result.Score,
+			ID_2:       session.ID,
+			UserID:     session.UserID,
+			QuizID:     session.QuizID,
+			StartedAt:  session.StartedAt,
+			FinishedAt: session.FinishedAt,
+			ClosesAt:   session.ClosesAt,
+		})
+	}
+	return rows, nil
+}
+
+// Fails like the unique constraint when the session already has a result
+func (q *QuizSessions) CreateQuizResult(ctx context.Context, arg db.CreateQuizResultParams) (*db.QuizResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, err := q.results.find(func(row *db.QuizResult) bool { return row.SessionID == arg.SessionID }); err == nil {
+		return nil, errUniqueViolation
+	}
+	result := q.results.insert(db.QuizResult(arg))
+	copied := *result
+	return &copied, nil
+}
+
+func (q *QuizSessions) UpdateQuizResultScores(ctx context.Context, arg db.UpdateQuizResultScoresParams) (*db.QuizResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	result, err := q.results.find(func(row *db.QuizResult) bool { return row.ID == arg.ID })
+	if err != nil {
+		return nil, err
+	}
+	result.MaxScore = arg.MaxScore
+	result.Score = arg.Score
+	copied := *result
+	return &copied, nil
+}
+
+func (q *QuizSessions) GetAnswerScores(ctx context.Context, quizResultID string) ([]*db.AnswerScore, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.scores.filter(func(row *db.AnswerScore) bool { return row.QuizResultID == quizResultID }), nil
+}
+
+func (q *QuizSessions) createScore(score db.AnswerScore) (*db.AnswerScore, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	created := q.scores.insert(score)
+	copied := *created
+	return &copied, nil
+}
+
+func (q *QuizSessions) CreateSingleChoiceAnswerScore(ctx context.Context, arg db.CreateSingleChoiceAnswerScoreParams) (*db.AnswerScore, error) {
+	return q.createScore(db.AnswerScore{
+		ID:                   arg.ID,
+		QuizResultID:         arg.QuizResultID,
+		SingleChoiceAnswerID: arg.SingleChoiceAnswerID,
+		MaxScore:             arg.MaxScore,
+		Score:                arg.Score,
+	})
+}
+
+func (q *QuizSessions) CreateMultipleChoiceAnswerScore(ctx context.Context, arg db.CreateMultipleChoiceAnswerScoreParams) (*db.AnswerScore, error) {
+	return q.createScore(db.AnswerScore{
+		ID:                     arg.ID,
+		QuizResultID:           arg.QuizResultID,
+		MultipleChoiceAnswerID: arg.MultipleChoiceAnswerID,
+		MaxScore:               arg.MaxScore,
+		Score:                  arg.Score,
+	})
+}
+
+func (q *QuizSessions) CreateTrueOrFalseAnswerScore(ctx context.Context, arg db.CreateTrueOrFalseAnswerScoreParams) (*db.AnswerScore, error) {
+	return q.createScore(db.AnswerScore{
+		ID:                  arg.ID,
+		QuizResultID:        arg.QuizResultID,
+		TrueOrFalseAnswerID: arg.TrueOrFalseAnswerID,
+		MaxScore:            arg.MaxScore,
+		Score:               arg.Score,
+	})
+}
@@ -0,0 +1,180 @@
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"spaced-ace-backend/quiz"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// In-memory quiz.Repository, the creators of SearchQuizzes come from users
+type Quizzes struct {
+	mu       sync.Mutex
+	users    *Users
+	quizzes  []*quiz.DBQuiz
+	accesses []quiz.DBQuizAccess
+}
+
+func (q *Quizzes) find(id string) (*quiz.DBQuiz, error) {
+	for _, dbQuiz := range q.quizzes {
+		if dbQuiz.Id == id {
+			return dbQuiz, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (q *Quizzes) CreateQuiz(_ context.Context, ownerid string, name string, description string) (*quiz.DBQuiz, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	created := quiz.DBQuiz{
+		Id:          uuid.NewString(),
+		Name:        name,
+		CreatorId:   sql.NullString{String: ownerid, Valid: true},
+		Description: sql.NullString{String: description, Valid: true},
+		Status:      quiz.STATUS_DRAFT,
+	}
+	q.quizzes = append(q.quizzes, &created)
+	q.accesses = append(q.accesses, quiz.DBQuizAccess{UserId: ownerid, QuizId: created.Id, RoleId: quiz.QUIZ_OWNER_ACCESS_ID})
+	copied := created
+	return &copied, nil
+}
+
+func (q *Quizzes) CreateQuizAccess(_ context.Context, userid string, quizid string, roleid int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.accesses = append(q.accesses, quiz.DBQuizAccess{UserId: userid, QuizId: quizid, RoleId: roleid})
+	return nil
+}
+
+func (q *Quizzes) GetQuizById(_ context.Context, id string) (*quiz.DBQuiz, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dbQuiz, err := q.find(id)
+	if err != nil {
+		return &quiz.DBQuiz{}, err
+	}
+	copied := *dbQuiz
+	return &copied, nil
+}
+
+func (q *Quizzes) GetQuizAccess(_ context.Context, userid string, quizid string) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, access := range q.accesses {
+		if access.UserId == userid && access.QuizId == quizid {
+			return access.RoleId, nil
+		}
+	}
+	return 0, nil
+}
+
+func (q *Quizzes) GetQuizAccessesOfUser(_ context.Context, userid string) (*[]quiz.DBQuizAccess, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	accesses := []quiz.DBQuizAccess{}
+	for _, access := range q.accesses {
+		if access.UserId == userid {
+			accesses = append(accesses, access)
+		}
+	}
+	return &accesses, nil
+}
+
+func (q *Quizzes) GetQuizAccesses(_ context.Context, quizid string) (*[]string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	accesses := []string{}
+	for _, access := range q.accesses {
+		if access.QuizId == quizid {
+			accesses = append(accesses, strconv.Itoa(access.RoleId))
+		}
+	}
+	return &accesses, nil
+}
+
+func (q *Quizzes) UpdateQuizAccess(_ context.Context, userid string, quizid string, roleid int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, access := range q.accesses {
+		if access.UserId == userid && access.QuizId == quizid {
+			q.accesses[i].RoleId = roleid
+		}
+	}
+	return nil
+}
+
+func (q *Quizzes) UpdateQuiz(_ context.Context, quizid string, name string, description string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dbQuiz, err := q.find(quizid)
+	if err != nil {
+		return nil
+	}
+	if name != "" {
+		dbQuiz.Name = name
+	}
+	if description != "" {
+		dbQuiz.Description = sql.NullString{String: description, Valid: true}
+	}
+	return nil
+}
+
+func (q *Quizzes) UpdateQuizStatus(_ context.Context, quizid string, status string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dbQuiz, err := q.find(quizid)
+	if err != nil {
+		return nil
+	}
+	dbQuiz.Status = status
+	if status == quiz.STATUS_PUBLISHED {
+		now := time.Now()
+		dbQuiz.PublishedAt = &now
+	}
+	return nil
+}
+
+func (q *Quizzes) DeleteQuizAccess(_ context.Context, userid string, quizid string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.accesses = slices.DeleteFunc(q.accesses, func(access quiz.DBQuizAccess) bool {
+		return access.UserId == userid && access.QuizId == quizid
+	})
+	return nil
+}
+
+// Deletes the accesses of the quiz with it, like the foreign key does
+func (q *Quizzes) DeleteQuiz(_ context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.quizzes = slices.DeleteFunc(q.quizzes, func(dbQuiz *quiz.DBQuiz) bool { return dbQuiz.Id == id })
+	q.accesses = slices.DeleteFunc(q.accesses, func(access quiz.DBQuizAccess) bool { return access.QuizId == id })
+	return nil
+}
+
+func (q *Quizzes) SearchQuizzes(ctx context.Context, query string, limit int, offset int) ([]quiz.DBQuizWithCreator, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	query = strings.ToLower(query)
+	matches := []quiz.DBQuizWithCreator{}
+	for _, dbQuiz := range q.quizzes {
+		withCreator := quiz.DBQuizWithCreator{DBQuiz: *dbQuiz}
+		if creator, err := q.users.GetUserById(ctx, dbQuiz.CreatorId.String); err == nil {
+			withCreator.CreatorName = sql.NullString{String: creator.Name, Valid: true}
+			withCreator.CreatorEmail = sql.NullString{String: creator.Email, Valid: true}
+		}
+		if strings.Contains(strings.ToLower(dbQuiz.Name), query) || strings.Contains(strings.ToLower(withCreator.CreatorEmail.String), query) {
+			matches = append(matches, withCreator)
+		}
+	}
+	slices.SortFunc(matches, func(a, b quiz.DBQuizWithCreator) int { return strings.Compare(a.Name, b.Name) })
+	return page(matches, limit, offset), nil
+}
@@ -0,0 +1,277 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/db"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// In-memory handlers.ReviewItemRepository, joined with the questions and
+// quizzes of the other fakes
+type ReviewItems struct {
+	mu        sync.Mutex
+	questions *Questions
+	quizzes   *Quizzes
+	items     table[db.ReviewItem]
+	learnList table[db.LearnListAddedItem]
+}
+
+func questionIdOf(item *db.ReviewItem) string {
+	for _, id := range []*string{item.SingleChoiceQuestionID, item.MultipleChoiceQuestionID, item.TrueOrFalseQuestionID} {
+		if id != nil {
+			return *id
+		}
+	}
+	return ""
+}
+
+// Returns the quiz id, quiz name and question text of the item
+func (r *ReviewItems) join(ctx context.Context, item *db.ReviewItem) (string, string, string) {
+	quizID, text := r.questions.quizAndText(questionIdOf(item))
+	dbQuiz, _ := r.quizzes.GetQuizById(ctx, quizID)
+	return quizID, dbQuiz.Name, text
+}
+
+// Matches the items of the user whose questions are published
+func (r *ReviewItems) visibleTo(userID string) func(row *db.ReviewItem) bool {
+	return func(row *db.ReviewItem) bool {
+		return row.UserID == userID && r.questions.isPublished(questionIdOf(row))
+	}
+}
+
+func (r *ReviewItems) GetReviewItem(ctx context.Context, id string) (*db.GetReviewItemRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	item, err := r.items.find(func(row *db.ReviewItem) bool {
+		return row.ID == id && r.questions.isPublished(questionIdOf(row))
+	})
+	if err != nil {
+		return nil, err
+	}
+	quizID, quizName, text := r.join(ctx, item)
+	row := db.GetReviewItemRow{
+		ID:                       item.ID,
+		UserID:                   item.UserID,
+		SingleChoiceQuestionID:   item.SingleChoiceQuestionID,
+		MultipleChoiceQuestionID: item.MultipleChoiceQuestionID,
+		TrueOrFalseQuestionID:    item.TrueOrFalseQuestionID,
+		EaseFactor:               item.EaseFactor,
+		Difficulty:               item.Difficulty,
+		Streak:                   item.Streak,
+		NextReviewDate:           item.NextReviewDate,
+		IntervalInMinutes:        item.IntervalInMinutes,
+		QuizName:                 quizName,
+		QuizID:                   quizID,
+		QuestionName:             text,
+	}
+	return &row, nil
+}
+
+func (r *ReviewItems) GetReviewItems(ctx context.Context, userID string) ([]*db.GetReviewItemsRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := []*db.GetReviewItemsRow{}
+	for _, item := range r.items.filter(r.visibleTo(userID)) {
+		quizID, quizName, text := r.join(ctx, item)
+		rows = append(rows, &db.GetReviewItemsRow{
+			ID:                       item.ID,
+			UserID:                   item.UserID,
+			SingleChoiceQuestionID:   item.SingleChoiceQuestionID,
+			MultipleChoiceQuestionID: item.MultipleChoiceQuestionID,
+			TrueOrFalseQuestionID:    item.TrueOrFalseQuestionID,
+			EaseFactor:               item.EaseFactor,
+			Difficulty:               item.Difficulty,
+			Streak:                   item.Streak,
+			NextReviewDate:           item.NextReviewDate,
+			IntervalInMinutes:        item.IntervalInMinutes,
+			QuizName:                 quizName,
+			QuizID:                   quizID,
+			QuestionName:             text,
+		})
+	}
+	return rows, nil
+}
+
+func (r *ReviewItems) GetReviewItemCounts(ctx context.Context, userID string) (*db.GetReviewItemCountsRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := db.GetReviewItemCountsRow{}
+	for _, item := range r.items.filter(r.visibleTo(userID)) {
+		counts.Total++
+		if item.NextReviewDate.Time.Before(time.Now()) {
+			counts.DueToReview++
+		}
+	}
+	return &counts, nil
+}
+
+func (r *ReviewItems) GetQuizOptions(ctx context.Context, userid string) ([]*db.GetQuizOptionsRow, error) {
+	accesses, _ := r.quizzes.GetQuizAccessesOfUser(ctx, userid)
+	options := []*db.GetQuizOptionsRow{}
+	for _, access := range *accesses {
+		if dbQuiz, err := r.quizzes.GetQuizById(ctx, access.QuizId); err == nil {
+			options = append(options, &db.GetQuizOptionsRow{QuizID: dbQuiz.Id, QuizName: dbQuiz.Name})
+		}
+	}
+	return options, nil
+}
+
+func (r *ReviewItems) UpdateReviewItem(ctx context.Context, arg db.UpdateReviewItemParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if item, err := r.items.find(func(row *db.ReviewItem) bool { return row.ID == arg.ID }); err == nil {
+		item.EaseFactor = arg.EaseFactor
+		item.Difficulty = arg.Difficulty
+		item.Streak = arg.Streak
+		item.NextReviewDate = arg.NextReviewDate
+		item.IntervalInMinutes = arg.IntervalInMinutes
+	}
+	return nil
+}
+
+func (r *ReviewItems) ResetReviewItemsOfQuestion(ctx context.Context, arg db.ResetReviewItemsOfQuestionParams) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var reset int64
+	for _, item := range r.items.rows {
+		if questionIdOf(item) == arg.QuestionID {
+			item.EaseFactor = arg.EaseFactor
+			item.Difficulty = arg.Difficulty
+			item.Streak = arg.Streak
+			item.NextReviewDate = arg.NextReviewDate
+			item.IntervalInMinutes = arg.IntervalInMinutes
+			reset++
+		}
+	}
+	return reset, nil
+}
+
+func (r *ReviewItems) create(item db.ReviewItem) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.items.insert(item).ID, nil
+}
+
+func (r *ReviewItems) CreateSingleChoiceReviewItem(ctx context.Context, arg db.CreateSingleChoiceReviewItemParams) (string, error) {
+	return r.create(db.ReviewItem{
+		ID:                     arg.ID,
+		UserID:                 arg.UserID,
+		SingleChoiceQuestionID: arg.SingleChoiceQuestionID,
+		EaseFactor:             arg.EaseFactor,
+		Difficulty:             arg.Difficulty,
+		Streak:                 arg.Streak,
+		NextReviewDate:         arg.NextReviewDate,
+		IntervalInMinutes:      arg.IntervalInMinutes,
+	})
+}
+
+func (r *ReviewItems) CreateMultipleChoiceReviewItem(ctx context.Context, arg db.CreateMultipleChoiceReviewItemParams) (string, error) {
+	return r.create(db.ReviewItem{
+		ID:                       arg.ID,
+		UserID:                   arg.UserID,
+		MultipleChoiceQuestionID: arg.MultipleChoiceQuestionID,
+		EaseFactor:               arg.EaseFactor,
+		Difficulty:               arg.Difficulty,
+		Streak:                   arg.Streak,
+		NextReviewDate:           arg.NextReviewDate,
+		IntervalInMinutes:        arg.IntervalInMinutes,
+	})
+}
+
+func (r *ReviewItems) CreateTrueOrFalseReviewItem(ctx context.Context, arg db.CreateTrueOrFalseReviewItemParams) (string, error) {
+	return r.create(db.ReviewItem{
+		ID:                    arg.ID,
+		UserID:                arg.UserID,
+		TrueOrFalseQuestionID: arg.TrueOrFalseQuestionID,
+		EaseFactor:            arg.EaseFactor,
+		Difficulty:            arg.Difficulty,
+		Streak:                arg.Streak,
+		NextReviewDate:        arg.NextReviewDate,
+		IntervalInMinutes:     arg.IntervalInMinutes,
+	})
+}
+
+func (r *ReviewItems) CreateMissingReviewItemsOfQuiz(ctx context.Context, arg db.CreateMissingReviewItemsOfQuizParams) (int64, error) {
+	single, _ := r.questions.GetPublishedSingleChoiceQuestions(ctx, arg.QuizID)
+	multiple, _ := r.questions.GetPublishedMultipleChoiceQuestions(ctx, arg.QuizID)
+	trueOrFalse, _ := r.questions.GetPublishedTrueOrFalseQuestions(ctx, arg.QuizID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var created int64
+	for _, learner := range r.learnList.filter(func(row *db.LearnListAddedItem) bool { return row.QuizID == arg.QuizID }) {
+		missing := func(questionID string) bool {
+			_, err := r.items.find(func(row *db.ReviewItem) bool {
+				return row.UserID == learner.UserID && questionIdOf(row) == questionID
+			})
+			return err != nil
+		}
+		item := db.ReviewItem{
+			UserID:            learner.UserID,
+			EaseFactor:        arg.EaseFactor,
+			Difficulty:        arg.Difficulty,
+			Streak:            arg.Streak,
+			NextReviewDate:    arg.NextReviewDate,
+			IntervalInMinutes: arg.IntervalInMinutes,
+		}
+		for _, q := range single {
+			if missing(q.UUID) {
+				item := item
+				item.ID, item.SingleChoiceQuestionID = uuid.NewString(), &q.UUID
+				r.items.insert(item)
+				created++
+			}
+		}
+		for _, q := range multiple {
+			if missing(q.UUID) {
+				item := item
+				item.ID, item.MultipleChoiceQuestionID = uuid.NewString(), &q.UUID
+				r.items.insert(item)
+				created++
+			}
+		}
+		for _, q := range trueOrFalse {
+			if missing(q.UUID) {
+				item := item
+				item.ID, item.TrueOrFalseQuestionID = uuid.NewString(), &q.UUID
+				r.items.insert(item)
+				created++
+			}
+		}
+	}
+	return created, nil
+}
+
+func (r *ReviewItems) DeleteReviewItemsByQuizID(ctx context.Context, arg db.DeleteReviewItemsByQuizIDParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items.delete(func(row *db.ReviewItem) bool {
+		quizID, _ := r.questions.quizAndText(questionIdOf(row))
+		return row.UserID == arg.UserID && quizID == arg.ID
+	})
+	return nil
+}
+
+func (r *ReviewItems) GetAddedLearnListItems(ctx context.Context, userID string) ([]*db.LearnListAddedItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.learnList.filter(func(row *db.LearnListAddedItem) bool { return row.UserID == userID }), nil
+}
+
+func (r *ReviewItems) AddQuizToLearnList(ctx context.Context, arg db.AddQuizToLearnListParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.learnList.find(func(row *db.LearnListAddedItem) bool { return *row == db.LearnListAddedItem(arg) }); err != nil {
+		r.learnList.insert(db.LearnListAddedItem(arg))
+	}
+	return nil
+}
+
+func (r *ReviewItems) RemoveQuizFromLearnList(ctx context.Context, arg db.RemoveQuizFromLearnListParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.learnList.delete(func(row *db.LearnListAddedItem) bool { return *row == db.LearnListAddedItem(arg) })
+	return nil
+}
@@ -0,0 +1,121 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/db"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// In-memory handlers.QuestionRevisionRepository, joined with the names of the
+// users fake and the quizzes of the questions fake
+type Revisions struct {
+	mu        sync.Mutex
+	users     *Users
+	questions *Questions
+	revisions table[db.QuestionRevision]
+}
+
+// The revisions are numbered under the mutex, so there is nothing to lock
+func (r *Revisions) LockSingleChoiceQuestion(ctx context.Context, uuid string) error {
+	return nil
+}
+
+func (r *Revisions) LockMultipleChoiceQuestion(ctx context.Context, uuid string) error {
+	return nil
+}
+
+func (r *Revisions) LockTrueOrFalseQuestion(ctx context.Context, uuid string) error {
+	return nil
+}
+
+func (r *Revisions) CreateQuestionRevision(ctx context.Context, arg db.CreateQuestionRevisionParams) (*db.QuestionRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	number := int32(len(r.revisions.filter(func(row *db.QuestionRevision) bool { return row.QuestionID == arg.QuestionID }))) + 1
+	created := r.revisions.insert(db.QuestionRevision{
+		ID:           arg.ID,
+		QuestionID:   arg.QuestionID,
+		QuestionType: arg.QuestionType,
+		Revision:     number,
+		AuthorID:     arg.AuthorID,
+		Snapshot:     arg.Snapshot,
+		RestoredFrom: arg.RestoredFrom,
+		CreatedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	copied := *created
+	return &copied, nil
+}
+
+func (r *Revisions) DeleteQuestionRevisions(ctx context.Context, questionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revisions.delete(func(row *db.QuestionRevision) bool { return row.QuestionID == questionID })
+	return nil
+}
+
+func (r *Revisions) DeleteQuestionRevisionsOfQuiz(ctx context.Context, quizID string) error {
+	questionIds := map[string]bool{}
+	singleChoice, _ := r.questions.GetSingleChoiceQuestions(ctx, quizID)
+	for _, q := range singleChoice {
+		questionIds[q.UUID] = true
+	}
+	multipleChoice, _ := r.questions.GetMultipleChoiceQuestions(ctx, quizID)
+	for _, q := range multipleChoice {
+		questionIds[q.UUID] = true
+	}
+	trueOrFalse, _ := r.questions.GetTrueOrFalseQuestions(ctx, quizID)
+	for _, q := range trueOrFalse {
+		questionIds[q.UUID] = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revisions.delete(func(row *db.QuestionRevision) bool { return questionIds[row.QuestionID] })
+	return nil
+}
+
+func (r *Revisions) GetQuestionRevisions(ctx context.Context, questionID string) ([]*db.GetQuestionRevisionsRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := []*db.GetQuestionRevisionsRow{}
+	matching := r.revisions.filter(func(row *db.QuestionRevision) bool { return row.QuestionID == questionID })
+	for i := len(matching) - 1; i >= 0; i-- {
+		row := db.GetQuestionRevisionsRow(r.join(ctx, matching[i]))
+		rows = append(rows, &row)
+	}
+	return rows, nil
+}
+
+func (r *Revisions) GetQuestionRevision(ctx context.Context, arg db.GetQuestionRevisionParams) (*db.GetQuestionRevisionRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revision, err := r.revisions.get(func(row *db.QuestionRevision) bool {
+		return row.QuestionID == arg.QuestionID && row.Revision == arg.Revision
+	})
+	if err != nil {
+		return nil, err
+	}
+	row := r.join(ctx, &revision)
+	return &row, nil
+}
+
+func (r *Revisions) join(ctx context.Context, revision *db.QuestionRevision) db.GetQuestionRevisionRow {
+	row := db.GetQuestionRevisionRow{
+		ID:           revision.ID,
+		QuestionID:   revision.QuestionID,
+		QuestionType: revision.QuestionType,
+		Revision:     revision.Revision,
+		AuthorID:     revision.AuthorID,
+		Snapshot:     revision.Snapshot,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+	if revision.AuthorID != nil {
+		if author, err := r.users.GetUserById(ctx, *revision.AuthorID); err == nil {
+			row.AuthorName = &author.Name
+		}
+	}
+	return row
+}
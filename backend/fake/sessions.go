@@ -0,0 +1,106 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"spaced-ace-backend/auth"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// In-memory auth.SessionRepository, expired sessions are kept but not returned
+type Sessions struct {
+	mu       sync.Mutex
+	sessions []*auth.Session
+}
+
+func (s *Sessions) valid(id string) (*auth.Session, error) {
+	for _, session := range s.sessions {
+		if session.Id == id && session.ValidUntil.After(time.Now()) {
+			return session, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (s *Sessions) GetSession(_ context.Context, sessionId string) (*auth.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.valid(sessionId)
+	if err != nil {
+		return &auth.Session{}, err
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (s *Sessions) GetSessionsOfUser(_ context.Context, userId string) ([]auth.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := []auth.Session{}
+	for _, session := range s.sessions {
+		if session.UserId == userId && session.ImpersonatorId == nil && session.ValidUntil.After(time.Now()) {
+			sessions = append(sessions, *session)
+		}
+	}
+	slices.SortFunc(sessions, func(a, b auth.Session) int { return b.LastSeenAt.Compare(a.LastSeenAt) })
+	return sessions, nil
+}
+
+func (s *Sessions) CreateSession(_ context.Context, session *auth.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	session.Id = uuid.NewString()
+	session.PublicId = uuid.NewString()
+	session.CreatedAt = now
+	session.LastSeenAt = now
+	created := *session
+	s.sessions = append(s.sessions, &created)
+	return nil
+}
+
+func (s *Sessions) RenewSession(_ context.Context, sessionId string, validUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, session := range s.sessions {
+		if session.Id == sessionId {
+			session.ValidUntil = validUntil
+			session.LastSeenAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (s *Sessions) delete(match func(session *auth.Session) bool) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before := len(s.sessions)
+	s.sessions = slices.DeleteFunc(s.sessions, match)
+	return int64(before - len(s.sessions))
+}
+
+func (s *Sessions) DeleteSession(_ context.Context, id string) error {
+	s.delete(func(session *auth.Session) bool { return session.Id == id })
+	return nil
+}
+
+func (s *Sessions) DeleteSessionOfUser(_ context.Context, publicId string, userId string) (int64, error) {
+	return s.delete(func(session *auth.Session) bool {
+		return session.PublicId == publicId && session.UserId == userId
+	}), nil
+}
+
+func (s *Sessions) DeleteOtherSessionsOfUser(_ context.Context, userId string, keepSessionId string) (int64, error) {
+	return s.delete(func(session *auth.Session) bool {
+		return session.UserId == userId && session.Id != keepSessionId
+	}), nil
+}
+
+func (s *Sessions) DeleteSessionsOfUser(_ context.Context, userId string) error {
+	s.delete(func(session *auth.Session) bool { return session.UserId == userId })
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package fake has in-memory implementations of the repositories, the audit
+// log, the usage ledger and the llm client, for testing the handlers without
+// Postgres and the llm api.
+package fake
+
+import (
+	"context"
+	"errors"
+	"spaced-ace-backend/api/handlers"
+	"spaced-ace-backend/auth"
+	"sync"
+)
+
+var errUniqueViolation = errors.New("duplicate key value violates unique constraint")
+
+// The fakes of one test, wired together like the tables they stand in for
+type Store struct {
+	Users        *Users
+	Sessions     *Sessions
+	Identities   *Identities
+	Totp         *Totp
+	ApiTokens    *ApiTokens
+	Lockouts     *Lockouts
+	Account      *Account
+	Quizzes      *Quizzes
+	Questions    *Questions
+	Revisions    *Revisions
+	QuizSessions *QuizSessions
+	Answers      *Answers
+	ReviewItems  *ReviewItems
+	Llm          *Llm
+	AuditLog     *AuditLog
+	UsageLedger  *UsageLedger
+
+	// Held by transactions, they run one at a time and are never rolled back
+	tx sync.Mutex
+}
+
+func New() *Store {
+	users := &Users{}
+	quizzes := &Quizzes{users: users}
+	sessions := &Sessions{}
+	questions := &Questions{}
+	return &Store{
+		Users:        users,
+		Sessions:     sessions,
+		Identities:   &Identities{},
+		Totp:         &Totp{},
+		ApiTokens:    &ApiTokens{},
+		Lockouts:     &Lockouts{},
+		Account:      &Account{users: users, sessions: sessions},
+		Quizzes:      quizzes,
+		Questions:    questions,
+		Revisions:    &Revisions{users: users, questions: questions},
+		QuizSessions: &QuizSessions{},
+		Answers:      &Answers{},
+		ReviewItems:  &ReviewItems{questions: questions, quizzes: quizzes},
+		Llm:          &Llm{},
+		AuditLog:     &AuditLog{},
+		UsageLedger:  &UsageLedger{},
+	}
+}
+
+func (s *Store) AuthRepositories() auth.Repositories {
+	repositories := auth.Repositories{
+		Users:      s.Users,
+		Sessions:   s.Sessions,
+		Identities: s.Identities,
+		Totp:       s.Totp,
+		ApiTokens:  s.ApiTokens,
+		Lockouts:   s.Lockouts,
+	}
+	txRepositories := repositories
+	txRepositories.WithTx = func(ctx context.Context, f func(tx auth.Repositories) error) error {
+		return f(txRepositories)
+	}
+	repositories.WithTx = func(ctx context.Context, f func(tx auth.Repositories) error) error {
+		s.tx.Lock()
+		defer s.tx.Unlock()
+		return f(txRepositories)
+	}
+	return repositories
+}
+
+func (s *Store) Dependencies() handlers.Dependencies {
+	dependencies := handlers.Dependencies{
+		Users:        s.Users,
+		Quizzes:      s.Quizzes,
+		Questions:    s.Questions,
+		Revisions:    s.Revisions,
+		QuizSessions: s.QuizSessions,
+		Answers:      s.Answers,
+		ReviewItems:  s.ReviewItems,
+		Llm:          s.Llm,
+		Audit:        s.AuditLog,
+		Usage:        s.UsageLedger,
+	}
+	txDependencies := dependencies
+	txDependencies.WithTx = func(ctx context.Context, f func(tx handlers.Dependencies) error) error {
+		return f(txDependencies)
+	}
+	dependencies.WithTx = func(ctx context.Context, f func(tx handlers.Dependencies) error) error {
+		s.tx.Lock()
+		defer s.tx.Unlock()
+		return f(txDependencies)
+	}
+	return dependencies
+}
@@ -0,0 +1,56 @@
+package fake
+
+import (
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// The rows of one table in insertion order, the fakes lock around it
+type table[T any] struct {
+	rows []*T
+}
+
+func (t *table[T]) insert(row T) *T {
+	t.rows = append(t.rows, &row)
+	return &row
+}
+
+// Returns the first matching row, pgx.ErrNoRows when there is none
+func (t *table[T]) find(match func(row *T) bool) (*T, error) {
+	for _, row := range t.rows {
+		if match(row) {
+			return row, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+// Returns copies of the matching rows, nil matches every row
+func (t *table[T]) filter(match func(row *T) bool) []*T {
+	rows := []*T{}
+	for _, row := range t.rows {
+		if match == nil || match(row) {
+			copied := *row
+			rows = append(rows, &copied)
+		}
+	}
+	return rows
+}
+
+func (t *table[T]) delete(match func(row *T) bool) int {
+	before := len(t.rows)
+	t.rows = slices.DeleteFunc(t.rows, match)
+	return before - len(t.rows)
+}
+
+// Returns a copy of the first matching row, and the zero value with
+// pgx.ErrNoRows when there is none
+func (t *table[T]) get(match func(row *T) bool) (T, error) {
+	row, err := t.find(match)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return *row, nil
+}
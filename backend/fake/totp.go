@@ -0,0 +1,155 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/auth"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// In-memory auth.TotpRepository with the recovery codes and the login challenges
+type Totp struct {
+	mu            sync.Mutex
+	credentials   table[auth.TotpCredential]
+	recoveryCodes table[recoveryCode]
+	challenges    table[auth.LoginChallenge]
+}
+
+type recoveryCode struct {
+	userId   string
+	codeHash string
+	used     bool
+}
+
+func (t *Totp) GetTotpCredential(_ context.Context, userId string) (*auth.TotpCredential, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	credential, err := t.credentials.get(func(credential *auth.TotpCredential) bool { return credential.UserId == userId })
+	return &credential, err
+}
+
+func (t *Totp) UpsertUnconfirmedTotpCredential(_ context.Context, userId string, secret string) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	credential, err := t.credentials.find(func(credential *auth.TotpCredential) bool { return credential.UserId == userId })
+	if err != nil {
+		t.credentials.insert(auth.TotpCredential{UserId: userId, Secret: secret})
+		return 1, nil
+	}
+	if credential.ConfirmedAt != nil {
+		return 0, nil
+	}
+	credential.Secret = secret
+	credential.LastUsedStep = 0
+	return 1, nil
+}
+
+func (t *Totp) ConfirmTotpCredential(_ context.Context, userId string, step int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if credential, err := t.credentials.find(func(credential *auth.TotpCredential) bool { return credential.UserId == userId }); err == nil {
+		now := time.Now()
+		credential.ConfirmedAt = &now
+		credential.LastUsedStep = step
+	}
+	return nil
+}
+
+func (t *Totp) UseTotpStep(_ context.Context, userId string, step int64) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	credential, err := t.credentials.find(func(credential *auth.TotpCredential) bool {
+		return credential.UserId == userId && credential.LastUsedStep < step
+	})
+	if err != nil {
+		return false, nil
+	}
+	credential.LastUsedStep = step
+	return true, nil
+}
+
+func (t *Totp) DeleteTotpCredential(_ context.Context, userId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.credentials.delete(func(credential *auth.TotpCredential) bool { return credential.UserId == userId })
+	return nil
+}
+
+func (t *Totp) ReplaceRecoveryCodes(_ context.Context, userId string, codeHashes []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recoveryCodes.delete(func(code *recoveryCode) bool { return code.userId == userId })
+	for _, codeHash := range codeHashes {
+		t.recoveryCodes.insert(recoveryCode{userId: userId, codeHash: codeHash})
+	}
+	return nil
+}
+
+func (t *Totp) UseRecoveryCode(_ context.Context, userId string, codeHash string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	code, err := t.recoveryCodes.find(func(code *recoveryCode) bool {
+		return code.userId == userId && code.codeHash == codeHash && !code.used
+	})
+	if err != nil {
+		return false, nil
+	}
+	code.used = true
+	return true, nil
+}
+
+func (t *Totp) CountUnusedRecoveryCodes(_ context.Context, userId string) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.recoveryCodes.filter(func(code *recoveryCode) bool { return code.userId == userId && !code.used })), nil
+}
+
+func (t *Totp) DeleteRecoveryCodes(_ context.Context, userId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recoveryCodes.delete(func(code *recoveryCode) bool { return code.userId == userId })
+	return nil
+}
+
+func (t *Totp) CreateLoginChallenge(_ context.Context, userId string, rememberMe bool) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	challenge := t.challenges.insert(auth.LoginChallenge{
+		Id:         uuid.NewString(),
+		UserId:     userId,
+		ValidUntil: time.Now().Add(5 * time.Minute),
+		RememberMe: rememberMe,
+	})
+	return challenge.Id, nil
+}
+
+func (t *Totp) ClaimLoginChallengeAttempt(_ context.Context, id string, maxAttempts int) (*auth.LoginChallenge, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	challenge, err := t.challenges.find(func(challenge *auth.LoginChallenge) bool {
+		return challenge.Id == id && challenge.ValidUntil.After(time.Now()) && challenge.Attempts < maxAttempts
+	})
+	if err != nil {
+		return &auth.LoginChallenge{}, pgx.ErrNoRows
+	}
+	challenge.Attempts++
+	claimed := *challenge
+	return &claimed, nil
+}
+
+func (t *Totp) DeleteLoginChallenge(_ context.Context, id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.challenges.delete(func(challenge *auth.LoginChallenge) bool { return challenge.Id == id })
+	return nil
+}
+
+func (t *Totp) DeleteLoginChallengesOfUser(_ context.Context, userId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.challenges.delete(func(challenge *auth.LoginChallenge) bool { return challenge.UserId == userId })
+	return nil
+}
@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"context"
+	"spaced-ace-backend/usage"
+	"sync"
+	"time"
+)
+
+// In-memory usage.Ledger
+type UsageLedger struct {
+	mu    sync.Mutex
+	calls []ledgerEntry
+	// Held by WithUserLock for every user at once
+	reserve sync.Mutex
+}
+
+type ledgerEntry struct {
+	usage.Call
+	at time.Time
+}
+
+func (l *UsageLedger) Record(ctx context.Context, call usage.Call) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range l.calls {
+		if l.calls[i].Id == call.Id {
+			l.calls[i].Call = call
+			return nil
+		}
+	}
+	l.calls = append(l.calls, ledgerEntry{Call: call, at: time.Now().UTC()})
+	return nil
+}
+
+func (l *UsageLedger) WithUserLock(ctx context.Context, userId string, f func(l usage.Ledger) error) error {
+	l.reserve.Lock()
+	defer l.reserve.Unlock()
+	return f(l)
+}
+
+func (l *UsageLedger) CountSuccessfulSince(ctx context.Context, userId string, since time.Time) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	count := 0
+	for _, entry := range l.calls {
+		if entry.UserId == userId && entry.Success && !entry.at.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Returns the recorded calls of the user, oldest first
+func (l *UsageLedger) Calls(userId string) []usage.Call {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	calls := []usage.Call{}
+	for _, entry := range l.calls {
+		if entry.UserId == userId {
+			calls = append(calls, entry.Call)
+		}
+	}
+	return calls
+}
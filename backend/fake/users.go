@@ -0,0 +1,146 @@
+package fake
+
+import (
+	"context"
+	"slices"
+	"spaced-ace-backend/auth"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// In-memory auth.UserRepository
+type Users struct {
+	mu    sync.Mutex
+	users []*auth.DBUser
+}
+
+// Adds the user as is, for seeding the fixtures of a test
+func (u *Users) Add(user auth.DBUser) *auth.DBUser {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if user.Plan == "" {
+		user.Plan = "free"
+	}
+	if user.Role == "" {
+		user.Role = auth.RoleUser
+	}
+	u.users = append(u.users, &user)
+	return &user
+}
+
+func (u *Users) find(match func(user *auth.DBUser) bool) (*auth.DBUser, error) {
+	for _, user := range u.users {
+		if match(user) {
+			return user, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (u *Users) get(match func(user *auth.DBUser) bool) (*auth.DBUser, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	user, err := u.find(match)
+	if err != nil {
+		return &auth.DBUser{}, err
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (u *Users) update(id string, change func(user *auth.DBUser)) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	// Updates of missing rows are no errors in Postgres either
+	if user, err := u.find(func(user *auth.DBUser) bool { return user.Id == id }); err == nil {
+		change(user)
+	}
+	return nil
+}
+
+// Deletes the user, for the erasures of the accounts
+func (u *Users) remove(id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.users = slices.DeleteFunc(u.users, func(user *auth.DBUser) bool { return user.Id == id })
+}
+
+func (u *Users) GetUserByEmail(_ context.Context, email string) (*auth.DBUser, error) {
+	return u.get(func(user *auth.DBUser) bool { return user.Email == email })
+}
+
+func (u *Users) GetUserById(_ context.Context, id string) (*auth.DBUser, error) {
+	return u.get(func(user *auth.DBUser) bool { return user.Id == id })
+}
+
+func (u *Users) CreateUser(_ context.Context, user *auth.DBUser) error {
+	u.Add(*user)
+	return nil
+}
+
+func (u *Users) UpdateUser(_ context.Context, updated *auth.DBUser) error {
+	return u.update(updated.Id, func(user *auth.DBUser) {
+		user.Name = updated.Name
+		user.Email = updated.Email
+		user.Password = updated.Password
+		user.EmailVerified = updated.EmailVerified
+		user.VerificationToken = updated.VerificationToken
+	})
+}
+
+func (u *Users) GetUserByVerificationToken(_ context.Context, token string) (*auth.DBUser, error) {
+	return u.get(func(user *auth.DBUser) bool {
+		return user.VerificationToken != nil && *user.VerificationToken == token
+	})
+}
+
+func (u *Users) VerifyEmail(_ context.Context, id string) error {
+	return u.update(id, func(user *auth.DBUser) {
+		user.EmailVerified = true
+		user.VerificationToken = nil
+	})
+}
+
+func (u *Users) SearchUsers(_ context.Context, query string, limit int, offset int) ([]auth.DBUser, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	query = strings.ToLower(query)
+	matches := []auth.DBUser{}
+	for _, user := range u.users {
+		if strings.Contains(strings.ToLower(user.Email), query) || strings.Contains(strings.ToLower(user.Name), query) {
+			matches = append(matches, *user)
+		}
+	}
+	slices.SortFunc(matches, func(a, b auth.DBUser) int { return strings.Compare(a.Email, b.Email) })
+	return page(matches, limit, offset), nil
+}
+
+func (u *Users) SetUserRole(_ context.Context, id string, role string) error {
+	return u.update(id, func(user *auth.DBUser) { user.Role = role })
+}
+
+func (u *Users) SetUserDisabled(_ context.Context, id string, disabled bool) error {
+	return u.update(id, func(user *auth.DBUser) {
+		if !disabled {
+			user.DisabledAt = nil
+		} else if user.DisabledAt == nil {
+			now := time.Now()
+			user.DisabledAt = &now
+		}
+	})
+}
+
+// Returns the rows of the page, like LIMIT and OFFSET
+func page[T any](rows []T, limit int, offset int) []T {
+	if offset >= len(rows) {
+		return []T{}
+	}
+	rows = rows[offset:]
+	if limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
@@ -0,0 +1,133 @@
+// Package health serves the liveness and readiness probes of compose and
+// Kubernetes
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// How long every check gets before it counts as failing
+const checkTimeout = 3 * time.Second
+
+// A dependency of the server. Without a required one no request can be served,
+// an optional one only breaks some features, so it is reported but the server
+// stays ready.
+type Check struct {
+	Name     string
+	Run      func(ctx context.Context) error
+	Optional bool
+}
+
+// Remembers the result of the check for the ttl, so probing the readiness
+// often does not call an external api every time
+func Cached(check Check, ttl time.Duration) Check {
+	var mu sync.Mutex
+	var checkedAt time.Time
+	var result error
+	run := check.Run
+	check.Run = func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !checkedAt.IsZero() && time.Since(checkedAt) < ttl {
+			return result
+		}
+		result = run(ctx)
+		checkedAt = time.Now()
+		return result
+	}
+	return check
+}
+
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+var draining atomic.Bool
+
+// Fails the readiness probe from now on, so no new traffic is routed to the
+// server while the in-flight requests finish
+func Drain() {
+	draining.Store(true)
+}
+
+// The process is up and serving, restarting it would not help with anything else
+func LivenessEndpoint(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Runs the checks concurrently and responds 503 when a required one fails, a
+// failing optional one only degrades the status. The errors are only logged,
+// they can name internal hosts.
+func ReadinessEndpoint(checks ...Check) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if draining.Load() {
+			return c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "draining", Checks: map[string]string{}})
+		}
+		ctx, cancel := context.WithTimeout(c.Request().Context(), checkTimeout)
+		defer cancel()
+
+		response := ReadinessResponse{Status: "ok", Checks: make(map[string]string, len(checks))}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, check := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := check.Run(ctx)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					slog.Warn("readiness check failed", "check", check.Name, "optional", check.Optional, "error", err)
+					response.Checks[check.Name] = "failing"
+					if !check.Optional {
+						response.Status = "unavailable"
+					} else if response.Status == "ok" {
+						response.Status = "degraded"
+					}
+					return
+				}
+				response.Checks[check.Name] = "ok"
+			}()
+		}
+		wg.Wait()
+
+		if response.Status == "unavailable" {
+			return c.JSON(http.StatusServiceUnavailable, response)
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// Asks the server listening on the port whether it is ready and fails when it
+// is not, the healthcheck of the container images that ship without curl
+func Probe(port int) error {
+	client := http.Client{Timeout: 2 * checkTimeout}
+	res, err := client.Get(fmt.Sprintf("http://localhost:%d/readyz", port))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		var response ReadinessResponse
+		json.NewDecoder(res.Body).Decode(&response)
+		failing := []string{}
+		for name, status := range response.Checks {
+			if status != "ok" {
+				failing = append(failing, name)
+			}
+		}
+		return fmt.Errorf("not ready, status %d, failing: %s", res.StatusCode, strings.Join(failing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func ready(t *testing.T, checks ...Check) (int, ReadinessResponse) {
+	t.Helper()
+	e := echo.New()
+	e.GET("/readyz", ReadinessEndpoint(checks...))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var response ReadinessResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return rec.Code, response
+}
+
+func TestReadiness(t *testing.T) {
+	passing := Check{Name: "postgres", Run: func(ctx context.Context) error { return nil }}
+	failing := Check{Name: "llm", Run: func(ctx context.Context) error { return errors.New("connection refused") }}
+	stuck := Check{Name: "email", Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	if code, response := ready(t, passing); code != http.StatusOK || response.Checks["postgres"] != "ok" {
+		t.Errorf("passing check: got %d %+v", code, response)
+	}
+	code, response := ready(t, passing, failing, stuck)
+	if code != http.StatusServiceUnavailable || response.Checks["postgres"] != "ok" || response.Checks["llm"] != "failing" || response.Checks["email"] != "failing" {
+		t.Errorf("failing checks: got %d %+v", code, response)
+	}
+
+	optional := failing
+	optional.Optional = true
+	code, response = ready(t, passing, optional)
+	if code != http.StatusOK || response.Status != "degraded" || response.Checks["llm"] != "failing" {
+		t.Errorf("failing optional check: got %d %+v", code, response)
+	}
+
+	Drain()
+	defer draining.Store(false)
+	if code, response := ready(t, passing); code != http.StatusServiceUnavailable || response.Status != "draining" {
+		t.Errorf("draining: got %d %+v", code, response)
+	}
+}
+
+func TestCached(t *testing.T) {
+	calls := 0
+	check := Cached(Check{Name: "email", Run: func(ctx context.Context) error {
+		calls++
+		return errors.New("unauthorized")
+	}}, time.Hour)
+	for range 3 {
+		if err := check.Run(context.Background()); err == nil {
+			t.Error("the cached failure got lost")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("checked %d times within the ttl", calls)
+	}
+
+	expired := Cached(Check{Name: "email", Run: func(ctx context.Context) error {
+		calls++
+		return nil
+	}}, 0)
+	expired.Run(context.Background())
+	expired.Run(context.Background())
+	if calls != 3 {
+		t.Errorf("checked %d times after the ttl, want 3", calls)
+	}
+}
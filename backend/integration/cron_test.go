@@ -0,0 +1,53 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"spaced-ace-backend/auth"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// pg_cron runs its jobs in the database it is configured for and not in the
+// schema of a test, so the test runs the command of the job itself, the same
+// statement the scheduler runs every hour
+func TestExpiredSessionsAreCleanedUp(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	sessions := auth.NewPostgresRepository(s.Queries)
+	alice := newUser(t, s, "alice")
+
+	var schedule, command string
+	err := s.Pool.QueryRow(ctx, "SELECT schedule, command FROM cron.job WHERE jobname = 'del_exp_sessions'").Scan(&schedule, &command)
+	if err != nil {
+		t.Fatalf("cleanup job is not scheduled: %v", err)
+	}
+	if schedule != "10 * * * *" {
+		t.Errorf("schedule: got %q", schedule)
+	}
+
+	start := func(validFor time.Duration) auth.Session {
+		session := auth.Session{UserId: alice.Id, ValidUntil: time.Now().Add(validFor), ExpiresAt: time.Now().Add(time.Hour)}
+		if err := sessions.CreateSession(ctx, &session); err != nil {
+			t.Fatal(err)
+		}
+		return session
+	}
+	expired := start(-time.Minute)
+	valid := start(time.Hour)
+
+	if _, err := s.Pool.Exec(ctx, command); err != nil {
+		t.Fatalf("running the cleanup: %v", err)
+	}
+	if _, err := sessions.GetSession(ctx, expired.Id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("expired session: got %v, want no rows", err)
+	}
+	if _, err := sessions.GetSession(ctx, valid.Id); err != nil {
+		t.Errorf("valid session: %v", err)
+	}
+}
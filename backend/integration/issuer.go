@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The client id the providers of the tests have to be registered with
+const IssuerClientId = "spacedace"
+
+// Stands in for an OpenID Connect provider, the issuer of the registered
+// provider has to be Url. The user signs in through Authorize instead of a
+// login page, the code it returns is exchanged for an id token with the claims.
+type Issuer struct {
+	Url    string
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	codes  map[string]map[string]any
+}
+
+func NewIssuer(t *testing.T) *Issuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := &Issuer{key: key, codes: map[string]map[string]any{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                issuer.Url,
+			"authorization_endpoint":                issuer.Url + "/authorize",
+			"token_endpoint":                        issuer.Url + "/token",
+			"jwks_uri":                              issuer.Url + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		claims, ok := issuer.codes[r.FormValue("code")]
+		delete(issuer.codes, r.FormValue("code"))
+		issuer.mu.Unlock()
+		if !ok {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		idToken, err := issuer.sign(claims)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]any{"access_token": uuid.NewString(), "token_type": "Bearer", "expires_in": 3600, "id_token": idToken})
+	})
+	issuer.server = httptest.NewServer(mux)
+	issuer.Url = issuer.server.URL
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// Signs in with the claims at the authorization url the backend handed out and
+// returns the code and state the frontend would post to the callback
+func (i *Issuer) Authorize(t *testing.T, authorizationUrl string, claims map[string]any) (code string, state string) {
+	t.Helper()
+	parsed, err := url.Parse(authorizationUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedIn := map[string]any{"nonce": parsed.Query().Get("nonce")}
+	for name, value := range claims {
+		signedIn[name] = value
+	}
+	code = uuid.NewString()
+	i.mu.Lock()
+	i.codes[code] = signedIn
+	i.mu.Unlock()
+	return code, parsed.Query().Get("state")
+}
+
+func (i *Issuer) sign(claims map[string]any) (string, error) {
+	payload := map[string]any{
+		"iss": i.Url,
+		"aud": IssuerClientId,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		payload[name] = value
+	}
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
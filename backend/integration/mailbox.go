@@ -0,0 +1,72 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type Email struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Html    string   `json:"html"`
+}
+
+// Stands in for the Resend api, RESEND_BASE_URL has to point at Url. Only
+// sending emails and listing the domains for the readiness probe are supported.
+type Mailbox struct {
+	Url    string
+	server *httptest.Server
+	mu     sync.Mutex
+	emails []Email
+}
+
+var token = regexp.MustCompile(`[?&]token=([\w-]+)`)
+
+func NewMailbox() *Mailbox {
+	mailbox := &Mailbox{}
+	mailbox.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/domains" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"data": []any{}})
+			return
+		}
+		var email Email
+		if r.Method != http.MethodPost || r.URL.Path != "/emails" || json.NewDecoder(r.Body).Decode(&email) != nil {
+			http.Error(w, "not an email", http.StatusBadRequest)
+			return
+		}
+		mailbox.mu.Lock()
+		mailbox.emails = append(mailbox.emails, email)
+		mailbox.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"id": uuid.NewString()})
+	}))
+	mailbox.Url = mailbox.server.URL + "/"
+	return mailbox
+}
+
+func (m *Mailbox) Close() {
+	m.server.Close()
+}
+
+// Returns the token of the link in the last email to the address, empty when there is none
+func (m *Mailbox) Token(to string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.emails) - 1; i >= 0; i-- {
+		for _, address := range m.emails[i].To {
+			if address != to {
+				continue
+			}
+			if match := token.FindStringSubmatch(m.emails[i].Html); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
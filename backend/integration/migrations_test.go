@@ -0,0 +1,64 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	_ "embed"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/migrations"
+	"testing"
+	"time"
+)
+
+//go:embed testdata/baseline_schema.sql
+var baselineSchema string
+
+// Databases created before the migrations are upgraded in place, the rows
+// they hold get the defaults of the new columns
+func TestMigrateUpFromBaseline(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewEmptyStore(t)
+	ctx := context.Background()
+
+	if _, err := s.Pool.Exec(ctx, baselineSchema); err != nil {
+		t.Fatalf("creating the baseline schema: %v", err)
+	}
+	const userId = "6f1c2a3e-0d4b-4c7a-9e52-2b8f0a1d3c45"
+	const sessionId = "0b7d5e9a-3f21-4d8c-a6b4-7e2c1f9d8a03"
+	if _, err := s.Pool.Exec(ctx, "INSERT INTO users (id, name, email, password) VALUES ($1, 'alice', 'alice@example.com', 'hash')", userId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Pool.Exec(ctx, "INSERT INTO sessions (id, user_id, valid_until) VALUES ($1, $2, now() + interval '1 hour')", sessionId, userId); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrations.Up(ctx, s.Pool); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	repository := auth.NewPostgresRepository(s.Queries)
+	user, err := repository.GetUserById(ctx, userId)
+	if err != nil {
+		t.Fatalf("user after the upgrade: %v", err)
+	}
+	if user.Plan != "free" || user.Role != "user" || user.DisabledAt != nil {
+		t.Errorf("user after the upgrade: got %+v", user)
+	}
+	session, err := repository.GetSession(ctx, sessionId)
+	if err != nil {
+		t.Fatalf("session after the upgrade: %v", err)
+	}
+	if session.PublicId == "" || session.RememberMe || session.ImpersonatorId != nil {
+		t.Errorf("session after the upgrade: got %+v", session)
+	}
+
+	// New sessions and login challenges use the added columns
+	created := auth.Session{UserId: userId, ValidUntil: time.Now().Add(time.Hour), ExpiresAt: time.Now().Add(time.Hour), RememberMe: true, UserAgent: "test"}
+	if err := repository.CreateSession(ctx, &created); err != nil {
+		t.Fatalf("creating a session: %v", err)
+	}
+	if sessions, err := repository.GetSessionsOfUser(ctx, userId); err != nil || len(sessions) != 2 {
+		t.Errorf("sessions of user: got %+v, %v", sessions, err)
+	}
+}
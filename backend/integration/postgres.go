@@ -0,0 +1,216 @@
+// Package integration runs the backend against a real Postgres. The tests
+// using it are behind the integration build tag:
+//
+//	go test -tags integration ./...
+//
+// The database is INTEGRATION_DATABASE_URL when it is set. Otherwise a
+// throwaway cluster is started with the local initdb and pg_ctl, which need
+// pg_cron installed, and without those with the image of
+// postgres/test-postgres-compose.yaml, built once with
+// `docker compose -f postgres/test-postgres-compose.yaml build`.
+//
+// Every test gets its own migrated schema, so tests and packages can share
+// the database and run in parallel.
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/migrations"
+	"spaced-ace-backend/store"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const image = "spacedace/postgres:0.0.1"
+
+type Postgres struct {
+	// Connection string of the database the schemas are created in
+	Url  string
+	stop func()
+}
+
+// Starts the database of the test run, Stop has to be called once the tests are done
+func Start(ctx context.Context) (*Postgres, error) {
+	if url := os.Getenv("INTEGRATION_DATABASE_URL"); url != "" {
+		return &Postgres{Url: url, stop: func() {}}, nil
+	}
+	if _, err := exec.LookPath("pg_ctl"); err == nil {
+		return startLocal(ctx)
+	}
+	if _, err := exec.LookPath("docker"); err == nil {
+		return startDocker(ctx)
+	}
+	return nil, errors.New("no database for the integration tests, set INTEGRATION_DATABASE_URL or install postgres or docker")
+}
+
+func (p *Postgres) Stop() {
+	p.stop()
+}
+
+// Returns a store on a new schema with every migration applied, the schema is
+// dropped when the test is over
+func (p *Postgres) NewStore(t testing.TB) *store.Store {
+	t.Helper()
+	s := p.NewEmptyStore(t)
+	if _, err := migrations.Up(context.Background(), s.Pool); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	return s
+}
+
+// Returns a store on a new schema without any tables, the schema is dropped
+// when the test is over
+func (p *Postgres) NewEmptyStore(t testing.TB) *store.Store {
+	t.Helper()
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	conn, err := pgx.Connect(ctx, p.Url)
+	if err != nil {
+		t.Fatalf("connecting to the database: %v", err)
+	}
+	defer conn.Close(ctx)
+	if _, err := conn.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("creating schema: %v", err)
+	}
+
+	config, err := pgxpool.ParseConfig(p.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unqualified names resolve to the schema of the test, pg_cron keeps
+	// its own cron schema for the whole database
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		pool.Close()
+		conn, err := pgx.Connect(ctx, p.Url)
+		if err != nil {
+			t.Errorf("connecting to the database: %v", err)
+			return
+		}
+		defer conn.Close(ctx)
+		if _, err := conn.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("dropping schema: %v", err)
+		}
+	})
+	return &store.Store{Queries: db.New(pool), Pool: pool}
+}
+
+// Creates a cluster in a temporary directory, it is removed again on Stop
+func startLocal(ctx context.Context) (*Postgres, error) {
+	dir, err := os.MkdirTemp("", "spaced-ace-postgres-")
+	if err != nil {
+		return nil, err
+	}
+	data := filepath.Join(dir, "data")
+	if err := run(ctx, "initdb", "-D", data, "-U", "test", "--auth=trust", "-E", "UTF8"); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -c shared_preload_libraries=pg_cron -c cron.database_name=postgres", port, dir)
+	if err := run(ctx, "pg_ctl", "-D", data, "-o", options, "-l", filepath.Join(dir, "postgres.log"), "-w", "start"); err != nil {
+		log, _ := os.ReadFile(filepath.Join(dir, "postgres.log"))
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("%w, is pg_cron installed?\n%s", err, log)
+	}
+	p := &Postgres{
+		Url: fmt.Sprintf("postgres://test@127.0.0.1:%d/postgres?sslmode=disable", port),
+		stop: func() {
+			_ = run(context.Background(), "pg_ctl", "-D", data, "-m", "immediate", "stop")
+			os.RemoveAll(dir)
+		},
+	}
+	return p, waitReady(ctx, p)
+}
+
+// Runs the test image on a free port, the container is removed on Stop
+func startDocker(ctx context.Context) (*Postgres, error) {
+	out, err := exec.CommandContext(ctx, "docker", "run", "-d", "--rm",
+		"-e", "POSTGRES_USER=test", "-e", "POSTGRES_PASSWORD=test", "-e", "POSTGRES_DB=postgres",
+		"-p", "127.0.0.1::5432", image).Output()
+	if err != nil {
+		return nil, fmt.Errorf("starting %s, build it with docker compose -f postgres/test-postgres-compose.yaml build: %w", image, commandError(err))
+	}
+	container := strings.TrimSpace(string(out))
+	stop := func() {
+		_ = exec.Command("docker", "rm", "-f", container).Run()
+	}
+	out, err = exec.CommandContext(ctx, "docker", "port", container, "5432/tcp").Output()
+	if err != nil {
+		stop()
+		return nil, commandError(err)
+	}
+	// The first line is the ipv4 mapping, e.g. 127.0.0.1:49153
+	address := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	p := &Postgres{
+		Url:  fmt.Sprintf("postgres://test:test@%s/postgres?sslmode=disable", address),
+		stop: stop,
+	}
+	if err := waitReady(ctx, p); err != nil {
+		stop()
+		return nil, err
+	}
+	return p, nil
+}
+
+// The server of the image only accepts tcp connections once the database is initialized
+func waitReady(ctx context.Context, p *Postgres) error {
+	deadline := time.Now().Add(time.Minute)
+	for {
+		conn, err := pgx.Connect(ctx, p.Url)
+		if err == nil {
+			conn.Close(ctx)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("database did not start: %w", err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func run(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w\n%s", name, err, out)
+	}
+	return nil
+}
+
+func commandError(err error) error {
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return fmt.Errorf("%w: %s", err, exitError.Stderr)
+	}
+	return err
+}
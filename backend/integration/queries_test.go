@@ -0,0 +1,585 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/question"
+	"spaced-ace-backend/quiz"
+	"spaced-ace-backend/store"
+	"spaced-ace-backend/usage"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func newUser(t *testing.T, s *store.Store, name string) *auth.DBUser {
+	t.Helper()
+	token := uuid.NewString()
+	user := &auth.DBUser{Id: uuid.NewString(), Name: name, Email: name + "@example.com", Password: "hash", VerificationToken: &token}
+	if err := auth.NewPostgresRepository(s.Queries).CreateUser(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func newQuiz(t *testing.T, s *store.Store, owner *auth.DBUser) *quiz.DBQuiz {
+	t.Helper()
+	created, err := quiz.NewPostgresRepository(s.Queries).CreateQuiz(context.Background(), owner.Id, "Primes", "Numbers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+func TestUsers(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	users := auth.NewPostgresRepository(s.Queries)
+	alice := newUser(t, s, "alice")
+
+	found, err := users.GetUserByEmail(context.Background(), "alice@example.com")
+	if err != nil || found.Id != alice.Id || found.Plan != "free" || found.Role != auth.RoleUser {
+		t.Fatalf("got %+v, %v", found, err)
+	}
+	if _, err := users.GetUserByEmail(context.Background(), "bob@example.com"); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("unknown email: got %v, want no rows", err)
+	}
+
+	found, err = users.GetUserByVerificationToken(context.Background(), *alice.VerificationToken)
+	if err != nil || found.Id != alice.Id {
+		t.Fatalf("by verification token: got %+v, %v", found, err)
+	}
+	if err := users.VerifyEmail(context.Background(), alice.Id); err != nil {
+		t.Fatal(err)
+	}
+	found, _ = users.GetUserById(context.Background(), alice.Id)
+	if !found.EmailVerified || found.VerificationToken != nil {
+		t.Errorf("verified user: got %+v", found)
+	}
+
+	if err := users.SetUserRole(context.Background(), alice.Id, auth.RoleModerator); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.SetUserDisabled(context.Background(), alice.Id, true); err != nil {
+		t.Fatal(err)
+	}
+	found, _ = users.GetUserById(context.Background(), alice.Id)
+	if found.Role != auth.RoleModerator || found.DisabledAt == nil {
+		t.Errorf("role and disabled: got %+v", found)
+	}
+
+	newUser(t, s, "bob")
+	page, err := users.SearchUsers(context.Background(), "example", 1, 1)
+	if err != nil || len(page) != 1 {
+		t.Errorf("second page of the search: got %+v, %v", page, err)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	sessions := auth.NewPostgresRepository(s.Queries)
+	alice := newUser(t, s, "alice")
+	admin := newUser(t, s, "admin")
+
+	start := func(validFor time.Duration, impersonatorId *string) auth.Session {
+		session := auth.Session{
+			UserId:         alice.Id,
+			ValidUntil:     time.Now().Add(validFor),
+			ExpiresAt:      time.Now().Add(time.Hour),
+			ImpersonatorId: impersonatorId,
+		}
+		if err := sessions.CreateSession(context.Background(), &session); err != nil {
+			t.Fatal(err)
+		}
+		return session
+	}
+	current := start(time.Hour, nil)
+	other := start(time.Hour, nil)
+	start(-time.Hour, nil)
+	start(time.Hour, &admin.Id)
+
+	if current.Id == "" || current.PublicId == "" || current.Id == current.PublicId {
+		t.Fatalf("generated ids: got %+v", current)
+	}
+	loaded, err := sessions.GetSession(context.Background(), current.Id)
+	if err != nil || loaded.UserId != alice.Id {
+		t.Errorf("user of session: got %+v, %v", loaded, err)
+	}
+
+	// The expired and the impersonation sessions are not listed
+	listed, err := sessions.GetSessionsOfUser(context.Background(), alice.Id)
+	if err != nil || len(listed) != 2 {
+		t.Fatalf("sessions of user: got %+v, %v", listed, err)
+	}
+
+	if deleted, err := sessions.DeleteSessionOfUser(context.Background(), other.PublicId, admin.Id); err != nil || deleted != 0 {
+		t.Errorf("deleting the session of another user: got %d, %v", deleted, err)
+	}
+	if deleted, err := sessions.DeleteOtherSessionsOfUser(context.Background(), alice.Id, current.Id); err != nil || deleted == 0 {
+		t.Errorf("deleting the other sessions: got %d, %v", deleted, err)
+	}
+	if _, err := sessions.GetSession(context.Background(), other.Id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("deleted session: got %v, want no rows", err)
+	}
+	if _, err := sessions.GetSession(context.Background(), current.Id); err != nil {
+		t.Errorf("kept session: %v", err)
+	}
+}
+
+func TestQuizzes(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	quizzes := quiz.NewPostgresRepository(s.Queries)
+	alice := newUser(t, s, "alice")
+	bob := newUser(t, s, "bob")
+	created := newQuiz(t, s, alice)
+
+	// Creating the quiz makes the creator its owner in the same statement
+	if access, err := quizzes.GetQuizAccess(context.Background(), alice.Id, created.Id); err != nil || access != quiz.QUIZ_OWNER_ACCESS_ID {
+		t.Errorf("access of the creator: got %d, %v", access, err)
+	}
+	if access, err := quizzes.GetQuizAccess(context.Background(), bob.Id, created.Id); err != nil || access != 0 {
+		t.Errorf("access of another user: got %d, %v", access, err)
+	}
+	if err := quizzes.CreateQuizAccess(context.Background(), bob.Id, created.Id, quiz.QUIZ_VIEWER_ACCESS_ID); err != nil {
+		t.Fatal(err)
+	}
+	accesses, err := quizzes.GetQuizAccessesOfUser(context.Background(), bob.Id)
+	if err != nil || len(*accesses) != 1 || (*accesses)[0].RoleId != quiz.QUIZ_VIEWER_ACCESS_ID {
+		t.Errorf("accesses of the viewer: got %+v, %v", accesses, err)
+	}
+
+	if err := quizzes.UpdateQuiz(context.Background(), created.Id, "Prime numbers", ""); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := quizzes.GetQuizById(context.Background(), created.Id)
+	if err != nil || updated.Name != "Prime numbers" || updated.Description.String != "Numbers" {
+		t.Errorf("updating only the name: got %+v, %v", updated, err)
+	}
+
+	found, err := quizzes.SearchQuizzes(context.Background(), "alice@", 10, 0)
+	if err != nil || len(found) != 1 || found[0].CreatorName.String != "alice" {
+		t.Errorf("search by creator email: got %+v, %v", found, err)
+	}
+
+	if err := quizzes.DeleteQuiz(context.Background(), created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := quizzes.GetQuizById(context.Background(), created.Id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("deleted quiz: got %v, want no rows", err)
+	}
+	if accesses, _ := quizzes.GetQuizAccessesOfUser(context.Background(), bob.Id); len(*accesses) != 0 {
+		t.Errorf("accesses of the deleted quiz: got %+v", accesses)
+	}
+}
+
+func TestQuestions(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	questions := question.NewPostgresRepository(s.Queries)
+	created := newQuiz(t, s, newUser(t, s, "alice"))
+
+	multipleChoice := question.DBMultipleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which are prime?", Answers: []string{"2", "3", "4", "6"}, CorrectAnswers: []string{"A", "B"}, Status: question.STATUS_APPROVED}
+	singleChoice := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which is even?", Answers: []string{"1", "2", "3", "5"}, CorrectAnswer: "B", Status: question.STATUS_APPROVED}
+	trueOrFalse := question.DBTrueOrFalseQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Is 2 prime?", CorrectAnswer: true, Status: question.STATUS_APPROVED}
+	for _, err := range []error{
+		questions.CreateMultipleChoiceQuestion(ctx, &multipleChoice),
+		questions.CreateSingleChoiceQuestion(ctx, &singleChoice),
+		questions.CreateTrueOrFalseQuestion(ctx, &trueOrFalse),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	multipleChoice.CorrectAnswers = []string{"A"}
+	if err := questions.UpdateMultipleChoiceQuestion(ctx, &multipleChoice); err != nil {
+		t.Fatal(err)
+	}
+	foundMultipleChoice, err := questions.GetMultipleChoiceQuestion(ctx, multipleChoice.UUID)
+	if err != nil || len(foundMultipleChoice.CorrectAnswers) != 1 || len(foundMultipleChoice.Answers) != 4 {
+		t.Errorf("updated multiple choice question: got %+v, %v", foundMultipleChoice, err)
+	}
+	foundSingleChoice, err := questions.GetSingleChoiceQuestions(ctx, created.Id)
+	if err != nil || len(foundSingleChoice) != 1 || foundSingleChoice[0].CorrectAnswer != "B" {
+		t.Errorf("single choice questions of the quiz: got %+v, %v", foundSingleChoice, err)
+	}
+
+	if err := questions.DeleteTrueOrFalseQuestion(ctx, trueOrFalse.UUID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := questions.GetTrueOrFalseQuestion(ctx, trueOrFalse.UUID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("deleted question: got %v, want no rows", err)
+	}
+
+	// The questions go with their quiz
+	if err := quiz.NewPostgresRepository(s.Queries).DeleteQuiz(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := questions.GetSingleChoiceQuestion(ctx, singleChoice.UUID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("question of the deleted quiz: got %v, want no rows", err)
+	}
+}
+
+func TestQuizSessions(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	alice := newUser(t, s, "alice")
+	created := newQuiz(t, s, alice)
+	singleChoice := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which is even?", Answers: []string{"1", "2", "3", "5"}, CorrectAnswer: "B", Status: question.STATUS_APPROVED}
+	if err := question.NewPostgresRepository(s.Queries).CreateSingleChoiceQuestion(ctx, &singleChoice); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := s.CreateQuizSession(ctx, db.CreateQuizSessionParams{
+		ID:        uuid.NewString(),
+		UserID:    alice.Id,
+		QuizID:    created.Id,
+		StartedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	open, err := s.HasOpenQuizSession(ctx, db.HasOpenQuizSessionParams{UserID: alice.Id, QuizID: created.Id})
+	if err != nil || !open {
+		t.Errorf("open session: got %v, %v", open, err)
+	}
+
+	// Updating an answer that was never given finds nothing, the handler creates it then
+	_, err = s.UpdateSingleChoiceAnswerBySessionAndQuestionId(ctx, db.UpdateSingleChoiceAnswerBySessionAndQuestionIdParams{SessionID: session.ID, QuestionID: singleChoice.UUID, Answer: []string{"A"}})
+	if !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("updating a missing answer: got %v, want no rows", err)
+	}
+	answer, err := s.CreateSingleChoiceAnswer(ctx, db.CreateSingleChoiceAnswerParams{ID: uuid.NewString(), SessionID: session.ID, QuestionID: singleChoice.UUID, Answer: []string{"A"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := s.UpdateSingleChoiceAnswerBySessionAndQuestionId(ctx, db.UpdateSingleChoiceAnswerBySessionAndQuestionIdParams{SessionID: session.ID, QuestionID: singleChoice.UUID, Answer: []string{"B"}})
+	if err != nil || updated.ID != answer.ID || updated.Answer[0] != "B" {
+		t.Errorf("updated answer: got %+v, %v", updated, err)
+	}
+
+	// A session has one result, the second submit of a retry has to fail
+	if _, err := s.CreateQuizResult(ctx, db.CreateQuizResultParams{ID: uuid.NewString(), SessionID: session.ID, MaxScore: 1, Score: 1}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CreateQuizResult(ctx, db.CreateQuizResultParams{ID: uuid.NewString(), SessionID: session.ID, MaxScore: 1, Score: 0})
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		t.Errorf("second result of the session: got %v, want a unique violation", err)
+	}
+
+	// The answers go with their question
+	if err := question.NewPostgresRepository(s.Queries).DeleteSingleChoiceQuestion(ctx, singleChoice.UUID); err != nil {
+		t.Fatal(err)
+	}
+	if answers, err := s.GetSingleChoiceAnswers(ctx, session.ID); err != nil || len(answers) != 0 {
+		t.Errorf("answers of the deleted question: got %+v, %v", answers, err)
+	}
+}
+
+func TestReviewItemCounts(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	alice := newUser(t, s, "alice")
+	created := newQuiz(t, s, alice)
+	questions := question.NewPostgresRepository(s.Queries)
+
+	addItem := func(due time.Duration) {
+		singleChoice := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which is even?", Answers: []string{"1", "2", "3", "5"}, CorrectAnswer: "B", Status: question.STATUS_APPROVED}
+		if err := questions.CreateSingleChoiceQuestion(ctx, &singleChoice); err != nil {
+			t.Fatal(err)
+		}
+		nextReview := time.Now().Add(due)
+		_, err := s.CreateSingleChoiceReviewItem(ctx, db.CreateSingleChoiceReviewItemParams{
+			ID:                     uuid.NewString(),
+			UserID:                 alice.Id,
+			SingleChoiceQuestionID: &singleChoice.UUID,
+			EaseFactor:             2.5,
+			Difficulty:             3,
+			NextReviewDate:         store.Timestamptz(&nextReview),
+			IntervalInMinutes:      60,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	addItem(-time.Hour)
+	addItem(time.Hour)
+	if err := questions.PublishQuestionsOfQuiz(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	// Not published yet, the item is not counted
+	addItem(-time.Hour)
+
+	counts, err := s.GetReviewItemCounts(ctx, alice.Id)
+	if err != nil || counts.Total != 2 || counts.DueToReview != 1 {
+		t.Errorf("review item counts: got %+v, %v", counts, err)
+	}
+	if due, err := s.CountDueReviewItems(ctx); err != nil || due != 1 {
+		t.Errorf("due review items of every user: got %d, %v", due, err)
+	}
+}
+
+func TestQuestionRevisions(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	alice := newUser(t, s, "alice")
+	created := newQuiz(t, s, alice)
+	questions := question.NewPostgresRepository(s.Queries)
+	singleChoice := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which is even?", Answers: []string{"1", "2", "3", "5"}, CorrectAnswer: "B", Status: question.STATUS_APPROVED}
+	if err := questions.CreateSingleChoiceQuestion(ctx, &singleChoice); err != nil {
+		t.Fatal(err)
+	}
+	if err := questions.PublishQuestionsOfQuiz(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	// The revisions are numbered per question
+	for _, authorId := range []*string{nil, &alice.Id} {
+		_, err := s.CreateQuestionRevision(ctx, db.CreateQuestionRevisionParams{
+			ID:           uuid.NewString(),
+			QuestionID:   singleChoice.UUID,
+			QuestionType: "single-choice",
+			AuthorID:     authorId,
+			Snapshot:     []byte(`{"question":"Which is even?"}`),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	revisions, err := s.GetQuestionRevisions(ctx, singleChoice.UUID)
+	if err != nil || len(revisions) != 2 || revisions[0].Revision != 2 || revisions[0].AuthorName == nil || *revisions[0].AuthorName != "alice" || revisions[1].AuthorName != nil {
+		t.Errorf("revisions newest first: got %+v, %v", revisions, err)
+	}
+	if _, err := s.GetQuestionRevision(ctx, db.GetQuestionRevisionParams{QuestionID: singleChoice.UUID, Revision: 3}); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("unknown revision: got %v, want no rows", err)
+	}
+
+	nextReview := time.Now().Add(time.Hour)
+	_, err = s.CreateSingleChoiceReviewItem(ctx, db.CreateSingleChoiceReviewItemParams{
+		ID:                     uuid.NewString(),
+		UserID:                 alice.Id,
+		SingleChoiceQuestionID: &singleChoice.UUID,
+		EaseFactor:             2.9,
+		Difficulty:             2,
+		Streak:                 4,
+		NextReviewDate:         store.Timestamptz(&nextReview),
+		IntervalInMinutes:      600,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	reset, err := s.ResetReviewItemsOfQuestion(ctx, db.ResetReviewItemsOfQuestionParams{
+		QuestionID:        singleChoice.UUID,
+		EaseFactor:        2.5,
+		Difficulty:        3,
+		NextReviewDate:    store.Timestamptz(&now),
+		IntervalInMinutes: 60,
+	})
+	if err != nil || reset != 1 {
+		t.Errorf("reset review items: got %d, %v", reset, err)
+	}
+	if counts, err := s.GetReviewItemCounts(ctx, alice.Id); err != nil || counts.DueToReview != 1 {
+		t.Errorf("the reset review item is due: got %+v, %v", counts, err)
+	}
+
+	// The revisions go with the quiz, or with every quiz of an erased creator
+	other := newQuiz(t, s, alice)
+	trueOrFalse := question.DBTrueOrFalseQuestion{UUID: uuid.NewString(), QuizID: other.Id, Question: "Is 2 prime?", CorrectAnswer: true, Status: question.STATUS_APPROVED}
+	if err := questions.CreateTrueOrFalseQuestion(ctx, &trueOrFalse); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CreateQuestionRevision(ctx, db.CreateQuestionRevisionParams{ID: uuid.NewString(), QuestionID: trueOrFalse.UUID, QuestionType: "true-or-false", Snapshot: []byte(`{}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteQuestionRevisionsOfQuiz(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	if revisions, err := s.GetQuestionRevisions(ctx, singleChoice.UUID); err != nil || len(revisions) != 0 {
+		t.Errorf("revisions of the deleted quiz: got %+v, %v", revisions, err)
+	}
+	if revisions, err := s.GetQuestionRevisions(ctx, trueOrFalse.UUID); err != nil || len(revisions) != 1 {
+		t.Errorf("revisions of the other quiz: got %+v, %v", revisions, err)
+	}
+	if err := s.DeleteQuestionRevisionsOfCreator(ctx, alice.Id); err != nil {
+		t.Fatal(err)
+	}
+	if revisions, err := s.GetQuestionRevisions(ctx, trueOrFalse.UUID); err != nil || len(revisions) != 0 {
+		t.Errorf("revisions of the erased creator: got %+v, %v", revisions, err)
+	}
+}
+
+func TestQuizPublishing(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	alice := newUser(t, s, "alice")
+	bob := newUser(t, s, "bob")
+	created := newQuiz(t, s, alice)
+	if created.Status != quiz.STATUS_DRAFT || created.PublishedAt != nil {
+		t.Errorf("new quiz: got %+v, want a draft", created)
+	}
+	questions := question.NewPostgresRepository(s.Queries)
+	approved := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which is even?", Answers: []string{"1", "2", "3", "5"}, CorrectAnswer: "B", Status: question.STATUS_APPROVED}
+	draft := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: created.Id, Question: "Which is odd?", Answers: []string{"1", "2", "4", "6"}, CorrectAnswer: "A", Status: question.STATUS_DRAFT}
+	for _, q := range []*question.DBSingleChoiceQuestion{&approved, &draft} {
+		if err := questions.CreateSingleChoiceQuestion(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Publishing takes the approved questions only
+	if err := questions.PublishQuestionsOfQuiz(ctx, created.Id); err != nil {
+		t.Fatal(err)
+	}
+	published, err := questions.GetPublishedSingleChoiceQuestions(ctx, created.Id)
+	if err != nil || len(published) != 1 || published[0].UUID != approved.UUID {
+		t.Errorf("published questions: got %+v, %v", published, err)
+	}
+	quizzes := quiz.NewPostgresRepository(s.Queries)
+	if err := quizzes.UpdateQuizStatus(ctx, created.Id, quiz.STATUS_PUBLISHED); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := quizzes.GetQuizById(ctx, created.Id); err != nil || found.Status != quiz.STATUS_PUBLISHED || found.PublishedAt == nil {
+		t.Errorf("published quiz: got %+v, %v", found, err)
+	}
+
+	// Learners get the review items they are missing once
+	if err := s.AddQuizToLearnList(ctx, db.AddQuizToLearnListParams{UserID: bob.Id, QuizID: created.Id}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, want := range []int64{1, 0} {
+		added, err := s.CreateMissingReviewItemsOfQuiz(ctx, db.CreateMissingReviewItemsOfQuizParams{
+			QuizID:            created.Id,
+			EaseFactor:        2.5,
+			Difficulty:        3,
+			NextReviewDate:    store.Timestamptz(&now),
+			IntervalInMinutes: 60,
+		})
+		if err != nil || added != want {
+			t.Errorf("missing review items: got %d, %v, want %d", added, err, want)
+		}
+	}
+
+	// Rejecting a published question hides its review items
+	if err := questions.UpdateSingleChoiceQuestionStatus(ctx, approved.UUID, question.STATUS_REJECTED); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := questions.GetSingleChoiceQuestion(ctx, approved.UUID); err != nil || found.Status != question.STATUS_REJECTED || found.Published {
+		t.Errorf("rejected question: got %+v, %v", found, err)
+	}
+	if items, err := s.GetReviewItems(ctx, bob.Id); err != nil || len(items) != 0 {
+		t.Errorf("review items of the rejected question: got %+v, %v", items, err)
+	}
+	if counts, err := s.GetReviewItemCounts(ctx, bob.Id); err != nil || counts.Total != 0 {
+		t.Errorf("review item counts without the rejected question: got %+v, %v", counts, err)
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	log := audit.NewPostgresLog(s.Queries)
+	alice := newUser(t, s, "alice")
+
+	for _, action := range []string{audit.LoginSucceeded, audit.LoginFailed, audit.LoginSucceeded} {
+		event := audit.Event{Id: uuid.NewString(), ActorId: &alice.Id, Action: action, TargetType: audit.TargetUser, TargetId: alice.Id, Diff: []byte("{}")}
+		if err := log.Record(ctx, &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.CreatedAt.IsZero() {
+			t.Errorf("recorded event without time: %+v", event)
+		}
+	}
+
+	events, err := log.Search(ctx, audit.Filter{ActorId: alice.Id, Action: audit.LoginSucceeded}, 10, 0)
+	if err != nil || len(events) != 2 {
+		t.Errorf("search by actor and action: got %+v, %v", events, err)
+	}
+	events, err = log.Search(ctx, audit.Filter{TargetId: alice.Id}, 2, 2)
+	if err != nil || len(events) != 1 {
+		t.Errorf("last page: got %+v, %v", events, err)
+	}
+}
+
+func TestUsageLedger(t *testing.T) {
+	t.Parallel()
+	s := postgres.NewStore(t)
+	ctx := context.Background()
+	ledger := usage.NewPostgresLedger(s)
+	alice := newUser(t, s, "alice")
+	created := newQuiz(t, s, alice)
+
+	since := time.Now().Add(-time.Minute)
+	for _, success := range []bool{true, false, true} {
+		err := ledger.Record(ctx, usage.Call{UserId: alice.Id, QuizId: created.Id, QuestionType: "single-choice", PromptChars: 42, Latency: time.Second, Success: success})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Failed calls do not count against the quota
+	if count, err := ledger.CountSuccessfulSince(ctx, alice.Id, since); err != nil || count != 2 {
+		t.Errorf("successful calls: got %d, %v", count, err)
+	}
+	if count, err := ledger.CountSuccessfulSince(ctx, alice.Id, time.Now().Add(time.Minute)); err != nil || count != 0 {
+		t.Errorf("calls of the future: got %d, %v", count, err)
+	}
+
+	// Reservations sent at once take the quota one after the other, a
+	// failed call gives its place back
+	usage.InitQuotaConfig(map[string]config.Quota{config.DefaultPlan: {Daily: 2}})
+	defer usage.InitQuotaConfig(config.Default().Quotas)
+	bob := newUser(t, s, "bob")
+	reservations := make(chan error, 5)
+	for i := 0; i < cap(reservations); i++ {
+		go func() {
+			_, err := usage.Reserve(ctx, ledger, usage.Call{UserId: bob.Id, QuestionType: "single-choice"}, config.DefaultPlan)
+			reservations <- err
+		}()
+	}
+	reserved := 0
+	for i := 0; i < cap(reservations); i++ {
+		var quotaErr *usage.QuotaExceededError
+		if err := <-reservations; err == nil {
+			reserved++
+		} else if !errors.As(err, &quotaErr) {
+			t.Fatal(err)
+		}
+	}
+	if reserved != 2 {
+		t.Errorf("reservations within the quota: got %d, want 2", reserved)
+	}
+	carol := newUser(t, s, "carol")
+	failed, err := usage.Reserve(ctx, ledger, usage.Call{UserId: carol.Id, QuestionType: "single-choice"}, config.DefaultPlan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed.Success = false
+	if err := usage.Record(ctx, ledger, failed); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := ledger.CountSuccessfulSince(ctx, carol.Id, since); err != nil || count != 0 {
+		t.Errorf("successful calls after a failed one: got %d, %v", count, err)
+	}
+}
@@ -0,0 +1,26 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestMiddlewareReplacesInvalidRequestIds(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware(func(c echo.Context) bool { return true }))
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, RequestId(c.Request().Context()))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "forged\nlog line")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	id := rec.Body.String()
+	if id == "" || id == "forged\nlog line" || rec.Header().Get(echo.HeaderXRequestID) != id {
+		t.Errorf("got request id %q and header %q, want a new id in both", id, rec.Header().Get(echo.HeaderXRequestID))
+	}
+}
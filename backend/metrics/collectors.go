@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolAcquiredConns = prometheus.NewDesc(namespace+"_db_pool_acquired_connections", "Connections currently in use.", nil, nil)
+	poolIdleConns     = prometheus.NewDesc(namespace+"_db_pool_idle_connections", "Connections currently idle.", nil, nil)
+	poolTotalConns    = prometheus.NewDesc(namespace+"_db_pool_connections", "Connections currently open.", nil, nil)
+	poolMaxConns      = prometheus.NewDesc(namespace+"_db_pool_max_connections", "Largest size of the pool.", nil, nil)
+	poolAcquires      = prometheus.NewDesc(namespace+"_db_pool_acquires_total", "Connections acquired from the pool.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc(namespace+"_db_pool_empty_acquires_total", "Acquires that had to wait for a connection.", nil, nil)
+	poolCanceled      = prometheus.NewDesc(namespace+"_db_pool_canceled_acquires_total", "Acquires canceled before getting a connection.", nil, nil)
+	poolAcquireTime   = prometheus.NewDesc(namespace+"_db_pool_acquire_duration_seconds_total", "Time spent acquiring connections.", nil, nil)
+	dueReviewItems    = prometheus.NewDesc(namespace+"_review_items_due", "Review items of every user due for review.", nil, nil)
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func (p *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{poolAcquiredConns, poolIdleConns, poolTotalConns, poolMaxConns, poolAcquires, poolEmptyAcquires, poolCanceled, poolAcquireTime} {
+		ch <- desc
+	}
+}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := p.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireTime, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+// Counts the due review items on every scrape, the count is indexed by the due date
+type dueReviewItemsCollector struct {
+	count func(ctx context.Context) (int64, error)
+}
+
+func (d *dueReviewItemsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dueReviewItems
+}
+
+func (d *dueReviewItemsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	due, err := d.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(dueReviewItems, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(dueReviewItems, prometheus.GaugeValue, float64(due))
+}
@@ -0,0 +1,120 @@
+// Package metrics exposes the Prometheus metrics of the backend on their own
+// port, away from the public api. The metrics are registered in the default
+// registry next to the Go runtime and process metrics.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "spacedace"
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the http requests by route pattern and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	llmCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "llm_call_duration_seconds",
+		Help:      "Latency of the llm api calls, the operation is the question type or chunk.",
+		// Generating a question takes seconds, the default buckets stop at 10s
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 40, 80},
+	}, []string{"operation", "provider"})
+	llmCallFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_call_failures_total",
+		Help:      "Failed llm api calls, the operation is the question type or chunk.",
+	}, []string{"operation", "provider"})
+
+	chunkCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "chunk_cache_lookups_total",
+		Help:      "Lookups of prompts in the chunk cache, the result is hit or miss.",
+	}, []string{"result"})
+
+	ReviewsSubmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviews_submitted_total",
+		Help:      "Answers submitted to review items.",
+	})
+	QuizzesSubmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quizzes_submitted_total",
+		Help:      "Quiz sessions submitted and scored, retried submits are not counted.",
+	})
+)
+
+// Records the latency of every request under its route pattern, so paths with
+// ids do not each get their own series
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		started := time.Now()
+		err := next(c)
+		status := c.Response().Status
+		if err != nil {
+			// The error handler writes the response after the middleware returns
+			status = http.StatusInternalServerError
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				status = httpErr.Code
+			}
+		}
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequestDuration.WithLabelValues(c.Request().Method, route, strconv.Itoa(status)).Observe(time.Since(started).Seconds())
+		return err
+	}
+}
+
+// Records a call to the llm api, the provider is empty when the api did not answer
+func ObserveLlmCall(operation string, provider string, latency time.Duration, err error) {
+	if provider == "" {
+		provider = "unknown"
+	}
+	llmCallDuration.WithLabelValues(operation, provider).Observe(latency.Seconds())
+	if err != nil {
+		llmCallFailures.WithLabelValues(operation, provider).Inc()
+	}
+}
+
+func ObserveChunkCacheLookup(hit bool) {
+	if hit {
+		chunkCacheLookups.WithLabelValues("hit").Inc()
+	} else {
+		chunkCacheLookups.WithLabelValues("miss").Inc()
+	}
+}
+
+// Registers the statistics of the pool and the number of due review items,
+// which are read when Prometheus scrapes
+func RegisterStore(pool *pgxpool.Pool, countDueReviewItems func(ctx context.Context) (int64, error)) {
+	prometheus.MustRegister(&poolCollector{pool: pool}, &dueReviewItemsCollector{count: countDueReviewItems})
+}
+
+// Serves /metrics on the port, the server is shut down together with the api
+func NewServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	// A failing collector, like the due count when the database is down, should
+	// not hide the other metrics
+	mux.Handle("/metrics", promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+		ErrorLog:      log.Default(),
+		ErrorHandling: promhttp.ContinueOnError,
+	}))
+	return &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux}
+}
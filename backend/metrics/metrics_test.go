@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMiddlewareLabelsByRoutePattern(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware)
+	e.GET("/quizzes/:id", func(c echo.Context) error {
+		if c.Param("id") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound, "quiz not found")
+		}
+		return c.String(http.StatusOK, "quiz")
+	})
+	for _, path := range []string{"/quizzes/1", "/quizzes/2", "/quizzes/missing", "/unknown"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	for _, series := range []struct {
+		route  string
+		status string
+		count  uint64
+	}{
+		{"/quizzes/:id", "200", 2},
+		{"/quizzes/:id", "404", 1},
+	} {
+		var metric dto.Metric
+		httpRequestDuration.WithLabelValues("GET", series.route, series.status).(prometheus.Histogram).Write(&metric)
+		if got := metric.GetHistogram().GetSampleCount(); got != series.count {
+			t.Errorf("%s %s: got %d requests, want %d", series.route, series.status, got, series.count)
+		}
+	}
+	if got := testutil.CollectAndCount(httpRequestDuration); got != 3 {
+		t.Errorf("got %d series, want the two of the route and one for unmatched paths", got)
+	}
+}
+
+func TestDueReviewItems(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&dueReviewItemsCollector{count: func(ctx context.Context) (int64, error) {
+		return 0, errors.New("connection refused")
+	}})
+	if _, err := registry.Gather(); err == nil {
+		t.Error("a failing count was reported as a value")
+	}
+
+	registry = prometheus.NewRegistry()
+	registry.MustRegister(&dueReviewItemsCollector{count: func(ctx context.Context) (int64, error) {
+		return 7, nil
+	}})
+	families, err := registry.Gather()
+	if err != nil || len(families) != 1 || families[0].GetMetric()[0].GetGauge().GetValue() != 7 {
+		t.Errorf("got %v, %v", families, err)
+	}
+}
@@ -0,0 +1,37 @@
+SELECT cron.unschedule(jobname) FROM cron.job WHERE jobname IN (
+    'del_exp_sessions',
+    'del_exp_oidc_states',
+    'del_exp_login_challenges',
+    'del_old_login_failures',
+    'del_idle_rate_limit_buckets',
+    'del_unconfirmed_erasures'
+);
+
+DROP TABLE IF EXISTS llm_usage;
+DROP TABLE IF EXISTS review_items;
+DROP TABLE IF EXISTS learn_list_added_items;
+DROP TABLE IF EXISTS answer_scores;
+DROP TABLE IF EXISTS quiz_results;
+DROP TABLE IF EXISTS true_or_false_answers;
+DROP TABLE IF EXISTS multiple_choice_answers;
+DROP TABLE IF EXISTS single_choice_answers;
+DROP TABLE IF EXISTS quiz_sessions;
+
+DROP TABLE IF EXISTS account_erasures;
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS rate_limit_buckets;
+DROP TABLE IF EXISTS login_failures;
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS totp_credentials;
+DROP TABLE IF EXISTS oidc_states;
+DROP TABLE IF EXISTS identities;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS true_or_false_questions;
+DROP TABLE IF EXISTS multiple_choice_questions;
+DROP TABLE IF EXISTS single_choice_questions;
+DROP TABLE IF EXISTS quiz_accesses;
+DROP TABLE IF EXISTS quizzes;
+DROP TABLE IF EXISTS users;
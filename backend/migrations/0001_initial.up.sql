@@ -0,0 +1,309 @@
+-- The schema as the InitDb functions and the postgres init script created it.
+-- Everything is IF NOT EXISTS, so databases set up before migrations adopt it,
+-- and the columns InitDb added to older tables are added here the same way.
+
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    name TEXT,
+    email TEXT,
+    password TEXT,
+    email_verified BOOLEAN DEFAULT FALSE,
+    verification_token TEXT,
+    plan TEXT NOT NULL DEFAULT 'free',
+    role TEXT NOT NULL DEFAULT 'user',
+    disabled_at TIMESTAMPTZ
+);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS plan TEXT NOT NULL DEFAULT 'free';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS users_email ON users(email);
+CREATE INDEX IF NOT EXISTS users_verification_token ON users(verification_token);
+
+CREATE TABLE IF NOT EXISTS quizzes(
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    creatorid UUID REFERENCES users(id) ON DELETE SET NULL,
+    description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS quiz_accesses(
+    userid UUID REFERENCES users(id) ON DELETE CASCADE,
+    quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
+    roleid SMALLINT NOT NULL, --1 = owner, 2 = viewer
+    PRIMARY KEY(userid, quizid, roleid),
+    UNIQUE(userid, quizid)
+);
+
+CREATE TABLE IF NOT EXISTS single_choice_questions (
+    uuid UUID PRIMARY KEY,
+    quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
+    question TEXT,
+    answers TEXT[4],
+    correct_answer CHAR
+);
+
+CREATE TABLE IF NOT EXISTS multiple_choice_questions (
+    uuid UUID PRIMARY KEY,
+    quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
+    question TEXT,
+    answers TEXT[4],
+    correct_answers CHAR[]
+);
+
+CREATE TABLE IF NOT EXISTS true_or_false_questions (
+    uuid UUID PRIMARY KEY,
+    quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
+    question TEXT,
+    correct_answer BOOLEAN
+);
+
+CREATE EXTENSION IF NOT EXISTS pg_cron;
+
+CREATE UNLOGGED TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    valid_until TIMESTAMPTZ,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL DEFAULT now() + interval '1 hour',
+    remember_me BOOLEAN NOT NULL DEFAULT FALSE,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    impersonator_id UUID REFERENCES users(id) ON DELETE CASCADE
+);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS public_id UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ NOT NULL DEFAULT now() + interval '1 hour';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS remember_me BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS ip_address TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS impersonator_id UUID REFERENCES users(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS sessions_id ON sessions(id);
+CREATE INDEX IF NOT EXISTS sessions_user_id ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS sessions_valid_until ON sessions(valid_until);
+CREATE UNIQUE INDEX IF NOT EXISTS sessions_public_id ON sessions(public_id);
+
+SELECT cron.schedule('del_exp_sessions', '10 * * * *', $$DELETE FROM sessions WHERE valid_until < now()$$);
+
+CREATE TABLE IF NOT EXISTS identities (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ,
+    UNIQUE(provider, subject)
+);
+CREATE INDEX IF NOT EXISTS identities_user_id ON identities(user_id);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS oidc_states (
+    state TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    valid_until TIMESTAMPTZ NOT NULL
+);
+
+SELECT cron.schedule('del_exp_oidc_states', '20 * * * *', $$DELETE FROM oidc_states WHERE valid_until < now()$$);
+
+CREATE TABLE IF NOT EXISTS totp_credentials (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS recovery_codes_user_id ON recovery_codes(user_id);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS login_challenges (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    valid_until TIMESTAMPTZ NOT NULL,
+    remember_me BOOLEAN NOT NULL DEFAULT FALSE
+);
+ALTER TABLE login_challenges ADD COLUMN IF NOT EXISTS remember_me BOOLEAN NOT NULL DEFAULT FALSE;
+
+SELECT cron.schedule('del_exp_login_challenges', '30 * * * *', $$DELETE FROM login_challenges WHERE valid_until < now()$$);
+
+CREATE TABLE IF NOT EXISTS login_failures (
+    email TEXT PRIMARY KEY,
+    failures INT NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ
+);
+
+SELECT cron.schedule('del_old_login_failures', '40 * * * *', $$DELETE FROM login_failures WHERE last_failure_at < now() - interval '1 day'$$);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+SELECT cron.schedule('del_idle_rate_limit_buckets', '20 * * * *', $$DELETE FROM rate_limit_buckets WHERE updated_at < now() - interval '1 day'$$);
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    token_prefix TEXT NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS api_tokens_user_id ON api_tokens(user_id);
+
+-- The actor is not a foreign key, the trail has to outlive deleted accounts.
+-- Rows can only be inserted, the trigger rejects updates and deletes.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY,
+    actor_id UUID,
+    impersonator_id UUID,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    diff JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS audit_events_created_at ON audit_events(created_at);
+CREATE INDEX IF NOT EXISTS audit_events_actor_id ON audit_events(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_events_target ON audit_events(target_type, target_id, created_at);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TABLE IF NOT EXISTS account_erasures (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    quiz_handling TEXT NOT NULL,
+    transfer_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    confirmed_at TIMESTAMPTZ,
+    erase_after TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS account_erasures_erase_after ON account_erasures(erase_after);
+
+SELECT cron.schedule('del_unconfirmed_erasures', '50 * * * *', $$DELETE FROM account_erasures WHERE confirmed_at IS NULL AND requested_at < now() - interval '1 day'$$);
+
+CREATE TABLE IF NOT EXISTS quiz_sessions(
+    id   UUID PRIMARY KEY NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    quiz_id UUID REFERENCES quizzes(id) ON DELETE CASCADE NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    closes_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_quiz_sessions_user_id ON quiz_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_quiz_sessions_quiz_id ON quiz_sessions(quiz_id);
+
+CREATE TABLE IF NOT EXISTS single_choice_answers(
+    id   UUID PRIMARY KEY NOT NULL,
+    session_id UUID REFERENCES quiz_sessions(id) NOT NULL,
+    question_id UUID REFERENCES single_choice_questions(uuid) ON DELETE CASCADE NOT NULL,
+    answer TEXT[1] NULL,
+    CONSTRAINT constraint_single_choice_answers_unique_session_and_question UNIQUE (session_id, question_id)
+);
+CREATE INDEX IF NOT EXISTS idx_single_choice_answers_session_id ON single_choice_answers(session_id);
+
+CREATE TABLE IF NOT EXISTS multiple_choice_answers(
+    id   UUID PRIMARY KEY NOT NULL,
+    session_id UUID REFERENCES quiz_sessions(id) NOT NULL,
+    question_id UUID REFERENCES multiple_choice_questions(uuid) ON DELETE CASCADE NOT NULL,
+    answers TEXT[4] NULL, -- list of letters e.g. ABD, maximum 4 answers are possible
+    CONSTRAINT constraint_multiple_choice_answers_unique_session_and_question UNIQUE (session_id, question_id)
+);
+CREATE INDEX IF NOT EXISTS idx_multiple_choice_answers_session_id ON multiple_choice_answers(session_id);
+
+CREATE TABLE IF NOT EXISTS true_or_false_answers(
+    id   UUID PRIMARY KEY NOT NULL,
+    session_id UUID REFERENCES quiz_sessions(id) NOT NULL,
+    question_id UUID REFERENCES true_or_false_questions(uuid) ON DELETE CASCADE NOT NULL,
+    answer BOOLEAN NULL,
+    CONSTRAINT constraint_true_or_false_answers_unique_session_and_question UNIQUE (session_id, question_id)
+);
+CREATE INDEX IF NOT EXISTS idx_true_or_false_answers_session_id ON true_or_false_answers(session_id);
+
+CREATE TABLE IF NOT EXISTS quiz_results(
+    id UUID PRIMARY KEY NOT NULL,
+    session_id UUID REFERENCES quiz_sessions(id) ON DELETE CASCADE NOT NULL,
+    max_score FLOAT NOT NULL,
+    score FLOAT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_quiz_result_session_id ON quiz_results(session_id);
+
+CREATE TABLE IF NOT EXISTS answer_scores(
+    id UUID PRIMARY KEY NOT NULL,
+    quiz_result_id UUID REFERENCES quiz_results(id) ON DELETE CASCADE NOT NULL,
+    single_choice_answer_id UUID REFERENCES single_choice_answers(id) NULL,
+    multiple_choice_answer_id UUID REFERENCES multiple_choice_answers(id) NULL,
+    true_or_false_answer_id UUID REFERENCES true_or_false_answers(id) NULL,
+    CHECK (
+        (single_choice_answer_id IS NOT NULL)::int +
+        (multiple_choice_answer_id IS NOT NULL)::int +
+        (true_or_false_answer_id IS NOT NULL)::int = 1
+    ),
+    max_score FLOAT NOT NULL,
+    score FLOAT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_answer_score_quiz_results_id ON answer_scores(quiz_result_id);
+
+CREATE TABLE IF NOT EXISTS learn_list_added_items(
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    quiz_id UUID REFERENCES quizzes(id) ON DELETE CASCADE NOT NULL,
+    UNIQUE (user_id, quiz_id)
+);
+CREATE INDEX IF NOT EXISTS idx_learn_list_added_items_user_id ON learn_list_added_items(user_id);
+
+CREATE TABLE IF NOT EXISTS review_items(
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    single_choice_question_id UUID REFERENCES single_choice_questions(uuid) ON DELETE CASCADE NULL,
+    multiple_choice_question_id UUID REFERENCES multiple_choice_questions(uuid) ON DELETE CASCADE NULL,
+    true_or_false_question_id UUID REFERENCES true_or_false_questions(uuid) ON DELETE CASCADE NULL,
+    CHECK (
+        (single_choice_question_id IS NOT NULL)::int +
+        (multiple_choice_question_id IS NOT NULL)::int +
+        (true_or_false_question_id IS NOT NULL)::int = 1
+    ),
+    ease_factor FLOAT NOT NULL,
+    difficulty FLOAT NOT NULL,
+    streak INT NOT NULL,
+    next_review_date TIMESTAMPTZ NOT NULL,
+    interval_in_minutes INT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_review_items_user_id ON review_items(user_id);
+CREATE INDEX IF NOT EXISTS idx_review_items_single_choice_question_id ON review_items(single_choice_question_id);
+CREATE INDEX IF NOT EXISTS idx_review_items_multiple_choice_question_id ON review_items(multiple_choice_question_id);
+CREATE INDEX IF NOT EXISTS idx_review_items_true_or_false_question_id ON review_items(true_or_false_question_id);
+
+CREATE TABLE IF NOT EXISTS llm_usage(
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    quiz_id UUID REFERENCES quizzes(id) ON DELETE SET NULL,
+    question_type TEXT NOT NULL,
+    prompt_chars INT NOT NULL,
+    latency_ms INT NOT NULL,
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_user_id_created_at ON llm_usage(user_id, created_at);
@@ -0,0 +1,17 @@
+-- Deleting a quiz session or an answer takes the rows that depend on it along
+ALTER TABLE single_choice_answers
+    DROP CONSTRAINT single_choice_answers_session_id_fkey,
+    ADD CONSTRAINT single_choice_answers_session_id_fkey FOREIGN KEY (session_id) REFERENCES quiz_sessions(id) ON DELETE CASCADE;
+ALTER TABLE multiple_choice_answers
+    DROP CONSTRAINT multiple_choice_answers_session_id_fkey,
+    ADD CONSTRAINT multiple_choice_answers_session_id_fkey FOREIGN KEY (session_id) REFERENCES quiz_sessions(id) ON DELETE CASCADE;
+ALTER TABLE true_or_false_answers
+    DROP CONSTRAINT true_or_false_answers_session_id_fkey,
+    ADD CONSTRAINT true_or_false_answers_session_id_fkey FOREIGN KEY (session_id) REFERENCES quiz_sessions(id) ON DELETE CASCADE;
+ALTER TABLE answer_scores
+    DROP CONSTRAINT answer_scores_single_choice_answer_id_fkey,
+    ADD CONSTRAINT answer_scores_single_choice_answer_id_fkey FOREIGN KEY (single_choice_answer_id) REFERENCES single_choice_answers(id) ON DELETE CASCADE,
+    DROP CONSTRAINT answer_scores_multiple_choice_answer_id_fkey,
+    ADD CONSTRAINT answer_scores_multiple_choice_answer_id_fkey FOREIGN KEY (multiple_choice_answer_id) REFERENCES multiple_choice_answers(id) ON DELETE CASCADE,
+    DROP CONSTRAINT answer_scores_true_or_false_answer_id_fkey,
+    ADD CONSTRAINT answer_scores_true_or_false_answer_id_fkey FOREIGN KEY (true_or_false_answer_id) REFERENCES true_or_false_answers(id) ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+ALTER TABLE quiz_results DROP CONSTRAINT quiz_results_session_id_key;
+CREATE INDEX idx_quiz_result_session_id ON quiz_results(session_id);
@@ -0,0 +1,8 @@
+-- A session has at most one result. Submits racing before the submission was
+-- transactional could store several, the most complete one is kept.
+DELETE FROM quiz_results
+WHERE id NOT IN (
+    SELECT DISTINCT ON (session_id) id FROM quiz_results ORDER BY session_id, max_score DESC, id
+);
+DROP INDEX idx_quiz_result_session_id;
+ALTER TABLE quiz_results ADD CONSTRAINT quiz_results_session_id_key UNIQUE (session_id);
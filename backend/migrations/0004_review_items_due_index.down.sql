@@ -0,0 +1 @@
+DROP INDEX idx_review_items_next_review_date;
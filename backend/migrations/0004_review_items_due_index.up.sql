@@ -0,0 +1,2 @@
+-- The metrics count the due review items of every user on each scrape
+CREATE INDEX idx_review_items_next_review_date ON review_items(next_review_date);
@@ -0,0 +1,15 @@
+-- Every change of a question, the snapshot is the question the way the api
+-- returns it. The question types live in separate tables, so question_id has
+-- no foreign key, the queries that delete questions delete their revisions
+-- with them.
+CREATE TABLE question_revisions (
+    id UUID PRIMARY KEY,
+    question_id UUID NOT NULL,
+    question_type TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    snapshot JSONB NOT NULL,
+    restored_from INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (question_id, revision)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE true_or_false_questions DROP COLUMN status, DROP COLUMN published;
+ALTER TABLE multiple_choice_questions DROP COLUMN status, DROP COLUMN published;
+ALTER TABLE single_choice_questions DROP COLUMN status, DROP COLUMN published;
+ALTER TABLE quizzes DROP COLUMN status, DROP COLUMN published_at;
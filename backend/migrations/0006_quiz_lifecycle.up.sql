@@ -0,0 +1,24 @@
+-- Quizzes are written as drafts and learners only get them once published.
+-- The quizzes and questions from before are what learners already use, so
+-- they start out published and approved.
+ALTER TABLE quizzes
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'archived')),
+    ADD COLUMN published_at TIMESTAMPTZ;
+UPDATE quizzes SET status = 'published', published_at = now();
+
+-- Generated questions wait in review as drafts. Published marks the questions
+-- approved when the quiz was last published, the set learners get.
+ALTER TABLE single_choice_questions
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'approved', 'rejected')),
+    ADD COLUMN published BOOLEAN NOT NULL DEFAULT false;
+UPDATE single_choice_questions SET status = 'approved', published = true;
+
+ALTER TABLE multiple_choice_questions
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'approved', 'rejected')),
+    ADD COLUMN published BOOLEAN NOT NULL DEFAULT false;
+UPDATE multiple_choice_questions SET status = 'approved', published = true;
+
+ALTER TABLE true_or_false_questions
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'approved', 'rejected')),
+    ADD COLUMN published BOOLEAN NOT NULL DEFAULT false;
+UPDATE true_or_false_questions SET status = 'approved', published = true;
@@ -0,0 +1,4 @@
+-- The questions published when the session started, the session is scored
+-- against them even when the quiz is published again before the submit.
+-- Sessions from before are scored against the questions published now.
+ALTER TABLE quiz_sessions ADD COLUMN question_ids UUID[];
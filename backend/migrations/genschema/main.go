@@ -0,0 +1,21 @@
+// Writes the up migrations joined together to the given file, run by go generate
+package main
+
+import (
+	"log"
+	"os"
+	"spaced-ace-backend/migrations"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalln("usage: genschema <output file>")
+	}
+	schema, err := migrations.Schema()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := os.WriteFile(os.Args[1], []byte(schema), 0644); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,266 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:generate go run ./genschema ../schema.sql
+
+// Migrations are numbered files, 0003_add_x.up.sql applies the change and
+// 0003_add_x.down.sql reverts it. Applied migrations must never be edited,
+// changes go into a new file.
+//
+//go:embed *.sql
+var files embed.FS
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Held while migrating so instances starting at the same time wait for each other
+const advisoryLockId = 7_351_925_106
+
+var schema = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+`
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Returns the embedded migrations ordered by version
+func All() ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s is not named like 0001_name.up.sql", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Returns the up migrations joined together, the schema sqlc generates the queries from
+func Schema() (string, error) {
+	migrations, err := All()
+	if err != nil {
+		return "", err
+	}
+	builder := strings.Builder{}
+	builder.WriteString("-- Code generated by go generate ./migrations from the up migrations. DO NOT EDIT.\n")
+	for _, migration := range migrations {
+		fmt.Fprintf(&builder, "\n-- %04d_%s\n\n", migration.Version, migration.Name)
+		builder.WriteString(strings.TrimSpace(migration.Up))
+		builder.WriteString("\n")
+	}
+	return builder.String(), nil
+}
+
+// Applies every migration that has not been applied yet and returns how many ran
+func Up(ctx context.Context, pool *pgxpool.Pool) (int, error) {
+	migrations, err := All()
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	err = withLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			if _, done := versions[migration.Version]; done {
+				continue
+			}
+			err := inTx(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Reverts the given number of migrations, newest first, and returns how many were reverted
+func Down(ctx context.Context, pool *pgxpool.Pool, steps int) (int, error) {
+	migrations, err := All()
+	if err != nil {
+		return 0, err
+	}
+	byVersion := map[int64]Migration{}
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+	reverted := 0
+	err = withLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		rows, _ := conn.Query(ctx, "SELECT version FROM schema_migrations ORDER BY version DESC LIMIT $1", steps)
+		versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return err
+		}
+		for _, version := range versions {
+			migration, exists := byVersion[version]
+			if !exists {
+				return fmt.Errorf("migration %d was applied by a newer build and cannot be reverted by this one", version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %04d_%s has no down file", migration.Version, migration.Name)
+			}
+			err := inTx(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version=$1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Returns every embedded migration with the time it was applied, nil when it is pending
+func GetStatus(ctx context.Context, pool *pgxpool.Pool) ([]Status, error) {
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pool.Exec(ctx, schema); err != nil {
+		return nil, err
+	}
+	rows, _ := pool.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	appliedAt := map[int64]time.Time{}
+	var version int64
+	var at time.Time
+	_, err = pgx.ForEachRow(rows, []any{&version, &at}, func() error {
+		appliedAt[version] = at
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		status := Status{Migration: migration}
+		if at, applied := appliedAt[migration.Version]; applied {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Runs f on one connection holding the advisory lock. The lock belongs to the
+// connection, so everything has to run on it and not on the pool.
+func withLock(ctx context.Context, pool *pgxpool.Pool, f func(conn *pgxpool.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", advisoryLockId); err != nil {
+		return err
+	}
+	// Unlocked without the context, a cancelled context must not leave the lock held
+	defer conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", advisoryLockId)
+
+	if _, err := conn.Exec(ctx, schema); err != nil {
+		return err
+	}
+	return f(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]struct{}, error) {
+	rows, _ := conn.Query(ctx, "SELECT version FROM schema_migrations")
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, err
+	}
+	applied := map[int64]struct{}{}
+	for _, version := range versions {
+		applied[version] = struct{}{}
+	}
+	return applied, nil
+}
+
+// Every migration runs in its own transaction together with its bookkeeping
+// row, so a failed migration leaves nothing half applied. The migrations are
+// run without arguments, pgx sends them with the simple protocol which allows
+// several statements in one call.
+func inTx(ctx context.Context, conn *pgxpool.Conn, f func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
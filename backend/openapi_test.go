@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The parts of the specification the routes are compared with
+type specification struct {
+	Paths map[string]map[string]any `yaml:"paths"`
+}
+
+var echoParam = regexp.MustCompile(`:([^/]+)`)
+
+// Every route of the server has to be described in api/openapi.yaml and every
+// operation of the specification has to be served, so the generated client of
+// the frontend cannot drift from the server
+func TestRoutesMatchSpec(t *testing.T) {
+	content, err := os.ReadFile("api/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec specification
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	var specified []string
+	for path, item := range spec.Paths {
+		for method := range item {
+			method = strings.ToUpper(method)
+			if slices.Contains(httpMethods, method) {
+				specified = append(specified, method+" "+path)
+			}
+		}
+	}
+
+	var served []string
+	for _, route := range newServer(newFixture(t).app, unlimited{}).Routes() {
+		// Echo registers its own handlers for unknown routes and methods
+		if !slices.Contains(httpMethods, route.Method) || strings.HasSuffix(route.Path, "*") {
+			continue
+		}
+		served = append(served, route.Method+" "+echoParam.ReplaceAllString(route.Path, "{$1}"))
+	}
+
+	for _, route := range served {
+		if !slices.Contains(specified, route) {
+			t.Errorf("%s is served but not in the specification", route)
+		}
+	}
+	for _, operation := range specified {
+		if !slices.Contains(served, operation) {
+			t.Errorf("%s is in the specification but not served", operation)
+		}
+	}
+}
+
+var httpMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
@@ -0,0 +1,57 @@
+-- Account erasures
+
+-- name: GetErasure :one
+    SELECT * FROM account_erasures WHERE user_id = $1;
+
+-- name: UpsertErasure :one
+    INSERT INTO account_erasures (user_id, token, quiz_handling, transfer_to)
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (user_id) DO UPDATE SET
+        token = EXCLUDED.token,
+        quiz_handling = EXCLUDED.quiz_handling,
+        transfer_to = EXCLUDED.transfer_to,
+        requested_at = now(),
+        confirmed_at = NULL,
+        erase_after = NULL
+    RETURNING *;
+
+-- name: ConfirmErasure :one
+    UPDATE account_erasures
+    SET confirmed_at = now(), erase_after = now() + make_interval(secs => sqlc.arg(grace_period_seconds)::float8)
+    WHERE token = $1 AND confirmed_at IS NULL AND requested_at > sqlc.arg(requested_after)
+    RETURNING *;
+
+-- name: DeleteErasure :execrows
+    DELETE FROM account_erasures WHERE user_id = $1;
+
+-- Locks the row so instances erasing at the same time each take another account
+-- name: LockNextDueErasure :one
+    SELECT * FROM account_erasures
+    WHERE erase_after <= now()
+    ORDER BY erase_after
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED;
+
+-- name: TransferQuizAccesses :exec
+    INSERT INTO quiz_accesses (userid, quizid, roleid)
+    SELECT sqlc.arg(recipient_id)::uuid, id, sqlc.arg(roleid)::smallint FROM quizzes WHERE creatorid = sqlc.arg(user_id)::uuid
+    ON CONFLICT (userid, quizid) DO UPDATE SET roleid = EXCLUDED.roleid;
+
+-- name: TransferQuizzes :exec
+    UPDATE quizzes SET creatorid = sqlc.arg(recipient_id)::uuid WHERE creatorid = sqlc.arg(user_id)::uuid;
+
+-- name: DeleteQuestionRevisionsOfCreator :exec
+    DELETE FROM question_revisions WHERE question_id IN (
+        SELECT uuid FROM single_choice_questions WHERE quizid IN (SELECT quizzes.id FROM quizzes WHERE quizzes.creatorid = sqlc.arg(user_id)::uuid)
+        UNION ALL SELECT uuid FROM multiple_choice_questions WHERE quizid IN (SELECT quizzes.id FROM quizzes WHERE quizzes.creatorid = sqlc.arg(user_id)::uuid)
+        UNION ALL SELECT uuid FROM true_or_false_questions WHERE quizid IN (SELECT quizzes.id FROM quizzes WHERE quizzes.creatorid = sqlc.arg(user_id)::uuid)
+    );
+
+-- name: DeleteQuizzesOfCreator :exec
+    DELETE FROM quizzes WHERE creatorid = $1;
+
+-- name: DeleteLoginFailuresOfUser :exec
+    DELETE FROM login_failures WHERE email = (SELECT lower(trim(email)) FROM users WHERE id = $1);
+
+-- name: DeleteUser :exec
+    DELETE FROM users WHERE id = $1;
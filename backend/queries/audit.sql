@@ -0,0 +1,16 @@
+-- name: CreateAuditEvent :one
+    INSERT INTO audit_events (id, actor_id, impersonator_id, action, target_type, target_id, ip_address, diff)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    RETURNING created_at;
+
+-- Filters that are NULL match every event
+-- name: SearchAuditEvents :many
+    SELECT * FROM audit_events
+    WHERE (sqlc.narg(actor_id)::uuid IS NULL OR actor_id = sqlc.narg(actor_id)::uuid OR impersonator_id = sqlc.narg(actor_id)::uuid)
+        AND (sqlc.narg(action)::text IS NULL OR action = sqlc.narg(action)::text)
+        AND (sqlc.narg(target_type)::text IS NULL OR target_type = sqlc.narg(target_type)::text)
+        AND (sqlc.narg(target_id)::text IS NULL OR target_id = sqlc.narg(target_id)::text)
+        AND (sqlc.narg(since)::timestamptz IS NULL OR created_at >= sqlc.narg(since)::timestamptz)
+        AND (sqlc.narg(until)::timestamptz IS NULL OR created_at < sqlc.narg(until)::timestamptz)
+    ORDER BY created_at DESC, id
+    LIMIT $1 OFFSET $2;
@@ -0,0 +1,190 @@
+-- Users
+
+-- name: GetUserByEmail :one
+    SELECT * FROM users WHERE email = $1;
+
+-- name: GetUserById :one
+    SELECT * FROM users WHERE id = $1;
+
+-- name: GetUserByVerificationToken :one
+    SELECT * FROM users WHERE verification_token = $1;
+
+-- name: CreateUser :exec
+    INSERT INTO users (id, name, email, password, email_verified, verification_token)
+    VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateUser :exec
+    UPDATE users
+    SET name = $2, email = $3, password = $4, email_verified = $5, verification_token = $6
+    WHERE id = $1;
+
+-- name: VerifyEmail :exec
+    UPDATE users SET email_verified = true, verification_token = NULL WHERE id = $1;
+
+-- name: SearchUsers :many
+    SELECT * FROM users
+    WHERE email ILIKE '%' || sqlc.arg(query)::text || '%'
+        OR name ILIKE '%' || sqlc.arg(query)::text || '%'
+    ORDER BY email
+    LIMIT $1 OFFSET $2;
+
+-- name: SetUserRole :exec
+    UPDATE users SET role = $2 WHERE id = $1;
+
+-- name: SetUserDisabled :exec
+    UPDATE users
+    SET disabled_at = CASE WHEN sqlc.arg(disabled)::boolean THEN COALESCE(disabled_at, now()) ELSE NULL END
+    WHERE id = $1;
+
+-- Sessions
+
+-- name: GetSession :one
+    SELECT * FROM sessions WHERE id = $1 AND valid_until > now();
+
+-- name: GetSessionsOfUser :many
+    SELECT * FROM sessions
+    WHERE user_id = $1
+        AND impersonator_id IS NULL
+        AND valid_until > now()
+    ORDER BY last_seen_at DESC;
+
+-- name: CreateSession :one
+    INSERT INTO sessions (id, user_id, valid_until, expires_at, remember_me, user_agent, ip_address, impersonator_id)
+    VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7)
+    RETURNING *;
+
+-- name: RenewSession :exec
+    UPDATE sessions SET valid_until = $2, last_seen_at = now() WHERE id = $1;
+
+-- name: DeleteSession :exec
+    DELETE FROM sessions WHERE id = $1;
+
+-- name: DeleteSessionOfUser :execrows
+    DELETE FROM sessions WHERE public_id = $1 AND user_id = $2;
+
+-- name: DeleteOtherSessionsOfUser :execrows
+    DELETE FROM sessions WHERE user_id = $1 AND id <> $2;
+
+-- name: DeleteSessionsOfUser :exec
+    DELETE FROM sessions WHERE user_id = $1;
+
+-- Identities of social logins
+
+-- name: GetIdentity :one
+    SELECT * FROM identities WHERE provider = $1 AND subject = $2;
+
+-- name: GetIdentitiesOfUser :many
+    SELECT * FROM identities WHERE user_id = $1 ORDER BY created_at;
+
+-- name: CreateIdentity :exec
+    INSERT INTO identities (id, user_id, provider, subject, email, last_login_at)
+    VALUES ($1, $2, $3, $4, $5, now());
+
+-- name: TouchIdentity :exec
+    UPDATE identities SET email = $2, last_login_at = now() WHERE id = $1;
+
+-- name: DeleteIdentity :execrows
+    DELETE FROM identities WHERE id = $1 AND user_id = $2;
+
+-- name: CreateOidcState :exec
+    INSERT INTO oidc_states (state, provider, nonce, code_verifier, valid_until)
+    VALUES ($1, $2, $3, $4, now() + interval '10 minutes');
+
+-- name: ConsumeOidcState :one
+    DELETE FROM oidc_states WHERE state = $1 AND valid_until > now() RETURNING *;
+
+-- Two-factor authentication
+
+-- name: GetTotpCredential :one
+    SELECT * FROM totp_credentials WHERE user_id = $1;
+
+-- name: UpsertUnconfirmedTotpCredential :execrows
+    INSERT INTO totp_credentials (user_id, secret) VALUES ($1, $2)
+    ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0
+    WHERE totp_credentials.confirmed_at IS NULL;
+
+-- name: ConfirmTotpCredential :exec
+    UPDATE totp_credentials SET confirmed_at = now(), last_used_step = $2 WHERE user_id = $1;
+
+-- name: UseTotpStep :execrows
+    UPDATE totp_credentials SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: DeleteTotpCredential :exec
+    DELETE FROM totp_credentials WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+    INSERT INTO recovery_codes (id, user_id, code_hash) VALUES (gen_random_uuid(), $1, $2);
+
+-- name: UseRecoveryCode :execrows
+    UPDATE recovery_codes SET used_at = now()
+    WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+    SELECT count(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodes :exec
+    DELETE FROM recovery_codes WHERE user_id = $1;
+
+-- name: CreateLoginChallenge :one
+    INSERT INTO login_challenges (id, user_id, valid_until, remember_me)
+    VALUES (gen_random_uuid(), $1, now() + interval '5 minutes', $2)
+    RETURNING id;
+
+-- Takes one of the attempts before the code is checked, so codes sent at once
+-- cannot try more than the allowed number. No row once they are used up.
+-- name: ClaimLoginChallengeAttempt :one
+    UPDATE login_challenges SET attempts = attempts + 1
+    WHERE id = $1 AND valid_until > now() AND attempts < sqlc.arg(max_attempts)::integer
+    RETURNING *;
+
+-- name: DeleteLoginChallenge :exec
+    DELETE FROM login_challenges WHERE id = $1;
+
+-- name: DeleteLoginChallengesOfUser :exec
+    DELETE FROM login_challenges WHERE user_id = $1;
+
+-- Personal access tokens
+
+-- name: CreateApiToken :exec
+    INSERT INTO api_tokens (id, user_id, name, token_hash, token_prefix, scopes, expires_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetActiveApiTokenByHash :one
+    SELECT * FROM api_tokens
+    WHERE token_hash = $1
+        AND revoked_at IS NULL
+        AND (expires_at IS NULL OR expires_at > now());
+
+-- name: GetApiTokensOfUser :many
+    SELECT * FROM api_tokens
+    WHERE user_id = $1 AND revoked_at IS NULL
+    ORDER BY created_at DESC;
+
+-- name: TouchApiToken :exec
+    UPDATE api_tokens SET last_used_at = now()
+    WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute');
+
+-- name: RevokeApiToken :execrows
+    UPDATE api_tokens SET revoked_at = now()
+    WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+-- Login lockout
+
+-- name: GetLoginFailure :one
+    SELECT * FROM login_failures WHERE email = $1;
+
+-- name: RecordLoginFailure :one
+    INSERT INTO login_failures (email, failures, last_failure_at) VALUES ($1, 1, now())
+    ON CONFLICT (email) DO UPDATE SET
+        failures = CASE
+            WHEN login_failures.last_failure_at < now() - make_interval(secs => sqlc.arg(window_seconds)::float8) THEN 1
+            ELSE login_failures.failures + 1
+        END,
+        last_failure_at = now()
+    RETURNING *;
+
+-- name: LockLogin :exec
+    UPDATE login_failures SET locked_until = $2 WHERE email = $1;
+
+-- name: DeleteLoginFailures :exec
+    DELETE FROM login_failures WHERE email = $1;
@@ -0,0 +1,137 @@
+-- Single choice questions
+
+-- name: CreateSingleChoiceQuestion :exec
+    INSERT INTO single_choice_questions (uuid, quizid, question, answers, correct_answer, status)
+    VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetSingleChoiceQuestions :many
+    SELECT * FROM single_choice_questions WHERE quizid = $1;
+
+-- name: GetPublishedSingleChoiceQuestions :many
+    SELECT * FROM single_choice_questions WHERE quizid = $1 AND published;
+
+-- name: GetSingleChoiceQuestion :one
+    SELECT * FROM single_choice_questions WHERE uuid = $1;
+
+-- name: UpdateSingleChoiceQuestion :exec
+    UPDATE single_choice_questions SET question = $2, answers = $3, correct_answer = $4 WHERE uuid = $1;
+
+-- Leaving approved takes the question out of the published set right away
+-- name: UpdateSingleChoiceQuestionStatus :exec
+    UPDATE single_choice_questions
+    SET status = sqlc.arg(status)::text, published = published AND sqlc.arg(status)::text = 'approved'
+    WHERE uuid = $1;
+
+-- name: DeleteSingleChoiceQuestion :exec
+    DELETE FROM single_choice_questions WHERE uuid = $1;
+
+-- Multiple choice questions
+
+-- name: CreateMultipleChoiceQuestion :exec
+    INSERT INTO multiple_choice_questions (uuid, quizid, question, answers, correct_answers, status)
+    VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetMultipleChoiceQuestions :many
+    SELECT * FROM multiple_choice_questions WHERE quizid = $1;
+
+-- name: GetPublishedMultipleChoiceQuestions :many
+    SELECT * FROM multiple_choice_questions WHERE quizid = $1 AND published;
+
+-- name: GetMultipleChoiceQuestion :one
+    SELECT * FROM multiple_choice_questions WHERE uuid = $1;
+
+-- name: UpdateMultipleChoiceQuestion :exec
+    UPDATE multiple_choice_questions SET question = $2, answers = $3, correct_answers = $4 WHERE uuid = $1;
+
+-- Leaving approved takes the question out of the published set right away
+-- name: UpdateMultipleChoiceQuestionStatus :exec
+    UPDATE multiple_choice_questions
+    SET status = sqlc.arg(status)::text, published = published AND sqlc.arg(status)::text = 'approved'
+    WHERE uuid = $1;
+
+-- name: DeleteMultipleChoiceQuestion :exec
+    DELETE FROM multiple_choice_questions WHERE uuid = $1;
+
+-- True or false questions
+
+-- name: CreateTrueOrFalseQuestion :exec
+    INSERT INTO true_or_false_questions (uuid, quizid, question, correct_answer, status)
+    VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetTrueOrFalseQuestions :many
+    SELECT * FROM true_or_false_questions WHERE quizid = $1;
+
+-- name: GetPublishedTrueOrFalseQuestions :many
+    SELECT * FROM true_or_false_questions WHERE quizid = $1 AND published;
+
+-- name: GetTrueOrFalseQuestion :one
+    SELECT * FROM true_or_false_questions WHERE uuid = $1;
+
+-- name: UpdateTrueOrFalseQuestion :exec
+    UPDATE true_or_false_questions SET question = $2, correct_answer = $3 WHERE uuid = $1;
+
+-- Leaving approved takes the question out of the published set right away
+-- name: UpdateTrueOrFalseQuestionStatus :exec
+    UPDATE true_or_false_questions
+    SET status = sqlc.arg(status)::text, published = published AND sqlc.arg(status)::text = 'approved'
+    WHERE uuid = $1;
+
+-- name: DeleteTrueOrFalseQuestion :exec
+    DELETE FROM true_or_false_questions WHERE uuid = $1;
+
+-- Publishing
+
+-- The approved questions become the ones learners get, the others leave the set
+-- name: PublishQuestionsOfQuiz :exec
+    WITH single_choice AS (
+        UPDATE single_choice_questions SET published = status = 'approved' WHERE quizid = sqlc.arg(quiz_id)::uuid
+    ), multiple_choice AS (
+        UPDATE multiple_choice_questions SET published = status = 'approved' WHERE quizid = sqlc.arg(quiz_id)::uuid
+    )
+    UPDATE true_or_false_questions SET published = status = 'approved' WHERE quizid = sqlc.arg(quiz_id)::uuid;
+
+-- Revisions
+
+-- The revisions of a question are numbered one after the other, the question
+-- row is locked for the transaction before the next number is taken
+-- name: LockSingleChoiceQuestion :exec
+    SELECT uuid FROM single_choice_questions WHERE uuid = $1 FOR UPDATE;
+
+-- name: LockMultipleChoiceQuestion :exec
+    SELECT uuid FROM multiple_choice_questions WHERE uuid = $1 FOR UPDATE;
+
+-- name: LockTrueOrFalseQuestion :exec
+    SELECT uuid FROM true_or_false_questions WHERE uuid = $1 FOR UPDATE;
+
+-- The revision is one after the last one of the question, lock the question first
+-- name: CreateQuestionRevision :one
+    INSERT INTO question_revisions (id, question_id, question_type, revision, author_id, snapshot, restored_from)
+    SELECT sqlc.arg(id)::uuid, sqlc.arg(question_id)::uuid, sqlc.arg(question_type)::text, coalesce(max(revision), 0) + 1,
+        sqlc.narg(author_id)::uuid, sqlc.arg(snapshot)::jsonb, sqlc.narg(restored_from)::integer
+    FROM question_revisions
+    WHERE question_id = sqlc.arg(question_id)::uuid
+    RETURNING *;
+
+-- name: DeleteQuestionRevisions :exec
+    DELETE FROM question_revisions WHERE question_id = $1;
+
+-- The questions are deleted with the quiz, so this runs before
+-- name: DeleteQuestionRevisionsOfQuiz :exec
+    DELETE FROM question_revisions WHERE question_id IN (
+        SELECT uuid FROM single_choice_questions WHERE quizid = sqlc.arg(quiz_id)::uuid
+        UNION ALL SELECT uuid FROM multiple_choice_questions WHERE quizid = sqlc.arg(quiz_id)::uuid
+        UNION ALL SELECT uuid FROM true_or_false_questions WHERE quizid = sqlc.arg(quiz_id)::uuid
+    );
+
+-- name: GetQuestionRevisions :many
+    SELECT question_revisions.*, users.name AS author_name
+    FROM question_revisions
+    LEFT JOIN users ON users.id = question_revisions.author_id
+    WHERE question_id = $1
+    ORDER BY revision DESC;
+
+-- name: GetQuestionRevision :one
+    SELECT question_revisions.*, users.name AS author_name
+    FROM question_revisions
+    LEFT JOIN users ON users.id = question_revisions.author_id
+    WHERE question_id = $1 AND revision = $2;
@@ -0,0 +1,59 @@
+-- Quizzes
+
+-- name: CreateQuiz :one
+    -- The quiz is never left without its owner access
+    WITH quiz AS (
+        INSERT INTO quizzes (id, name, creatorid, description)
+        VALUES (gen_random_uuid(), $1, $2, $3)
+        RETURNING *
+    ), owner_access AS (
+        INSERT INTO quiz_accesses (userid, quizid, roleid)
+        SELECT creatorid, id, sqlc.arg(owner_roleid) FROM quiz
+    )
+    SELECT * FROM quiz;
+
+-- name: GetQuizById :one
+    SELECT * FROM quizzes WHERE id = $1;
+
+-- name: UpdateQuiz :exec
+    UPDATE quizzes
+    SET name = COALESCE(sqlc.narg(name), name), description = COALESCE(sqlc.narg(description), description)
+    WHERE id = $1;
+
+-- name: UpdateQuizStatus :exec
+    UPDATE quizzes
+    SET status = sqlc.arg(status)::text,
+        published_at = CASE WHEN sqlc.arg(status)::text = 'published' THEN now() ELSE published_at END
+    WHERE id = $1;
+
+-- name: DeleteQuiz :exec
+    DELETE FROM quizzes WHERE id = $1;
+
+-- name: SearchQuizzes :many
+    SELECT q.*, u.name AS creator_name, u.email AS creator_email
+    FROM quizzes q
+    LEFT JOIN users u ON u.id = q.creatorid
+    WHERE q.name ILIKE '%' || sqlc.arg(query)::text || '%'
+        OR u.email ILIKE '%' || sqlc.arg(query)::text || '%'
+    ORDER BY q.name
+    LIMIT $1 OFFSET $2;
+
+-- Quiz accesses
+
+-- name: CreateQuizAccess :exec
+    INSERT INTO quiz_accesses (userid, quizid, roleid) VALUES ($1, $2, $3);
+
+-- name: GetQuizAccess :one
+    SELECT roleid FROM quiz_accesses WHERE userid = $1 AND quizid = $2 LIMIT 1;
+
+-- name: GetQuizAccessesOfUser :many
+    SELECT * FROM quiz_accesses WHERE userid = $1;
+
+-- name: GetQuizAccesses :many
+    SELECT roleid FROM quiz_accesses WHERE quizid = $1;
+
+-- name: UpdateQuizAccess :exec
+    UPDATE quiz_accesses SET roleid = $3 WHERE userid = $1 AND quizid = $2;
+
+-- name: DeleteQuizAccess :exec
+    DELETE FROM quiz_accesses WHERE userid = $1 AND quizid = $2;
@@ -0,0 +1,9 @@
+-- name: EnsureRateLimitBucket :exec
+    INSERT INTO rate_limit_buckets (key, tokens, updated_at) VALUES ($1, $2, now())
+    ON CONFLICT (key) DO NOTHING;
+
+-- name: LockRateLimitBucket :one
+    SELECT tokens, updated_at, now()::timestamptz AS now FROM rate_limit_buckets WHERE key = $1 FOR UPDATE;
+
+-- name: UpdateRateLimitBucket :exec
+    UPDATE rate_limit_buckets SET tokens = $2, updated_at = $3 WHERE key = $1;
@@ -25,10 +25,13 @@
     ) AS exists;
 
 -- name: CreateQuizSession :one
-    INSERT INTO quiz_sessions (id, user_id, quiz_id, started_at, finished_at, closes_at)
-    VALUES ($1, $2, $3, $4, NULL, $5)
+    INSERT INTO quiz_sessions (id, user_id, quiz_id, started_at, finished_at, closes_at, question_ids)
+    VALUES ($1, $2, $3, $4, NULL, $5, sqlc.arg(question_ids)::uuid[])
     RETURNING *;
 
+-- name: LockQuizSession :one
+    SELECT * FROM quiz_sessions WHERE id = $1 FOR UPDATE;
+
 -- name: UpdateQuizSessionFinishedAt :one
     UPDATE quiz_sessions
     SET finished_at = $2
@@ -227,7 +230,9 @@
         OR q.id = TQC.quizid
     )
     WHERE true
-      AND user_id = $1;
+      AND user_id = $1
+      -- Questions left out when the quiz was last published keep their items for later
+      AND (SQC.published OR MQC.published OR TQC.published);
 
 -- name: GetReviewItem :one
     SELECT
@@ -248,7 +253,8 @@
        OR q.id = MQC.quizid
        OR q.id = TQC.quizid
     )
-    WHERE review_items.id = $1;
+    WHERE review_items.id = $1
+        AND (SQC.published OR MQC.published OR TQC.published);
 
 -- name: CreateSingleChoiceReviewItem :one
     INSERT INTO review_items(id, user_id, single_choice_question_id, ease_factor, difficulty, streak, next_review_date, interval_in_minutes)
@@ -264,6 +270,30 @@
     VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     RETURNING id;
 
+-- Gives every learner with the quiz in their learn list the review items of
+-- the published questions they have none of yet
+-- name: CreateMissingReviewItemsOfQuiz :execrows
+    WITH learners AS (
+        SELECT user_id FROM learn_list_added_items WHERE quiz_id = sqlc.arg(quiz_id)::uuid
+    ), single_choice AS (
+        INSERT INTO review_items(id, user_id, single_choice_question_id, ease_factor, difficulty, streak, next_review_date, interval_in_minutes)
+        SELECT gen_random_uuid(), learners.user_id, Q.uuid, $1, $2, $3, $4, $5
+        FROM learners, single_choice_questions Q
+        WHERE Q.quizid = sqlc.arg(quiz_id)::uuid AND Q.published
+            AND NOT EXISTS (SELECT 1 FROM review_items R WHERE R.user_id = learners.user_id AND R.single_choice_question_id = Q.uuid)
+    ), multiple_choice AS (
+        INSERT INTO review_items(id, user_id, multiple_choice_question_id, ease_factor, difficulty, streak, next_review_date, interval_in_minutes)
+        SELECT gen_random_uuid(), learners.user_id, Q.uuid, $1, $2, $3, $4, $5
+        FROM learners, multiple_choice_questions Q
+        WHERE Q.quizid = sqlc.arg(quiz_id)::uuid AND Q.published
+            AND NOT EXISTS (SELECT 1 FROM review_items R WHERE R.user_id = learners.user_id AND R.multiple_choice_question_id = Q.uuid)
+    )
+    INSERT INTO review_items(id, user_id, true_or_false_question_id, ease_factor, difficulty, streak, next_review_date, interval_in_minutes)
+    SELECT gen_random_uuid(), learners.user_id, Q.uuid, $1, $2, $3, $4, $5
+    FROM learners, true_or_false_questions Q
+    WHERE Q.quizid = sqlc.arg(quiz_id)::uuid AND Q.published
+        AND NOT EXISTS (SELECT 1 FROM review_items R WHERE R.user_id = learners.user_id AND R.true_or_false_question_id = Q.uuid);
+
 -- name: DeleteReviewItem :exec
     DELETE FROM review_items
     WHERE true
@@ -295,6 +325,15 @@
     WHERE true
         AND id = $1;
 
+-- Starts the review items of the question over, for when its correct answer changed
+-- name: ResetReviewItemsOfQuestion :execrows
+    UPDATE review_items
+    SET ease_factor = $1, difficulty = $2, streak = $3, next_review_date = $4, interval_in_minutes = $5
+    WHERE false
+        OR single_choice_question_id = sqlc.arg(question_id)::uuid
+        OR multiple_choice_question_id = sqlc.arg(question_id)::uuid
+        OR true_or_false_question_id = sqlc.arg(question_id)::uuid;
+
 -- name: GetQuizOptions :many
     SELECT
         Q.id as quiz_id,
@@ -307,7 +346,7 @@
 
 -- name: GetReviewItemCounts :one
     SELECT
-        count(id) as total,
+        count(review_items.id) as total,
         count(
             CASE
                 WHEN true
@@ -317,5 +356,41 @@
             END
         ) as due_to_review
     FROM review_items
+    LEFT JOIN single_choice_questions SQC ON review_items.single_choice_question_id = SQC.uuid
+    LEFT JOIN multiple_choice_questions MQC ON review_items.multiple_choice_question_id = MQC.uuid
+    LEFT JOIN true_or_false_questions TQC ON review_items.true_or_false_question_id = TQC.uuid
+    WHERE true
+        AND review_items.user_id = $1
+        AND (SQC.published OR MQC.published OR TQC.published);
+
+-- Only the items learners can review now, like GetReviewItems
+-- name: CountDueReviewItems :one
+    SELECT count(*)
+    FROM review_items
+    LEFT JOIN single_choice_questions SQC ON review_items.single_choice_question_id = SQC.uuid
+    LEFT JOIN multiple_choice_questions MQC ON review_items.multiple_choice_question_id = MQC.uuid
+    LEFT JOIN true_or_false_questions TQC ON review_items.true_or_false_question_id = TQC.uuid
+    WHERE next_review_date < now()
+        AND (SQC.published OR MQC.published OR TQC.published);
+
+-- LLM usage
+
+-- A reserved call is stored again with its outcome, it keeps the time of the reservation
+-- name: RecordLlmUsage :one
+    INSERT INTO llm_usage (id, user_id, quiz_id, question_type, prompt_chars, latency_ms, success, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    ON CONFLICT (id) DO UPDATE SET prompt_chars = EXCLUDED.prompt_chars, latency_ms = EXCLUDED.latency_ms, success = EXCLUDED.success
+    RETURNING *;
+
+-- Held while a call is reserved, so concurrent generations of the user count
+-- each other. The two key form keeps it apart from the lock of the migrations.
+-- name: LockLlmUsageOfUser :exec
+    SELECT pg_advisory_xact_lock(1, hashtext(sqlc.arg(user_id)::text));
+
+-- name: CountSuccessfulLlmUsageSince :one
+    SELECT count(*)
+    FROM llm_usage
     WHERE true
-        AND review_items.user_id = $1;
\ No newline at end of file
+        AND user_id = $1
+        AND success
+        AND created_at >= $2;
@@ -1,155 +1,283 @@
 package question
 
 import (
-	_ "fmt"
-	"github.com/lib/pq"
+	"context"
 	"spaced-ace-backend/api/models"
-	"spaced-ace-backend/utils"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
 )
 
-var schema = `
-CREATE TABLE IF NOT EXISTS multiple_choice_questions (
-	uuid UUID PRIMARY KEY,
-	quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
-	question TEXT,
-	answers TEXT[4],
-	correct_answers CHAR[]
-);
-CREATE TABLE IF NOT EXISTS single_choice_questions (
-	uuid UUID PRIMARY KEY,
-	quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
-	question TEXT,
-	answers TEXT[4],
-	correct_answer CHAR
-);
-CREATE TABLE IF NOT EXISTS true_or_false_questions (
-	uuid UUID PRIMARY KEY,
-	quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
-	question TEXT,
-	correct_answer BOOLEAN
-);
-`
-
 type DBMultipleChoiceQuestion struct {
-	UUID           string         `db:"uuid"`
-	QuizID         string         `db:"quizid"`
-	Question       string         `db:"question"`
-	Answers        pq.StringArray `db:"answers"`
-	CorrectAnswers pq.StringArray `db:"correct_answers"`
+	UUID           string
+	QuizID         string
+	Question       string
+	Answers        []string
+	CorrectAnswers []string
+	Status         string
+	Published      bool
 }
 type DBSingleChoiceQuestion struct {
-	UUID          string         `db:"uuid"`
-	QuizID        string         `db:"quizid"`
-	Question      string         `db:"question"`
-	Answers       pq.StringArray `db:"answers"`
-	CorrectAnswer string         `db:"correct_answer"`
+	UUID          string
+	QuizID        string
+	Question      string
+	Answers       []string
+	CorrectAnswer string
+	Status        string
+	Published     bool
 }
 type DBTrueOrFalseQuestion struct {
-	UUID          string `db:"uuid"`
-	QuizID        string `db:"quizid"`
-	Question      string `db:"question"`
-	CorrectAnswer bool   `db:"correct_answer"`
+	UUID          string
+	QuizID        string
+	Question      string
+	CorrectAnswer bool
+	Status        string
+	Published     bool
+}
+
+// The review states of a question. Generated questions start as drafts, only
+// approved ones are published with the quiz.
+const (
+	STATUS_DRAFT    = "draft"
+	STATUS_APPROVED = "approved"
+	STATUS_REJECTED = "rejected"
+)
+
+// The questions of the quizzes. PostgresRepository is the one the server uses,
+// the handler tests use an in-memory fake.
+type Repository interface {
+	CreateMultipleChoiceQuestion(ctx context.Context, question *DBMultipleChoiceQuestion) error
+	CreateSingleChoiceQuestion(ctx context.Context, question *DBSingleChoiceQuestion) error
+	CreateTrueOrFalseQuestion(ctx context.Context, question *DBTrueOrFalseQuestion) error
+	GetMultipleChoiceQuestions(ctx context.Context, quizID string) ([]DBMultipleChoiceQuestion, error)
+	GetMultipleChoiceQuestion(ctx context.Context, uuid string) (DBMultipleChoiceQuestion, error)
+	GetSingleChoiceQuestions(ctx context.Context, quizID string) ([]DBSingleChoiceQuestion, error)
+	GetSingleChoiceQuestion(ctx context.Context, id string) (DBSingleChoiceQuestion, error)
+	GetTrueOrFalseQuestions(ctx context.Context, quizID string) ([]DBTrueOrFalseQuestion, error)
+	GetTrueOrFalseQuestion(ctx context.Context, id string) (DBTrueOrFalseQuestion, error)
+	// The questions learners get, the ones approved when the quiz was last published
+	GetPublishedMultipleChoiceQuestions(ctx context.Context, quizID string) ([]DBMultipleChoiceQuestion, error)
+	GetPublishedSingleChoiceQuestions(ctx context.Context, quizID string) ([]DBSingleChoiceQuestion, error)
+	GetPublishedTrueOrFalseQuestions(ctx context.Context, quizID string) ([]DBTrueOrFalseQuestion, error)
+	DeleteMultipleChoiceQuestion(ctx context.Context, uuid string) error
+	DeleteSingleChoiceQuestion(ctx context.Context, uuid string) error
+	DeleteTrueOrFalseQuestion(ctx context.Context, uuid string) error
+	UpdateMultipleChoiceQuestion(ctx context.Context, question *DBMultipleChoiceQuestion) error
+	UpdateSingleChoiceQuestion(ctx context.Context, question *DBSingleChoiceQuestion) error
+	UpdateTrueOrFalseQuestion(ctx context.Context, question *DBTrueOrFalseQuestion) error
+	// Questions that are no longer approved leave the published set at once,
+	// approved ones join it when the quiz is published again
+	UpdateMultipleChoiceQuestionStatus(ctx context.Context, uuid string, status string) error
+	UpdateSingleChoiceQuestionStatus(ctx context.Context, uuid string, status string) error
+	UpdateTrueOrFalseQuestionStatus(ctx context.Context, uuid string, status string) error
+	// Makes the approved questions of the quiz the published ones
+	PublishQuestionsOfQuiz(ctx context.Context, quizID string) error
+}
+
+type PostgresRepository struct {
+	queries *db.Queries
 }
 
-func InitDb() {
-	utils.DB.MustExec(schema)
+func NewPostgresRepository(queries *db.Queries) *PostgresRepository {
+	return &PostgresRepository{queries: queries}
 }
 
-func CreateMultipleChoiceQuestion(question *DBMultipleChoiceQuestion) error {
-	_, err := utils.DB.Exec(
-		"INSERT INTO multiple_choice_questions (uuid, quizid, question, answers, correct_answers) VALUES ($1,$2,$3,$4,$5)",
-		question.UUID, question.QuizID, question.Question, question.Answers, question.CorrectAnswers,
-	)
-	return err
+func (r *PostgresRepository) CreateMultipleChoiceQuestion(ctx context.Context, question *DBMultipleChoiceQuestion) error {
+	return r.queries.CreateMultipleChoiceQuestion(ctx, db.CreateMultipleChoiceQuestionParams{
+		Uuid:           question.UUID,
+		Quizid:         &question.QuizID,
+		Question:       &question.Question,
+		Answers:        question.Answers,
+		CorrectAnswers: question.CorrectAnswers,
+		Status:         question.Status,
+	})
 }
-func CreateSingleChoiceQuestion(question *DBSingleChoiceQuestion) error {
-	_, err := utils.DB.Exec(
-		"INSERT INTO single_choice_questions (uuid, quizid, question, answers, correct_answer) VALUES ($1,$2,$3,$4,$5)",
-		question.UUID, question.QuizID, question.Question, question.Answers, question.CorrectAnswer,
-	)
-	return err
+
+func (r *PostgresRepository) CreateSingleChoiceQuestion(ctx context.Context, question *DBSingleChoiceQuestion) error {
+	return r.queries.CreateSingleChoiceQuestion(ctx, db.CreateSingleChoiceQuestionParams{
+		Uuid:          question.UUID,
+		Quizid:        &question.QuizID,
+		Question:      &question.Question,
+		Answers:       question.Answers,
+		CorrectAnswer: &question.CorrectAnswer,
+		Status:        question.Status,
+	})
 }
-func CreateTrueOrFalseQuestion(question *DBTrueOrFalseQuestion) error {
-	_, err := utils.DB.Exec(
-		"INSERT INTO true_or_false_questions (uuid, quizid, question, correct_answer) VALUES ($1,$2,$3,$4)",
-		question.UUID, question.QuizID, question.Question, question.CorrectAnswer,
-	)
-	return err
+
+func (r *PostgresRepository) CreateTrueOrFalseQuestion(ctx context.Context, question *DBTrueOrFalseQuestion) error {
+	return r.queries.CreateTrueOrFalseQuestion(ctx, db.CreateTrueOrFalseQuestionParams{
+		Uuid:          question.UUID,
+		Quizid:        &question.QuizID,
+		Question:      &question.Question,
+		CorrectAnswer: &question.CorrectAnswer,
+		Status:        question.Status,
+	})
 }
 
-func GetMultipleChoiceQuestions(quizID string) ([]DBMultipleChoiceQuestion, error) {
+func (r *PostgresRepository) GetMultipleChoiceQuestions(ctx context.Context, quizID string) ([]DBMultipleChoiceQuestion, error) {
+	rows, err := r.queries.GetMultipleChoiceQuestions(ctx, &quizID)
 	questions := []DBMultipleChoiceQuestion{}
-	err := utils.DB.Select(&questions, "SELECT * FROM multiple_choice_questions WHERE quizid=$1", quizID)
+	for _, row := range rows {
+		questions = append(questions, mapMultipleChoiceQuestion(row))
+	}
 	return questions, err
 }
 
-func GetMultipleChoiceQuestion(uuid string) (DBMultipleChoiceQuestion, error) {
-	question := DBMultipleChoiceQuestion{}
-	err := utils.DB.Get(&question, "SELECT * FROM multiple_choice_questions WHERE uuid=$1", uuid)
-	return question, err
+func (r *PostgresRepository) GetMultipleChoiceQuestion(ctx context.Context, uuid string) (DBMultipleChoiceQuestion, error) {
+	question, err := r.queries.GetMultipleChoiceQuestion(ctx, uuid)
+	return mapMultipleChoiceQuestion(question), err
 }
 
-func GetSingleChoiceQuestions(quizID string) ([]DBSingleChoiceQuestion, error) {
+func (r *PostgresRepository) GetSingleChoiceQuestions(ctx context.Context, quizID string) ([]DBSingleChoiceQuestion, error) {
+	rows, err := r.queries.GetSingleChoiceQuestions(ctx, &quizID)
 	questions := []DBSingleChoiceQuestion{}
-	err := utils.DB.Select(&questions, "SELECT * FROM single_choice_questions WHERE quizid=$1", quizID)
+	for _, row := range rows {
+		questions = append(questions, mapSingleChoiceQuestion(row))
+	}
 	return questions, err
 }
 
-func GetSingleChoiceQuestion(id string) (DBSingleChoiceQuestion, error) {
-	question := DBSingleChoiceQuestion{}
-	err := utils.DB.Get(&question, "SELECT * FROM single_choice_questions WHERE uuid=$1", id)
-	return question, err
+func (r *PostgresRepository) GetSingleChoiceQuestion(ctx context.Context, id string) (DBSingleChoiceQuestion, error) {
+	question, err := r.queries.GetSingleChoiceQuestion(ctx, id)
+	return mapSingleChoiceQuestion(question), err
 }
 
-func GetTrueOrFalseQuestions(quizID string) ([]DBTrueOrFalseQuestion, error) {
+func (r *PostgresRepository) GetTrueOrFalseQuestions(ctx context.Context, quizID string) ([]DBTrueOrFalseQuestion, error) {
+	rows, err := r.queries.GetTrueOrFalseQuestions(ctx, &quizID)
 	questions := []DBTrueOrFalseQuestion{}
-	err := utils.DB.Select(&questions, "SELECT * FROM true_or_false_questions WHERE quizid=$1", quizID)
+	for _, row := range rows {
+		questions = append(questions, mapTrueOrFalseQuestion(row))
+	}
 	return questions, err
 }
 
-func GetTrueOrFalseQuestion(id string) (DBTrueOrFalseQuestion, error) {
-	question := DBTrueOrFalseQuestion{}
-	err := utils.DB.Get(&question, "SELECT * FROM true_or_false_questions WHERE uuid=$1", id)
-	return question, err
+func (r *PostgresRepository) GetTrueOrFalseQuestion(ctx context.Context, id string) (DBTrueOrFalseQuestion, error) {
+	question, err := r.queries.GetTrueOrFalseQuestion(ctx, id)
+	return mapTrueOrFalseQuestion(question), err
 }
 
-func DeleteMultipleChoiceQuestion(uuid string) error {
-	_, err := utils.DB.Exec("DELETE FROM multiple_choice_questions WHERE uuid=$1", uuid)
-	return err
+func (r *PostgresRepository) GetPublishedMultipleChoiceQuestions(ctx context.Context, quizID string) ([]DBMultipleChoiceQuestion, error) {
+	rows, err := r.queries.GetPublishedMultipleChoiceQuestions(ctx, &quizID)
+	questions := []DBMultipleChoiceQuestion{}
+	for _, row := range rows {
+		questions = append(questions, mapMultipleChoiceQuestion(row))
+	}
+	return questions, err
+}
+
+func (r *PostgresRepository) GetPublishedSingleChoiceQuestions(ctx context.Context, quizID string) ([]DBSingleChoiceQuestion, error) {
+	rows, err := r.queries.GetPublishedSingleChoiceQuestions(ctx, &quizID)
+	questions := []DBSingleChoiceQuestion{}
+	for _, row := range rows {
+		questions = append(questions, mapSingleChoiceQuestion(row))
+	}
+	return questions, err
 }
 
-func DeleteSingleChoiceQuestion(uuid string) error {
-	_, err := utils.DB.Exec("DELETE FROM single_choice_questions WHERE uuid=$1", uuid)
-	return err
+func (r *PostgresRepository) GetPublishedTrueOrFalseQuestions(ctx context.Context, quizID string) ([]DBTrueOrFalseQuestion, error) {
+	rows, err := r.queries.GetPublishedTrueOrFalseQuestions(ctx, &quizID)
+	questions := []DBTrueOrFalseQuestion{}
+	for _, row := range rows {
+		questions = append(questions, mapTrueOrFalseQuestion(row))
+	}
+	return questions, err
 }
 
-func DeleteTrueOrFalseQuestion(uuid string) error {
-	_, err := utils.DB.Exec("DELETE FROM true_or_false_questions WHERE uuid=$1", uuid)
-	return err
+func (r *PostgresRepository) DeleteMultipleChoiceQuestion(ctx context.Context, uuid string) error {
+	return r.queries.DeleteMultipleChoiceQuestion(ctx, uuid)
 }
 
-func UpdateMultipleChoiceQuestion(question *DBMultipleChoiceQuestion) error {
-	_, err := utils.DB.Exec(
-		"UPDATE multiple_choice_questions SET question=$1, answers=$2, correct_answers=$3 WHERE uuid=$4",
-		question.Question, question.Answers, question.CorrectAnswers, question.UUID,
-	)
-	return err
+func (r *PostgresRepository) DeleteSingleChoiceQuestion(ctx context.Context, uuid string) error {
+	return r.queries.DeleteSingleChoiceQuestion(ctx, uuid)
 }
 
-func UpdateSingleChoiceQuestion(question *DBSingleChoiceQuestion) error {
-	_, err := utils.DB.Exec(
-		"UPDATE single_choice_questions SET question=$1, answers=$2, correct_answer=$3 WHERE uuid=$4",
-		question.Question, question.Answers, question.CorrectAnswer, question.UUID,
-	)
-	return err
+func (r *PostgresRepository) DeleteTrueOrFalseQuestion(ctx context.Context, uuid string) error {
+	return r.queries.DeleteTrueOrFalseQuestion(ctx, uuid)
 }
 
-func UpdateTrueOrFalseQuestion(question *DBTrueOrFalseQuestion) error {
-	_, err := utils.DB.Exec(
-		"UPDATE true_or_false_questions SET question=$1, correct_answer=$2 WHERE uuid=$3",
-		question.Question, question.CorrectAnswer, question.UUID,
-	)
-	return err
+func (r *PostgresRepository) UpdateMultipleChoiceQuestion(ctx context.Context, question *DBMultipleChoiceQuestion) error {
+	return r.queries.UpdateMultipleChoiceQuestion(ctx, db.UpdateMultipleChoiceQuestionParams{
+		Uuid:           question.UUID,
+		Question:       &question.Question,
+		Answers:        question.Answers,
+		CorrectAnswers: question.CorrectAnswers,
+	})
+}
+
+func (r *PostgresRepository) UpdateSingleChoiceQuestion(ctx context.Context, question *DBSingleChoiceQuestion) error {
+	return r.queries.UpdateSingleChoiceQuestion(ctx, db.UpdateSingleChoiceQuestionParams{
+		Uuid:          question.UUID,
+		Question:      &question.Question,
+		Answers:       question.Answers,
+		CorrectAnswer: &question.CorrectAnswer,
+	})
+}
+
+func (r *PostgresRepository) UpdateTrueOrFalseQuestion(ctx context.Context, question *DBTrueOrFalseQuestion) error {
+	return r.queries.UpdateTrueOrFalseQuestion(ctx, db.UpdateTrueOrFalseQuestionParams{
+		Uuid:          question.UUID,
+		Question:      &question.Question,
+		CorrectAnswer: &question.CorrectAnswer,
+	})
+}
+
+func (r *PostgresRepository) UpdateMultipleChoiceQuestionStatus(ctx context.Context, uuid string, status string) error {
+	return r.queries.UpdateMultipleChoiceQuestionStatus(ctx, db.UpdateMultipleChoiceQuestionStatusParams{Uuid: uuid, Status: status})
+}
+
+func (r *PostgresRepository) UpdateSingleChoiceQuestionStatus(ctx context.Context, uuid string, status string) error {
+	return r.queries.UpdateSingleChoiceQuestionStatus(ctx, db.UpdateSingleChoiceQuestionStatusParams{Uuid: uuid, Status: status})
+}
+
+func (r *PostgresRepository) UpdateTrueOrFalseQuestionStatus(ctx context.Context, uuid string, status string) error {
+	return r.queries.UpdateTrueOrFalseQuestionStatus(ctx, db.UpdateTrueOrFalseQuestionStatusParams{Uuid: uuid, Status: status})
+}
+
+func (r *PostgresRepository) PublishQuestionsOfQuiz(ctx context.Context, quizID string) error {
+	return r.queries.PublishQuestionsOfQuiz(ctx, quizID)
+}
+
+func mapMultipleChoiceQuestion(question *db.MultipleChoiceQuestion) DBMultipleChoiceQuestion {
+	if question == nil {
+		return DBMultipleChoiceQuestion{}
+	}
+	return DBMultipleChoiceQuestion{
+		UUID:           question.Uuid,
+		QuizID:         store.Value(question.Quizid),
+		Question:       store.Value(question.Question),
+		Answers:        question.Answers,
+		CorrectAnswers: question.CorrectAnswers,
+		Status:         question.Status,
+		Published:      question.Published,
+	}
+}
+
+func mapSingleChoiceQuestion(question *db.SingleChoiceQuestion) DBSingleChoiceQuestion {
+	if question == nil {
+		return DBSingleChoiceQuestion{}
+	}
+	return DBSingleChoiceQuestion{
+		UUID:          question.Uuid,
+		QuizID:        store.Value(question.Quizid),
+		Question:      store.Value(question.Question),
+		Answers:       question.Answers,
+		CorrectAnswer: store.Value(question.CorrectAnswer),
+		Status:        question.Status,
+		Published:     question.Published,
+	}
+}
+
+func mapTrueOrFalseQuestion(question *db.TrueOrFalseQuestion) DBTrueOrFalseQuestion {
+	if question == nil {
+		return DBTrueOrFalseQuestion{}
+	}
+	return DBTrueOrFalseQuestion{
+		UUID:          question.Uuid,
+		QuizID:        store.Value(question.Quizid),
+		Question:      store.Value(question.Question),
+		CorrectAnswer: store.Value(question.CorrectAnswer),
+		Status:        question.Status,
+		Published:     question.Published,
+	}
 }
 
 func (q DBSingleChoiceQuestion) MapToModel() *models.SingleChoiceQuestion {
@@ -160,6 +288,8 @@ func (q DBSingleChoiceQuestion) MapToModel() *models.SingleChoiceQuestion {
 		Question:      q.Question,
 		Answers:       q.Answers,
 		CorrectAnswer: q.CorrectAnswer,
+		Status:        q.Status,
+		Published:     q.Published,
 	}
 }
 func (q DBMultipleChoiceQuestion) MapToModel() *models.MultipleChoiceQuestion {
@@ -170,6 +300,8 @@ func (q DBMultipleChoiceQuestion) MapToModel() *models.MultipleChoiceQuestion {
 		Question:       q.Question,
 		Answers:        q.Answers,
 		CorrectAnswers: q.CorrectAnswers,
+		Status:         q.Status,
+		Published:      q.Published,
 	}
 }
 func (q DBTrueOrFalseQuestion) MapToModel() *models.TrueOrFalseQuestion {
@@ -179,5 +311,7 @@ func (q DBTrueOrFalseQuestion) MapToModel() *models.TrueOrFalseQuestion {
 		QuestionType:  models.TrueOrFalse,
 		Question:      q.Question,
 		CorrectAnswer: q.CorrectAnswer,
+		Status:        q.Status,
+		Published:     q.Published,
 	}
 }
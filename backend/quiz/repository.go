@@ -1,117 +1,186 @@
 package quiz
 
 import (
+	"context"
 	"database/sql"
-	_ "fmt"
-	"spaced-ace-backend/utils"
+	"errors"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
-var schema = `
-	CREATE TABLE IF NOT EXISTS quizzes(
-		id UUID PRIMARY KEY,
-		name TEXT NOT NULL,
-		creatorid UUID REFERENCES users(id) ON DELETE SET NULL,
-		description TEXT
-	);
-	CREATE TABLE IF NOT EXISTS quiz_accesses(
-		userid UUID REFERENCES users(id) ON DELETE CASCADE,
-		quizid UUID REFERENCES quizzes(id) ON DELETE CASCADE,
-		roleid SMALLINT NOT NULL, --1 = owner, 2 = viewer
-		PRIMARY KEY(userid, quizid, roleid),
-		UNIQUE(userid, quizid)
-	);
-	`
 var (
 	QUIZ_OWNER_ACCESS_ID  = 1
 	QUIZ_VIEWER_ACCESS_ID = 2
 )
 
-func InitDb() {
-	utils.DB.MustExec(schema)
-}
+// The lifecycle of a quiz. Learners only start sessions on published quizzes
+// and archived ones take no new learners.
+const (
+	STATUS_DRAFT     = "draft"
+	STATUS_PUBLISHED = "published"
+	STATUS_ARCHIVED  = "archived"
+)
 
 type DBQuiz struct {
-	Id          string         `db:"id"`
-	Name        string         `db:"name"`
-	CreatorId   sql.NullString `db:"creatorid"`
-	Description sql.NullString `db:"description"`
+	Id          string
+	Name        string
+	CreatorId   sql.NullString
+	Description sql.NullString
+	Status      string
+	PublishedAt *time.Time
+}
+
+// A quiz listed in the admin console with the account of its creator
+type DBQuizWithCreator struct {
+	DBQuiz
+	CreatorName  sql.NullString
+	CreatorEmail sql.NullString
 }
 
 type DBQuizAccess struct {
-	UserId string `db:"userid"`
-	QuizId string `db:"quizid"`
-	RoleId int    `db:"roleid"`
+	UserId string
+	QuizId string
+	RoleId int
 }
 
-func CreateQuiz(ownerid string, name string, description string) (*DBQuiz, error) {
-	quiz := DBQuiz{}
-	err := utils.DB.Get(&quiz, "INSERT INTO quizzes (id, name, creatorid, description) VALUES (gen_random_uuid(), $1, $2, $3) RETURNING *", name, ownerid, description)
-	if err != nil {
-		return nil, err
-	}
-	err = CreateQuizAccess(ownerid, quiz.Id, QUIZ_OWNER_ACCESS_ID)
+// The quizzes and who can access them. PostgresRepository is the one the
+// server uses, the handler tests use an in-memory fake.
+type Repository interface {
+	CreateQuiz(ctx context.Context, ownerid string, name string, description string) (*DBQuiz, error)
+	CreateQuizAccess(ctx context.Context, userid string, quizid string, roleid int) error
+	GetQuizById(ctx context.Context, id string) (*DBQuiz, error)
+	// Returns 0 when the user has no access to the quiz
+	GetQuizAccess(ctx context.Context, userid string, quizid string) (int, error)
+	GetQuizAccessesOfUser(ctx context.Context, userid string) (*[]DBQuizAccess, error)
+	GetQuizAccesses(ctx context.Context, quizid string) (*[]string, error)
+	UpdateQuizAccess(ctx context.Context, userid string, quizid string, roleid int) error
+	// Empty values leave the field unchanged
+	UpdateQuiz(ctx context.Context, quizid string, name string, description string) error
+	// Publishing sets the time it was published at
+	UpdateQuizStatus(ctx context.Context, quizid string, status string) error
+	DeleteQuizAccess(ctx context.Context, userid string, quizid string) error
+	DeleteQuiz(ctx context.Context, id string) error
+	// Returns a page of all quizzes whose name contains the query or whose creator's email does
+	SearchQuizzes(ctx context.Context, query string, limit int, offset int) ([]DBQuizWithCreator, error)
+}
+
+type PostgresRepository struct {
+	queries *db.Queries
+}
+
+func NewPostgresRepository(queries *db.Queries) *PostgresRepository {
+	return &PostgresRepository{queries: queries}
+}
+
+func (r *PostgresRepository) CreateQuiz(ctx context.Context, ownerid string, name string, description string) (*DBQuiz, error) {
+	quiz, err := r.queries.CreateQuiz(ctx, db.CreateQuizParams{
+		Name:        name,
+		Creatorid:   &ownerid,
+		Description: &description,
+		OwnerRoleid: int16(QUIZ_OWNER_ACCESS_ID),
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &quiz, err
+	return mapQuiz((*db.Quiz)(quiz)), nil
 }
 
-func CreateQuizAccess(userid string, quizid string, roleid int) error {
-	_, err := utils.DB.Exec("INSERT INTO quiz_accesses(userid, quizid, roleid) VALUES ($1, $2, $3)", userid, quizid, roleid)
-	return err
+func (r *PostgresRepository) CreateQuizAccess(ctx context.Context, userid string, quizid string, roleid int) error {
+	return r.queries.CreateQuizAccess(ctx, db.CreateQuizAccessParams{Userid: userid, Quizid: quizid, Roleid: int16(roleid)})
 }
 
-func GetQuizById(id string) (*DBQuiz, error) {
-	quiz := DBQuiz{}
-	err := utils.DB.Get(&quiz, "SELECT * FROM quizzes WHERE id = $1", id)
-	return &quiz, err
+func (r *PostgresRepository) GetQuizById(ctx context.Context, id string) (*DBQuiz, error) {
+	quiz, err := r.queries.GetQuizById(ctx, id)
+	return mapQuiz(quiz), err
 }
 
-func GetQuizAccess(userid string, quizid string) (int, error) {
-	var access []int
-	err := utils.DB.Select(&access, "SELECT roleid FROM quiz_accesses WHERE userid = $1 AND quizid = $2 LIMIT 1", userid, quizid)
-	if err != nil || len(access) == 0 {
-		return 0, err
+func (r *PostgresRepository) GetQuizAccess(ctx context.Context, userid string, quizid string) (int, error) {
+	roleid, err := r.queries.GetQuizAccess(ctx, db.GetQuizAccessParams{Userid: userid, Quizid: quizid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
 	}
-	return access[len(access)-1], nil
+	return int(roleid), err
 }
 
-func GetQuizAccessesOfUser(userid string) (*[]DBQuizAccess, error) {
+func (r *PostgresRepository) GetQuizAccessesOfUser(ctx context.Context, userid string) (*[]DBQuizAccess, error) {
+	rows, err := r.queries.GetQuizAccessesOfUser(ctx, userid)
 	accesses := []DBQuizAccess{}
-	err := utils.DB.Select(&accesses, "SELECT userid, quizid, roleid FROM quiz_accesses WHERE userid = $1", userid)
+	for _, row := range rows {
+		accesses = append(accesses, DBQuizAccess{UserId: row.Userid, QuizId: row.Quizid, RoleId: int(row.Roleid)})
+	}
 	return &accesses, err
 }
 
-func GetQuizAccesses(quizid string) (*[]string, error) {
-	var accesses []string
-	err := utils.DB.Select(&accesses, "SELECT roleid FROM quiz_accesses WHERE quizid = $1", quizid)
+func (r *PostgresRepository) GetQuizAccesses(ctx context.Context, quizid string) (*[]string, error) {
+	roleids, err := r.queries.GetQuizAccesses(ctx, quizid)
+	accesses := []string{}
+	for _, roleid := range roleids {
+		accesses = append(accesses, strconv.Itoa(int(roleid)))
+	}
 	return &accesses, err
 }
 
-func UpdateQuizAccess(userid string, quizid string, roleid int) error {
-	_, err := utils.DB.Exec("UPDATE quiz_accesses SET roleid=$3 WHERE userid = $1 AND quizid = $2", userid, quizid, roleid)
-	return err
+func (r *PostgresRepository) UpdateQuizAccess(ctx context.Context, userid string, quizid string, roleid int) error {
+	return r.queries.UpdateQuizAccess(ctx, db.UpdateQuizAccessParams{Userid: userid, Quizid: quizid, Roleid: int16(roleid)})
 }
 
-func UpdateQuiz(quizid string, name string, description string) error {
-	if name == "" && description != "" {
-		_, err := utils.DB.Exec("UPDATE quizzes SET description=$2 WHERE id=$1", quizid, description)
-		return err
+func (r *PostgresRepository) UpdateQuiz(ctx context.Context, quizid string, name string, description string) error {
+	params := db.UpdateQuizParams{ID: quizid}
+	if name != "" {
+		params.Name = &name
 	}
-	if name != "" && description == "" {
-		_, err := utils.DB.Exec("UPDATE quizzes SET name=$2 WHERE id=$1", quizid, name)
-		return err
+	if description != "" {
+		params.Description = &description
 	}
-	_, err := utils.DB.Exec("UPDATE quizzes SET name=$2, description=$3 WHERE id=$1", quizid, name, description)
-	return err
+	return r.queries.UpdateQuiz(ctx, params)
+}
+
+func (r *PostgresRepository) UpdateQuizStatus(ctx context.Context, quizid string, status string) error {
+	return r.queries.UpdateQuizStatus(ctx, db.UpdateQuizStatusParams{ID: quizid, Status: status})
+}
+
+func (r *PostgresRepository) DeleteQuizAccess(ctx context.Context, userid string, quizid string) error {
+	return r.queries.DeleteQuizAccess(ctx, db.DeleteQuizAccessParams{Userid: userid, Quizid: quizid})
+}
+
+func (r *PostgresRepository) DeleteQuiz(ctx context.Context, id string) error {
+	return r.queries.DeleteQuiz(ctx, id)
 }
 
-func DeleteQuizAccess(userid string, quizid string) error {
-	_, err := utils.DB.Exec("DELETE FROM quiz_accesses WHERE userid = $1 AND quizid = $2", userid, quizid)
-	return err
+func (r *PostgresRepository) SearchQuizzes(ctx context.Context, query string, limit int, offset int) ([]DBQuizWithCreator, error) {
+	rows, err := r.queries.SearchQuizzes(ctx, db.SearchQuizzesParams{Query: query, Limit: int32(limit), Offset: int32(offset)})
+	quizzes := []DBQuizWithCreator{}
+	for _, row := range rows {
+		quizzes = append(quizzes, DBQuizWithCreator{
+			DBQuiz: DBQuiz{
+				Id:          row.ID,
+				Name:        row.Name,
+				CreatorId:   store.NullString(row.Creatorid),
+				Description: store.NullString(row.Description),
+				Status:      row.Status,
+				PublishedAt: store.TimePtr(row.PublishedAt),
+			},
+			CreatorName:  store.NullString(row.CreatorName),
+			CreatorEmail: store.NullString(row.CreatorEmail),
+		})
+	}
+	return quizzes, err
 }
 
-func DeleteQuiz(id string) error {
-	_, err := utils.DB.Exec("DELETE FROM quizzes WHERE id = $1", id)
-	return err
+func mapQuiz(quiz *db.Quiz) *DBQuiz {
+	if quiz == nil {
+		return &DBQuiz{}
+	}
+	return &DBQuiz{
+		Id:          quiz.ID,
+		Name:        quiz.Name,
+		CreatorId:   store.NullString(quiz.Creatorid),
+		Description: store.NullString(quiz.Description),
+		Status:      quiz.Status,
+		PublishedAt: store.TimePtr(quiz.PublishedAt),
+	}
 }
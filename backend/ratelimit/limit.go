@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/store"
+	"time"
+)
+
+// A token bucket holding up to Burst requests, refilled with Requests every Per
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+func PerSecond(requests int) Limit {
+	return Limit{Requests: requests, Per: time.Second, Burst: requests}
+}
+
+func PerMinute(requests int) Limit {
+	return Limit{Requests: requests, Per: time.Minute, Burst: requests}
+}
+
+func PerHour(requests int) Limit {
+	return Limit{Requests: requests, Per: time.Hour, Burst: requests}
+}
+
+// Returns the limit with a different bucket size, the refill rate is unchanged
+func (l Limit) WithBurst(burst int) Limit {
+	l.Burst = burst
+	return l
+}
+
+// Tokens refilled per second
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+type Store interface {
+	// Takes a token from the bucket of the key. When the bucket is empty it
+	// returns false and the time until the next token is available.
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// Refills the bucket for the time elapsed since it was last updated and takes
+// a token if there is one. Shared by the stores so they behave the same.
+func take(tokens float64, updatedAt time.Time, now time.Time, limit Limit) (remaining float64, allowed bool, retryAfter time.Duration) {
+	elapsed := now.Sub(updatedAt).Seconds()
+	if elapsed > 0 {
+		tokens = math.Min(float64(limit.Burst), tokens+elapsed*limit.rate())
+	}
+	if tokens >= 1 {
+		return tokens - 1, true, 0
+	}
+	missing := (1 - tokens) / limit.rate()
+	return tokens, false, time.Duration(math.Ceil(missing * float64(time.Second)))
+}
+
+// Returns the configured store, memory or postgres on top of s
+func NewStore(cfg config.RateLimit, s *store.Store) (Store, error) {
+	switch cfg.Store {
+	case "memory":
+		return NewMemoryStore(), nil
+	case "postgres":
+		return NewPostgresStore(s), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q, use memory or postgres", cfg.Store)
+	}
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/logging"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Returns what the requests are counted by, an empty key skips limiting
+type KeyFunc func(c echo.Context) string
+
+func ByIP(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// Counts by the authenticated user, so it must run after auth.RequireAuthentication
+func ByUser(c echo.Context) string {
+	if userId := auth.CurrentUserId(c); userId != "" {
+		return "user:" + userId
+	}
+	return ByIP(c)
+}
+
+// Limits the requests of every key to the limit within the class. Classes keep
+// the buckets of different route groups apart, e.g. "login" and "generation".
+func Middleware(store Store, class string, limit Limit, keyFunc KeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := keyFunc(c)
+			if key == "" {
+				return next(c)
+			}
+			ctx, cancel := context.WithTimeout(c.Request().Context(), 2*time.Second)
+			defer cancel()
+
+			allowed, retryAfter, err := store.Take(ctx, class+":"+key, limit)
+			if err != nil {
+				// Fail open, an unavailable limiter should not take the api down
+				logging.Logger(c.Request().Context()).Error("rate limiter unavailable", "error", err)
+				return next(c)
+			}
+			if !allowed {
+				return TooManyRequests(c, retryAfter)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Responds with 429 and a Retry-After header in whole seconds
+func TooManyRequests(c echo.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	return apperror.New(http.StatusTooManyRequests, apperror.CodeTooManyRequests, "too many requests, retry in "+strconv.Itoa(seconds)+" seconds")
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
+	"time"
+)
+
+// Keeps the buckets in Postgres so every backend instance shares them
+type PostgresStore struct {
+	store *store.Store
+}
+
+func NewPostgresStore(s *store.Store) *PostgresStore {
+	return &PostgresStore{store: s}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	allowed, retryAfter := false, time.Duration(0)
+	err := s.store.WithTx(ctx, func(tx *store.Tx) error {
+		err := tx.EnsureRateLimitBucket(ctx, db.EnsureRateLimitBucketParams{Key: key, Tokens: float64(limit.Burst)})
+		if err != nil {
+			return err
+		}
+		bucket, err := tx.LockRateLimitBucket(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		var remaining float64
+		remaining, allowed, retryAfter = take(bucket.Tokens, bucket.UpdatedAt.Time, bucket.Now.Time, limit)
+		return tx.UpdateRateLimitBucket(ctx, db.UpdateRateLimitBucketParams{
+			Key:       key,
+			Tokens:    remaining,
+			UpdatedAt: bucket.Now,
+		})
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return allowed, retryAfter, nil
+}
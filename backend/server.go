@@ -1,101 +1,307 @@
 package main
 
 import (
-	"golang.org/x/net/context"
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"spaced-ace-backend/account"
 	"spaced-ace-backend/api/handlers"
+	"spaced-ace-backend/apperror"
+	"spaced-ace-backend/audit"
 	"spaced-ace-backend/auth"
-	"spaced-ace-backend/constants"
-	"spaced-ace-backend/question"
-	"spaced-ace-backend/quiz"
-	"spaced-ace-backend/utils"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/health"
+	"spaced-ace-backend/logging"
+	"spaced-ace-backend/metrics"
+	"spaced-ace-backend/migrations"
+	"spaced-ace-backend/ratelimit"
+	"spaced-ace-backend/store"
+	"spaced-ace-backend/tracing"
+	"spaced-ace-backend/usage"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 )
 
+// How long the readiness probe trusts the last answer of an external api
+const externalCheckTtl = 30 * time.Second
+
 func main() {
-	e := echo.New()
-	e.Use(middleware.Logger())
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	// Unlike the other commands the healthcheck talks to the running server, not the database
+	if len(os.Args) == 2 && os.Args[1] == "healthcheck" {
+		if err := health.Probe(cfg.Server.Port); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	logging.Init(cfg.Logging)
+	log.Printf("Configuration:\n%s", cfg)
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalln(err)
+	}
 
-	err := auth.InitEmailService()
+	// The one pool every repository and handler shares, commands included
+	s, err := store.New(context.Background(), cfg.Database)
 	if err != nil {
-		panic("Failed to initialize email service")
+		log.Fatalln(err)
 	}
-	auth.InitDb()
-	quiz.InitDb()
-	question.InitDb()
+	defer s.Close()
+	llm := handlers.NewLlmClient(cfg.Llm.ApiUrl)
+	a := newApp(auth.NewPostgresRepositories(s), account.NewPostgresRepository(s), handlers.NewPostgresDependencies(s, llm))
+	account.InitErasureConfig(cfg.Erasure)
 
-	// Init and close SQLC connection gracefully
-	sqlcQuerier := utils.GetQuerier()
-	_, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	defer func() {
-		if err := sqlcQuerier.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
+	if len(os.Args) > 1 {
+		if err := runCommand(s, a, os.Args[1:]); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	err = auth.InitEmailService(cfg.Email, cfg.Server.AppBaseUrl)
+	if err != nil {
+		panic(err)
+	}
+	a.auth.InitOidcProviders(cfg.OidcProviders(), cfg.Server.AppBaseUrl)
+	auth.InitSessionConfig(cfg.Sessions)
+	auth.InitTotpConfig(cfg.Totp)
+	usage.InitQuotaConfig(cfg.Quotas)
+	handlers.InitReviewItemConfig(cfg.ReviewItems)
+	// Instances starting together take turns, the first one applies the migrations
+	applied, err := migrations.Up(context.Background(), s.Pool)
+	if err != nil {
+		panic(err)
+	}
+	if applied > 0 {
+		slog.Info("applied database migrations", "count", applied)
+	}
+
+	metrics.RegisterStore(s.Pool, s.Queries.CountDueReviewItems)
+	metricsServer := metrics.NewServer(cfg.Metrics.Port)
+
+	limiter, err := ratelimit.NewStore(cfg.RateLimit, s)
+	if err != nil {
+		panic(err)
+	}
+	e := newServer(a, limiter,
+		health.Check{Name: "postgres", Run: s.Pool.Ping},
+		health.Cached(health.Check{Name: "llm", Run: llm.Ping, Optional: true}, externalCheckTtl),
+		health.Cached(health.Check{Name: "email", Run: auth.GetEmailVerificationService().Ping, Optional: true}, externalCheckTtl),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		a.account.RunErasureWorker(ctx)
+	}()
+
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", cfg.Server.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalln(err)
+		}
+	}()
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalln(err)
 		}
 	}()
 
+	<-ctx.Done()
+	stop()
+	slog.Info("shutting down, waiting for requests and workers to finish", "timeout", cfg.Server.ShutdownTimeout)
+	health.Drain()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down the server", "error", err)
+	}
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-shutdownCtx.Done():
+		slog.Warn("background workers did not finish before the shutdown deadline")
+	}
+	// Stopped last, so the scrapes still see the drain
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down the metrics server", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("failed to flush the spans", "error", err)
+	}
+}
+
+// The handlers of every package with what they read and write. They share
+// the users and the audit log of the handler dependencies.
+type app struct {
+	auth     *auth.Handlers
+	account  *account.Handlers
+	handlers *handlers.Handlers
+	auditLog audit.Log
+}
+
+func newApp(authRepositories auth.Repositories, accountRepository account.Repository, dependencies handlers.Dependencies) *app {
+	return &app{
+		auth:     auth.New(authRepositories, dependencies.Audit),
+		account:  account.New(accountRepository, dependencies.Users, dependencies.Audit),
+		handlers: handlers.New(dependencies),
+		auditLog: dependencies.Audit,
+	}
+}
+
+// Returns the server with every route of the app. The checks are the
+// dependencies the readiness probe reports on.
+func newServer(a *app, limiter ratelimit.Store, checks ...health.Check) *echo.Echo {
+	e := echo.New()
+	e.Use(tracing.Middleware)
+	e.Use(metrics.Middleware)
+	// The probes run every few seconds, logging them would drown the requests
+	e.Use(logging.Middleware(func(c echo.Context) bool {
+		return c.Path() == "/healthz" || c.Path() == "/readyz"
+	}))
+	e.HTTPErrorHandler = apperror.ErrorHandler
+	// Only trust X-Forwarded-For from the frontend on the private network, so
+	// clients cannot pick their own address to dodge the rate limits
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+
+	loginLimit := ratelimit.Middleware(limiter, "login", ratelimit.PerMinute(10), ratelimit.ByIP)
+	signupLimit := ratelimit.Middleware(limiter, "signup", ratelimit.PerHour(5), ratelimit.ByIP)
+	emailLimit := ratelimit.Middleware(limiter, "email", ratelimit.PerHour(5), ratelimit.ByIP)
+	exportLimit := ratelimit.Middleware(limiter, "export", ratelimit.PerHour(5), ratelimit.ByUser)
+	erasureLimit := ratelimit.Middleware(limiter, "erasure", ratelimit.PerHour(5), ratelimit.ByUser)
+	generationLimit := ratelimit.Middleware(limiter, "generation", ratelimit.PerMinute(5).WithBurst(10), ratelimit.ByUser)
+
+	// The frontend proxies most public calls, so only the endpoints it forwards
+	// the client address for are limited by ip
+	e.GET("/healthz", health.LivenessEndpoint)
+	e.GET("/readyz", health.ReadinessEndpoint(checks...))
+
 	public := e.Group("")
-	public.POST("/authenticate-user", auth.AuthenticateUser)
-	public.GET("/authenticated", auth.Authenticated)
-	public.POST("/create-user", auth.Register)
-	public.DELETE("/delete-user/:id", auth.DeleteUserEndpoint)
-	public.GET("/verify-email", auth.VerifyEmailEndpoint)
-	public.POST("/resend-verification", auth.ResendVerificationEmailEndpoint)
-
-	protected := e.Group("")
-	protected.POST("/logout", auth.Logout)
-
-	quizGroup := protected.Group("/quizzes")
-	quizGroup.GET("/:id", handlers.GetQuizEndpoint)
-	quizGroup.PATCH("/:id", handlers.UpdateQuizEndpoint)
-	quizGroup.DELETE("/:id", handlers.DeleteQuizEndpoint)
-	quizGroup.GET("/user/:id", handlers.GetQuizzesOfUserEndpoint)
-	quizGroup.POST("/create", handlers.CreateQuizEndpoint)
-
-	questions := protected.Group("/questions")
-	questions.POST("/multiple-choice", handlers.CreateMultipleChoiceQuestionEndpoint)
-	questions.GET("/multiple-choice/:id", handlers.GetMultipleChoiceEndpoint)
-	questions.PATCH("/multiple-choice/:id", handlers.UpdateMultipleChoiceQuestionEndpoint)
-	questions.DELETE("/multiple-choice/:quizId/:id", handlers.DeleteMultipleChoiceQuestionEndpoint)
-
-	questions.POST("/single-choice", handlers.CreateSingleChoiceQuestionEndpoint)
-	questions.GET("/single-choice/:id", handlers.GetSingleChoiceEndpoint)
-	questions.PATCH("/single-choice/:id", handlers.UpdateSingleChoiceQuestionEndpoint)
-	questions.DELETE("/single-choice/:quizId/:id", handlers.DeleteSingleChoiceQuestionEndpoint)
-
-	questions.POST("/true-or-false", handlers.CreateTrueOrFalseQuestionEndpoint)
-	questions.GET("/true-or-false/:id", handlers.GetTrueOrFalseEndpoint)
-	questions.PATCH("/true-or-false/:id", handlers.UpdateTrueOrFalseQuestionEndpoint)
-	questions.DELETE("/true-or-false/:quizId/:id", handlers.DeleteTrueOrFalseQuestionEndpoint)
-
-	quizSessions := protected.Group("/quiz-sessions")
-	quizSessions.GET("/:quizSessionId", handlers.GetQuizSession)
-	quizSessions.GET("", handlers.GetQuizSessions)
-	quizSessions.GET("/has-open", handlers.HasOpenQuizSession)
-	quizSessions.POST("/start", handlers.StartQuizSession)
-	quizSessions.POST("/:quizSessionId/submit", handlers.PostSubmitQuiz)
-	quizSessions.GET("/:quizSessionId/result", handlers.GetQuizResult)
-	quizSessions.GET("/:quizSessionId/answers", handlers.GetAnswers)
-	quizSessions.PUT("/:quizSessionId/answers", handlers.PutCreateOrUpdateAnswer)
-
-	quizHistory := protected.Group("/quiz-history")
-	quizHistory.GET("", handlers.GetQuizHistoryEntries)
-
-	learnList := protected.Group("/learn-list")
-	learnList.GET("", handlers.GetLearnList)
-	learnList.POST("/:quizID/add", handlers.PostAddQuizToLearnList)
-	learnList.POST("/:quizID/remove", handlers.PostRemoveQuizFromLearnList)
-
-	reviewItem := protected.Group("/review-items")
-	reviewItem.GET("", handlers.GetReviewItems)
-	reviewItem.GET("/quiz-options", handlers.GetQuizOptions)
-	reviewItem.GET("/item-counts", handlers.GetReviewItemCounts)
-	reviewItem.GET("/get-question/:reviewItemID", handlers.GetReviewItemQuestion)
-	reviewItem.GET("/get-question", handlers.GetReviewItemQuestion)
-	reviewItem.POST("/:reviewItemID/submit", handlers.PostSubmitReviewItemQuestion)
-
-	e.Logger.Fatal(e.Start(":" + constants.PORT))
+	public.POST("/authenticate-user", a.auth.AuthenticateUser, loginLimit)
+	public.POST("/authenticate-user/totp", a.auth.AuthenticateTotpEndpoint, loginLimit)
+	public.GET("/authenticated", a.auth.Authenticated, a.auth.RequireAuthentication)
+	public.POST("/create-user", a.auth.Register, signupLimit)
+	public.GET("/verify-email", a.auth.VerifyEmailEndpoint)
+	public.POST("/resend-verification", a.auth.ResendVerificationEmailEndpoint, emailLimit)
+	public.GET("/oidc/providers", a.auth.GetOidcProvidersEndpoint)
+	public.GET("/oidc/:provider/authorize", a.auth.OidcAuthorizeEndpoint, loginLimit)
+	public.POST("/erasure/confirm", a.account.ConfirmErasureEndpoint)
+	public.POST("/oidc/:provider/callback", a.auth.OidcCallbackEndpoint, loginLimit)
+
+	protected := e.Group("", a.auth.RequireAuthentication, ratelimit.Middleware(limiter, "api", ratelimit.PerSecond(10).WithBurst(100), ratelimit.ByUser))
+	protected.POST("/logout", a.auth.Logout, auth.RequireSession)
+	protected.GET("/sessions", a.auth.GetSessionsEndpoint, auth.RequireSession)
+	protected.DELETE("/sessions", a.auth.RevokeOtherSessionsEndpoint, auth.RequireSession)
+	protected.DELETE("/sessions/:id", a.auth.RevokeSessionEndpoint, auth.RequireSession)
+	protected.GET("/identities", a.auth.GetIdentitiesEndpoint, auth.RequireSession, auth.RejectImpersonation)
+	protected.DELETE("/identities/:id", a.auth.DeleteIdentityEndpoint, auth.RequireSession)
+	protected.GET("/totp", a.auth.GetTotpStatusEndpoint, auth.RequireSession)
+	protected.POST("/totp/enroll", a.auth.EnrollTotpEndpoint, auth.RequireSession)
+	protected.POST("/totp/confirm", a.auth.ConfirmTotpEndpoint, auth.RequireSession)
+	protected.POST("/totp/disable", a.auth.DisableTotpEndpoint, auth.RequireSession)
+	protected.POST("/totp/recovery-codes", a.auth.RegenerateRecoveryCodesEndpoint, auth.RequireSession)
+	protected.GET("/tokens", a.auth.GetApiTokensEndpoint, auth.RequireSession, auth.RejectImpersonation)
+	protected.POST("/tokens", a.auth.CreateApiTokenEndpoint, auth.RequireSession)
+	protected.DELETE("/tokens/:id", a.auth.RevokeApiTokenEndpoint, auth.RequireSession)
+	protected.GET("/me/usage", a.handlers.GetUsageEndpoint)
+	protected.GET("/me/export", a.account.ExportDataEndpoint, auth.RequireSession, auth.RejectImpersonation, exportLimit)
+	protected.GET("/me/erasure", a.account.GetErasureEndpoint, auth.RequireSession)
+	protected.POST("/me/erasure", a.account.RequestErasureEndpoint, auth.RequireSession, erasureLimit)
+	protected.DELETE("/me/erasure", a.account.CancelErasureEndpoint, auth.RequireSession)
+
+	// Moderators manage content, user management is for admins only
+	admin := protected.Group("/admin", auth.RequireSession)
+	staffOnly := auth.RequireRole(auth.RoleModerator, auth.RoleAdmin)
+	adminOnly := auth.RequireRole(auth.RoleAdmin)
+	admin.GET("/quizzes", a.handlers.AdminSearchQuizzesEndpoint, staffOnly)
+	admin.DELETE("/quizzes/:id", a.handlers.AdminDeleteQuizEndpoint, staffOnly)
+	admin.GET("/users", a.auth.AdminSearchUsersEndpoint, adminOnly)
+	admin.GET("/users/:id", a.auth.AdminGetUserEndpoint, adminOnly)
+	admin.POST("/users/:id/resend-verification", a.auth.AdminResendVerificationEndpoint, adminOnly)
+	admin.POST("/users/:id/verify", a.auth.AdminVerifyEmailEndpoint, adminOnly)
+	admin.POST("/users/:id/disable", a.auth.AdminDisableUserEndpoint, adminOnly)
+	admin.POST("/users/:id/enable", a.auth.AdminEnableUserEndpoint, adminOnly)
+	admin.POST("/users/:id/reset-totp", a.auth.AdminResetTotpEndpoint, adminOnly)
+	admin.PUT("/users/:id/role", a.auth.AdminSetRoleEndpoint, adminOnly)
+	admin.POST("/users/:id/impersonate", a.auth.AdminImpersonateEndpoint, adminOnly)
+	admin.GET("/audit-events", a.handlers.AdminGetAuditEventsEndpoint, adminOnly)
+
+	quizGroup := protected.Group("/quizzes", a.auth.RequireTokenScope("quizzes"))
+	quizGroup.GET("/:id", a.handlers.GetQuizEndpoint)
+	quizGroup.PATCH("/:id", a.handlers.UpdateQuizEndpoint)
+	quizGroup.DELETE("/:id", a.handlers.DeleteQuizEndpoint)
+	quizGroup.POST("/:id/publish", a.handlers.PublishQuizEndpoint)
+	quizGroup.POST("/:id/archive", a.handlers.ArchiveQuizEndpoint)
+	quizGroup.GET("/user/:id", a.handlers.GetQuizzesOfUserEndpoint)
+	quizGroup.POST("/create", a.handlers.CreateQuizEndpoint)
+	quizGroup.POST("/import", a.handlers.ImportQuizEndpoint)
+
+	questions := protected.Group("/questions", a.auth.RequireTokenScope("quizzes"))
+	questions.POST("/multiple-choice", a.handlers.CreateMultipleChoiceQuestionEndpoint, generationLimit)
+	questions.POST("/multiple-choice/manual", a.handlers.CreateManualMultipleChoiceQuestionEndpoint)
+	questions.GET("/multiple-choice/:id", a.handlers.GetMultipleChoiceEndpoint)
+	questions.PATCH("/multiple-choice/:id", a.handlers.UpdateMultipleChoiceQuestionEndpoint)
+	questions.DELETE("/multiple-choice/:quizId/:id", a.handlers.DeleteMultipleChoiceQuestionEndpoint)
+
+	questions.POST("/single-choice", a.handlers.CreateSingleChoiceQuestionEndpoint, generationLimit)
+	questions.POST("/single-choice/manual", a.handlers.CreateManualSingleChoiceQuestionEndpoint)
+	questions.GET("/single-choice/:id", a.handlers.GetSingleChoiceEndpoint)
+	questions.PATCH("/single-choice/:id", a.handlers.UpdateSingleChoiceQuestionEndpoint)
+	questions.DELETE("/single-choice/:quizId/:id", a.handlers.DeleteSingleChoiceQuestionEndpoint)
+
+	questions.POST("/true-or-false", a.handlers.CreateTrueOrFalseQuestionEndpoint, generationLimit)
+	questions.POST("/true-or-false/manual", a.handlers.CreateManualTrueOrFalseQuestionEndpoint)
+	questions.GET("/true-or-false/:id", a.handlers.GetTrueOrFalseEndpoint)
+	questions.PATCH("/true-or-false/:id", a.handlers.UpdateTrueOrFalseQuestionEndpoint)
+	questions.DELETE("/true-or-false/:quizId/:id", a.handlers.DeleteTrueOrFalseQuestionEndpoint)
+
+	questions.GET("/:id/revisions", a.handlers.GetQuestionRevisionsEndpoint)
+	questions.GET("/:id/revisions/diff", a.handlers.GetQuestionRevisionDiffEndpoint)
+	questions.POST("/:id/revisions/:revision/restore", a.handlers.RestoreQuestionRevisionEndpoint)
+	questions.PUT("/:id/status", a.handlers.UpdateQuestionStatusEndpoint)
+
+	quizSessions := protected.Group("/quiz-sessions", a.auth.RequireTokenScope("sessions"))
+	quizSessions.GET("/:quizSessionId", a.handlers.GetQuizSession)
+	quizSessions.GET("", a.handlers.GetQuizSessions)
+	quizSessions.GET("/has-open", a.handlers.HasOpenQuizSession)
+	quizSessions.POST("/start", a.handlers.StartQuizSession)
+	quizSessions.POST("/:quizSessionId/submit", a.handlers.PostSubmitQuiz)
+	quizSessions.GET("/:quizSessionId/result", a.handlers.GetQuizResult)
+	quizSessions.GET("/:quizSessionId/answers", a.handlers.GetAnswers)
+	quizSessions.PUT("/:quizSessionId/answers", a.handlers.PutCreateOrUpdateAnswer)
+
+	quizHistory := protected.Group("/quiz-history", a.auth.RequireTokenScope("sessions"))
+	quizHistory.GET("", a.handlers.GetQuizHistoryEntries)
+
+	learnList := protected.Group("/learn-list", a.auth.RequireTokenScope("learn"))
+	learnList.GET("", a.handlers.GetLearnList)
+	learnList.POST("/:quizID/add", a.handlers.PostAddQuizToLearnList)
+	learnList.POST("/:quizID/remove", a.handlers.PostRemoveQuizFromLearnList)
+
+	reviewItem := protected.Group("/review-items", a.auth.RequireTokenScope("learn"))
+	reviewItem.GET("", a.handlers.GetReviewItems)
+	reviewItem.GET("/quiz-options", a.handlers.GetQuizOptions)
+	reviewItem.GET("/item-counts", a.handlers.GetReviewItemCounts)
+	reviewItem.GET("/get-question/:reviewItemID", a.handlers.GetReviewItemQuestion)
+	reviewItem.GET("/get-question", a.handlers.GetReviewItemQuestion)
+	reviewItem.POST("/:reviewItemID/submit", a.handlers.PostSubmitReviewItemQuestion)
+
+	return e
 }
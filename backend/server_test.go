@@ -0,0 +1,714 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"spaced-ace-backend/api/models"
+	"spaced-ace-backend/audit"
+	"spaced-ace-backend/auth"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/fake"
+	"spaced-ace-backend/question"
+	"spaced-ace-backend/ratelimit"
+	"spaced-ace-backend/store"
+	"spaced-ace-backend/usage"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Lets every request through, the tests are not about the limits
+type unlimited struct{}
+
+func (unlimited) Take(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	return true, 0, nil
+}
+
+// The server on top of fresh fakes, seeded with a quiz of the owner that the
+// viewer can see, a question of every type, an open quiz session and a due
+// review item of the owner
+type fixture struct {
+	store    *fake.Store
+	app      *app
+	server   *echo.Echo
+	cookies  map[string]string
+	replacer *strings.Replacer
+	// The route the last request was routed to
+	route string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{store: fake.New(), cookies: map[string]string{}}
+	ctx := context.Background()
+	f.app = newApp(f.store.AuthRepositories(), f.store.Account, f.store.Dependencies())
+	f.store.Llm.Respond("multiple-choice", map[string]any{
+		"question":        "Which are prime?",
+		"options":         []string{"2", "3", "4", "6"},
+		"correct_options": []string{"A", "B"},
+	})
+	f.store.Llm.Respond("single-choice", map[string]any{
+		"question":       "Which is even?",
+		"options":        []string{"1", "2", "3", "5"},
+		"correct_option": "B",
+	})
+	f.store.Llm.Respond("true-or-false", map[string]any{
+		"question":       "Is 2 prime?",
+		"correct_option": true,
+	})
+
+	users := map[string]*auth.DBUser{}
+	for name, role := range map[string]string{
+		"owner":     auth.RoleUser,
+		"viewer":    auth.RoleUser,
+		"other":     auth.RoleUser,
+		"moderator": auth.RoleModerator,
+		"admin":     auth.RoleAdmin,
+	} {
+		users[name] = f.store.Users.Add(auth.DBUser{
+			Id:            uuid.NewString(),
+			Name:          name,
+			Email:         name + "@example.com",
+			EmailVerified: true,
+			Role:          role,
+		})
+		f.cookies[name] = f.startSession(t, users[name].Id, nil)
+	}
+	verificationToken := "verification-token"
+	f.store.Users.Add(auth.DBUser{
+		Id:                uuid.NewString(),
+		Name:              "unverified",
+		Email:             "unverified@example.com",
+		VerificationToken: &verificationToken,
+	})
+	f.cookies["impersonation"] = f.startSession(t, users["owner"].Id, &users["admin"].Id)
+	secondSession := auth.Session{UserId: users["owner"].Id, ValidUntil: time.Now().Add(time.Hour), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := f.store.Sessions.CreateSession(ctx, &secondSession); err != nil {
+		t.Fatal(err)
+	}
+
+	quiz, err := f.store.Quizzes.CreateQuiz(ctx, users["owner"].Id, "Primes", "Numbers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.store.Quizzes.CreateQuizAccess(ctx, users["viewer"].Id, quiz.Id, 2); err != nil {
+		t.Fatal(err)
+	}
+	singleChoice := question.DBSingleChoiceQuestion{UUID: uuid.NewString(), QuizID: quiz.Id, Question: "Which is even?", Answers: []string{"1", "2", "3", "5"}, CorrectAnswer: "B", Status: question.STATUS_APPROVED}
+	multipleChoice := question.DBMultipleChoiceQuestion{UUID: uuid.NewString(), QuizID: quiz.Id, Question: "Which are prime?", Answers: []string{"2", "3", "4", "6"}, CorrectAnswers: []string{"A", "B"}, Status: question.STATUS_APPROVED}
+	trueOrFalse := question.DBTrueOrFalseQuestion{UUID: uuid.NewString(), QuizID: quiz.Id, Question: "Is 2 prime?", CorrectAnswer: true, Status: question.STATUS_APPROVED}
+	_ = f.store.Questions.CreateSingleChoiceQuestion(ctx, &singleChoice)
+	_ = f.store.Questions.CreateMultipleChoiceQuestion(ctx, &multipleChoice)
+	_ = f.store.Questions.CreateTrueOrFalseQuestion(ctx, &trueOrFalse)
+	_ = f.store.Questions.PublishQuestionsOfQuiz(ctx, quiz.Id)
+	_ = f.store.Quizzes.UpdateQuizStatus(ctx, quiz.Id, "published")
+
+	now := time.Now()
+	quizSession, err := f.store.QuizSessions.CreateQuizSession(ctx, db.CreateQuizSessionParams{
+		ID:        uuid.NewString(),
+		UserID:    users["owner"].Id,
+		QuizID:    quiz.Id,
+		StartedAt: pgtype.Timestamp{Time: now, Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := now.Add(-time.Hour)
+	reviewItemId, err := f.store.ReviewItems.CreateSingleChoiceReviewItem(ctx, db.CreateSingleChoiceReviewItemParams{
+		ID:                     uuid.NewString(),
+		UserID:                 users["owner"].Id,
+		SingleChoiceQuestionID: &singleChoice.UUID,
+		EaseFactor:             2.5,
+		Difficulty:             3,
+		NextReviewDate:         store.Timestamptz(&due),
+		IntervalInMinutes:      60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.replacer = strings.NewReplacer(
+		"{owner}", users["owner"].Id,
+		"{other}", users["other"].Id,
+		"{admin}", users["admin"].Id,
+		"{unknown}", uuid.NewString(),
+		"{ownerSession}", secondSession.PublicId,
+		"{quiz}", quiz.Id,
+		"{single}", singleChoice.UUID,
+		"{multiple}", multipleChoice.UUID,
+		"{trueOrFalse}", trueOrFalse.UUID,
+		"{session}", quizSession.ID,
+		"{reviewItem}", reviewItemId,
+	)
+
+	f.server = newServer(f.app, unlimited{})
+	f.server.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			f.route = c.Path()
+			return next(c)
+		}
+	})
+	return f
+}
+
+func (f *fixture) startSession(t *testing.T, userId string, impersonatorId *string) string {
+	t.Helper()
+	session := auth.Session{
+		UserId:         userId,
+		ValidUntil:     time.Now().Add(time.Hour),
+		ExpiresAt:      time.Now().Add(time.Hour),
+		ImpersonatorId: impersonatorId,
+	}
+	if err := f.store.Sessions.CreateSession(context.Background(), &session); err != nil {
+		t.Fatal(err)
+	}
+	return session.Id
+}
+
+// Sends the request as the user, or without a session when as is empty. The
+// placeholders of the fixture in the path and body are replaced by their ids.
+func (f *fixture) do(method string, path string, as string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, f.replacer.Replace(path), strings.NewReader(f.replacer.Replace(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if as != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: f.cookies[as]})
+	}
+	rec := httptest.NewRecorder()
+	f.route = ""
+	f.server.ServeHTTP(rec, req)
+	return rec
+}
+
+type routeCase struct {
+	name   string
+	method string
+	// The route pattern of server.go the request has to reach
+	route  string
+	path   string
+	as     string
+	body   string
+	status int
+	// Requests sent before the case, for states like a submitted quiz session
+	before []routeCase
+	// Checks what the request left behind besides the response
+	check func(t *testing.T, f *fixture)
+}
+
+var routeCases = []routeCase{
+	{name: "liveness", method: "GET", route: "/healthz", path: "/healthz", status: 200},
+	{name: "readiness", method: "GET", route: "/readyz", path: "/readyz", status: 200},
+	{name: "login with malformed body", method: "POST", route: "/authenticate-user", path: "/authenticate-user", body: "{", status: 400},
+	{name: "totp login without code", method: "POST", route: "/authenticate-user/totp", path: "/authenticate-user/totp", body: "{}", status: 400},
+	{name: "authenticated", method: "GET", route: "/authenticated", path: "/authenticated", as: "owner", status: 200},
+	{name: "authenticated while impersonating", method: "GET", route: "/authenticated", path: "/authenticated", as: "impersonation", status: 200},
+	{name: "register without name", method: "POST", route: "/create-user", path: "/create-user", body: `{"email":"new@example.com"}`, status: 400},
+	{name: "register taken email", method: "POST", route: "/create-user", path: "/create-user", body: `{"name":"new","email":"owner@example.com","password":"password","passwordAgain":"password"}`, status: 409},
+	{name: "verify email", method: "GET", route: "/verify-email", path: "/verify-email?token=verification-token", status: 200},
+	{name: "verify email with unknown token", method: "GET", route: "/verify-email", path: "/verify-email?token=unknown", status: 404},
+	{name: "resend verification to unknown email", method: "POST", route: "/resend-verification", path: "/resend-verification", body: `{"email":"nobody@example.com"}`, status: 200},
+	{name: "oidc providers", method: "GET", route: "/oidc/providers", path: "/oidc/providers", status: 200},
+	{name: "authorize with unknown provider", method: "GET", route: "/oidc/:provider/authorize", path: "/oidc/unknown/authorize", status: 404},
+	{name: "callback of unknown provider", method: "POST", route: "/oidc/:provider/callback", path: "/oidc/unknown/callback", body: "{}", status: 404},
+	{name: "confirm erasure without token", method: "POST", route: "/erasure/confirm", path: "/erasure/confirm", body: "{}", status: 400},
+
+	{name: "logout", method: "POST", route: "/logout", path: "/logout", as: "owner", status: 200},
+	{name: "logout while impersonating", method: "POST", route: "/logout", path: "/logout", as: "impersonation", status: 200},
+	{name: "list sessions", method: "GET", route: "/sessions", path: "/sessions", as: "owner", status: 200},
+	{name: "revoke other sessions", method: "DELETE", route: "/sessions", path: "/sessions", as: "owner", status: 200},
+	{name: "revoke session", method: "DELETE", route: "/sessions/:id", path: "/sessions/{ownerSession}", as: "owner", status: 200},
+	{name: "revoke session of another user", method: "DELETE", route: "/sessions/:id", path: "/sessions/{ownerSession}", as: "other", status: 404},
+	{name: "revoke session while impersonating", method: "DELETE", route: "/sessions/:id", path: "/sessions/{ownerSession}", as: "impersonation", status: 403},
+	{name: "delete identity while impersonating", method: "DELETE", route: "/identities/:id", path: "/identities/{unknown}", as: "impersonation", status: 403},
+	{name: "enroll totp while impersonating", method: "POST", route: "/totp/enroll", path: "/totp/enroll", as: "impersonation", status: 403},
+	{name: "confirm totp while impersonating", method: "POST", route: "/totp/confirm", path: "/totp/confirm", as: "impersonation", status: 403},
+	{name: "disable totp while impersonating", method: "POST", route: "/totp/disable", path: "/totp/disable", as: "impersonation", status: 403},
+	{name: "regenerate recovery codes while impersonating", method: "POST", route: "/totp/recovery-codes", path: "/totp/recovery-codes", as: "impersonation", status: 403},
+	{name: "identities", method: "GET", route: "/identities", path: "/identities", as: "owner", status: 200},
+	{name: "totp status", method: "GET", route: "/totp", path: "/totp", as: "owner", status: 200},
+	{name: "tokens", method: "GET", route: "/tokens", path: "/tokens", as: "owner", status: 200},
+	{name: "export", method: "GET", route: "/me/export", path: "/me/export", as: "owner", status: 200},
+	{name: "erasure without request", method: "GET", route: "/me/erasure", path: "/me/erasure", as: "owner", status: 404},
+	{name: "identities while impersonating", method: "GET", route: "/identities", path: "/identities", as: "impersonation", status: 403},
+	{name: "tokens while impersonating", method: "GET", route: "/tokens", path: "/tokens", as: "impersonation", status: 403},
+	{name: "export while impersonating", method: "GET", route: "/me/export", path: "/me/export", as: "impersonation", status: 403},
+	{name: "create token while impersonating", method: "POST", route: "/tokens", path: "/tokens", as: "impersonation", status: 403},
+	{name: "revoke token while impersonating", method: "DELETE", route: "/tokens/:id", path: "/tokens/{unknown}", as: "impersonation", status: 403},
+	{name: "usage", method: "GET", route: "/me/usage", path: "/me/usage", as: "owner", status: 200},
+	{name: "request erasure while impersonating", method: "POST", route: "/me/erasure", path: "/me/erasure", as: "impersonation", status: 403},
+	{name: "cancel erasure while impersonating", method: "DELETE", route: "/me/erasure", path: "/me/erasure", as: "impersonation", status: 403},
+
+	{name: "admin search quizzes", method: "GET", route: "/admin/quizzes", path: "/admin/quizzes?q=primes", as: "moderator", status: 200},
+	{name: "admin search quizzes as user", method: "GET", route: "/admin/quizzes", path: "/admin/quizzes", as: "owner", status: 403},
+	{name: "admin delete quiz", method: "DELETE", route: "/admin/quizzes/:id", path: "/admin/quizzes/{quiz}", as: "moderator", status: 200},
+	{name: "admin delete quiz as user", method: "DELETE", route: "/admin/quizzes/:id", path: "/admin/quizzes/{quiz}", as: "viewer", status: 403},
+	{name: "admin search users", method: "GET", route: "/admin/users", path: "/admin/users?q=example", as: "admin", status: 200},
+	{name: "admin search users as moderator", method: "GET", route: "/admin/users", path: "/admin/users", as: "moderator", status: 403},
+	{name: "admin get user", method: "GET", route: "/admin/users/:id", path: "/admin/users/{owner}", as: "admin", status: 200},
+	{name: "admin get unknown user", method: "GET", route: "/admin/users/:id", path: "/admin/users/{unknown}", as: "admin", status: 404},
+	{name: "admin resend verification of verified user", method: "POST", route: "/admin/users/:id/resend-verification", path: "/admin/users/{owner}/resend-verification", as: "admin", status: 409},
+	{name: "admin verify email", method: "POST", route: "/admin/users/:id/verify", path: "/admin/users/{owner}/verify", as: "admin", status: 200},
+	{name: "admin verify email as moderator", method: "POST", route: "/admin/users/:id/verify", path: "/admin/users/{owner}/verify", as: "moderator", status: 403},
+	{name: "admin disable user", method: "POST", route: "/admin/users/:id/disable", path: "/admin/users/{other}/disable", as: "admin", status: 200},
+	{name: "admin disable self", method: "POST", route: "/admin/users/:id/disable", path: "/admin/users/{admin}/disable", as: "admin", status: 400},
+	{name: "admin enable user", method: "POST", route: "/admin/users/:id/enable", path: "/admin/users/{other}/enable", as: "admin", status: 200},
+	{name: "admin reset totp", method: "POST", route: "/admin/users/:id/reset-totp", path: "/admin/users/{other}/reset-totp", as: "admin", status: 200, check: func(t *testing.T, f *fixture) {
+		if events := f.store.AuditLog.Events(audit.TotpDisabled); len(events) != 1 || events[0].ActorId == nil || *events[0].ActorId == events[0].TargetId {
+			t.Errorf("totp reset events: %+v", events)
+		}
+	}},
+	{name: "admin reset totp as moderator", method: "POST", route: "/admin/users/:id/reset-totp", path: "/admin/users/{other}/reset-totp", as: "moderator", status: 403},
+	{name: "admin set role", method: "PUT", route: "/admin/users/:id/role", path: "/admin/users/{other}/role", as: "admin", body: `{"role":"moderator"}`, status: 200, check: func(t *testing.T, f *fixture) {
+		if events := f.store.AuditLog.Events(audit.AdminRoleChanged); len(events) != 1 {
+			t.Errorf("role change events: %+v", events)
+		}
+	}},
+	{name: "admin set invalid role", method: "PUT", route: "/admin/users/:id/role", path: "/admin/users/{other}/role", as: "admin", body: `{"role":"owner"}`, status: 400},
+	{name: "admin set role as moderator", method: "PUT", route: "/admin/users/:id/role", path: "/admin/users/{other}/role", as: "moderator", body: `{"role":"admin"}`, status: 403},
+	{name: "admin impersonate", method: "POST", route: "/admin/users/:id/impersonate", path: "/admin/users/{owner}/impersonate", as: "admin", status: 200},
+	{name: "admin impersonate self", method: "POST", route: "/admin/users/:id/impersonate", path: "/admin/users/{admin}/impersonate", as: "admin", status: 400},
+	{name: "admin audit events", method: "GET", route: "/admin/audit-events", path: "/admin/audit-events", as: "admin", status: 200},
+	{name: "admin audit events as moderator", method: "GET", route: "/admin/audit-events", path: "/admin/audit-events", as: "moderator", status: 403},
+
+	{name: "get quiz", method: "GET", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "owner", status: 200},
+	{name: "get quiz as viewer", method: "GET", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "viewer", status: 200},
+	{name: "get quiz as moderator", method: "GET", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "moderator", status: 200},
+	{name: "get quiz of another user", method: "GET", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "other", status: 404},
+	{name: "get quiz with invalid id", method: "GET", route: "/quizzes/:id", path: "/quizzes/invalid", as: "owner", status: 404},
+	{name: "update quiz", method: "PATCH", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "owner", body: `{"name":"Primes and more"}`, status: 200},
+	{name: "update quiz as viewer", method: "PATCH", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "viewer", body: `{"name":"Mine"}`, status: 403},
+	{name: "update quiz as moderator", method: "PATCH", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "moderator", body: `{"name":"Mine"}`, status: 403},
+	{name: "update quiz of another user", method: "PATCH", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "other", body: `{"name":"Mine"}`, status: 404},
+	{name: "update quiz while impersonating", method: "PATCH", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "impersonation", body: `{"name":"Mine"}`, status: 403},
+	{name: "delete quiz", method: "DELETE", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "owner", status: 200, before: []routeCase{
+		{method: "PATCH", path: "/questions/single-choice/{single}", as: "owner", body: `{"quizId":"{quiz}","question":"Which one is even?"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		if revisions, _ := f.store.Revisions.GetQuestionRevisions(context.Background(), f.replacer.Replace("{single}")); len(revisions) != 0 {
+			t.Errorf("want the revisions deleted with the quiz, got %+v", revisions)
+		}
+	}},
+	{name: "delete quiz as viewer", method: "DELETE", route: "/quizzes/:id", path: "/quizzes/{quiz}", as: "viewer", status: 403},
+	{name: "quizzes of user", method: "GET", route: "/quizzes/user/:id", path: "/quizzes/user/{owner}", as: "owner", status: 200},
+	{name: "create quiz", method: "POST", route: "/quizzes/create", path: "/quizzes/create", as: "owner", body: `{"name":"Squares","description":"Numbers"}`, status: 200},
+	{name: "create quiz while impersonating", method: "POST", route: "/quizzes/create", path: "/quizzes/create", as: "impersonation", body: `{"name":"Squares"}`, status: 403},
+	{name: "import quiz", method: "POST", route: "/quizzes/import", path: "/quizzes/import", as: "owner", body: `{"name":"Squares","questions":[{"questionType":0,"question":"Which is a square?","answers":["2","3","4","5"],"correctAnswer":"C"},{"questionType":1,"question":"Which are squares?","answers":["1","2","4","6"],"correctAnswers":["A","C"]},{"questionType":2,"question":"Nine is a square.","correct_answer":true}]}`, status: 200, check: func(t *testing.T, f *fixture) {
+		quizzes, _ := f.store.Quizzes.SearchQuizzes(context.Background(), "Squares", 10, 0)
+		if len(quizzes) != 1 {
+			t.Fatalf("quizzes: %+v", quizzes)
+		}
+		single, _ := f.store.Questions.GetSingleChoiceQuestions(context.Background(), quizzes[0].Id)
+		multiple, _ := f.store.Questions.GetMultipleChoiceQuestions(context.Background(), quizzes[0].Id)
+		trueOrFalse, _ := f.store.Questions.GetTrueOrFalseQuestions(context.Background(), quizzes[0].Id)
+		if len(single) != 1 || len(multiple) != 1 || len(trueOrFalse) != 1 || !trueOrFalse[0].CorrectAnswer {
+			t.Errorf("questions: %+v %+v %+v", single, multiple, trueOrFalse)
+		}
+	}},
+	{name: "import quiz with an invalid question", method: "POST", route: "/quizzes/import", path: "/quizzes/import", as: "owner", body: `{"name":"Squares","questions":[{"questionType":2,"question":"Nine is a square."},{"questionType":0,"question":"Which is a square?","answers":["2","3","4"],"correctAnswer":"C"}]}`, status: 400, check: func(t *testing.T, f *fixture) {
+		if quizzes, _ := f.store.Quizzes.SearchQuizzes(context.Background(), "Squares", 10, 0); len(quizzes) != 0 {
+			t.Errorf("quizzes: %+v", quizzes)
+		}
+	}},
+	{name: "import quiz while impersonating", method: "POST", route: "/quizzes/import", path: "/quizzes/import", as: "impersonation", body: `{"name":"Squares"}`, status: 403},
+
+	{name: "generate multiple choice question", method: "POST", route: "/questions/multiple-choice", path: "/questions/multiple-choice", as: "owner", body: `{"quizId":"{quiz}","prompt":"Primes are divisible by one and themselves."}`, status: 200},
+	{name: "generate multiple choice question as viewer", method: "POST", route: "/questions/multiple-choice", path: "/questions/multiple-choice", as: "viewer", body: `{"quizId":"{quiz}","prompt":"Primes"}`, status: 403},
+	{name: "create multiple choice question", method: "POST", route: "/questions/multiple-choice/manual", path: "/questions/multiple-choice/manual", as: "owner", body: `{"quizId":"{quiz}","question":"Which are odd?","answers":["1","2","3","4"],"correctAnswers":["A","C"]}`, status: 200, check: func(t *testing.T, f *fixture) {
+		owner, _ := f.store.Users.GetUserByEmail(context.Background(), "owner@example.com")
+		if calls := f.store.UsageLedger.Calls(owner.Id); len(calls) != 0 {
+			t.Errorf("usage calls: %+v", calls)
+		}
+	}},
+	{name: "create multiple choice question without a correct answer", method: "POST", route: "/questions/multiple-choice/manual", path: "/questions/multiple-choice/manual", as: "owner", body: `{"quizId":"{quiz}","question":"Which are odd?","answers":["1","2","3","4"],"correctAnswers":[]}`, status: 400},
+	{name: "create multiple choice question as viewer", method: "POST", route: "/questions/multiple-choice/manual", path: "/questions/multiple-choice/manual", as: "viewer", body: `{"quizId":"{quiz}","question":"Which are odd?","answers":["1","2","3","4"],"correctAnswers":["A"]}`, status: 403},
+	{name: "get multiple choice question", method: "GET", route: "/questions/multiple-choice/:id", path: "/questions/multiple-choice/{multiple}", as: "viewer", status: 200},
+	{name: "get multiple choice question of another user", method: "GET", route: "/questions/multiple-choice/:id", path: "/questions/multiple-choice/{multiple}", as: "other", status: 404},
+	{name: "update multiple choice question", method: "PATCH", route: "/questions/multiple-choice/:id", path: "/questions/multiple-choice/{multiple}", as: "owner", body: `{"quizId":"{quiz}","question":"Which are odd?","answers":["1","2","3","4"],"correctAnswers":["A","C"]}`, status: 200},
+	{name: "update multiple choice question as viewer", method: "PATCH", route: "/questions/multiple-choice/:id", path: "/questions/multiple-choice/{multiple}", as: "viewer", body: `{"quizId":"{quiz}","question":"Mine"}`, status: 403},
+	{name: "update multiple choice question with an invalid answer", method: "PATCH", route: "/questions/multiple-choice/:id", path: "/questions/multiple-choice/{multiple}", as: "owner", body: `{"quizId":"{quiz}","correctAnswers":["E"]}`, status: 400},
+	{name: "delete multiple choice question", method: "DELETE", route: "/questions/multiple-choice/:quizId/:id", path: "/questions/multiple-choice/{quiz}/{multiple}", as: "owner", status: 200},
+	{name: "delete multiple choice question as viewer", method: "DELETE", route: "/questions/multiple-choice/:quizId/:id", path: "/questions/multiple-choice/{quiz}/{multiple}", as: "viewer", status: 403},
+	{name: "generate single choice question", method: "POST", route: "/questions/single-choice", path: "/questions/single-choice", as: "owner", body: `{"quizId":"{quiz}","prompt":"Two is the only even prime."}`, status: 200, check: func(t *testing.T, f *fixture) {
+		owner, _ := f.store.Users.GetUserByEmail(context.Background(), "owner@example.com")
+		if calls := f.store.UsageLedger.Calls(owner.Id); len(calls) != 1 || !calls[0].Success || calls[0].QuestionType != "single-choice" {
+			t.Errorf("usage calls: %+v", calls)
+		}
+	}},
+	{name: "generate single choice question of another user", method: "POST", route: "/questions/single-choice", path: "/questions/single-choice", as: "other", body: `{"quizId":"{quiz}","prompt":"Primes"}`, status: 404},
+	{name: "create single choice question", method: "POST", route: "/questions/single-choice/manual", path: "/questions/single-choice/manual", as: "owner", body: `{"quizId":"{quiz}","question":"Which is odd?","answers":["1","2","4","6"],"correctAnswer":"A"}`, status: 200, check: func(t *testing.T, f *fixture) {
+		quizzes, _ := f.store.Quizzes.SearchQuizzes(context.Background(), "Primes", 10, 0)
+		questions, _ := f.store.Questions.GetSingleChoiceQuestions(context.Background(), quizzes[0].Id)
+		if len(questions) != 2 {
+			t.Errorf("questions: %+v", questions)
+		}
+	}},
+	{name: "create single choice question with three answers", method: "POST", route: "/questions/single-choice/manual", path: "/questions/single-choice/manual", as: "owner", body: `{"quizId":"{quiz}","question":"Which is odd?","answers":["1","2","4"],"correctAnswer":"A"}`, status: 400},
+	{name: "create single choice question of another user", method: "POST", route: "/questions/single-choice/manual", path: "/questions/single-choice/manual", as: "other", body: `{"quizId":"{quiz}","question":"Which is odd?","answers":["1","2","4","6"],"correctAnswer":"A"}`, status: 404},
+	{name: "get single choice question", method: "GET", route: "/questions/single-choice/:id", path: "/questions/single-choice/{single}", as: "owner", status: 200},
+	{name: "get unknown single choice question", method: "GET", route: "/questions/single-choice/:id", path: "/questions/single-choice/{unknown}", as: "owner", status: 404},
+	{name: "update single choice question", method: "PATCH", route: "/questions/single-choice/:id", path: "/questions/single-choice/{single}", as: "owner", body: `{"quizId":"{quiz}","question":"Which is odd?","answers":["1","2","4","6"],"correctAnswer":"A"}`, status: 200},
+	{name: "update single choice question as viewer", method: "PATCH", route: "/questions/single-choice/:id", path: "/questions/single-choice/{single}", as: "viewer", body: `{"quizId":"{quiz}","question":"Mine"}`, status: 403},
+	{name: "update single choice question with an empty answer", method: "PATCH", route: "/questions/single-choice/:id", path: "/questions/single-choice/{single}", as: "owner", body: `{"quizId":"{quiz}","answers":["1","","4","6"]}`, status: 400},
+	{name: "delete single choice question", method: "DELETE", route: "/questions/single-choice/:quizId/:id", path: "/questions/single-choice/{quiz}/{single}", as: "owner", status: 200, before: []routeCase{
+		{method: "PATCH", path: "/questions/single-choice/{single}", as: "owner", body: `{"quizId":"{quiz}","question":"Which one is even?"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		if revisions, _ := f.store.Revisions.GetQuestionRevisions(context.Background(), f.replacer.Replace("{single}")); len(revisions) != 0 {
+			t.Errorf("want the revisions deleted with the question, got %+v", revisions)
+		}
+	}},
+	{name: "delete single choice question of another user", method: "DELETE", route: "/questions/single-choice/:quizId/:id", path: "/questions/single-choice/{quiz}/{single}", as: "other", status: 404},
+	{name: "generate true or false question", method: "POST", route: "/questions/true-or-false", path: "/questions/true-or-false", as: "owner", body: `{"quizId":"{quiz}","prompt":"Two is prime."}`, status: 200},
+	{name: "generate true or false question as viewer", method: "POST", route: "/questions/true-or-false", path: "/questions/true-or-false", as: "viewer", body: `{"quizId":"{quiz}","prompt":"Primes"}`, status: 403},
+	{name: "create true or false question", method: "POST", route: "/questions/true-or-false/manual", path: "/questions/true-or-false/manual", as: "owner", body: `{"quizId":"{quiz}","question":"Is 4 prime?","correctAnswer":false}`, status: 200},
+	{name: "create true or false question without a question", method: "POST", route: "/questions/true-or-false/manual", path: "/questions/true-or-false/manual", as: "owner", body: `{"quizId":"{quiz}","question":" ","correctAnswer":false}`, status: 400},
+	{name: "create true or false question as viewer", method: "POST", route: "/questions/true-or-false/manual", path: "/questions/true-or-false/manual", as: "viewer", body: `{"quizId":"{quiz}","question":"Is 4 prime?","correctAnswer":false}`, status: 403},
+	{name: "get true or false question", method: "GET", route: "/questions/true-or-false/:id", path: "/questions/true-or-false/{trueOrFalse}", as: "owner", status: 200},
+	{name: "get true or false question of another user", method: "GET", route: "/questions/true-or-false/:id", path: "/questions/true-or-false/{trueOrFalse}", as: "other", status: 404},
+	{name: "update true or false question", method: "PATCH", route: "/questions/true-or-false/:id", path: "/questions/true-or-false/{trueOrFalse}", as: "owner", body: `{"quizId":"{quiz}","question":"Is 4 prime?","correctAnswer":false}`, status: 200},
+	{name: "update true or false question as viewer", method: "PATCH", route: "/questions/true-or-false/:id", path: "/questions/true-or-false/{trueOrFalse}", as: "viewer", body: `{"quizId":"{quiz}","question":"Mine"}`, status: 403},
+	{name: "delete true or false question", method: "DELETE", route: "/questions/true-or-false/:quizId/:id", path: "/questions/true-or-false/{quiz}/{trueOrFalse}", as: "owner", status: 200},
+	{name: "delete true or false question as viewer", method: "DELETE", route: "/questions/true-or-false/:quizId/:id", path: "/questions/true-or-false/{quiz}/{trueOrFalse}", as: "viewer", status: 403},
+	{name: "update single choice question and reset its review items", method: "PATCH", route: "/questions/single-choice/:id", path: "/questions/single-choice/{single}?resetReviewItems=true", as: "owner", body: `{"quizId":"{quiz}","answers":["1","3","2","5"],"correctAnswer":"C"}`, status: 200, before: []routeCase{
+		{method: "POST", path: "/review-items/{reviewItem}/submit", as: "owner", body: `{"singleChoiceValue":"B"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		item, err := f.store.ReviewItems.GetReviewItem(context.Background(), f.replacer.Replace("{reviewItem}"))
+		if err != nil || item.Streak != 0 || item.EaseFactor != 2.5 || item.NextReviewDate.Time.After(time.Now()) {
+			t.Errorf("review item was not reset: %+v", item)
+		}
+		if events := f.store.AuditLog.Events(audit.ReviewItemsReset); len(events) != 1 {
+			t.Errorf("got %d reset events, want 1", len(events))
+		}
+	}},
+	{name: "update single choice question without changing the correct answer", method: "PATCH", route: "/questions/single-choice/:id", path: "/questions/single-choice/{single}?resetReviewItems=true", as: "owner", body: `{"quizId":"{quiz}","question":"Which one is even?"}`, status: 200, before: []routeCase{
+		{method: "POST", path: "/review-items/{reviewItem}/submit", as: "owner", body: `{"singleChoiceValue":"B"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		item, _ := f.store.ReviewItems.GetReviewItem(context.Background(), f.replacer.Replace("{reviewItem}"))
+		if item.Streak != 1 {
+			t.Errorf("got streak %d, the review item should be kept", item.Streak)
+		}
+	}},
+	{name: "question revisions", method: "GET", route: "/questions/:id/revisions", path: "/questions/{single}/revisions", as: "viewer", status: 200, before: []routeCase{
+		{method: "PATCH", path: "/questions/single-choice/{single}", as: "owner", body: `{"quizId":"{quiz}","question":"Which one is even?"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		revisions, _ := f.store.Revisions.GetQuestionRevisions(context.Background(), f.replacer.Replace("{single}"))
+		if len(revisions) != 2 || revisions[1].AuthorID != nil || revisions[0].AuthorName == nil || *revisions[0].AuthorName != "owner" {
+			t.Errorf("want the question before the history and the change of the owner, got %+v", revisions)
+		}
+	}},
+	{name: "question revisions of another user", method: "GET", route: "/questions/:id/revisions", path: "/questions/{single}/revisions", as: "other", status: 404},
+	{name: "question revisions of unknown question", method: "GET", route: "/questions/:id/revisions", path: "/questions/{unknown}/revisions", as: "owner", status: 404},
+	{name: "question revision diff", method: "GET", route: "/questions/:id/revisions/diff", path: "/questions/{multiple}/revisions/diff?from=1", as: "owner", status: 200, before: []routeCase{
+		{method: "PATCH", path: "/questions/multiple-choice/{multiple}", as: "owner", body: `{"quizId":"{quiz}","correctAnswers":["A"]}`, status: 200},
+	}},
+	{name: "question revision diff of unknown revision", method: "GET", route: "/questions/:id/revisions/diff", path: "/questions/{multiple}/revisions/diff?from=1&to=5", as: "owner", status: 404, before: []routeCase{
+		{method: "PATCH", path: "/questions/multiple-choice/{multiple}", as: "owner", body: `{"quizId":"{quiz}","correctAnswers":["A"]}`, status: 200},
+	}},
+	{name: "question revision diff without from", method: "GET", route: "/questions/:id/revisions/diff", path: "/questions/{multiple}/revisions/diff", as: "owner", status: 400},
+	{name: "restore question revision", method: "POST", route: "/questions/:id/revisions/:revision/restore", path: "/questions/{trueOrFalse}/revisions/1/restore", as: "owner", status: 200, before: []routeCase{
+		{method: "PATCH", path: "/questions/true-or-false/{trueOrFalse}", as: "owner", body: `{"quizId":"{quiz}","question":"Is 4 prime?","correctAnswer":false}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		restored, _ := f.store.Questions.GetTrueOrFalseQuestion(context.Background(), f.replacer.Replace("{trueOrFalse}"))
+		if restored.Question != "Is 2 prime?" || !restored.CorrectAnswer {
+			t.Errorf("question was not restored: %+v", restored)
+		}
+		revisions, _ := f.store.Revisions.GetQuestionRevisions(context.Background(), restored.UUID)
+		if len(revisions) != 3 || revisions[0].RestoredFrom == nil || *revisions[0].RestoredFrom != 1 {
+			t.Errorf("want the restore as the third revision, got %+v", revisions)
+		}
+	}},
+	{name: "restore unknown question revision", method: "POST", route: "/questions/:id/revisions/:revision/restore", path: "/questions/{trueOrFalse}/revisions/7/restore", as: "owner", status: 404},
+	{name: "restore question revision as viewer", method: "POST", route: "/questions/:id/revisions/:revision/restore", path: "/questions/{trueOrFalse}/revisions/1/restore", as: "viewer", status: 403, before: []routeCase{
+		{method: "PATCH", path: "/questions/true-or-false/{trueOrFalse}", as: "owner", body: `{"quizId":"{quiz}","question":"Is 4 prime?","correctAnswer":false}`, status: 200},
+	}},
+
+	{name: "generated question waits for review", method: "POST", route: "/questions/single-choice", path: "/questions/single-choice", as: "owner", body: `{"quizId":"{quiz}","prompt":"Two is the only even prime."}`, status: 200, check: func(t *testing.T, f *fixture) {
+		quizId := f.replacer.Replace("{quiz}")
+		questions, _ := f.store.Questions.GetSingleChoiceQuestions(context.Background(), quizId)
+		published, _ := f.store.Questions.GetPublishedSingleChoiceQuestions(context.Background(), quizId)
+		if len(questions) != 2 || questions[1].Status != question.STATUS_DRAFT || len(published) != 1 {
+			t.Errorf("want the generated question as an unpublished draft, got %+v", questions)
+		}
+	}},
+	{name: "reject question", method: "PUT", route: "/questions/:id/status", path: "/questions/{single}/status", as: "owner", body: `{"status":"rejected"}`, status: 200, check: func(t *testing.T, f *fixture) {
+		rejected, _ := f.store.Questions.GetSingleChoiceQuestion(context.Background(), f.replacer.Replace("{single}"))
+		if rejected.Status != question.STATUS_REJECTED || rejected.Published {
+			t.Errorf("question was not taken from learners: %+v", rejected)
+		}
+		if _, err := f.store.ReviewItems.GetReviewItem(context.Background(), f.replacer.Replace("{reviewItem}")); err == nil {
+			t.Error("the review item of the rejected question is still reviewed")
+		}
+	}},
+	{name: "approve question without publishing", method: "PUT", route: "/questions/:id/status", path: "/questions/{single}/status", as: "owner", body: `{"status":"approved"}`, status: 200, before: []routeCase{
+		{method: "PUT", path: "/questions/{single}/status", as: "owner", body: `{"status":"rejected"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		approved, _ := f.store.Questions.GetSingleChoiceQuestion(context.Background(), f.replacer.Replace("{single}"))
+		if approved.Status != question.STATUS_APPROVED || approved.Published {
+			t.Errorf("want the question approved for the next publishing, got %+v", approved)
+		}
+	}},
+	{name: "update question status to draft", method: "PUT", route: "/questions/:id/status", path: "/questions/{single}/status", as: "owner", body: `{"status":"draft"}`, status: 400},
+	{name: "update question status as viewer", method: "PUT", route: "/questions/:id/status", path: "/questions/{single}/status", as: "viewer", body: `{"status":"rejected"}`, status: 403},
+	{name: "publish quiz", method: "POST", route: "/quizzes/:id/publish", path: "/quizzes/{quiz}/publish", as: "owner", status: 200, before: []routeCase{
+		{method: "PUT", path: "/questions/{single}/status", as: "owner", body: `{"status":"rejected"}`, status: 200},
+		{method: "POST", path: "/learn-list/{quiz}/add", as: "viewer", status: 200},
+		{method: "PUT", path: "/questions/{single}/status", as: "owner", body: `{"status":"approved"}`, status: 200},
+	}, check: func(t *testing.T, f *fixture) {
+		published, _ := f.store.Questions.GetPublishedSingleChoiceQuestions(context.Background(), f.replacer.Replace("{quiz}"))
+		if len(published) != 1 {
+			t.Errorf("want the approved question published, got %+v", published)
+		}
+		viewer, _ := f.store.Users.GetUserByEmail(context.Background(), "viewer@example.com")
+		if items, _ := f.store.ReviewItems.GetReviewItems(context.Background(), viewer.Id); len(items) != 3 {
+			t.Errorf("want a review item for every published question, got %d", len(items))
+		}
+		if events := f.store.AuditLog.Events(audit.QuizPublished); len(events) != 1 {
+			t.Errorf("got %d publish events, want 1", len(events))
+		}
+	}},
+	{name: "publish quiz without approved questions", method: "POST", route: "/quizzes/:id/publish", path: "/quizzes/{quiz}/publish", as: "owner", status: 409, before: []routeCase{
+		{method: "PUT", path: "/questions/{single}/status", as: "owner", body: `{"status":"rejected"}`, status: 200},
+		{method: "PUT", path: "/questions/{multiple}/status", as: "owner", body: `{"status":"rejected"}`, status: 200},
+		{method: "PUT", path: "/questions/{trueOrFalse}/status", as: "owner", body: `{"status":"rejected"}`, status: 200},
+	}},
+	{name: "publish quiz as viewer", method: "POST", route: "/quizzes/:id/publish", path: "/quizzes/{quiz}/publish", as: "viewer", status: 403},
+	{name: "archive quiz", method: "POST", route: "/quizzes/:id/archive", path: "/quizzes/{quiz}/archive", as: "owner", status: 200, check: func(t *testing.T, f *fixture) {
+		archived, _ := f.store.Quizzes.GetQuizById(context.Background(), f.replacer.Replace("{quiz}"))
+		if archived.Status != "archived" {
+			t.Errorf("got status %q", archived.Status)
+		}
+	}},
+	{name: "archive quiz of another user", method: "POST", route: "/quizzes/:id/archive", path: "/quizzes/{quiz}/archive", as: "other", status: 404},
+	{name: "start quiz session of archived quiz", method: "POST", route: "/quiz-sessions/start", path: "/quiz-sessions/start", as: "viewer", body: `{"quizId":"{quiz}"}`, status: 409, before: []routeCase{
+		{method: "POST", path: "/quizzes/{quiz}/archive", as: "owner", status: 200},
+	}},
+	{name: "add archived quiz to learn list", method: "POST", route: "/learn-list/:quizID/add", path: "/learn-list/{quiz}/add", as: "viewer", status: 409, before: []routeCase{
+		{method: "POST", path: "/quizzes/{quiz}/archive", as: "owner", status: 200},
+	}},
+	{name: "get quiz session", method: "GET", route: "/quiz-sessions/:quizSessionId", path: "/quiz-sessions/{session}", as: "owner", status: 200},
+	{name: "get quiz session of another user", method: "GET", route: "/quiz-sessions/:quizSessionId", path: "/quiz-sessions/{session}", as: "other", status: 404},
+	{name: "list quiz sessions", method: "GET", route: "/quiz-sessions", path: "/quiz-sessions?quizId={quiz}&open=true", as: "owner", status: 200},
+	{name: "has open quiz session", method: "GET", route: "/quiz-sessions/has-open", path: "/quiz-sessions/has-open?quizId={quiz}", as: "owner", status: 200},
+	{name: "has no open quiz session", method: "GET", route: "/quiz-sessions/has-open", path: "/quiz-sessions/has-open?quizId={quiz}", as: "viewer", status: 404},
+	{name: "start quiz session", method: "POST", route: "/quiz-sessions/start", path: "/quiz-sessions/start", as: "viewer", body: `{"quizId":"{quiz}"}`, status: 200},
+	{name: "start quiz session of another user", method: "POST", route: "/quiz-sessions/start", path: "/quiz-sessions/start", as: "other", body: `{"quizId":"{quiz}"}`, status: 404},
+	{name: "submit quiz session", method: "POST", route: "/quiz-sessions/:quizSessionId/submit", path: "/quiz-sessions/{session}/submit", as: "owner", status: 200},
+	{name: "submit quiz session again", method: "POST", route: "/quiz-sessions/:quizSessionId/submit", path: "/quiz-sessions/{session}/submit", as: "owner", status: 200, before: []routeCase{
+		{method: "POST", path: "/quiz-sessions/{session}/submit", as: "owner", status: 200},
+	}},
+	{name: "submit quiz session of another user", method: "POST", route: "/quiz-sessions/:quizSessionId/submit", path: "/quiz-sessions/{session}/submit", as: "other", status: 404},
+	{name: "get quiz result", method: "GET", route: "/quiz-sessions/:quizSessionId/result", path: "/quiz-sessions/{session}/result", as: "owner", status: 200, before: []routeCase{
+		{method: "POST", path: "/quiz-sessions/{session}/submit", as: "owner", status: 200},
+	}},
+	{name: "get quiz result before submit", method: "GET", route: "/quiz-sessions/:quizSessionId/result", path: "/quiz-sessions/{session}/result", as: "owner", status: 404},
+	{name: "get quiz result of another user", method: "GET", route: "/quiz-sessions/:quizSessionId/result", path: "/quiz-sessions/{session}/result", as: "other", status: 404},
+	{name: "get answers", method: "GET", route: "/quiz-sessions/:quizSessionId/answers", path: "/quiz-sessions/{session}/answers", as: "owner", status: 200},
+	{name: "get answers of another user", method: "GET", route: "/quiz-sessions/:quizSessionId/answers", path: "/quiz-sessions/{session}/answers", as: "other", status: 404},
+	{name: "answer", method: "PUT", route: "/quiz-sessions/:quizSessionId/answers", path: "/quiz-sessions/{session}/answers", as: "owner", body: `{"questionId":"{single}","answerType":"single-choice","answer":"B"}`, status: 200},
+	{name: "change answer", method: "PUT", route: "/quiz-sessions/:quizSessionId/answers", path: "/quiz-sessions/{session}/answers", as: "owner", body: `{"questionId":"{single}","answerType":"single-choice","answer":"A"}`, status: 200, before: []routeCase{
+		{method: "PUT", path: "/quiz-sessions/{session}/answers", as: "owner", body: `{"questionId":"{single}","answerType":"single-choice","answer":"B"}`, status: 200},
+	}},
+	{name: "answer in session of another user", method: "PUT", route: "/quiz-sessions/:quizSessionId/answers", path: "/quiz-sessions/{session}/answers", as: "other", body: `{"questionId":"{single}","answerType":"single-choice","answer":"B"}`, status: 404},
+	{name: "answer after submit", method: "PUT", route: "/quiz-sessions/:quizSessionId/answers", path: "/quiz-sessions/{session}/answers", as: "owner", body: `{"questionId":"{trueOrFalse}","answerType":"true-or-false","answer":true}`, status: 403, before: []routeCase{
+		{method: "POST", path: "/quiz-sessions/{session}/submit", as: "owner", status: 200},
+	}},
+
+	{name: "quiz history", method: "GET", route: "/quiz-history", path: "/quiz-history?userID={owner}", as: "owner", status: 200, before: []routeCase{
+		{method: "POST", path: "/quiz-sessions/{session}/submit", as: "owner", status: 200},
+	}},
+	{name: "quiz history of another user", method: "GET", route: "/quiz-history", path: "/quiz-history?userID={owner}", as: "other", status: 403},
+
+	{name: "learn list", method: "GET", route: "/learn-list", path: "/learn-list", as: "owner", status: 200},
+	{name: "add quiz to learn list", method: "POST", route: "/learn-list/:quizID/add", path: "/learn-list/{quiz}/add", as: "viewer", status: 200},
+	{name: "add quiz of another user to learn list", method: "POST", route: "/learn-list/:quizID/add", path: "/learn-list/{quiz}/add", as: "other", status: 404},
+	{name: "remove quiz from learn list", method: "POST", route: "/learn-list/:quizID/remove", path: "/learn-list/{quiz}/remove", as: "viewer", status: 200, before: []routeCase{
+		{method: "POST", path: "/learn-list/{quiz}/add", as: "viewer", status: 200},
+	}},
+
+	{name: "review items", method: "GET", route: "/review-items", path: "/review-items", as: "owner", body: `{"status":"due"}`, status: 200},
+	{name: "review items with invalid filter", method: "GET", route: "/review-items", path: "/review-items", as: "owner", body: `{"status":"soon"}`, status: 400},
+	{name: "quiz options", method: "GET", route: "/review-items/quiz-options", path: "/review-items/quiz-options", as: "owner", status: 200},
+	{name: "review item counts", method: "GET", route: "/review-items/item-counts", path: "/review-items/item-counts", as: "owner", status: 200},
+	{name: "review item question", method: "GET", route: "/review-items/get-question/:reviewItemID", path: "/review-items/get-question/{reviewItem}", as: "owner", status: 200},
+	{name: "review item question of another user", method: "GET", route: "/review-items/get-question/:reviewItemID", path: "/review-items/get-question/{reviewItem}", as: "other", status: 404},
+	{name: "next due review item question", method: "GET", route: "/review-items/get-question", path: "/review-items/get-question", as: "owner", status: 200},
+	{name: "no due review item question", method: "GET", route: "/review-items/get-question", path: "/review-items/get-question", as: "viewer", status: 404},
+	{name: "submit review item", method: "POST", route: "/review-items/:reviewItemID/submit", path: "/review-items/{reviewItem}/submit", as: "owner", body: `{"singleChoiceValue":"B"}`, status: 200},
+	{name: "submit review item of another user", method: "POST", route: "/review-items/:reviewItemID/submit", path: "/review-items/{reviewItem}/submit", as: "other", body: `{"singleChoiceValue":"B"}`, status: 404},
+}
+
+// Routes anyone can call, every other route rejects requests without a session
+var publicRoutes = map[string]bool{
+	"GET /healthz":                  true,
+	"GET /readyz":                   true,
+	"POST /authenticate-user":       true,
+	"POST /authenticate-user/totp":  true,
+	"POST /create-user":             true,
+	"GET /verify-email":             true,
+	"POST /resend-verification":     true,
+	"GET /oidc/providers":           true,
+	"GET /oidc/:provider/authorize": true,
+	"POST /oidc/:provider/callback": true,
+	"POST /erasure/confirm":         true,
+}
+
+func TestRoutes(t *testing.T) {
+	for _, tc := range routeCases {
+		t.Run(tc.method+" "+tc.route+" "+tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			for _, before := range tc.before {
+				if rec := f.do(before.method, before.path, before.as, before.body); rec.Code != before.status {
+					t.Fatalf("%s %s: got %d, want %d: %s", before.method, before.path, rec.Code, before.status, rec.Body)
+				}
+			}
+			rec := f.do(tc.method, tc.path, tc.as, tc.body)
+			if f.route != tc.route {
+				t.Errorf("routed to %q, want %q", f.route, tc.route)
+			}
+			if rec.Code != tc.status {
+				t.Errorf("got %d, want %d: %s", rec.Code, tc.status, rec.Body)
+			}
+			if tc.check != nil {
+				tc.check(t, f)
+			}
+		})
+	}
+}
+
+// Clients outside the private network cannot pick the address they are
+// limited by, only the frontend's X-Forwarded-For is trusted
+func TestSpoofedForwardedForKeepsLoginLimit(t *testing.T) {
+	f := newFixture(t)
+	server := newServer(f.app, ratelimit.NewMemoryStore())
+	for i := 0; i < 11; i++ {
+		// The malformed body stops the handler before the lockout, only the limiter counts
+		req := httptest.NewRequest("POST", "/authenticate-user", strings.NewReader(`{`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderXForwardedFor, fmt.Sprintf("198.51.100.%d", i))
+		req.RemoteAddr = "203.0.113.7:4711"
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		if i < 10 && rec.Code == http.StatusTooManyRequests {
+			t.Fatalf("attempt %d limited already", i+1)
+		}
+		if i == 10 && rec.Code != http.StatusTooManyRequests {
+			t.Errorf("attempt 11 with a new X-Forwarded-For: got %d, want 429", rec.Code)
+		}
+	}
+}
+
+// Publishing while a learner takes the quiz changes what later sessions get,
+// the running one is scored against the questions it started with
+func TestQuizSessionKeepsItsQuestions(t *testing.T) {
+	f := newFixture(t)
+	rec := f.do("POST", "/quiz-sessions/start", "viewer", `{"quizId":"{quiz}"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("start: got %d: %s", rec.Code, rec.Body)
+	}
+	var session models.QuizSession
+	if err := json.Unmarshal(rec.Body.Bytes(), &session); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, step := range []routeCase{
+		{method: "PUT", path: "/quiz-sessions/" + session.ID + "/answers", as: "viewer", body: `{"questionId":"{single}","answerType":"single-choice","answer":"B"}`},
+		{method: "POST", path: "/questions/true-or-false/manual", as: "owner", body: `{"quizId":"{quiz}","question":"Is 4 prime?","correctAnswer":false}`},
+		{method: "PUT", path: "/questions/{single}/status", as: "owner", body: `{"status":"rejected"}`},
+		{method: "POST", path: "/quizzes/{quiz}/publish", as: "owner"},
+	} {
+		if rec := f.do(step.method, step.path, step.as, step.body); rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: got %d: %s", step.method, step.path, rec.Code, rec.Body)
+		}
+	}
+
+	rec = f.do("POST", "/quiz-sessions/"+session.ID+"/submit", "viewer", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("submit: got %d: %s", rec.Code, rec.Body)
+	}
+	var result models.QuizResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.AnswerScores) != 3 || result.MaxScore != 3 || result.Score != 1 {
+		t.Errorf("want the three questions of the start with the single choice one right, got %+v", result)
+	}
+}
+
+// Generations sent at once must not get past the quota together, and a
+// failed one gives its place back
+func TestGenerationsKeepTheQuota(t *testing.T) {
+	f := newFixture(t)
+	usage.InitQuotaConfig(map[string]config.Quota{config.DefaultPlan: {Daily: 2}})
+	t.Cleanup(func() { usage.InitQuotaConfig(config.Default().Quotas) })
+
+	f.store.Llm.Fail("multiple-choice")
+	if rec := f.do("POST", "/questions/multiple-choice", "owner", `{"quizId":"{quiz}","prompt":"Primes"}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("failed generation: got %d: %s", rec.Code, rec.Body)
+	}
+
+	// Without the route recorder of the fixture, which is for one request at a time
+	server := newServer(f.app, unlimited{})
+	statuses := make(chan int, 6)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(statuses); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/questions/single-choice", strings.NewReader(f.replacer.Replace(`{"quizId":"{quiz}","prompt":"Primes"}`)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.AddCookie(&http.Cookie{Name: "session", Value: f.cookies["owner"]})
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			statuses <- rec.Code
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+	counts := map[int]int{}
+	for status := range statuses {
+		counts[status]++
+	}
+	if counts[http.StatusOK] != 2 || counts[http.StatusTooManyRequests] != 4 {
+		t.Errorf("want two generations and four over the quota, got %v", counts)
+	}
+}
+
+func TestProtectedRoutesRequireSession(t *testing.T) {
+	f := newFixture(t)
+	for _, route := range f.server.Routes() {
+		key := route.Method + " " + route.Path
+		if route.Method == echo.RouteNotFound || publicRoutes[key] {
+			continue
+		}
+		t.Run(key, func(t *testing.T) {
+			path := strings.NewReplacer(":provider", "google", ":quizSessionId", "{session}", ":reviewItemID", "{reviewItem}", ":quizID", "{quiz}", ":quizId", "{quiz}", ":id", "{unknown}").Replace(route.Path)
+			rec := f.do(route.Method, path, "", "{}")
+			if f.route != route.Path {
+				t.Errorf("routed to %q, want %q", f.route, route.Path)
+			}
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("got %d, want 401: %s", rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestEveryRouteHasCases(t *testing.T) {
+	covered := map[string]bool{}
+	for _, tc := range routeCases {
+		covered[tc.method+" "+tc.route] = true
+	}
+	f := newFixture(t)
+	for _, route := range f.server.Routes() {
+		key := route.Method + " " + route.Path
+		if route.Method != echo.RouteNotFound && !covered[key] {
+			t.Errorf("no case for %s", key)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"spaced-ace-backend/config"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// The one connection pool of the backend with the sqlc queries on top
+type Store struct {
+	*db.Queries
+	Pool *pgxpool.Pool
+}
+
+// The queries of a transaction, Tx runs the few statements sqlc cannot express
+type Tx struct {
+	*db.Queries
+	Tx pgx.Tx
+}
+
+// Connects to the configured database
+func New(ctx context.Context, cfg config.Database) (*Store, error) {
+	poolConfig, err := pgxpool.ParseConfig("sslmode=disable")
+	if err != nil {
+		return nil, fmt.Errorf("parsing database config: %w", err)
+	}
+	// Set directly instead of formatting a connection string, so passwords
+	// with spaces or quotes need no escaping
+	poolConfig.ConnConfig.Host = cfg.Host
+	poolConfig.ConnConfig.Port = uint16(cfg.Port)
+	poolConfig.ConnConfig.User = cfg.User
+	poolConfig.ConnConfig.Password = cfg.Password
+	poolConfig.ConnConfig.Database = cfg.Name
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating connection pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("connecting to the database: %w", err)
+	}
+	return &Store{Queries: db.New(pool), Pool: pool}, nil
+}
+
+func (s *Store) Close() {
+	s.Pool.Close()
+}
+
+// Runs f in a transaction, committed when f returns nil and rolled back otherwise
+func (s *Store) WithTx(ctx context.Context, f func(tx *Tx) error) error {
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := f(&Tx{Queries: s.Queries.WithTx(tx), Tx: tx}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Conversions between the pgtype values of the sqlc models and the plain Go
+// types of the repositories
+
+func Timestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+func TimePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// Returns the zero value for nil, for nullable columns that are always set in practice
+func Value[T any](p *T) T {
+	var value T
+	if p != nil {
+		value = *p
+	}
+	return value
+}
+
+func Ptr[T any](value T) *T {
+	return &value
+}
+
+func NullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
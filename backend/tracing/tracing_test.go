@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRequestToTheLlmApiContinuesTheIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var forwarded string
+	llm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("traceparent")
+	}))
+	defer llm.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	e := echo.New()
+	e.Use(Middleware)
+	e.POST("/questions/:type", func(c echo.Context) error {
+		req, _ := http.NewRequestWithContext(c.Request().Context(), http.MethodPost, llm.URL+"/generate", nil)
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		return c.NoContent(http.StatusCreated)
+	})
+	const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/questions/single-choice", nil)
+	req.Header.Set("traceparent", "00-"+traceId+"-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want the client and the server span", len(spans))
+	}
+	outgoing, server := spans[0], spans[1]
+	if server.Name() != "POST /questions/:type" || server.SpanKind() != trace.SpanKindServer {
+		t.Errorf("server span: got %q of kind %v", server.Name(), server.SpanKind())
+	}
+	if server.SpanContext().TraceID().String() != traceId || server.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("server span: got parent %v, want the incoming trace", server.Parent())
+	}
+	if outgoing.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("client span: got parent %v, want the server span", outgoing.Parent())
+	}
+	if want := "00-" + traceId + "-" + outgoing.SpanContext().SpanID().String() + "-01"; forwarded != want {
+		t.Errorf("traceparent sent to the llm api: got %q, want %q", forwarded, want)
+	}
+}
@@ -0,0 +1,39 @@
+package usage
+
+import (
+	"spaced-ace-backend/config"
+)
+
+const DefaultPlan = config.DefaultPlan
+
+// A limit of 0 means the plan is not limited in that period
+type Plan struct {
+	Name         string
+	DailyLimit   int
+	MonthlyLimit int
+}
+
+var plans = newPlans(config.Default().Quotas)
+
+// Replaces the plans with the configured generation quotas
+//
+// unsafe to call concurrently
+func InitQuotaConfig(quotas map[string]config.Quota) {
+	plans = newPlans(quotas)
+}
+
+func newPlans(quotas map[string]config.Quota) map[string]*Plan {
+	plans := make(map[string]*Plan, len(quotas))
+	for name, quota := range quotas {
+		plans[name] = &Plan{Name: name, DailyLimit: quota.Daily, MonthlyLimit: quota.Monthly}
+	}
+	return plans
+}
+
+// Returns the plan with the given name, users on unknown plans get the default one
+func GetPlan(name string) Plan {
+	if plan, exists := plans[name]; exists {
+		return *plan
+	}
+	return *plans[DefaultPlan]
+}
@@ -0,0 +1,183 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"spaced-ace-backend/db"
+	"spaced-ace-backend/store"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// A single request to the llm api
+type Call struct {
+	Id           string
+	UserId       string
+	QuizId       string
+	QuestionType string
+	PromptChars  int
+	Latency      time.Duration
+	Success      bool
+}
+
+type Period struct {
+	Name     string    `json:"name"`
+	Limit    int       `json:"limit"`
+	Used     int       `json:"used"`
+	ResetsAt time.Time `json:"resetsAt"`
+}
+
+type Summary struct {
+	Plan    string `json:"plan"`
+	Daily   Period `json:"daily"`
+	Monthly Period `json:"monthly"`
+}
+
+type QuotaExceededError struct {
+	Period Period
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s generation quota of %d questions exceeded", e.Period.Name, e.Period.Limit)
+}
+
+// Where the calls are stored, PostgresLedger on the server and an in-memory
+// fake in the handler tests
+type Ledger interface {
+	// Stores the call, or its outcome when a call with its id is stored already
+	Record(ctx context.Context, call Call) error
+	// Returns the number of successful calls of the user at or after since
+	CountSuccessfulSince(ctx context.Context, userId string, since time.Time) (int, error)
+	// Runs f with the calls of the user locked against other reservations, so
+	// what f counts still holds when it records
+	WithUserLock(ctx context.Context, userId string, f func(l Ledger) error) error
+}
+
+// Stores the call as successful before it is made, unless that takes the user
+// over the quota of the plan, in which case it returns a *QuotaExceededError.
+// Record the outcome with the returned call, a failed one frees its place.
+func Reserve(ctx context.Context, ledger Ledger, call Call, planName string) (Call, error) {
+	call.Id = uuid.NewString()
+	call.Success = true
+	err := ledger.WithUserLock(ctx, call.UserId, func(l Ledger) error {
+		if err := checkQuota(ctx, l, call.UserId, planName); err != nil {
+			return err
+		}
+		return l.Record(ctx, call)
+	})
+	return call, err
+}
+
+// Stores the outcome of a reserved call, or adds a call made without reserving
+func Record(ctx context.Context, ledger Ledger, call Call) error {
+	if call.Id == "" {
+		call.Id = uuid.NewString()
+	}
+	return ledger.Record(ctx, call)
+}
+
+type PostgresLedger struct {
+	queries *db.Queries
+	// Nil for the ledger of a transaction
+	store *store.Store
+}
+
+func NewPostgresLedger(s *store.Store) *PostgresLedger {
+	return &PostgresLedger{queries: s.Queries, store: s}
+}
+
+func (l *PostgresLedger) Record(ctx context.Context, call Call) error {
+	var quizId *string
+	if call.QuizId != "" {
+		quizId = &call.QuizId
+	}
+	_, err := l.queries.RecordLlmUsage(ctx, db.RecordLlmUsageParams{
+		ID:           call.Id,
+		UserID:       call.UserId,
+		QuizID:       quizId,
+		QuestionType: call.QuestionType,
+		PromptChars:  int32(call.PromptChars),
+		LatencyMs:    int32(call.Latency.Milliseconds()),
+		Success:      call.Success,
+		CreatedAt:    timestamp(time.Now().UTC()),
+	})
+	return err
+}
+
+func (l *PostgresLedger) WithUserLock(ctx context.Context, userId string, f func(l Ledger) error) error {
+	if l.store == nil {
+		return f(l)
+	}
+	return l.store.WithTx(ctx, func(tx *store.Tx) error {
+		if err := tx.LockLlmUsageOfUser(ctx, userId); err != nil {
+			return err
+		}
+		return f(&PostgresLedger{queries: tx.Queries})
+	})
+}
+
+// Returns the successful generations of the user in the current day and
+// month. Periods are calendar days and months in UTC, failed calls are free.
+func GetSummary(ctx context.Context, ledger Ledger, userId string, planName string) (*Summary, error) {
+	plan := GetPlan(planName)
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	daily, err := ledger.CountSuccessfulSince(ctx, userId, dayStart)
+	if err != nil {
+		return nil, err
+	}
+	monthly, err := ledger.CountSuccessfulSince(ctx, userId, monthStart)
+	if err != nil {
+		return nil, err
+	}
+	return &Summary{
+		Plan: plan.Name,
+		Daily: Period{
+			Name:     "daily",
+			Limit:    plan.DailyLimit,
+			Used:     daily,
+			ResetsAt: dayStart.AddDate(0, 0, 1),
+		},
+		Monthly: Period{
+			Name:     "monthly",
+			Limit:    plan.MonthlyLimit,
+			Used:     monthly,
+			ResetsAt: monthStart.AddDate(0, 1, 0),
+		},
+	}, nil
+}
+
+// Returns a *QuotaExceededError when the user cannot generate another question
+func checkQuota(ctx context.Context, ledger Ledger, userId string, planName string) error {
+	summary, err := GetSummary(ctx, ledger, userId, planName)
+	if err != nil {
+		return err
+	}
+	// The monthly quota is reported first, it takes longer to reset
+	for _, period := range []Period{summary.Monthly, summary.Daily} {
+		if period.Limit > 0 && period.Used >= period.Limit {
+			return &QuotaExceededError{Period: period}
+		}
+	}
+	return nil
+}
+
+func (l *PostgresLedger) CountSuccessfulSince(ctx context.Context, userId string, since time.Time) (int, error) {
+	count, err := l.queries.CountSuccessfulLlmUsageSince(ctx, db.CountSuccessfulLlmUsageSinceParams{
+		UserID:    userId,
+		CreatedAt: timestamp(since),
+	})
+	return int(count), err
+}
+
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{
+		Time:             t,
+		InfinityModifier: pgtype.Finite,
+		Valid:            true,
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"spaced-ace/context"
+	"spaced-ace/logging"
+	"spaced-ace/models/request"
+	"spaced-ace/render"
+	"spaced-ace/views/components"
+	"spaced-ace/views/pages"
+)
+
+// The session of the admin is kept in this cookie while they look at the account of a user
+const adminSessionCookie = "admin_session"
+
+func bindAdminSearch(c echo.Context) request.AdminSearchForm {
+	var form request.AdminSearchForm
+	_ = c.Bind(&form)
+	if form.Page < 1 {
+		form.Page = 1
+	}
+	return form
+}
+
+func handleAdminUsersPage(c echo.Context) error {
+	hxRequest := c.Request().Header.Get("HX-Request") == "true"
+	if !hxRequest {
+		return handleNonHXRequest(c)
+	}
+
+	cc := c.(*context.AppContext)
+	form := bindAdminSearch(c)
+	users, err := cc.ApiService.AdminSearchUsers(form.Query, form.Page)
+	if err != nil {
+		return err
+	}
+
+	viewModel := pages.AdminUsersPageViewModel{
+		Query: form.Query,
+		Users: *users,
+	}
+	return render.TemplRender(c, 200, pages.AdminUsersPage(viewModel))
+}
+func handleAdminSearchUsers(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	form := bindAdminSearch(c)
+	users, err := cc.ApiService.AdminSearchUsers(form.Query, form.Page)
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.AdminUserList(*users, form.Query))
+}
+func handleAdminUserPage(c echo.Context) error {
+	hxRequest := c.Request().Header.Get("HX-Request") == "true"
+	if !hxRequest {
+		return handleNonHXRequest(c)
+	}
+
+	cc := c.(*context.AppContext)
+	user, err := cc.ApiService.AdminGetUser(c.Param("userId"))
+	if err != nil {
+		return c.Redirect(http.StatusFound, "/not-found")
+	}
+
+	viewModel := pages.AdminUserPageViewModel{
+		User:   *user,
+		IsSelf: user.Id == cc.Session.User.Id,
+	}
+	return render.TemplRender(c, 200, pages.AdminUserPage(viewModel))
+}
+
+// Runs one of the account actions of the admin user page and renders the details again
+func handleAdminUserAction(action func(cc *context.AppContext, userId string) error, successMessage string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cc := c.(*context.AppContext)
+		errors := map[string]string{}
+		message := ""
+
+		if err := action(cc, c.Param("userId")); err != nil {
+			errors["other"] = "Error: " + err.Error()
+		} else {
+			message = successMessage
+		}
+		return renderAdminUserDetails(c, message, errors)
+	}
+}
+func handleAdminSetRole(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+	message := ""
+
+	var form request.AdminSetRoleForm
+	if err := c.Bind(&form); err != nil {
+		errors["other"] = "Parsing error: " + err.Error()
+	} else if err := cc.ApiService.AdminSetRole(c.Param("userId"), form.Role); err != nil {
+		errors["other"] = "Error changing the role: " + err.Error()
+	} else {
+		message = "Role changed"
+	}
+	return renderAdminUserDetails(c, message, errors)
+}
+func renderAdminUserDetails(c echo.Context, message string, errors map[string]string) error {
+	cc := c.(*context.AppContext)
+	user, err := cc.ApiService.AdminGetUser(c.Param("userId"))
+	if err != nil {
+		return err
+	}
+	isSelf := user.Id == cc.Session.User.Id
+	return render.TemplRender(c, 200, components.AdminUserDetails(*user, isSelf, message, errors))
+}
+
+// Swaps the session cookie for a read-only session of the user. The admin's
+// own session is kept aside and restored by handleStopImpersonation.
+func handleAdminImpersonate(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	impersonation, err := cc.ApiService.AdminImpersonate(c.Param("userId"))
+	if err != nil {
+		return renderAdminUserDetails(c, "", map[string]string{"other": "Error: " + err.Error()})
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     adminSessionCookie,
+		Value:    cc.Session.Id,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.SetCookie(&http.Cookie{
+		Name:     "session",
+		Value:    impersonation.Session,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  impersonation.ValidUntil,
+	})
+	c.Response().Header().Set("HX-Redirect", "/my-quizzes")
+	return c.NoContent(http.StatusOK)
+}
+func handleStopImpersonation(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	if cc.Session.ImpersonatedBy != nil {
+		if err := cc.ApiService.DeleteSession(); err != nil {
+			logging.Logger(c.Request().Context()).Error("failed to end impersonation session", "error", err)
+		}
+	}
+
+	adminSession, err := c.Cookie(adminSessionCookie)
+	if err != nil {
+		c.SetCookie(&http.Cookie{Name: "session", Path: "/", MaxAge: -1})
+		c.Response().Header().Set("HX-Redirect", "/login")
+		return c.NoContent(http.StatusOK)
+	}
+	c.SetCookie(&http.Cookie{
+		Name:     "session",
+		Value:    adminSession.Value,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	c.SetCookie(&http.Cookie{Name: adminSessionCookie, Path: "/", MaxAge: -1})
+	c.Response().Header().Set("HX-Redirect", "/admin/users/"+cc.Session.User.Id)
+	return c.NoContent(http.StatusOK)
+}
+
+func handleAdminQuizzesPage(c echo.Context) error {
+	hxRequest := c.Request().Header.Get("HX-Request") == "true"
+	if !hxRequest {
+		return handleNonHXRequest(c)
+	}
+
+	cc := c.(*context.AppContext)
+	form := bindAdminSearch(c)
+	quizzes, err := cc.ApiService.AdminSearchQuizzes(form.Query, form.Page)
+	if err != nil {
+		return err
+	}
+
+	viewModel := pages.AdminQuizzesPageViewModel{
+		Query:   form.Query,
+		Quizzes: *quizzes,
+		IsAdmin: cc.Session.User.IsAdmin(),
+	}
+	return render.TemplRender(c, 200, pages.AdminQuizzesPage(viewModel))
+}
+func handleAdminSearchQuizzes(c echo.Context) error {
+	return renderAdminQuizList(c, map[string]string{})
+}
+func handleAdminDeleteQuiz(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	if err := cc.ApiService.AdminDeleteQuiz(c.Param("quizId")); err != nil {
+		errors["other"] = "Error deleting the quiz: " + err.Error()
+	}
+	return renderAdminQuizList(c, errors)
+}
+func renderAdminQuizList(c echo.Context, errors map[string]string) error {
+	cc := c.(*context.AppContext)
+	form := bindAdminSearch(c)
+	quizzes, err := cc.ApiService.AdminSearchQuizzes(form.Query, form.Page)
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.AdminQuizList(*quizzes, form.Query, errors))
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"spaced-ace/auth"
 	"spaced-ace/context"
+	"spaced-ace/logging"
 )
 
 func RegisterRoutes(e *echo.Echo) {
@@ -52,6 +53,21 @@ func RegisterRoutes(e *echo.Echo) {
 	protected.PATCH("/quizzes/:id", handleUpdateQuiz)
 	protected.DELETE("/questions/:questionId", handleDeleteQuestion)
 
+	// Question editing
+	protected.GET("/questions/new", handleNewQuestionForm)
+	protected.POST("/questions", handleCreateQuestion)
+	protected.GET("/questions/:questionId", handleGetQuestion)
+	protected.GET("/questions/:questionId/edit", handleEditQuestion)
+	protected.PATCH("/questions/:questionId", handleUpdateQuestion)
+	protected.GET("/questions/:questionId/revisions", handleQuestionRevisions)
+	protected.GET("/questions/:questionId/revisions/diff", handleQuestionRevisionDiff)
+	protected.POST("/questions/:questionId/revisions/:revision/restore", handleRestoreQuestionRevision)
+
+	// Question review and publishing
+	protected.PUT("/questions/:questionId/status", handleUpdateQuestionStatus)
+	protected.POST("/quizzes/:id/publish", handlePublishQuiz)
+	protected.POST("/quizzes/:id/archive", handleArchiveQuiz)
+
 	protected.GET("/learn/review-item-list", handleGetReviewItemList)
 	protected.GET("/learn", handleLearnPage)
 	protected.GET("/learn/:reviewItemID", handleReviewPage)
@@ -59,18 +75,67 @@ func RegisterRoutes(e *echo.Echo) {
 	protected.POST("/learn/:reviewItemID/submit", handleSubmitReviewItemQuestion)
 	protected.POST("/learn/:reviewItemID/submit-and-next", handleSubmitReviewItemQuestionAndNext)
 
+	// Account page
+	protected.GET("/account", handleAccountPage)
+	protected.GET("/account/totp", handleGetTotpSettings)
+	protected.POST("/account/totp/enroll", handleEnrollTotp)
+	protected.POST("/account/totp/confirm", handleConfirmTotp)
+	protected.POST("/account/totp/disable", handleDisableTotp)
+	protected.POST("/account/totp/recovery-codes", handleRegenerateRecoveryCodes)
+	protected.POST("/account/tokens", handleCreateApiToken)
+	protected.DELETE("/account/tokens/:tokenId", handleRevokeApiToken)
+	protected.DELETE("/account/sessions", handleRevokeOtherSessions)
+	protected.DELETE("/account/sessions/:sessionId", handleRevokeSession)
+	protected.GET("/account/export", handleDownloadDataExport)
+	protected.POST("/account/erasure", handleRequestErasure)
+	protected.DELETE("/account/erasure", handleCancelErasure)
+
+	// Admin console, moderators only manage quizzes
+	staff := protected.Group("/admin", context.RequireStaffMiddleware)
+	staff.GET("/quizzes", handleAdminQuizzesPage)
+	staff.GET("/quizzes/search", handleAdminSearchQuizzes)
+	staff.DELETE("/quizzes/:quizId", handleAdminDeleteQuiz)
+	admin := protected.Group("/admin", context.RequireAdminMiddleware)
+	admin.GET("/users", handleAdminUsersPage)
+	admin.GET("/users/search", handleAdminSearchUsers)
+	admin.GET("/users/:userId", handleAdminUserPage)
+	admin.POST("/users/:userId/resend-verification", handleAdminUserAction(func(cc *context.AppContext, userId string) error {
+		return cc.ApiService.AdminResendVerification(userId)
+	}, "Verification email sent"))
+	admin.POST("/users/:userId/verify", handleAdminUserAction(func(cc *context.AppContext, userId string) error {
+		return cc.ApiService.AdminVerifyEmail(userId)
+	}, "Email marked verified"))
+	admin.POST("/users/:userId/disable", handleAdminUserAction(func(cc *context.AppContext, userId string) error {
+		return cc.ApiService.AdminDisableUser(userId)
+	}, "Account disabled"))
+	admin.POST("/users/:userId/enable", handleAdminUserAction(func(cc *context.AppContext, userId string) error {
+		return cc.ApiService.AdminEnableUser(userId)
+	}, "Account enabled"))
+	admin.POST("/users/:userId/reset-totp", handleAdminUserAction(func(cc *context.AppContext, userId string) error {
+		return cc.ApiService.AdminResetTotp(userId)
+	}, "Two-factor authentication reset"))
+	admin.PUT("/users/:userId/role", handleAdminSetRole)
+	admin.POST("/users/:userId/impersonate", handleAdminImpersonate)
+	// The impersonated user is not an admin, so ending the impersonation only needs a session
+	protected.POST("/admin/impersonation/stop", handleStopImpersonation)
+
 	// Auth endpoints
 	public.POST("/login", auth.PostLogin)
+	public.POST("/login/totp", auth.PostLoginTotp)
 	public.POST("/signup", auth.PostRegister)
 	public.GET("/verify-email", auth.GetVerifyEmail)
 	public.GET("/email-verification-needed", auth.GetEmailVerificationNeeded)
 	public.POST("/resend-verification", auth.PostResendVerification)
+	public.GET("/confirm-erasure", auth.GetConfirmErasure)
+	public.POST("/confirm-erasure", auth.PostConfirmErasure)
+	public.GET("/login/oidc/:provider", auth.GetOidcLogin)
+	public.GET("/login/oidc/:provider/callback", auth.GetOidcCallback)
 	protected.POST("/logout", func(c echo.Context) error {
 		c.Response().Header().Set("HX-Redirect", "/")
 
 		cc, ok := c.(*context.AppContext)
 		if !ok {
-			c.Logger().Printf("Cannot cast echo.Context to context.AppContext")
+			logging.Logger(c.Request().Context()).Error("cannot cast echo.Context to context.AppContext")
 			return c.NoContent(http.StatusOK)
 		}
 		if cc.Session != nil {
@@ -3,23 +3,31 @@ package api
 import (
 	"fmt"
 	"github.com/labstack/echo/v4"
-	"log"
 	"math"
 	"net/http"
 	"slices"
-	"spaced-ace/constants"
+	"spaced-ace/config"
 	"spaced-ace/context"
+	"spaced-ace/logging"
 	"spaced-ace/models"
 	"spaced-ace/models/business"
 	"spaced-ace/models/request"
 	"spaced-ace/render"
+	"spaced-ace/service"
 	"spaced-ace/utils"
 	"spaced-ace/views/components"
 	"spaced-ace/views/forms"
 	"spaced-ace/views/pages"
 	"strconv"
+	"strings"
 )
 
+var reviewItemConfig = config.Default().ReviewItems
+
+func Init(cfg config.ReviewItems) {
+	reviewItemConfig = cfg
+}
+
 var (
 	difficultyOptions = []business.Option{
 		{Name: "Easy", Value: "easy"},
@@ -178,7 +186,7 @@ func handleGenerateQuestion(c echo.Context) error {
 		{
 			question, err := cc.ApiService.GenerateSingleChoiceQuestion(requestForm.QuizId, requestForm.Context)
 			if err != nil {
-				errors["other"] = "Error generating question: " + err.Error()
+				setGenerationError(errors, err)
 				return render.TemplRender(
 					c,
 					200,
@@ -196,6 +204,7 @@ func handleGenerateQuestion(c echo.Context) error {
 				Question:                  question,
 				Answer:                    nil,
 				AllowDeleting:             true,
+				AllowEditing:              true,
 				ReplacePlaceholderWithOOB: true,
 			})
 			return render.TemplRender(
@@ -213,7 +222,7 @@ func handleGenerateQuestion(c echo.Context) error {
 		{
 			question, err := cc.ApiService.GenerateMultipleChoiceQuestion(requestForm.QuizId, requestForm.Context)
 			if err != nil {
-				errors["other"] = "Error generating question: " + err.Error()
+				setGenerationError(errors, err)
 				return render.TemplRender(
 					c,
 					200,
@@ -231,6 +240,7 @@ func handleGenerateQuestion(c echo.Context) error {
 				Question:                  question,
 				Answer:                    nil,
 				AllowDeleting:             true,
+				AllowEditing:              true,
 				ReplacePlaceholderWithOOB: true,
 			})
 			return render.TemplRender(
@@ -248,7 +258,7 @@ func handleGenerateQuestion(c echo.Context) error {
 		{
 			question, err := cc.ApiService.GenerateTrueOrFalseQuestion(requestForm.QuizId, requestForm.Context)
 			if err != nil {
-				errors["other"] = "Error generating question: " + err.Error()
+				setGenerationError(errors, err)
 				return render.TemplRender(
 					c,
 					200,
@@ -266,6 +276,7 @@ func handleGenerateQuestion(c echo.Context) error {
 				Question:                  question,
 				Answer:                    nil,
 				AllowDeleting:             true,
+				AllowEditing:              true,
 				ReplacePlaceholderWithOOB: true,
 			})
 			return render.TemplRender(
@@ -282,6 +293,21 @@ func handleGenerateQuestion(c echo.Context) error {
 	}
 }
 
+// Explains an exhausted generation quota instead of showing the raw backend error
+func setGenerationError(errors map[string]string, err error) {
+	period, ok := service.QuotaExceeded(err)
+	if !ok {
+		errors["other"] = "Error generating question: " + err.Error()
+		return
+	}
+	errors["quota"] = fmt.Sprintf(
+		"You have used all %d questions of your %s quota, it resets on %s.",
+		period.Limit,
+		period.Name,
+		period.ResetsAt.Local().Format("2006-01-02 15:04"),
+	)
+}
+
 func handleAnswerQuestion(c echo.Context) error {
 	cc := c.(*context.AppContext)
 
@@ -405,6 +431,208 @@ func handleDeleteQuestion(c echo.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// Renders the form of a new question again when its type is changed, keeping
+// what was already written
+func handleNewQuestionForm(c echo.Context) error {
+	var requestForm request.QuestionForm
+	if err := c.Bind(&requestForm); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid question form")
+	}
+	requestForm.Correct = nil
+	return render.TemplRender(c, 200, forms.QuestionForm(requestForm, map[string]string{}))
+}
+func handleCreateQuestion(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	var requestForm request.QuestionForm
+	if err := c.Bind(&requestForm); err != nil {
+		return render.TemplRender(c, 200, forms.QuestionForm(requestForm, map[string]string{"other": "Parsing error: " + err.Error()}))
+	}
+	requestForm.QuestionId = ""
+	if errors := validateQuestionForm(requestForm); len(errors) > 0 {
+		return render.TemplRender(c, 200, forms.QuestionForm(requestForm, errors))
+	}
+
+	question, err := cc.ApiService.CreateQuestion(requestForm)
+	if err != nil {
+		return render.TemplRender(c, 200, forms.QuestionForm(requestForm, map[string]string{"other": "Error creating the question: " + err.Error()}))
+	}
+
+	emptyForm := request.QuestionForm{QuizId: requestForm.QuizId, QuestionType: requestForm.QuestionType}
+	return render.TemplRender(c, 200, forms.QuestionCreated(emptyForm, components.EditableQuestion(question)))
+}
+func handleGetQuestion(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	question, err := cc.ApiService.GetQuestion(c.QueryParam("type"), c.Param("questionId"))
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.EditableQuestion(question))
+}
+func handleEditQuestion(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	question, err := cc.ApiService.GetQuestion(c.QueryParam("type"), c.Param("questionId"))
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, forms.QuestionForm(questionFormOf(question), map[string]string{}))
+}
+func handleUpdateQuestion(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	var requestForm request.QuestionForm
+	if err := c.Bind(&requestForm); err != nil {
+		return render.TemplRender(c, 200, forms.QuestionForm(requestForm, map[string]string{"other": "Parsing error: " + err.Error()}))
+	}
+	requestForm.QuestionId = c.Param("questionId")
+	if errors := validateQuestionForm(requestForm); len(errors) > 0 {
+		return render.TemplRender(c, 200, forms.QuestionForm(requestForm, errors))
+	}
+
+	question, err := cc.ApiService.UpdateQuestion(requestForm)
+	if err != nil {
+		return render.TemplRender(c, 200, forms.QuestionForm(requestForm, map[string]string{"other": "Error saving the question: " + err.Error()}))
+	}
+	return render.TemplRender(c, 200, components.EditableQuestion(question))
+}
+func handleQuestionRevisions(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	questionId := c.Param("questionId")
+	revisions, err := cc.ApiService.GetQuestionRevisions(questionId)
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.QuestionRevisions(questionId, c.QueryParam("quizId"), c.QueryParam("type"), revisions))
+}
+func handleQuestionRevisionDiff(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	from, err := strconv.Atoi(c.QueryParam("from"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid revision")
+	}
+	diff, err := cc.ApiService.GetQuestionRevisionDiff(c.Param("questionId"), from)
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.QuestionRevisionDiff(diff))
+}
+func handleRestoreQuestionRevision(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid revision")
+	}
+	question, err := cc.ApiService.RestoreQuestionRevision(c.Param("questionId"), revision, c.FormValue("resetReviewItems") == "true")
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.EditableQuestion(question))
+}
+
+// Approves or rejects the question in place of it on the quiz editor
+func handleUpdateQuestionStatus(c echo.Context) error {
+	cc := c.(*context.AppContext)
+
+	question, err := cc.ApiService.UpdateQuestionStatus(c.Param("questionId"), c.FormValue("status"))
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.EditableQuestion(question))
+}
+
+// Publishing changes which questions learners get, so the whole editor is
+// rendered again
+func handlePublishQuiz(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	return renderQuizStatusChange(c, cc.ApiService.PublishQuiz)
+}
+func handleArchiveQuiz(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	return renderQuizStatusChange(c, cc.ApiService.ArchiveQuiz)
+}
+func renderQuizStatusChange(c echo.Context, change func(quizId string) (*business.Quiz, error)) error {
+	cc := c.(*context.AppContext)
+	quizId := c.Param("id")
+
+	quiz, err := change(quizId)
+	if err == nil {
+		return render.TemplRender(c, 200, pages.EditQuizPage(pages.EditQuizPageViewModel{Quiz: quiz}))
+	}
+	quiz, getErr := cc.ApiService.GetQuiz(quizId)
+	if getErr != nil {
+		return getErr
+	}
+	viewModel := pages.EditQuizPageViewModel{
+		Quiz:        quiz,
+		StatusError: err.Error(),
+	}
+	return render.TemplRender(c, 200, pages.EditQuizPage(viewModel))
+}
+
+// The errors are keyed by the field of the form they are shown under
+func validateQuestionForm(form request.QuestionForm) map[string]string {
+	errors := map[string]string{}
+	if form.QuizId == "" {
+		errors["other"] = "quizId is required"
+	}
+	if strings.TrimSpace(form.Question) == "" {
+		errors["question"] = "Question is required"
+	}
+
+	switch form.QuestionType {
+	case models.SingleChoiceQuestion, models.MultipleChoiceQuestion:
+		if len(form.Answers) != 4 || slices.ContainsFunc(form.Answers, func(answer string) bool { return strings.TrimSpace(answer) == "" }) {
+			errors["answers"] = "All four options are required"
+		}
+		if len(form.Correct) == 0 {
+			errors["correct"] = "Mark the correct option"
+		} else if form.QuestionType == models.SingleChoiceQuestion && len(form.Correct) > 1 {
+			errors["correct"] = "Only one option can be correct"
+		}
+	case models.TrueOrFalseQuestion:
+		if len(form.Correct) != 1 {
+			errors["correct"] = "Mark the correct answer"
+		}
+	default:
+		errors["other"] = fmt.Sprintf("Invalid question type: '%s'.", form.QuestionType)
+	}
+	return errors
+}
+
+// Returns the values of the form that edits the question
+func questionFormOf(question interface{}) request.QuestionForm {
+	var form request.QuestionForm
+	var options []business.QuestionOption
+	switch q := question.(type) {
+	case *business.SingleChoiceQuestion:
+		form = request.QuestionForm{QuestionType: models.SingleChoiceQuestion, QuestionId: q.Id, QuizId: q.QuizId, Question: q.Question}
+		options = q.Options
+	case *business.MultipleChoiceQuestion:
+		form = request.QuestionForm{QuestionType: models.MultipleChoiceQuestion, QuestionId: q.Id, QuizId: q.QuizId, Question: q.Question}
+		options = q.Options
+	case *business.TrueOrFalseQuestion:
+		return request.QuestionForm{
+			QuestionType: models.TrueOrFalseQuestion,
+			QuestionId:   q.Id,
+			QuizId:       q.QuizId,
+			Question:     q.Question,
+			Correct:      []string{strconv.FormatBool(q.Answer)},
+		}
+	}
+	for index, option := range options {
+		form.Answers = append(form.Answers, option.Value)
+		if option.Correct {
+			form.Correct = append(form.Correct, string(rune('A'+index)))
+		}
+	}
+	return form
+}
 func handleDeleteQuiz(c echo.Context) error {
 	cc := c.(*context.AppContext)
 
@@ -425,13 +653,13 @@ func handleSubmitQuiz(c echo.Context) error {
 
 	quizSessionId := c.Param("quizSessionId")
 	if quizSessionId == "" {
-		log.Default().Print("missing quizSessionId in url params\n")
+		logging.Logger(c.Request().Context()).Debug("missing quizSessionId in url params")
 		return echo.NewHTTPError(http.StatusBadRequest, "missing quizSessionId in url param")
 	}
 
 	quizSession, err := cc.ApiService.GetQuizSession(quizSessionId)
 	if err != nil {
-		log.Default().Printf("invalid quiz session ID `%s`\n", quizSessionId)
+		logging.Logger(c.Request().Context()).Debug("failed to get quiz session", "quizSessionId", quizSessionId, "error", err)
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid quizSessionId: %s", quizSessionId))
 	}
 
@@ -568,7 +796,7 @@ func handleGetReviewItemList(c echo.Context) error {
 		}
 	}
 
-	pageCount := int(math.Ceil(float64(maxReviewItemCount) / float64(constants.REVIEW_ITEM_PAGE_SIZE)))
+	pageCount := int(math.Ceil(float64(maxReviewItemCount) / float64(reviewItemConfig.PageSize)))
 	pageOptions := make([]int, 0, 1)
 	for i := 1; i <= pageCount; i++ {
 		if page >= i && len(pageOptions) < 5 {
@@ -648,14 +876,14 @@ func handleQuizPreviewPopup(c echo.Context) error {
 	}
 
 	// TODO
-	_, err = cc.ApiService.HasQuizSession(cc.Session.User.Id, quizId)
+	_, err = cc.ApiService.HasQuizSession(quizId)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "error checking open sessions: "+err.Error())
 	}
 
 	// TODO get all sessions and then find the open one
 
-	quizSessions, err := cc.ApiService.GetQuizSessions(cc.Session.User.Id, quizId)
+	quizSessions, err := cc.ApiService.GetQuizSessions(quizId)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "error getting open sessions: "+err.Error())
 	}
@@ -688,3 +916,226 @@ func handleQuizDrawerPopup(c echo.Context) error {
 	}
 	return render.TemplRender(c, 200, components.QuizDrawerPopup(props))
 }
+
+func handleGetTotpSettings(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	totpStatus, err := cc.ApiService.GetTotpStatus()
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.TotpSettings(*totpStatus, map[string]string{}))
+}
+func handleEnrollTotp(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	enrollment, err := cc.ApiService.EnrollTotp()
+	if err != nil {
+		errors["other"] = "Could not start two-factor enrollment"
+		return render.TemplRender(c, 200, components.TotpSettings(business.TotpStatus{}, errors))
+	}
+	return renderTotpEnrollment(c, *enrollment, errors)
+}
+func handleConfirmTotp(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	var form request.TotpConfirmForm
+	if err := c.Bind(&form); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	enrollment := business.TotpEnrollment{
+		Secret:          form.Secret,
+		ProvisioningUri: form.ProvisioningUri,
+	}
+	if form.Code == "" {
+		errors["code"] = "Code is required"
+		return renderTotpEnrollment(c, enrollment, errors)
+	}
+
+	recoveryCodes, err := cc.ApiService.ConfirmTotp(form.Code)
+	if err != nil {
+		errors["code"] = "Invalid code"
+		return renderTotpEnrollment(c, enrollment, errors)
+	}
+	return render.TemplRender(c, 200, components.TotpRecoveryCodes(recoveryCodes))
+}
+func handleDisableTotp(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	var form request.TotpCodeForm
+	if err := c.Bind(&form); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := cc.ApiService.DisableTotp(form.Code); err != nil {
+		return renderTotpSettingsWithCodeError(c, errors)
+	}
+	return render.TemplRender(c, 200, components.TotpSettings(business.TotpStatus{}, errors))
+}
+func handleRegenerateRecoveryCodes(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	var form request.TotpCodeForm
+	if err := c.Bind(&form); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	recoveryCodes, err := cc.ApiService.RegenerateRecoveryCodes(form.Code)
+	if err != nil {
+		return renderTotpSettingsWithCodeError(c, errors)
+	}
+	return render.TemplRender(c, 200, components.TotpRecoveryCodes(recoveryCodes))
+}
+
+func renderTotpEnrollment(c echo.Context, enrollment business.TotpEnrollment, errors map[string]string) error {
+	qrCode, err := utils.QRCodeDataURI(enrollment.ProvisioningUri)
+	if err != nil {
+		logging.Logger(c.Request().Context()).Error("failed to generate qr code", "error", err)
+	}
+	return render.TemplRender(c, 200, components.TotpEnrollment(enrollment, qrCode, errors))
+}
+func renderTotpSettingsWithCodeError(c echo.Context, errors map[string]string) error {
+	cc := c.(*context.AppContext)
+	totpStatus, err := cc.ApiService.GetTotpStatus()
+	if err != nil {
+		return err
+	}
+	errors["code"] = "Invalid code"
+	return render.TemplRender(c, 200, components.TotpSettings(*totpStatus, errors))
+}
+
+func handleCreateApiToken(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	var form request.CreateApiTokenForm
+	if err := c.Bind(&form); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if form.Name == "" {
+		errors["name"] = "Name is required"
+	}
+	if len(form.Scopes) == 0 {
+		errors["scopes"] = "Select at least one scope"
+	}
+
+	newToken := ""
+	if len(errors) == 0 {
+		token, err := cc.ApiService.CreateApiToken(form)
+		if err != nil {
+			errors["other"] = "Error creating a token: " + err.Error()
+		} else {
+			newToken = token
+			form = request.CreateApiTokenForm{}
+		}
+	}
+
+	tokens, err := cc.ApiService.GetApiTokens()
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.ApiTokens(components.ApiTokensProps{
+		Tokens:   tokens,
+		NewToken: newToken,
+		Values:   form,
+		Errors:   errors,
+	}))
+}
+func handleRevokeApiToken(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	if err := cc.ApiService.RevokeApiToken(c.Param("tokenId")); err != nil {
+		errors["other"] = "Error revoking the token: " + err.Error()
+	}
+
+	tokens, err := cc.ApiService.GetApiTokens()
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.ApiTokens(components.ApiTokensProps{
+		Tokens: tokens,
+		Errors: errors,
+	}))
+}
+
+func handleRevokeSession(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	if err := cc.ApiService.RevokeSession(c.Param("sessionId")); err != nil {
+		errors["other"] = "Error signing out the session: " + err.Error()
+	}
+	return renderActiveSessions(c, errors)
+}
+func handleRevokeOtherSessions(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	if err := cc.ApiService.RevokeOtherSessions(); err != nil {
+		errors["other"] = "Error signing out the other sessions: " + err.Error()
+	}
+	return renderActiveSessions(c, errors)
+}
+func renderActiveSessions(c echo.Context, errors map[string]string) error {
+	cc := c.(*context.AppContext)
+	sessions, err := cc.ApiService.GetActiveSessions()
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.ActiveSessions(sessions, errors))
+}
+
+func handleDownloadDataExport(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	archive, contentDisposition, err := cc.ApiService.DownloadDataExport()
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, contentDisposition)
+	return c.Blob(http.StatusOK, "application/zip", archive)
+}
+func handleRequestErasure(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	var form request.ErasureForm
+	if err := c.Bind(&form); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if form.QuizHandling == "transfer" && form.TransferTo == "" {
+		errors["transferTo"] = "Enter the email of the new owner"
+	}
+
+	var erasure *business.Erasure
+	if len(errors) == 0 {
+		requested, err := cc.ApiService.RequestErasure(form)
+		if err != nil {
+			errors["other"] = "Error requesting the deletion: " + err.Error()
+		} else {
+			erasure = requested
+		}
+	}
+	return render.TemplRender(c, 200, components.AccountErasure(components.AccountErasureProps{
+		Erasure: erasure,
+		Values:  form,
+		Errors:  errors,
+	}))
+}
+func handleCancelErasure(c echo.Context) error {
+	cc := c.(*context.AppContext)
+	errors := map[string]string{}
+
+	if err := cc.ApiService.CancelErasure(); err != nil {
+		errors["other"] = "Error cancelling the deletion: " + err.Error()
+	}
+	erasure, err := cc.ApiService.GetErasure()
+	if err != nil {
+		return err
+	}
+	return render.TemplRender(c, 200, components.AccountErasure(components.AccountErasureProps{
+		Erasure: erasure,
+		Errors:  errors,
+	}))
+}
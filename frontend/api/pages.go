@@ -3,10 +3,11 @@ package api
 import (
 	"fmt"
 	"github.com/labstack/echo/v4"
-	"log"
 	"net/http"
 	"slices"
+	"spaced-ace/auth"
 	"spaced-ace/context"
+	"spaced-ace/logging"
 	"spaced-ace/models/business"
 	"spaced-ace/render"
 	"spaced-ace/utils"
@@ -45,6 +46,7 @@ func handleEditQuizPage(c echo.Context) error {
 	}
 	return render.TemplRender(c, 200, pages.EditQuizPage(viewModel))
 }
+
 func handleLoginPage(c echo.Context) error {
 	cc := c.(*context.AppContext)
 	if cc.Session != nil {
@@ -52,7 +54,8 @@ func handleLoginPage(c echo.Context) error {
 	}
 
 	viewModel := pages.LoginPageViewModel{
-		Errors: map[string]string{},
+		Errors:        map[string]string{},
+		OidcProviders: auth.GetOidcProviders(c),
 	}
 	return render.TemplRender(c, 200, pages.LoginPage(viewModel))
 }
@@ -96,7 +99,7 @@ func handleTakeQuizPage(c echo.Context) error {
 
 	quizSessionId := c.Param("quizSessionId")
 	if quizSessionId == "" {
-		quizSession, err := cc.ApiService.CreateQuizSession(cc.Session.User.Id, quizId)
+		quizSession, err := cc.ApiService.CreateQuizSession(quizId)
 		if err != nil {
 			return err
 		}
@@ -120,6 +123,8 @@ func handleTakeQuizPage(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	// The owner also gets the questions in review, they are not part of the session
+	quiz.Questions = quiz.PublishedQuestions()
 
 	var answerLists *business.AnswerLists
 	answers, err := cc.ApiService.GetAnswers(quizSession.Id)
@@ -162,19 +167,19 @@ func handleQuizResultPage(c echo.Context) error {
 
 	quizSessionId := c.Param("quizSessionId")
 	if quizSessionId == "" {
-		log.Default().Print("missing quizSessionId in url params\n")
+		logging.Logger(c.Request().Context()).Debug("missing quizSessionId in url params")
 		return redirectToMyQuizzes()
 	}
 
 	quizSession, err := cc.ApiService.GetQuizSession(quizSessionId)
 	if err != nil {
-		log.Default().Printf("invalid quiz session ID `%s`\n", quizSessionId)
+		logging.Logger(c.Request().Context()).Debug("failed to get quiz session", "quizSessionId", quizSessionId, "error", err)
 		return redirectToMyQuizzes()
 	}
 
 	quizResult, err := cc.ApiService.GetQuizResult(quizSession.Id)
 	if err != nil {
-		log.Default().Printf("quiz session is not finised. quiz session ID `%s`\n", quizSessionId)
+		logging.Logger(c.Request().Context()).Debug("quiz session is not finished", "quizSessionId", quizSessionId, "error", err)
 		url := fmt.Sprintf("/quizzes/%s/take/%s", quizSession.QuizId, quizSession.Id)
 		c.Response().Header().Set("HX-Replace-Url", url)
 		return c.Redirect(http.StatusFound, url)
@@ -182,9 +187,10 @@ func handleQuizResultPage(c echo.Context) error {
 
 	quiz, err := cc.ApiService.GetQuiz(quizSession.QuizId)
 	if err != nil {
-		log.Default().Printf("quiz not find with ID `%s`", quizSession.QuizId)
+		logging.Logger(c.Request().Context()).Debug("failed to get quiz", "quizId", quizSession.QuizId, "error", err)
 		return redirectToMyQuizzes()
 	}
+	quiz.Questions = quiz.PublishedQuestions()
 
 	var answerLists *business.AnswerLists
 	answers, err := cc.ApiService.GetAnswers(quizSession.Id)
@@ -240,7 +246,7 @@ func handleLearnPage(c echo.Context) error {
 
 	total, dueToReview, err := cc.ApiService.GetReviewItemCounts()
 	if err != nil {
-		log.Default().Print(fmt.Errorf("getting review item counts: %s\n", err))
+		logging.Logger(c.Request().Context()).Error("failed to get review item counts", "error", err)
 		total = -1
 		dueToReview = -1
 	}
@@ -262,7 +268,7 @@ func handleReviewPage(c echo.Context) error {
 	reviewItemID := c.Param("reviewItemID")
 	reviewItemQuestion, err := cc.ApiService.GetReviewItemQuestion(reviewItemID)
 	if err != nil {
-		log.Default().Print(err)
+		logging.Logger(c.Request().Context()).Debug("failed to get review item question", "reviewItemId", reviewItemID, "error", err)
 		c.Response().Header().Set("HX-Replace-Url", "/learn")
 		return c.Redirect(http.StatusFound, "/learn")
 	}
@@ -279,6 +285,52 @@ func handleReviewPage(c echo.Context) error {
 	return render.TemplRender(c, 200, pages.QuizReviewPage(viewModel))
 }
 
+func handleAccountPage(c echo.Context) error {
+	hxRequest := c.Request().Header.Get("HX-Request") == "true"
+	if !hxRequest {
+		return handleNonHXRequest(c)
+	}
+
+	cc := c.(*context.AppContext)
+	totpStatus, err := cc.ApiService.GetTotpStatus()
+	if err != nil {
+		return err
+	}
+
+	// The backend keeps the tokens from impersonating admins
+	var apiTokens []business.ApiToken
+	if cc.Session.ImpersonatedBy == nil {
+		apiTokens, err = cc.ApiService.GetApiTokens()
+		if err != nil {
+			return err
+		}
+	}
+
+	activeSessions, err := cc.ApiService.GetActiveSessions()
+	if err != nil {
+		return err
+	}
+
+	usage, err := cc.ApiService.GetUsage()
+	if err != nil {
+		return err
+	}
+
+	erasure, err := cc.ApiService.GetErasure()
+	if err != nil {
+		return err
+	}
+
+	viewModel := pages.AccountPageViewModel{
+		TotpStatus:     *totpStatus,
+		ApiTokens:      apiTokens,
+		ActiveSessions: activeSessions,
+		Usage:          *usage,
+		Erasure:        erasure,
+	}
+	return render.TemplRender(c, 200, pages.AccountPage(viewModel))
+}
+
 func handleNonHXRequest(c echo.Context) error {
 	activeUrl := c.Request().URL.Path
 	sideBarProps, err := createSideBarProps(c, activeUrl)
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"spaced-ace/service"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Returns how the address of the browser is read. Without trusted proxies it is
+// the peer of the connection, a client can write anything into X-Forwarded-For
+// and the backend limits logins and signups by the address forwarded to it.
+func IPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		// The configuration is validated on startup
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err == nil {
+			options = append(options, echo.TrustIPRange(ipRange))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// Returns the error status the backend answered with, false when the request
+// did not get an answer
+func backendError(err error) (*service.ApiError, bool) {
+	var apiErr *service.ApiError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// Returns the error to show when the backend rate limited the request
+func tooManyRequestsMessage(apiErr *service.ApiError) string {
+	seconds := apiErr.RetryAfter
+	if seconds <= 0 {
+		return "Too many attempts, please try again later"
+	}
+	if seconds < 120 {
+		return fmt.Sprintf("Too many attempts, please try again in %d seconds", seconds)
+	}
+	return fmt.Sprintf("Too many attempts, please try again in %d minutes", (seconds+59)/60)
+}
+
+const accountDisabledMessage = "This account has been disabled. Contact support if you think this is a mistake."
+
+// Returns true when the backend refused the login because an admin disabled the account
+func isAccountDisabled(apiErr *service.ApiError) bool {
+	return apiErr.StatusCode == http.StatusForbidden && apiErr.Message == "account disabled"
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"spaced-ace/config"
+	"spaced-ace/service"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// The backend limits logins by the forwarded address, so a client must not be
+// able to choose it
+func TestForwardedAddress(t *testing.T) {
+	var forwarded string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get(echo.HeaderXForwardedFor)
+		w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"challenge":"challenge","totpRequired":true}`))
+	}))
+	defer backend.Close()
+	service.Init(config.Backend{Url: backend.URL})
+
+	for _, tc := range []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		want           string
+	}{
+		{name: "spoofed without proxies", remoteAddr: "203.0.113.7:4711", want: "203.0.113.7"},
+		{name: "spoofed from the private network", remoteAddr: "10.0.0.5:4711", want: "10.0.0.5"},
+		{name: "spoofed outside the proxies", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "203.0.113.7:4711", want: "203.0.113.7"},
+		{name: "behind a trusted proxy", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "10.0.0.5:4711", want: "198.51.100.1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = IPExtractor(tc.trustedProxies)
+			e.POST("/login", func(c echo.Context) error {
+				_, err := service.NewBrowserApiService(c).Login("alice@example.com", "password", false)
+				return err
+			})
+
+			req := httptest.NewRequest("POST", "/login", nil)
+			req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.1")
+			req.RemoteAddr = tc.remoteAddr
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("got %d: %s", rec.Code, rec.Body)
+			}
+			if forwarded != tc.want {
+				t.Errorf("forwarded %q, want %q", forwarded, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"spaced-ace/logging"
+	"spaced-ace/render"
+	"spaced-ace/service"
+	"spaced-ace/views/pages"
+)
+
+func GetConfirmErasure(c echo.Context) error {
+	return render.TemplRender(c, http.StatusOK, pages.ConfirmErasurePage(c.QueryParam("token")))
+}
+
+// The link of the email needs no session, the token identifies the account
+func PostConfirmErasure(c echo.Context) error {
+	erasure, err := service.NewApiService(c.Request().Context(), nil).ConfirmErasure(c.FormValue("token"))
+	if err != nil {
+		var apiErr *service.ApiError
+		if errors.As(err, &apiErr) {
+			return render.TemplRender(c, http.StatusOK, pages.ConfirmErasureStatus(nil, apiErr.Message))
+		}
+		logging.Logger(c.Request().Context()).Error("failed to confirm erasure", "error", err)
+		return render.TemplRender(c, http.StatusOK, pages.ConfirmErasureStatus(nil, "Error connecting to the server"))
+	}
+	return render.TemplRender(c, http.StatusOK, pages.ConfirmErasureStatus(erasure, ""))
+}
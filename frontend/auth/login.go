@@ -1,21 +1,15 @@
 package auth
 
 import (
-	"bytes"
-	"encoding/json"
 	"github.com/labstack/echo/v4"
 	"net/http"
-	"spaced-ace/constants"
+	"spaced-ace/logging"
 	"spaced-ace/models/request"
 	"spaced-ace/render"
+	"spaced-ace/service"
 	"spaced-ace/views/forms"
 )
 
-type LoginRequestBody struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
-
 func PostLogin(c echo.Context) error {
 	errors := map[string]string{}
 
@@ -25,43 +19,67 @@ func PostLogin(c echo.Context) error {
 		return render.TemplRender(c, 200, forms.LoginForm(errors))
 	}
 
-	bodyMap := LoginRequestBody{
-		Email:    loginForm.Email,
-		Password: loginForm.Password,
-	}
-	bodyBytes, err := json.Marshal(bodyMap)
-	if err != nil {
-		errors["other"] = "Internal server error"
-		return render.TemplRender(c, 200, forms.LoginForm(errors))
-	}
-	bodyBuffer := bytes.NewBuffer(bodyBytes)
-
-	resp, err := http.Post(constants.BACKEND_URL+"/authenticate-user", "application/json", bodyBuffer)
+	login, err := service.NewBrowserApiService(c).Login(loginForm.Email, loginForm.Password, loginForm.RememberMe)
 	if err != nil {
-		errors["other"] = "Error: Bad gateway"
-		return render.TemplRender(c, 200, forms.LoginForm(errors))
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusForbidden {
+		apiErr, ok := backendError(err)
+		switch {
+		case !ok:
+			logging.Logger(c.Request().Context()).Error("failed to log in", "error", err)
+			errors["other"] = "Error: Bad gateway"
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			errors["other"] = tooManyRequestsMessage(apiErr)
+		case isAccountDisabled(apiErr):
+			errors["other"] = accountDisabledMessage
+		case apiErr.StatusCode == http.StatusForbidden:
 			// This is the error for unverified email
 			errors["other"] = "Email not verified. Please check your inbox for the verification link."
-			return render.TemplRender(c, 200, forms.LoginForm(errors))
+		default:
+			errors["other"] = "Invalid e-mail or password"
 		}
-		errors["other"] = "Invalid e-mail or password"
 		return render.TemplRender(c, 200, forms.LoginForm(errors))
 	}
+	if login.TotpChallenge != "" {
+		// Two-factor authentication is enabled, the session is only created after the second step
+		return render.TemplRender(c, 200, forms.LoginTotpForm(login.TotpChallenge, errors))
+	}
 
-	var sessionCookie *http.Cookie
-	for _, cookie := range resp.Cookies() {
-		if cookie.Name == "session" {
-			sessionCookie = cookie
-			break
-		}
+	c.SetCookie(login.SessionCookie)
+	c.Response().Header().Set("HX-Redirect", "/my-quizzes")
+	return c.String(http.StatusOK, "login successful")
+}
+
+func PostLoginTotp(c echo.Context) error {
+	errors := map[string]string{}
+
+	var totpForm = request.LoginTotpForm{}
+	if err := c.Bind(&totpForm); err != nil {
+		errors["other"] = err.Error()
+		return render.TemplRender(c, 200, forms.LoginTotpForm(totpForm.Challenge, errors))
 	}
-	if sessionCookie == nil {
-		errors["other"] = "session cookie not found"
-		return render.TemplRender(c, 200, forms.LoginForm(errors))
+	if totpForm.Code == "" {
+		errors["code"] = "Code is required"
+		return render.TemplRender(c, 200, forms.LoginTotpForm(totpForm.Challenge, errors))
+	}
+
+	sessionCookie, err := service.NewBrowserApiService(c).LoginTotp(totpForm.Challenge, totpForm.Code)
+	if err != nil {
+		apiErr, ok := backendError(err)
+		switch {
+		case !ok:
+			logging.Logger(c.Request().Context()).Error("failed to log in with totp", "error", err)
+			errors["other"] = "Error: Bad gateway"
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			errors["other"] = tooManyRequestsMessage(apiErr)
+		case apiErr.StatusCode == http.StatusGone:
+			errors["other"] = "Login expired, please log in again"
+			return render.TemplRender(c, 200, forms.LoginForm(errors))
+		case isAccountDisabled(apiErr):
+			errors["other"] = accountDisabledMessage
+			return render.TemplRender(c, 200, forms.LoginForm(errors))
+		default:
+			errors["code"] = "Invalid code"
+		}
+		return render.TemplRender(c, 200, forms.LoginTotpForm(totpForm.Challenge, errors))
 	}
 
 	c.SetCookie(sessionCookie)
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"spaced-ace/logging"
+	"spaced-ace/models/business"
+	"spaced-ace/render"
+	"spaced-ace/service"
+	"spaced-ace/views/pages"
+)
+
+const oidcStateCookieName = "oidc_state"
+
+// GetOidcProviders returns the social login providers configured in the backend
+func GetOidcProviders(c echo.Context) []business.OidcProvider {
+	providers, err := service.NewBrowserApiService(c).GetOidcProviders()
+	if err != nil {
+		logging.Logger(c.Request().Context()).Error("failed to fetch identity providers", "error", err)
+		return []business.OidcProvider{}
+	}
+	return providers
+}
+
+func GetOidcLogin(c echo.Context) error {
+	provider := c.Param("provider")
+	authorization, err := service.NewBrowserApiService(c).AuthorizeOidc(provider)
+	if err != nil {
+		apiErr, ok := backendError(err)
+		if !ok {
+			return renderOidcError(c, "Error: Bad gateway")
+		}
+		if apiErr.StatusCode == http.StatusTooManyRequests {
+			return renderOidcError(c, tooManyRequestsMessage(apiErr))
+		}
+		return renderOidcError(c, "Sign in with this provider is currently unavailable")
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    authorization.State,
+		Path:     "/login/oidc",
+		MaxAge:   10 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return c.Redirect(http.StatusFound, authorization.Url)
+}
+
+func GetOidcCallback(c echo.Context) error {
+	if c.QueryParam("error") != "" {
+		return renderOidcError(c, "Sign in was cancelled")
+	}
+
+	stateCookie, err := c.Cookie(oidcStateCookieName)
+	if err != nil || stateCookie.Value == "" || stateCookie.Value != c.QueryParam("state") {
+		return renderOidcError(c, "Sign in expired, please try again")
+	}
+	c.SetCookie(&http.Cookie{
+		Name:   oidcStateCookieName,
+		Path:   "/login/oidc",
+		MaxAge: -1,
+	})
+
+	provider := c.Param("provider")
+	login, err := service.NewBrowserApiService(c).OidcCallback(provider, c.QueryParam("code"), stateCookie.Value)
+	if err != nil {
+		apiErr, ok := backendError(err)
+		switch {
+		case !ok:
+			logging.Logger(c.Request().Context()).Error("failed to sign in with identity provider", "error", err)
+			return renderOidcError(c, "Error: Bad gateway")
+		case isAccountDisabled(apiErr):
+			return renderOidcError(c, accountDisabledMessage)
+		case apiErr.StatusCode == http.StatusForbidden:
+			return renderOidcError(c, "Email not verified. Please check your inbox for the verification link.")
+		case apiErr.StatusCode == http.StatusConflict:
+			return renderOidcError(c, "An account with this email already exists. Log in with your password first.")
+		}
+		return renderOidcError(c, "Sign in failed, please try again")
+	}
+	if login.TotpChallenge != "" {
+		viewModel := pages.LoginPageViewModel{
+			Errors:        map[string]string{},
+			TotpChallenge: login.TotpChallenge,
+		}
+		return render.TemplRender(c, http.StatusOK, pages.LoginPage(viewModel))
+	}
+
+	c.SetCookie(login.SessionCookie)
+	return c.Redirect(http.StatusFound, "/my-quizzes")
+}
+
+func renderOidcError(c echo.Context, message string) error {
+	viewModel := pages.LoginPageViewModel{
+		Errors:        map[string]string{"other": message},
+		OidcProviders: GetOidcProviders(c),
+	}
+	return render.TemplRender(c, http.StatusOK, pages.LoginPage(viewModel))
+}
@@ -1,13 +1,11 @@
 package auth
 
 import (
-	"bytes"
-	"encoding/json"
 	"github.com/labstack/echo/v4"
-	"log"
 	"net/http"
-	"spaced-ace/constants"
+	"spaced-ace/logging"
 	"spaced-ace/render"
+	"spaced-ace/service"
 	"spaced-ace/views/components"
 	"spaced-ace/views/pages"
 )
@@ -17,22 +15,17 @@ func GetVerifyEmail(c echo.Context) error {
 	if token == "" {
 		return render.TemplRender(c, http.StatusBadRequest, pages.VerifyEmailPage("error", "Missing verification token"))
 	}
-	resp, err := http.Get(constants.BACKEND_URL + "/verify-email?token=" + token)
-	if err != nil {
-		log.Println("Error verifying email:", err)
-		return render.TemplRender(c, http.StatusInternalServerError, pages.VerifyEmailPage("error", "Error connecting to verification service"))
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		var errorResp struct {
-			Message string `json:"message"`
+	if err := service.NewBrowserApiService(c).VerifyEmail(token); err != nil {
+		apiErr, ok := backendError(err)
+		if !ok {
+			logging.Logger(c.Request().Context()).Error("failed to verify email", "error", err)
+			return render.TemplRender(c, http.StatusInternalServerError, pages.VerifyEmailPage("error", "Error connecting to verification service"))
 		}
-		err = json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			errorResp.Message = "Verification failed"
+		message := apiErr.Message
+		if message == "" {
+			message = "Verification failed"
 		}
-		return render.TemplRender(c, resp.StatusCode, pages.VerifyEmailPage("error", errorResp.Message))
+		return render.TemplRender(c, apiErr.StatusCode, pages.VerifyEmailPage("error", message))
 	}
 
 	return render.TemplRender(c, http.StatusOK, pages.VerifyEmailPage("success", ""))
@@ -55,28 +48,17 @@ func PostResendVerification(c echo.Context) error {
 		return render.TemplRender(c, http.StatusBadRequest, components.VerificationFailed("Invalid request"))
 	}
 
-	requestBody, err := json.Marshal(request)
-	if err != nil {
-		log.Println("Error marshaling resend request:", err)
-		return render.TemplRender(c, http.StatusInternalServerError, components.VerificationFailed("Error processing request"))
-	}
-
-	resp, err := http.Post(constants.BACKEND_URL+"/resend-verification", "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		log.Println("Error resending verification email:", err)
-		return render.TemplRender(c, http.StatusInternalServerError, components.VerificationFailed("Error connecting to verification service"))
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		var errorResp struct {
-			Message string `json:"message"`
+	if err := service.NewBrowserApiService(c).ResendVerification(request.Email); err != nil {
+		apiErr, ok := backendError(err)
+		if !ok {
+			logging.Logger(c.Request().Context()).Error("failed to resend verification email", "error", err)
+			return render.TemplRender(c, http.StatusInternalServerError, components.VerificationFailed("Error connecting to verification service"))
 		}
-		err = json.NewDecoder(resp.Body).Decode(&errorResp)
-		if err != nil {
-			errorResp.Message = "Failed to resend verification email"
+		message := apiErr.Message
+		if message == "" {
+			message = "Failed to resend verification email"
 		}
-		return render.TemplRender(c, resp.StatusCode, components.VerificationFailed(errorResp.Message))
+		return render.TemplRender(c, apiErr.StatusCode, components.VerificationFailed(message))
 	}
 
 	return render.TemplRender(c, http.StatusOK, components.VerificationEmailSent())
@@ -0,0 +1,588 @@
+package backendapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"spaced-ace/models"
+	"spaced-ace/models/business"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// The mapping of the generated models to the ones the pages are rendered from
+
+func (q QuizInfo) MapToBusiness() business.QuizInfo {
+	return business.QuizInfo{
+		Id:          q.Id,
+		Title:       q.Title,
+		Description: q.Description,
+		CreatorId:   q.CreatorId,
+		CreatorName: q.CreatorName,
+		Status:      string(q.Status),
+	}
+}
+
+// Questions that cannot be mapped are left out, the rest of the quiz is still
+// usable. The questions are returned newest first.
+func (q Quiz) MapToBusiness() *business.Quiz {
+	var questions []interface{}
+	for _, question := range q.Questions {
+		mapped, err := question.MapToBusiness()
+		if err != nil {
+			continue
+		}
+		questions = append(questions, mapped)
+	}
+	slices.Reverse(questions)
+
+	return &business.Quiz{
+		QuizInfo: business.QuizInfo{
+			Id:          q.Id,
+			Title:       q.Title,
+			Description: q.Description,
+			CreatorId:   q.CreatorId,
+			CreatorName: q.CreatorName,
+			Status:      string(q.Status),
+		},
+		PublishedAt: q.PublishedAt,
+		Questions:   questions,
+	}
+}
+
+func (l QuizList) MapToBusiness() []business.QuizInfo {
+	if l.Quizzes == nil {
+		return nil
+	}
+	quizInfos := make([]business.QuizInfo, 0, len(*l.Quizzes))
+	for _, quiz := range *l.Quizzes {
+		quizInfos = append(quizInfos, quiz.MapToBusiness())
+	}
+	return quizInfos
+}
+
+// Returns which of the question types the union holds
+func (q Question) Type() (QuestionType, error) {
+	raw, err := q.MarshalJSON()
+	if err != nil {
+		return 0, err
+	}
+	var common struct {
+		QuestionType QuestionType `json:"questionType"`
+	}
+	if err := json.Unmarshal(raw, &common); err != nil {
+		return 0, err
+	}
+	return common.QuestionType, nil
+}
+
+// Returns the question as the business model of its type
+func (q Question) MapToBusiness() (interface{}, error) {
+	questionType, err := q.Type()
+	if err != nil {
+		return nil, err
+	}
+
+	switch models.QuestionType(questionType) {
+	case models.SingleChoice:
+		question, err := q.AsSingleChoiceQuestion()
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.MultipleChoice:
+		question, err := q.AsMultipleChoiceQuestion()
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.TrueOrFalse:
+		question, err := q.AsTrueOrFalseQuestion()
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness(), nil
+	default:
+		return nil, fmt.Errorf("unknown question type %d", questionType)
+	}
+}
+
+func (r QuestionRevision) MapToBusiness() (business.QuestionRevision, error) {
+	question, err := r.Question.MapToBusiness()
+	if err != nil {
+		return business.QuestionRevision{}, err
+	}
+	revision := business.QuestionRevision{
+		Revision:     r.Revision,
+		CreatedAt:    r.CreatedAt,
+		RestoredFrom: r.RestoredFrom,
+		Question:     question,
+	}
+	if r.AuthorName != nil {
+		revision.AuthorName = *r.AuthorName
+	}
+	return revision, nil
+}
+
+// The changes are ordered by field
+func (d QuestionRevisionDiff) MapToBusiness() *business.QuestionRevisionDiff {
+	diff := &business.QuestionRevisionDiff{From: d.From, To: d.To, CorrectAnswerChanged: d.CorrectAnswerChanged}
+	for field, change := range d.Changes {
+		diff.Changes = append(diff.Changes, business.QuestionChange{
+			Field: field,
+			From:  formatChangedValue(change.From),
+			To:    formatChangedValue(change.To),
+		})
+	}
+	slices.SortFunc(diff.Changes, func(a, b business.QuestionChange) int { return strings.Compare(a.Field, b.Field) })
+	return diff
+}
+
+func formatChangedValue(value *interface{}) string {
+	if value == nil || *value == nil {
+		return ""
+	}
+	if values, ok := (*value).([]interface{}); ok {
+		formatted := make([]string, 0, len(values))
+		for _, v := range values {
+			formatted = append(formatted, fmt.Sprint(v))
+		}
+		return strings.Join(formatted, ", ")
+	}
+	return fmt.Sprint(*value)
+}
+
+func (q SingleChoiceQuestion) MapToBusiness() (*business.SingleChoiceQuestion, error) {
+	if len(q.Answers) != 4 {
+		return nil, echo.NewHTTPError(500, fmt.Sprintf("Invalid number of possible answers. Expected: %d, got: %d", 4, len(q.Answers)))
+	}
+	if !slices.Contains([]SingleChoiceQuestionCorrectAnswer{SingleChoiceQuestionCorrectAnswerA, SingleChoiceQuestionCorrectAnswerB, SingleChoiceQuestionCorrectAnswerC, SingleChoiceQuestionCorrectAnswerD}, q.CorrectAnswer) {
+		return nil, echo.NewHTTPError(500, fmt.Sprintf("Invalid answer. Expected on of [A, B, C, D], got: %s", q.CorrectAnswer))
+	}
+
+	return &business.SingleChoiceQuestion{
+		CommonQuestionProperties: business.CommonQuestionProperties{
+			Id:           q.Id,
+			QuizId:       q.Quizid,
+			Order:        0,
+			QuestionType: models.SingleChoice,
+			Question:     q.Question,
+			Status:       questionStatus(q.Status),
+			Published:    q.Published != nil && *q.Published,
+		},
+		Options: []business.QuestionOption{
+			{Value: q.Answers[0], Correct: q.CorrectAnswer == SingleChoiceQuestionCorrectAnswerA},
+			{Value: q.Answers[1], Correct: q.CorrectAnswer == SingleChoiceQuestionCorrectAnswerB},
+			{Value: q.Answers[2], Correct: q.CorrectAnswer == SingleChoiceQuestionCorrectAnswerC},
+			{Value: q.Answers[3], Correct: q.CorrectAnswer == SingleChoiceQuestionCorrectAnswerD},
+		},
+	}, nil
+}
+
+func (q MultipleChoiceQuestion) MapToBusiness() (*business.MultipleChoiceQuestion, error) {
+	if len(q.Answers) != 4 {
+		return nil, echo.NewHTTPError(500, fmt.Sprintf("Invalid number of possible answers. Expected: %d, got: %d", 4, len(q.Answers)))
+	}
+	if len(q.CorrectAnswers) == 0 {
+		return nil, echo.NewHTTPError(500, fmt.Sprintf("There are no correct answers: %+v", q.CorrectAnswers))
+	}
+
+	return &business.MultipleChoiceQuestion{
+		CommonQuestionProperties: business.CommonQuestionProperties{
+			Id:           q.Id,
+			QuizId:       q.Quizid,
+			Order:        0,
+			QuestionType: models.MultipleChoice,
+			Question:     q.Question,
+			Status:       questionStatus(q.Status),
+			Published:    q.Published != nil && *q.Published,
+		},
+		Options: []business.QuestionOption{
+			{Value: q.Answers[0], Correct: slices.Contains(q.CorrectAnswers, MultipleChoiceQuestionCorrectAnswersA)},
+			{Value: q.Answers[1], Correct: slices.Contains(q.CorrectAnswers, MultipleChoiceQuestionCorrectAnswersB)},
+			{Value: q.Answers[2], Correct: slices.Contains(q.CorrectAnswers, MultipleChoiceQuestionCorrectAnswersC)},
+			{Value: q.Answers[3], Correct: slices.Contains(q.CorrectAnswers, MultipleChoiceQuestionCorrectAnswersD)},
+		},
+	}, nil
+}
+
+func (q TrueOrFalseQuestion) MapToBusiness() *business.TrueOrFalseQuestion {
+	return &business.TrueOrFalseQuestion{
+		CommonQuestionProperties: business.CommonQuestionProperties{
+			Id:           q.Id,
+			QuizId:       q.Quizid,
+			Order:        0,
+			QuestionType: models.TrueOrFalse,
+			Question:     q.Question,
+			Status:       questionStatus(q.Status),
+			Published:    q.Published != nil && *q.Published,
+		},
+		Answer: q.CorrectAnswer,
+	}
+}
+
+func (s QuizSession) MapToBusiness() *business.QuizSession {
+	return &business.QuizSession{
+		Id:         s.Id,
+		UserId:     s.Userid,
+		QuizId:     s.Quizid,
+		StartedAt:  valueOrZero(s.StartedAt),
+		FinishedAt: valueOrZero(s.FinishedAt),
+		ClosesAt:   valueOrZero(s.ClosesAt),
+		Finished:   !valueOrZero(s.FinishedAt).IsZero(),
+	}
+}
+
+func (r QuizResult) MapToBusiness() *business.QuizResult {
+	answerScores := make([]business.AnswerScore, 0, len(r.AnswerScores))
+	for _, score := range r.AnswerScores {
+		answerScores = append(answerScores, business.AnswerScore{
+			ID:                     score.Id,
+			QuizResultId:           score.QuizResultId,
+			SingleChoiceAnswerID:   valueOrZero(score.SingleChoiceAnswerId),
+			MultipleChoiceAnswerID: valueOrZero(score.MultipleChoiceAnswerId),
+			TrueOrFalseAnswerID:    valueOrZero(score.TrueOrFalseAnswerId),
+			MaxScore:               score.MaxScore,
+			Score:                  score.Score,
+		})
+	}
+
+	return &business.QuizResult{
+		ID:           r.Id,
+		SessionID:    r.SessionId,
+		MaxScore:     r.MaxScore,
+		Score:        r.Score,
+		AnswerScores: answerScores,
+	}
+}
+
+func (a SingleChoiceAnswer) MapToBusiness() *business.SingleChoiceAnswer {
+	return &business.SingleChoiceAnswer{
+		CommonAnswerData: business.CommonAnswerData{
+			Id:         a.Id,
+			SessionId:  a.SessionId,
+			QuestionId: a.QuestionId,
+			AnswerType: models.AnswerType(a.AnswerType),
+		},
+		Answer: a.Answer,
+	}
+}
+
+func (a MultipleChoiceAnswer) MapToBusiness() *business.MultipleChoiceAnswer {
+	return &business.MultipleChoiceAnswer{
+		CommonAnswerData: business.CommonAnswerData{
+			Id:         a.Id,
+			SessionId:  a.SessionId,
+			QuestionId: a.QuestionId,
+			AnswerType: models.AnswerType(a.AnswerType),
+		},
+		Answers: strings.Split(a.Answers, ""),
+	}
+}
+
+func (a TrueOrFalseAnswer) MapToBusiness() *business.TrueOrFalseAnswer {
+	return &business.TrueOrFalseAnswer{
+		CommonAnswerData: business.CommonAnswerData{
+			Id:         a.Id,
+			SessionId:  a.SessionId,
+			QuestionId: a.QuestionId,
+			AnswerType: models.AnswerType(a.AnswerType),
+		},
+		Answer: a.Answer,
+	}
+}
+
+func (l AnswerLists) MapToBusiness() *business.AnswerLists {
+	singleChoiceAnswers := make([]business.SingleChoiceAnswer, 0, len(l.SingleChoiceAnswers))
+	for _, answer := range l.SingleChoiceAnswers {
+		singleChoiceAnswers = append(singleChoiceAnswers, *answer.MapToBusiness())
+	}
+	multipleChoiceAnswers := make([]business.MultipleChoiceAnswer, 0, len(l.MultipleChoiceAnswers))
+	for _, answer := range l.MultipleChoiceAnswers {
+		multipleChoiceAnswers = append(multipleChoiceAnswers, *answer.MapToBusiness())
+	}
+	trueOrFalseAnswers := make([]business.TrueOrFalseAnswer, 0, len(l.TrueOrFalseAnswer))
+	for _, answer := range l.TrueOrFalseAnswer {
+		trueOrFalseAnswers = append(trueOrFalseAnswers, *answer.MapToBusiness())
+	}
+
+	return &business.AnswerLists{
+		SingleChoiceAnswers:   singleChoiceAnswers,
+		MultipleChoiceAnswers: multipleChoiceAnswers,
+		TrueOrFalseAnswers:    trueOrFalseAnswers,
+	}
+}
+
+func (e QuizHistoryEntry) MapToBusiness() business.QuizHistoryEntry {
+	return business.QuizHistoryEntry{
+		QuizId:          e.QuizID,
+		QuizName:        e.QuizName,
+		SessionID:       e.SessionID,
+		Finished:        e.Finished,
+		DateTaken:       e.DateTaken,
+		TimeSpent:       time.Duration(e.TimeSpent),
+		ScorePercentage: e.ScorePercentage,
+	}
+}
+
+func (l LearnList) MapToBusiness() *business.LearnList {
+	availableItems := make([]business.LearnListItem, 0, len(l.AvailableItems))
+	for _, item := range l.AvailableItems {
+		availableItems = append(availableItems, business.LearnListItem{QuizID: item.QuizID, QuizName: item.QuizName})
+	}
+	selectedItems := make([]business.LearnListItem, 0, len(l.SelectedItems))
+	for _, item := range l.SelectedItems {
+		selectedItems = append(selectedItems, business.LearnListItem{QuizID: item.QuizID, QuizName: item.QuizName})
+	}
+
+	return &business.LearnList{
+		AvailableItems: availableItems,
+		SelectedItems:  selectedItems,
+	}
+}
+
+func (r ReviewItem) MapToBusiness() (*business.ReviewItem, error) {
+	var questionID string
+	if r.SingleChoiceQuestionID != nil {
+		questionID = *r.SingleChoiceQuestionID
+	} else if r.MultipleChoiceQuestionID != nil {
+		questionID = *r.MultipleChoiceQuestionID
+	} else if r.TrueOrFalseQuestionID != nil {
+		questionID = *r.TrueOrFalseQuestionID
+	}
+	if questionID == "" {
+		return nil, fmt.Errorf("nil question ID")
+	}
+
+	now := time.Now().UTC()
+	nextReviewDateUTC := valueOrZero(r.NextReviewDate).UTC()
+
+	return &business.ReviewItem{
+		ID:           r.Id,
+		QuizName:     r.QuizName,
+		QuestionName: r.QuestionName,
+		QuestionID:   questionID,
+		NextReview:   nextReviewDateUTC,
+		Difficulty:   r.Difficulty,
+		Streak:       int(r.Streak),
+		NeedToReview: nextReviewDateUTC.Before(now),
+	}, nil
+}
+
+func (q ReviewItemQuestion) MapToBusiness() (*business.ReviewItemQuestionData, error) {
+	data := &business.ReviewItemQuestionData{CurrentReviewItemID: q.CurrentReviewItemID}
+	if q.SingleChoiceQuestion != nil {
+		question, err := q.SingleChoiceQuestion.MapToBusiness()
+		if err != nil {
+			return nil, err
+		}
+		data.SingleChoiceQuestion = question
+	}
+	if q.MultipleChoiceQuestion != nil {
+		question, err := q.MultipleChoiceQuestion.MapToBusiness()
+		if err != nil {
+			return nil, err
+		}
+		data.MultipleChoiceQuestion = question
+	}
+	if q.TrueOrFalseQuestion != nil {
+		data.TrueOrFalseQuestion = q.TrueOrFalseQuestion.MapToBusiness()
+	}
+	return data, nil
+}
+
+func (o QuizOptions) MapToBusiness() []business.Option {
+	options := make([]business.Option, 0, len(o.QuizOptions))
+	for _, option := range o.QuizOptions {
+		options = append(options, business.Option{Name: option.Name, Value: option.Value})
+	}
+	return options
+}
+
+func (s TotpStatus) MapToBusiness() *business.TotpStatus {
+	return &business.TotpStatus{
+		Enabled:                s.Enabled,
+		RecoveryCodesRemaining: s.RecoveryCodesRemaining,
+	}
+}
+
+func (e TotpEnrollment) MapToBusiness() *business.TotpEnrollment {
+	return &business.TotpEnrollment{
+		Secret:          e.Secret,
+		ProvisioningUri: e.ProvisioningUri,
+	}
+}
+
+func (t ApiToken) MapToBusiness() business.ApiToken {
+	scopes := make([]string, 0, len(t.Scopes))
+	for _, scope := range t.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return business.ApiToken{
+		Id:         t.Id,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+	}
+}
+
+func (s ActiveSession) MapToBusiness() business.ActiveSession {
+	return business.ActiveSession{
+		Id:         s.Id,
+		UserAgent:  s.UserAgent,
+		IpAddress:  s.IpAddress,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ValidUntil: s.ValidUntil,
+		RememberMe: s.RememberMe,
+		Current:    s.Current,
+	}
+}
+
+func (e Erasure) MapToBusiness() *business.Erasure {
+	return &business.Erasure{
+		QuizHandling:    string(e.QuizHandling),
+		TransferToEmail: valueOrZero(e.TransferToEmail),
+		RequestedAt:     e.RequestedAt,
+		ConfirmedAt:     e.ConfirmedAt,
+		EraseAfter:      e.EraseAfter,
+	}
+}
+
+func (p UsagePeriod) MapToBusiness() business.UsagePeriod {
+	return business.UsagePeriod{
+		Name:     p.Name,
+		Limit:    p.Limit,
+		Used:     p.Used,
+		ResetsAt: p.ResetsAt,
+	}
+}
+
+func (u Usage) MapToBusiness() *business.Usage {
+	return &business.Usage{
+		Plan:    u.Plan,
+		Daily:   u.Daily.MapToBusiness(),
+		Monthly: u.Monthly.MapToBusiness(),
+	}
+}
+
+// The exhausted period of a quota_exceeded error
+func (q QuotaExceededDetails) MapToBusiness() *business.UsagePeriod {
+	return &business.UsagePeriod{
+		Name:     string(q.Period),
+		Limit:    q.Limit,
+		Used:     q.Used,
+		ResetsAt: q.ResetsAt,
+	}
+}
+
+func (u AdminUser) MapToBusiness() *business.AdminUser {
+	return &business.AdminUser{
+		Id:            u.Id,
+		Name:          u.Name,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Role:          u.Role,
+		Plan:          u.Plan,
+		DisabledAt:    u.DisabledAt,
+	}
+}
+
+func (p AdminUserPage) MapToBusiness() *business.AdminUserPage {
+	users := make([]business.AdminUser, 0, len(p.Users))
+	for _, user := range p.Users {
+		users = append(users, *user.MapToBusiness())
+	}
+	return &business.AdminUserPage{
+		Users:   users,
+		Page:    p.Page,
+		HasMore: p.HasMore,
+	}
+}
+
+func (p AdminQuizPage) MapToBusiness() *business.AdminQuizPage {
+	quizzes := make([]business.AdminQuiz, 0, len(p.Quizzes))
+	for _, quiz := range p.Quizzes {
+		quizzes = append(quizzes, business.AdminQuiz{
+			QuizInfo: business.QuizInfo{
+				Id:          quiz.Id,
+				Title:       quiz.Title,
+				Description: quiz.Description,
+				CreatorId:   quiz.CreatorId,
+				CreatorName: quiz.CreatorName,
+				Status:      string(quiz.Status),
+			},
+			CreatorEmail: quiz.CreatorEmail,
+		})
+	}
+	return &business.AdminQuizPage{
+		Quizzes: quizzes,
+		Page:    p.Page,
+		HasMore: p.HasMore,
+	}
+}
+
+func (i Impersonation) MapToBusiness() *business.Impersonation {
+	return &business.Impersonation{
+		Session:    i.Session,
+		ValidUntil: i.ValidUntil,
+	}
+}
+
+func (u User) MapToBusiness() business.User {
+	return business.User{
+		Id:            u.Id,
+		Name:          u.Name,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Role:          string(u.Role),
+	}
+}
+
+func (s Session) MapToBusiness() *business.Session {
+	session := &business.Session{
+		Id:   s.Session,
+		User: s.User.MapToBusiness(),
+	}
+	if s.ImpersonatedBy != nil {
+		impersonator := s.ImpersonatedBy.MapToBusiness()
+		session.ImpersonatedBy = &impersonator
+	}
+	return session
+}
+
+// The optional fields are generated as pointers, the pages go by the zero value
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+// Questions of revision snapshots come without a status
+func questionStatus(status *QuestionStatus) string {
+	if status == nil {
+		return ""
+	}
+	return string(*status)
+}
+
+func (p OidcProvider) MapToBusiness() business.OidcProvider {
+	return business.OidcProvider{
+		Id:          p.Id,
+		DisplayName: p.DisplayName,
+	}
+}
+
+func (a OidcAuthorization) MapToBusiness() *business.OidcAuthorization {
+	return &business.OidcAuthorization{
+		Url:   a.Url,
+		State: a.State,
+	}
+}
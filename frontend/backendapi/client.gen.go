@@ -0,0 +1,12935 @@
+// Package backendapi provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package backendapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerTokenScopes   = "bearerToken.Scopes"
+	SessionCookieScopes = "sessionCookie.Scopes"
+)
+
+// Defines values for AnswerType.
+const (
+	AnswerTypeN0 AnswerType = 0
+	AnswerTypeN1 AnswerType = 1
+	AnswerTypeN2 AnswerType = 2
+)
+
+// Defines values for ApiTokenScopes.
+const (
+	ApiTokenScopesLearn    ApiTokenScopes = "learn"
+	ApiTokenScopesQuizzes  ApiTokenScopes = "quizzes"
+	ApiTokenScopesSessions ApiTokenScopes = "sessions"
+)
+
+// Defines values for CreatedApiTokenScopes.
+const (
+	CreatedApiTokenScopesLearn    CreatedApiTokenScopes = "learn"
+	CreatedApiTokenScopesQuizzes  CreatedApiTokenScopes = "quizzes"
+	CreatedApiTokenScopesSessions CreatedApiTokenScopes = "sessions"
+)
+
+// Defines values for ErasureQuizHandling.
+const (
+	ErasureQuizHandlingDelete   ErasureQuizHandling = "delete"
+	ErasureQuizHandlingTransfer ErasureQuizHandling = "transfer"
+)
+
+// Defines values for ErasureRequestQuizHandling.
+const (
+	ErasureRequestQuizHandlingDelete   ErasureRequestQuizHandling = "delete"
+	ErasureRequestQuizHandlingTransfer ErasureRequestQuizHandling = "transfer"
+)
+
+// Defines values for ErrorCode.
+const (
+	ErrorCodeBadRequest      ErrorCode = "bad_request"
+	ErrorCodeConflict        ErrorCode = "conflict"
+	ErrorCodeExpired         ErrorCode = "expired"
+	ErrorCodeForbidden       ErrorCode = "forbidden"
+	ErrorCodeInternalError   ErrorCode = "internal_error"
+	ErrorCodeNotFound        ErrorCode = "not_found"
+	ErrorCodeQuotaExceeded   ErrorCode = "quota_exceeded"
+	ErrorCodeTooManyRequests ErrorCode = "too_many_requests"
+	ErrorCodeUnauthorized    ErrorCode = "unauthorized"
+	ErrorCodeUnavailable     ErrorCode = "unavailable"
+)
+
+// Defines values for MultipleChoiceQuestionCorrectAnswers.
+const (
+	MultipleChoiceQuestionCorrectAnswersA MultipleChoiceQuestionCorrectAnswers = "A"
+	MultipleChoiceQuestionCorrectAnswersB MultipleChoiceQuestionCorrectAnswers = "B"
+	MultipleChoiceQuestionCorrectAnswersC MultipleChoiceQuestionCorrectAnswers = "C"
+	MultipleChoiceQuestionCorrectAnswersD MultipleChoiceQuestionCorrectAnswers = "D"
+)
+
+// Defines values for QuestionStatus.
+const (
+	QuestionStatusApproved QuestionStatus = "approved"
+	QuestionStatusDraft    QuestionStatus = "draft"
+	QuestionStatusRejected QuestionStatus = "rejected"
+)
+
+// Defines values for QuestionStatusRequestStatus.
+const (
+	QuestionStatusRequestStatusApproved QuestionStatusRequestStatus = "approved"
+	QuestionStatusRequestStatusRejected QuestionStatusRequestStatus = "rejected"
+)
+
+// Defines values for QuestionType.
+const (
+	QuestionTypeN0 QuestionType = 0
+	QuestionTypeN1 QuestionType = 1
+	QuestionTypeN2 QuestionType = 2
+	QuestionTypeN3 QuestionType = 3
+)
+
+// Defines values for QuizStatus.
+const (
+	Archived  QuizStatus = "archived"
+	Draft     QuizStatus = "draft"
+	Published QuizStatus = "published"
+)
+
+// Defines values for QuotaExceededDetailsPeriod.
+const (
+	Daily   QuotaExceededDetailsPeriod = "daily"
+	Monthly QuotaExceededDetailsPeriod = "monthly"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	ReadinessStatusDegraded    ReadinessStatus = "degraded"
+	ReadinessStatusDraining    ReadinessStatus = "draining"
+	ReadinessStatusOk          ReadinessStatus = "ok"
+	ReadinessStatusUnavailable ReadinessStatus = "unavailable"
+)
+
+// Defines values for ReviewItemFilterDifficulty.
+const (
+	ReviewItemFilterDifficultyEasy   ReviewItemFilterDifficulty = "easy"
+	ReviewItemFilterDifficultyEmpty  ReviewItemFilterDifficulty = ""
+	ReviewItemFilterDifficultyHard   ReviewItemFilterDifficulty = "hard"
+	ReviewItemFilterDifficultyMedium ReviewItemFilterDifficulty = "medium"
+)
+
+// Defines values for ReviewItemFilterStatus.
+const (
+	ReviewItemFilterStatusDue    ReviewItemFilterStatus = "due"
+	ReviewItemFilterStatusEmpty  ReviewItemFilterStatus = ""
+	ReviewItemFilterStatusNotDue ReviewItemFilterStatus = "not-due"
+)
+
+// Defines values for SetRoleRequestRole.
+const (
+	SetRoleRequestRoleAdmin     SetRoleRequestRole = "admin"
+	SetRoleRequestRoleModerator SetRoleRequestRole = "moderator"
+	SetRoleRequestRoleUser      SetRoleRequestRole = "user"
+)
+
+// Defines values for SingleChoiceQuestionCorrectAnswer.
+const (
+	SingleChoiceQuestionCorrectAnswerA SingleChoiceQuestionCorrectAnswer = "A"
+	SingleChoiceQuestionCorrectAnswerB SingleChoiceQuestionCorrectAnswer = "B"
+	SingleChoiceQuestionCorrectAnswerC SingleChoiceQuestionCorrectAnswer = "C"
+	SingleChoiceQuestionCorrectAnswerD SingleChoiceQuestionCorrectAnswer = "D"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleAdmin     UserRole = "admin"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleUser      UserRole = "user"
+)
+
+// ActiveSession defines model for ActiveSession.
+type ActiveSession struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current The session of the request
+	Current    bool      `json:"current"`
+	Id         string    `json:"id"`
+	IpAddress  string    `json:"ipAddress"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	RememberMe bool      `json:"rememberMe"`
+	UserAgent  string    `json:"userAgent"`
+	ValidUntil time.Time `json:"validUntil"`
+}
+
+// AdminQuiz defines model for AdminQuiz.
+type AdminQuiz struct {
+	CreatorEmail string `json:"creatorEmail"`
+	CreatorId    string `json:"creatorId"`
+
+	// CreatorName Deleted when the creator has no account anymore
+	CreatorName string `json:"creatorName"`
+	Description string `json:"description"`
+	Id          string `json:"id"`
+
+	// Status Learners only start sessions on published quizzes, archived ones take no new learners
+	Status QuizStatus `json:"status"`
+	Title  string     `json:"title"`
+}
+
+// AdminQuizPage defines model for AdminQuizPage.
+type AdminQuizPage struct {
+	HasMore bool        `json:"hasMore"`
+	Page    int         `json:"page"`
+	Quizzes []AdminQuiz `json:"quizzes"`
+}
+
+// AdminUser defines model for AdminUser.
+type AdminUser struct {
+	DisabledAt    *time.Time `json:"disabledAt"`
+	Email         string     `json:"email"`
+	EmailVerified bool       `json:"emailVerified"`
+	Id            string     `json:"id"`
+	Name          string     `json:"name"`
+	Plan          string     `json:"plan"`
+	Role          string     `json:"role"`
+}
+
+// AdminUserPage defines model for AdminUserPage.
+type AdminUserPage struct {
+	HasMore bool        `json:"hasMore"`
+	Page    int         `json:"page"`
+	Users   []AdminUser `json:"users"`
+}
+
+// Answer One of the answer types, told apart by the answerType
+type Answer struct {
+	union json.RawMessage
+}
+
+// AnswerLists defines model for AnswerLists.
+type AnswerLists struct {
+	MultipleChoiceAnswers []MultipleChoiceAnswer `json:"multipleChoiceAnswers"`
+	SingleChoiceAnswers   []SingleChoiceAnswer   `json:"singleChoiceAnswers"`
+	TrueOrFalseAnswer     []TrueOrFalseAnswer    `json:"trueOrFalseAnswer"`
+}
+
+// AnswerRequest defines model for AnswerRequest.
+type AnswerRequest struct {
+	union json.RawMessage
+}
+
+// AnswerScore defines model for AnswerScore.
+type AnswerScore struct {
+	Id                     string  `json:"id"`
+	MaxScore               float64 `json:"maxScore"`
+	MultipleChoiceAnswerId *string `json:"multipleChoiceAnswerId,omitempty"`
+	QuizResultId           string  `json:"quizResultId"`
+	Score                  float64 `json:"score"`
+	SingleChoiceAnswerId   *string `json:"singleChoiceAnswerId,omitempty"`
+	TrueOrFalseAnswerId    *string `json:"trueOrFalseAnswerId,omitempty"`
+}
+
+// AnswerType 0 single choice, 1 multiple choice, 2 true or false
+type AnswerType int
+
+// ApiToken defines model for ApiToken.
+type ApiToken struct {
+	CreatedAt  time.Time        `json:"createdAt"`
+	ExpiresAt  *time.Time       `json:"expiresAt"`
+	Id         string           `json:"id"`
+	LastUsedAt *time.Time       `json:"lastUsedAt"`
+	Name       string           `json:"name"`
+	Prefix     string           `json:"prefix"`
+	Scopes     []ApiTokenScopes `json:"scopes"`
+}
+
+// ApiTokenScopes defines model for ApiToken.Scopes.
+type ApiTokenScopes string
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Action     string    `json:"action"`
+	ActorEmail *string   `json:"actorEmail,omitempty"`
+	ActorId    *string   `json:"actorId"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// Diff The changed fields of the target
+	Diff           *interface{} `json:"diff,omitempty"`
+	Id             string       `json:"id"`
+	ImpersonatorId *string      `json:"impersonatorId"`
+	IpAddress      string       `json:"ipAddress"`
+	TargetId       string       `json:"targetId"`
+	TargetType     string       `json:"targetType"`
+}
+
+// AuditEventPage defines model for AuditEventPage.
+type AuditEventPage struct {
+	Events  []AuditEvent `json:"events"`
+	HasMore bool         `json:"hasMore"`
+	Page    int          `json:"page"`
+}
+
+// ConfirmErasureRequest defines model for ConfirmErasureRequest.
+type ConfirmErasureRequest struct {
+	Token string `json:"token"`
+}
+
+// CreateApiTokenRequest defines model for CreateApiTokenRequest.
+type CreateApiTokenRequest struct {
+	// ExpiresInDays The token does not expire when it is 0
+	ExpiresInDays *int     `json:"expiresInDays,omitempty"`
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+}
+
+// CreatedApiToken defines model for CreatedApiToken.
+type CreatedApiToken struct {
+	CreatedAt  time.Time               `json:"createdAt"`
+	ExpiresAt  *time.Time              `json:"expiresAt"`
+	Id         string                  `json:"id"`
+	LastUsedAt *time.Time              `json:"lastUsedAt"`
+	Name       string                  `json:"name"`
+	Prefix     string                  `json:"prefix"`
+	Scopes     []CreatedApiTokenScopes `json:"scopes"`
+	Token      string                  `json:"token"`
+}
+
+// CreatedApiTokenScopes defines model for CreatedApiToken.Scopes.
+type CreatedApiTokenScopes string
+
+// EmailVerification defines model for EmailVerification.
+type EmailVerification struct {
+	Message string `json:"message"`
+}
+
+// Erasure defines model for Erasure.
+type Erasure struct {
+	ConfirmedAt     *time.Time          `json:"confirmedAt"`
+	EraseAfter      *time.Time          `json:"eraseAfter"`
+	QuizHandling    ErasureQuizHandling `json:"quizHandling"`
+	RequestedAt     time.Time           `json:"requestedAt"`
+	TransferToEmail *string             `json:"transferToEmail,omitempty"`
+}
+
+// ErasureQuizHandling defines model for Erasure.QuizHandling.
+type ErasureQuizHandling string
+
+// ErasureRequest defines model for ErasureRequest.
+type ErasureRequest struct {
+	QuizHandling ErasureRequestQuizHandling `json:"quizHandling"`
+
+	// TransferTo Email of the user receiving the quizzes when they are transferred
+	TransferTo *string `json:"transferTo,omitempty"`
+}
+
+// ErasureRequestQuizHandling defines model for ErasureRequest.QuizHandling.
+type ErasureRequestQuizHandling string
+
+// Error defines model for Error.
+type Error struct {
+	Code ErrorCode `json:"code"`
+
+	// Details Extra fields of the error, QuotaExceededDetails for quota_exceeded
+	Details *interface{} `json:"details,omitempty"`
+
+	// Message Written for the user, the cause of internal errors is only logged
+	Message   string  `json:"message"`
+	RequestId *string `json:"requestId,omitempty"`
+}
+
+// ErrorCode defines model for Error.Code.
+type ErrorCode string
+
+// GenerateQuestionRequest defines model for GenerateQuestionRequest.
+type GenerateQuestionRequest struct {
+	// Prompt The text the question is generated from
+	Prompt string `json:"prompt"`
+	QuizId string `json:"quizId"`
+}
+
+// Identity defines model for Identity.
+type Identity struct {
+	CreatedAt   time.Time  `json:"createdAt"`
+	Email       string     `json:"email"`
+	Id          string     `json:"id"`
+	LastLoginAt *time.Time `json:"lastLoginAt"`
+	Provider    string     `json:"provider"`
+}
+
+// Impersonation defines model for Impersonation.
+type Impersonation struct {
+	Session    string    `json:"session"`
+	ValidUntil time.Time `json:"validUntil"`
+}
+
+// LearnList defines model for LearnList.
+type LearnList struct {
+	AvailableItems []LearnListItem `json:"availableItems"`
+	SelectedItems  []LearnListItem `json:"selectedItems"`
+}
+
+// LearnListItem defines model for LearnListItem.
+type LearnListItem struct {
+	QuizID   string `json:"quizID"`
+	QuizName string `json:"quizName"`
+}
+
+// Liveness defines model for Liveness.
+type Liveness struct {
+	Status string `json:"status"`
+}
+
+// LoginChallenge defines model for LoginChallenge.
+type LoginChallenge struct {
+	Challenge    string `json:"challenge"`
+	TotpRequired bool   `json:"totpRequired"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe *bool  `json:"rememberMe,omitempty"`
+}
+
+// LoginTotpRequest defines model for LoginTotpRequest.
+type LoginTotpRequest struct {
+	Challenge string `json:"challenge"`
+
+	// Code A code of the authenticator app or a recovery code
+	Code string `json:"code"`
+}
+
+// MultipleChoiceAnswer defines model for MultipleChoiceAnswer.
+type MultipleChoiceAnswer struct {
+	// AnswerType 0 single choice, 1 multiple choice, 2 true or false
+	AnswerType AnswerType `json:"answerType"`
+
+	// Answers The chosen options joined, e.g. AC
+	Answers    string `json:"answers"`
+	Id         string `json:"id"`
+	QuestionId string `json:"questionId"`
+	SessionId  string `json:"sessionId"`
+}
+
+// MultipleChoiceAnswerRequest defines model for MultipleChoiceAnswerRequest.
+type MultipleChoiceAnswerRequest struct {
+	AnswerType string   `json:"answerType"`
+	Answers    []string `json:"answers"`
+	QuestionId string   `json:"questionId"`
+}
+
+// MultipleChoiceQuestion defines model for MultipleChoiceQuestion.
+type MultipleChoiceQuestion struct {
+	Answers        []string                               `json:"answers"`
+	CorrectAnswers []MultipleChoiceQuestionCorrectAnswers `json:"correctAnswers"`
+	Id             string                                 `json:"id"`
+
+	// Published Whether learners get the question, it was approved when the quiz was last published
+	Published *bool  `json:"published,omitempty"`
+	Question  string `json:"question"`
+
+	// QuestionType 0 single choice, 1 multiple choice, 2 true or false, 3 open ended
+	QuestionType QuestionType `json:"questionType"`
+	Quizid       string       `json:"quizid"`
+
+	// Status Generated questions start as drafts in the review queue, written and
+	// imported ones are approved. Set by the server, ignored on import.
+	Status *QuestionStatus `json:"status,omitempty"`
+}
+
+// MultipleChoiceQuestionCorrectAnswers defines model for MultipleChoiceQuestion.CorrectAnswers.
+type MultipleChoiceQuestionCorrectAnswers string
+
+// MultipleChoiceQuestionUpdate defines model for MultipleChoiceQuestionUpdate.
+type MultipleChoiceQuestionUpdate struct {
+	Answers        []string `json:"answers"`
+	CorrectAnswers []string `json:"correctAnswers"`
+	Question       string   `json:"question"`
+	QuizId         string   `json:"quizId"`
+}
+
+// OidcAuthorization defines model for OidcAuthorization.
+type OidcAuthorization struct {
+	State string `json:"state"`
+	Url   string `json:"url"`
+}
+
+// OidcCallbackRequest defines model for OidcCallbackRequest.
+type OidcCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// OidcProvider defines model for OidcProvider.
+type OidcProvider struct {
+	DisplayName string `json:"displayName"`
+	Id          string `json:"id"`
+}
+
+// Option defines model for Option.
+type Option struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Question One of the question types, told apart by the questionType
+type Question struct {
+	union json.RawMessage
+}
+
+// QuestionChange defines model for QuestionChange.
+type QuestionChange struct {
+	// From The value in the older revision
+	From *interface{} `json:"from,omitempty"`
+
+	// To The value in the newer revision
+	To *interface{} `json:"to,omitempty"`
+}
+
+// QuestionRevision defines model for QuestionRevision.
+type QuestionRevision struct {
+	// AuthorId Missing for the version from before the history was kept
+	AuthorId   *string   `json:"authorId"`
+	AuthorName *string   `json:"authorName"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Id         string    `json:"id"`
+
+	// Question One of the question types, told apart by the questionType
+	Question   Question `json:"question"`
+	QuestionId string   `json:"questionId"`
+
+	// QuestionType 0 single choice, 1 multiple choice, 2 true or false, 3 open ended
+	QuestionType QuestionType `json:"questionType"`
+
+	// RestoredFrom The revision this one restored
+	RestoredFrom *int `json:"restoredFrom,omitempty"`
+	Revision     int  `json:"revision"`
+}
+
+// QuestionRevisionDiff defines model for QuestionRevisionDiff.
+type QuestionRevisionDiff struct {
+	// Changes The changed fields of the question by their name
+	Changes map[string]QuestionChange `json:"changes"`
+
+	// CorrectAnswerChanged Whether the correct answer, with the text of its options, differs
+	CorrectAnswerChanged bool `json:"correctAnswerChanged"`
+	From                 int  `json:"from"`
+	To                   int  `json:"to"`
+}
+
+// QuestionStatus Generated questions start as drafts in the review queue, written and
+// imported ones are approved. Set by the server, ignored on import.
+type QuestionStatus string
+
+// QuestionStatusRequest defines model for QuestionStatusRequest.
+type QuestionStatusRequest struct {
+	Status QuestionStatusRequestStatus `json:"status"`
+}
+
+// QuestionStatusRequestStatus defines model for QuestionStatusRequest.Status.
+type QuestionStatusRequestStatus string
+
+// QuestionType 0 single choice, 1 multiple choice, 2 true or false, 3 open ended
+type QuestionType int
+
+// Quiz defines model for Quiz.
+type Quiz struct {
+	CreatorId string `json:"creatorId"`
+
+	// CreatorName Deleted when the creator has no account anymore
+	CreatorName string     `json:"creatorName"`
+	Description string     `json:"description"`
+	Id          string     `json:"id"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+
+	// Questions Every question for the owner, the published ones for everyone else
+	Questions []Question `json:"questions"`
+
+	// Status Learners only start sessions on published quizzes, archived ones take no new learners
+	Status QuizStatus `json:"status"`
+	Title  string     `json:"title"`
+}
+
+// QuizHistory defines model for QuizHistory.
+type QuizHistory struct {
+	Length             int                `json:"length"`
+	QuizHistoryEntries []QuizHistoryEntry `json:"quizHistoryEntries"`
+}
+
+// QuizHistoryEntry defines model for QuizHistoryEntry.
+type QuizHistoryEntry struct {
+	DateTaken       time.Time `json:"dateTaken"`
+	Finished        bool      `json:"finished"`
+	QuizID          string    `json:"quizID"`
+	QuizName        string    `json:"quizName"`
+	ScorePercentage float64   `json:"scorePercentage"`
+	SessionID       string    `json:"sessionID"`
+
+	// TimeSpent Nanoseconds
+	TimeSpent int64 `json:"timeSpent"`
+}
+
+// QuizImport defines model for QuizImport.
+type QuizImport struct {
+	Description *string    `json:"description,omitempty"`
+	Name        string     `json:"name"`
+	Questions   []Question `json:"questions"`
+}
+
+// QuizInfo defines model for QuizInfo.
+type QuizInfo struct {
+	CreatorId string `json:"creatorId"`
+
+	// CreatorName Deleted when the creator has no account anymore
+	CreatorName string `json:"creatorName"`
+	Description string `json:"description"`
+	Id          string `json:"id"`
+
+	// Status Learners only start sessions on published quizzes, archived ones take no new learners
+	Status QuizStatus `json:"status"`
+	Title  string     `json:"title"`
+}
+
+// QuizList defines model for QuizList.
+type QuizList struct {
+	Length  int         `json:"length"`
+	Quizzes *[]QuizInfo `json:"quizzes"`
+}
+
+// QuizOptions defines model for QuizOptions.
+type QuizOptions struct {
+	QuizOptions []Option `json:"quizOptions"`
+}
+
+// QuizRequest defines model for QuizRequest.
+type QuizRequest struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+}
+
+// QuizResult defines model for QuizResult.
+type QuizResult struct {
+	AnswerScores []AnswerScore `json:"answerScores"`
+	Id           string        `json:"id"`
+	MaxScore     float64       `json:"maxScore"`
+	Score        float64       `json:"score"`
+	SessionId    string        `json:"sessionId"`
+}
+
+// QuizSession defines model for QuizSession.
+type QuizSession struct {
+	ClosesAt *time.Time `json:"closesAt"`
+
+	// FinishedAt Null or the zero time while the session is open
+	FinishedAt *time.Time `json:"finishedAt"`
+	Id         string     `json:"id"`
+	Quizid     string     `json:"quizid"`
+	StartedAt  *time.Time `json:"startedAt"`
+	Userid     string     `json:"userid"`
+}
+
+// QuizSessionList defines model for QuizSessionList.
+type QuizSessionList struct {
+	Length       int           `json:"length"`
+	QuizSessions []QuizSession `json:"quizSessions"`
+}
+
+// QuizStatus Learners only start sessions on published quizzes, archived ones take no new learners
+type QuizStatus string
+
+// QuotaExceededDetails defines model for QuotaExceededDetails.
+type QuotaExceededDetails struct {
+	Limit    int                        `json:"limit"`
+	Period   QuotaExceededDetailsPeriod `json:"period"`
+	ResetsAt time.Time                  `json:"resetsAt"`
+	Used     int                        `json:"used"`
+}
+
+// QuotaExceededDetailsPeriod defines model for QuotaExceededDetails.Period.
+type QuotaExceededDetailsPeriod string
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks map[string]string `json:"checks"`
+	Status ReadinessStatus   `json:"status"`
+}
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// ResendVerificationRequest defines model for ResendVerificationRequest.
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ReviewItem defines model for ReviewItem.
+type ReviewItem struct {
+	Difficulty               float64    `json:"difficulty"`
+	EaseFactor               float64    `json:"easeFactor"`
+	Id                       string     `json:"id"`
+	IntervalInMinutes        int32      `json:"intervalInMinutes"`
+	MultipleChoiceQuestionID *string    `json:"multipleChoiceQuestionID"`
+	NextReviewDate           *time.Time `json:"nextReviewDate"`
+	QuestionName             string     `json:"questionName"`
+	QuizID                   string     `json:"quizID"`
+	QuizName                 string     `json:"quizName"`
+	SingleChoiceQuestionID   *string    `json:"singleChoiceQuestionID"`
+	Streak                   int32      `json:"streak"`
+	TrueOrFalseQuestionID    *string    `json:"trueOrFalseQuestionID"`
+	UserID                   string     `json:"userID"`
+}
+
+// ReviewItemAnswer Only the value of the type of the review item is read
+type ReviewItemAnswer struct {
+	MultipleChoiceValue *[]string `json:"multipleChoiceValue,omitempty"`
+	SingleChoiceValue   *string   `json:"singleChoiceValue,omitempty"`
+	TrueOrFalseValue    *bool     `json:"trueOrFalseValue,omitempty"`
+}
+
+// ReviewItemCounts defines model for ReviewItemCounts.
+type ReviewItemCounts struct {
+	DueToReview int `json:"dueToReview"`
+	Total       int `json:"total"`
+}
+
+// ReviewItemFilter defines model for ReviewItemFilter.
+type ReviewItemFilter struct {
+	Difficulty *ReviewItemFilterDifficulty `json:"difficulty,omitempty"`
+	Page       *int                        `json:"page,omitempty"`
+	Query      *string                     `json:"query,omitempty"`
+
+	// Quiz Id of the quiz, every quiz when empty
+	Quiz   *string                 `json:"quiz,omitempty"`
+	Status *ReviewItemFilterStatus `json:"status,omitempty"`
+}
+
+// ReviewItemFilterDifficulty defines model for ReviewItemFilter.Difficulty.
+type ReviewItemFilterDifficulty string
+
+// ReviewItemFilterStatus defines model for ReviewItemFilter.Status.
+type ReviewItemFilterStatus string
+
+// ReviewItemPage defines model for ReviewItemPage.
+type ReviewItemPage struct {
+	ReviewItemCountForFilter int          `json:"reviewItemCountForFilter"`
+	ReviewItems              []ReviewItem `json:"reviewItems"`
+}
+
+// ReviewItemQuestion Only the question of the type of the review item is set
+type ReviewItemQuestion struct {
+	CurrentReviewItemID    string                  `json:"currentReviewItemID"`
+	MultipleChoiceQuestion *MultipleChoiceQuestion `json:"multipleChoiceQuestion"`
+	SingleChoiceQuestion   *SingleChoiceQuestion   `json:"singleChoiceQuestion"`
+	TrueOrFalseQuestion    *TrueOrFalseQuestion    `json:"trueOrFalseQuestion"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	ImpersonatedBy *User  `json:"impersonatedBy,omitempty"`
+	Session        string `json:"session"`
+	User           User   `json:"user"`
+}
+
+// SetRoleRequest defines model for SetRoleRequest.
+type SetRoleRequest struct {
+	Role SetRoleRequestRole `json:"role"`
+}
+
+// SetRoleRequestRole defines model for SetRoleRequest.Role.
+type SetRoleRequestRole string
+
+// SignupRequest defines model for SignupRequest.
+type SignupRequest struct {
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	Password      string `json:"password"`
+	PasswordAgain string `json:"passwordAgain"`
+}
+
+// SingleChoiceAnswer defines model for SingleChoiceAnswer.
+type SingleChoiceAnswer struct {
+	// Answer The chosen option, A to D, or empty
+	Answer string `json:"answer"`
+
+	// AnswerType 0 single choice, 1 multiple choice, 2 true or false
+	AnswerType AnswerType `json:"answerType"`
+	Id         string     `json:"id"`
+	QuestionId string     `json:"questionId"`
+	SessionId  string     `json:"sessionId"`
+}
+
+// SingleChoiceAnswerRequest defines model for SingleChoiceAnswerRequest.
+type SingleChoiceAnswerRequest struct {
+	Answer     string `json:"answer"`
+	AnswerType string `json:"answerType"`
+	QuestionId string `json:"questionId"`
+}
+
+// SingleChoiceQuestion defines model for SingleChoiceQuestion.
+type SingleChoiceQuestion struct {
+	Answers       []string                          `json:"answers"`
+	CorrectAnswer SingleChoiceQuestionCorrectAnswer `json:"correctAnswer"`
+	Id            string                            `json:"id"`
+
+	// Published Whether learners get the question, it was approved when the quiz was last published
+	Published *bool  `json:"published,omitempty"`
+	Question  string `json:"question"`
+
+	// QuestionType 0 single choice, 1 multiple choice, 2 true or false, 3 open ended
+	QuestionType QuestionType `json:"questionType"`
+	Quizid       string       `json:"quizid"`
+
+	// Status Generated questions start as drafts in the review queue, written and
+	// imported ones are approved. Set by the server, ignored on import.
+	Status *QuestionStatus `json:"status,omitempty"`
+}
+
+// SingleChoiceQuestionCorrectAnswer defines model for SingleChoiceQuestion.CorrectAnswer.
+type SingleChoiceQuestionCorrectAnswer string
+
+// SingleChoiceQuestionUpdate defines model for SingleChoiceQuestionUpdate.
+type SingleChoiceQuestionUpdate struct {
+	Answers       []string `json:"answers"`
+	CorrectAnswer string   `json:"correctAnswer"`
+	Question      string   `json:"question"`
+	QuizId        string   `json:"quizId"`
+}
+
+// StartQuizSessionRequest defines model for StartQuizSessionRequest.
+type StartQuizSessionRequest struct {
+	QuizId string `json:"quizId"`
+}
+
+// TotpCodeRequest defines model for TotpCodeRequest.
+type TotpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TotpEnrollment defines model for TotpEnrollment.
+type TotpEnrollment struct {
+	ProvisioningUri string `json:"provisioningUri"`
+	Secret          string `json:"secret"`
+}
+
+// TotpStatus defines model for TotpStatus.
+type TotpStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+// TrueOrFalseAnswer defines model for TrueOrFalseAnswer.
+type TrueOrFalseAnswer struct {
+	Answer *bool `json:"answer"`
+
+	// AnswerType 0 single choice, 1 multiple choice, 2 true or false
+	AnswerType AnswerType `json:"answerType"`
+	Id         string     `json:"id"`
+	QuestionId string     `json:"questionId"`
+	SessionId  string     `json:"sessionId"`
+}
+
+// TrueOrFalseAnswerRequest defines model for TrueOrFalseAnswerRequest.
+type TrueOrFalseAnswerRequest struct {
+	Answer     *bool  `json:"answer"`
+	AnswerType string `json:"answerType"`
+	QuestionId string `json:"questionId"`
+}
+
+// TrueOrFalseQuestion defines model for TrueOrFalseQuestion.
+type TrueOrFalseQuestion struct {
+	CorrectAnswer bool   `json:"correct_answer"`
+	Id            string `json:"id"`
+
+	// Published Whether learners get the question, it was approved when the quiz was last published
+	Published *bool  `json:"published,omitempty"`
+	Question  string `json:"question"`
+
+	// QuestionType 0 single choice, 1 multiple choice, 2 true or false, 3 open ended
+	QuestionType QuestionType `json:"questionType"`
+	Quizid       string       `json:"quizid"`
+
+	// Status Generated questions start as drafts in the review queue, written and
+	// imported ones are approved. Set by the server, ignored on import.
+	Status *QuestionStatus `json:"status,omitempty"`
+}
+
+// TrueOrFalseQuestionUpdate defines model for TrueOrFalseQuestionUpdate.
+type TrueOrFalseQuestionUpdate struct {
+	CorrectAnswer bool   `json:"correctAnswer"`
+	Question      string `json:"question"`
+	QuizId        string `json:"quizId"`
+}
+
+// Usage defines model for Usage.
+type Usage struct {
+	Daily   UsagePeriod `json:"daily"`
+	Monthly UsagePeriod `json:"monthly"`
+	Plan    string      `json:"plan"`
+}
+
+// UsagePeriod defines model for UsagePeriod.
+type UsagePeriod struct {
+	Limit    int       `json:"limit"`
+	Name     string    `json:"name"`
+	ResetsAt time.Time `json:"resetsAt"`
+	Used     int       `json:"used"`
+}
+
+// User defines model for User.
+type User struct {
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Id            string   `json:"id"`
+	Name          string   `json:"name"`
+	Role          UserRole `json:"role"`
+}
+
+// UserRole defines model for User.Role.
+type UserRole string
+
+// Id defines model for Id.
+type Id = string
+
+// LearnListQuizId defines model for LearnListQuizId.
+type LearnListQuizId = string
+
+// Page defines model for Page.
+type Page = int
+
+// Provider defines model for Provider.
+type Provider = string
+
+// Query defines model for Query.
+type Query = string
+
+// QuizId defines model for QuizId.
+type QuizId = string
+
+// QuizSessionId defines model for QuizSessionId.
+type QuizSessionId = string
+
+// ResetReviewItems defines model for ResetReviewItems.
+type ResetReviewItems = bool
+
+// ReviewItemId defines model for ReviewItemId.
+type ReviewItemId = string
+
+// Revision defines model for Revision.
+type Revision = int
+
+// Deleted defines model for Deleted.
+type Deleted = string
+
+// GenerateQuestion defines model for GenerateQuestion.
+type GenerateQuestion = GenerateQuestionRequest
+
+// AdminGetAuditEventsParams defines parameters for AdminGetAuditEvents.
+type AdminGetAuditEventsParams struct {
+	// Page Starts at 1
+	Page       *Page   `form:"page,omitempty" json:"page,omitempty"`
+	Actor      *string `form:"actor,omitempty" json:"actor,omitempty"`
+	Action     *string `form:"action,omitempty" json:"action,omitempty"`
+	TargetType *string `form:"targetType,omitempty" json:"targetType,omitempty"`
+	TargetId   *string `form:"targetId,omitempty" json:"targetId,omitempty"`
+
+	// Since RFC 3339 time or date
+	Since *string `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until RFC 3339 time or date
+	Until *string `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// AdminSearchQuizzesParams defines parameters for AdminSearchQuizzes.
+type AdminSearchQuizzesParams struct {
+	Q *Query `form:"q,omitempty" json:"q,omitempty"`
+
+	// Page Starts at 1
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// AdminSearchUsersParams defines parameters for AdminSearchUsers.
+type AdminSearchUsersParams struct {
+	Q *Query `form:"q,omitempty" json:"q,omitempty"`
+
+	// Page Starts at 1
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// UpdateMultipleChoiceQuestionParams defines parameters for UpdateMultipleChoiceQuestion.
+type UpdateMultipleChoiceQuestionParams struct {
+	// ResetReviewItems Starts the review items of the question over when the change alters its correct answer
+	ResetReviewItems *ResetReviewItems `form:"resetReviewItems,omitempty" json:"resetReviewItems,omitempty"`
+}
+
+// UpdateSingleChoiceQuestionParams defines parameters for UpdateSingleChoiceQuestion.
+type UpdateSingleChoiceQuestionParams struct {
+	// ResetReviewItems Starts the review items of the question over when the change alters its correct answer
+	ResetReviewItems *ResetReviewItems `form:"resetReviewItems,omitempty" json:"resetReviewItems,omitempty"`
+}
+
+// UpdateTrueOrFalseQuestionParams defines parameters for UpdateTrueOrFalseQuestion.
+type UpdateTrueOrFalseQuestionParams struct {
+	// ResetReviewItems Starts the review items of the question over when the change alters its correct answer
+	ResetReviewItems *ResetReviewItems `form:"resetReviewItems,omitempty" json:"resetReviewItems,omitempty"`
+}
+
+// GetQuestionRevisionDiffParams defines parameters for GetQuestionRevisionDiff.
+type GetQuestionRevisionDiffParams struct {
+	From int `form:"from" json:"from"`
+
+	// To The latest revision when missing
+	To *int `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// RestoreQuestionRevisionParams defines parameters for RestoreQuestionRevision.
+type RestoreQuestionRevisionParams struct {
+	// ResetReviewItems Starts the review items of the question over when the change alters its correct answer
+	ResetReviewItems *ResetReviewItems `form:"resetReviewItems,omitempty" json:"resetReviewItems,omitempty"`
+}
+
+// GetQuizHistoryParams defines parameters for GetQuizHistory.
+type GetQuizHistoryParams struct {
+	// UserID Has to be the signed in user
+	UserID string `form:"userID" json:"userID"`
+}
+
+// GetQuizSessionsParams defines parameters for GetQuizSessions.
+type GetQuizSessionsParams struct {
+	QuizId string `form:"quizId" json:"quizId"`
+
+	// Open Only the open sessions when true, only the finished ones when false
+	Open *bool `form:"open,omitempty" json:"open,omitempty"`
+}
+
+// HasOpenQuizSessionParams defines parameters for HasOpenQuizSession.
+type HasOpenQuizSessionParams struct {
+	QuizId string `form:"quizId" json:"quizId"`
+}
+
+// VerifyEmailParams defines parameters for VerifyEmail.
+type VerifyEmailParams struct {
+	Token string `form:"token" json:"token"`
+}
+
+// AdminSetRoleJSONRequestBody defines body for AdminSetRole for application/json ContentType.
+type AdminSetRoleJSONRequestBody = SetRoleRequest
+
+// AuthenticateUserJSONRequestBody defines body for AuthenticateUser for application/json ContentType.
+type AuthenticateUserJSONRequestBody = LoginRequest
+
+// AuthenticateTotpJSONRequestBody defines body for AuthenticateTotp for application/json ContentType.
+type AuthenticateTotpJSONRequestBody = LoginTotpRequest
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = SignupRequest
+
+// ConfirmErasureJSONRequestBody defines body for ConfirmErasure for application/json ContentType.
+type ConfirmErasureJSONRequestBody = ConfirmErasureRequest
+
+// RequestErasureJSONRequestBody defines body for RequestErasure for application/json ContentType.
+type RequestErasureJSONRequestBody = ErasureRequest
+
+// OidcCallbackJSONRequestBody defines body for OidcCallback for application/json ContentType.
+type OidcCallbackJSONRequestBody = OidcCallbackRequest
+
+// CreateMultipleChoiceQuestionJSONRequestBody defines body for CreateMultipleChoiceQuestion for application/json ContentType.
+type CreateMultipleChoiceQuestionJSONRequestBody = GenerateQuestionRequest
+
+// CreateManualMultipleChoiceQuestionJSONRequestBody defines body for CreateManualMultipleChoiceQuestion for application/json ContentType.
+type CreateManualMultipleChoiceQuestionJSONRequestBody = MultipleChoiceQuestionUpdate
+
+// UpdateMultipleChoiceQuestionJSONRequestBody defines body for UpdateMultipleChoiceQuestion for application/json ContentType.
+type UpdateMultipleChoiceQuestionJSONRequestBody = MultipleChoiceQuestionUpdate
+
+// CreateSingleChoiceQuestionJSONRequestBody defines body for CreateSingleChoiceQuestion for application/json ContentType.
+type CreateSingleChoiceQuestionJSONRequestBody = GenerateQuestionRequest
+
+// CreateManualSingleChoiceQuestionJSONRequestBody defines body for CreateManualSingleChoiceQuestion for application/json ContentType.
+type CreateManualSingleChoiceQuestionJSONRequestBody = SingleChoiceQuestionUpdate
+
+// UpdateSingleChoiceQuestionJSONRequestBody defines body for UpdateSingleChoiceQuestion for application/json ContentType.
+type UpdateSingleChoiceQuestionJSONRequestBody = SingleChoiceQuestionUpdate
+
+// CreateTrueOrFalseQuestionJSONRequestBody defines body for CreateTrueOrFalseQuestion for application/json ContentType.
+type CreateTrueOrFalseQuestionJSONRequestBody = GenerateQuestionRequest
+
+// CreateManualTrueOrFalseQuestionJSONRequestBody defines body for CreateManualTrueOrFalseQuestion for application/json ContentType.
+type CreateManualTrueOrFalseQuestionJSONRequestBody = TrueOrFalseQuestionUpdate
+
+// UpdateTrueOrFalseQuestionJSONRequestBody defines body for UpdateTrueOrFalseQuestion for application/json ContentType.
+type UpdateTrueOrFalseQuestionJSONRequestBody = TrueOrFalseQuestionUpdate
+
+// UpdateQuestionStatusJSONRequestBody defines body for UpdateQuestionStatus for application/json ContentType.
+type UpdateQuestionStatusJSONRequestBody = QuestionStatusRequest
+
+// StartQuizSessionJSONRequestBody defines body for StartQuizSession for application/json ContentType.
+type StartQuizSessionJSONRequestBody = StartQuizSessionRequest
+
+// PutAnswerJSONRequestBody defines body for PutAnswer for application/json ContentType.
+type PutAnswerJSONRequestBody = AnswerRequest
+
+// CreateQuizJSONRequestBody defines body for CreateQuiz for application/json ContentType.
+type CreateQuizJSONRequestBody = QuizRequest
+
+// ImportQuizJSONRequestBody defines body for ImportQuiz for application/json ContentType.
+type ImportQuizJSONRequestBody = QuizImport
+
+// UpdateQuizJSONRequestBody defines body for UpdateQuiz for application/json ContentType.
+type UpdateQuizJSONRequestBody = QuizRequest
+
+// ResendVerificationJSONRequestBody defines body for ResendVerification for application/json ContentType.
+type ResendVerificationJSONRequestBody = ResendVerificationRequest
+
+// GetReviewItemsJSONRequestBody defines body for GetReviewItems for application/json ContentType.
+type GetReviewItemsJSONRequestBody = ReviewItemFilter
+
+// SubmitReviewItemJSONRequestBody defines body for SubmitReviewItem for application/json ContentType.
+type SubmitReviewItemJSONRequestBody = ReviewItemAnswer
+
+// CreateApiTokenJSONRequestBody defines body for CreateApiToken for application/json ContentType.
+type CreateApiTokenJSONRequestBody = CreateApiTokenRequest
+
+// ConfirmTotpJSONRequestBody defines body for ConfirmTotp for application/json ContentType.
+type ConfirmTotpJSONRequestBody = TotpCodeRequest
+
+// DisableTotpJSONRequestBody defines body for DisableTotp for application/json ContentType.
+type DisableTotpJSONRequestBody = TotpCodeRequest
+
+// RegenerateRecoveryCodesJSONRequestBody defines body for RegenerateRecoveryCodes for application/json ContentType.
+type RegenerateRecoveryCodesJSONRequestBody = TotpCodeRequest
+
+// AsSingleChoiceAnswer returns the union data inside the Answer as a SingleChoiceAnswer
+func (t Answer) AsSingleChoiceAnswer() (SingleChoiceAnswer, error) {
+	var body SingleChoiceAnswer
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSingleChoiceAnswer overwrites any union data inside the Answer as the provided SingleChoiceAnswer
+func (t *Answer) FromSingleChoiceAnswer(v SingleChoiceAnswer) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSingleChoiceAnswer performs a merge with any union data inside the Answer, using the provided SingleChoiceAnswer
+func (t *Answer) MergeSingleChoiceAnswer(v SingleChoiceAnswer) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsMultipleChoiceAnswer returns the union data inside the Answer as a MultipleChoiceAnswer
+func (t Answer) AsMultipleChoiceAnswer() (MultipleChoiceAnswer, error) {
+	var body MultipleChoiceAnswer
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMultipleChoiceAnswer overwrites any union data inside the Answer as the provided MultipleChoiceAnswer
+func (t *Answer) FromMultipleChoiceAnswer(v MultipleChoiceAnswer) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMultipleChoiceAnswer performs a merge with any union data inside the Answer, using the provided MultipleChoiceAnswer
+func (t *Answer) MergeMultipleChoiceAnswer(v MultipleChoiceAnswer) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTrueOrFalseAnswer returns the union data inside the Answer as a TrueOrFalseAnswer
+func (t Answer) AsTrueOrFalseAnswer() (TrueOrFalseAnswer, error) {
+	var body TrueOrFalseAnswer
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTrueOrFalseAnswer overwrites any union data inside the Answer as the provided TrueOrFalseAnswer
+func (t *Answer) FromTrueOrFalseAnswer(v TrueOrFalseAnswer) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTrueOrFalseAnswer performs a merge with any union data inside the Answer, using the provided TrueOrFalseAnswer
+func (t *Answer) MergeTrueOrFalseAnswer(v TrueOrFalseAnswer) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Answer) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Answer) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsSingleChoiceAnswerRequest returns the union data inside the AnswerRequest as a SingleChoiceAnswerRequest
+func (t AnswerRequest) AsSingleChoiceAnswerRequest() (SingleChoiceAnswerRequest, error) {
+	var body SingleChoiceAnswerRequest
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSingleChoiceAnswerRequest overwrites any union data inside the AnswerRequest as the provided SingleChoiceAnswerRequest
+func (t *AnswerRequest) FromSingleChoiceAnswerRequest(v SingleChoiceAnswerRequest) error {
+	v.AnswerType = "single-choice"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSingleChoiceAnswerRequest performs a merge with any union data inside the AnswerRequest, using the provided SingleChoiceAnswerRequest
+func (t *AnswerRequest) MergeSingleChoiceAnswerRequest(v SingleChoiceAnswerRequest) error {
+	v.AnswerType = "single-choice"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsMultipleChoiceAnswerRequest returns the union data inside the AnswerRequest as a MultipleChoiceAnswerRequest
+func (t AnswerRequest) AsMultipleChoiceAnswerRequest() (MultipleChoiceAnswerRequest, error) {
+	var body MultipleChoiceAnswerRequest
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMultipleChoiceAnswerRequest overwrites any union data inside the AnswerRequest as the provided MultipleChoiceAnswerRequest
+func (t *AnswerRequest) FromMultipleChoiceAnswerRequest(v MultipleChoiceAnswerRequest) error {
+	v.AnswerType = "multiple-choice"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMultipleChoiceAnswerRequest performs a merge with any union data inside the AnswerRequest, using the provided MultipleChoiceAnswerRequest
+func (t *AnswerRequest) MergeMultipleChoiceAnswerRequest(v MultipleChoiceAnswerRequest) error {
+	v.AnswerType = "multiple-choice"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTrueOrFalseAnswerRequest returns the union data inside the AnswerRequest as a TrueOrFalseAnswerRequest
+func (t AnswerRequest) AsTrueOrFalseAnswerRequest() (TrueOrFalseAnswerRequest, error) {
+	var body TrueOrFalseAnswerRequest
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTrueOrFalseAnswerRequest overwrites any union data inside the AnswerRequest as the provided TrueOrFalseAnswerRequest
+func (t *AnswerRequest) FromTrueOrFalseAnswerRequest(v TrueOrFalseAnswerRequest) error {
+	v.AnswerType = "true-or-false"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTrueOrFalseAnswerRequest performs a merge with any union data inside the AnswerRequest, using the provided TrueOrFalseAnswerRequest
+func (t *AnswerRequest) MergeTrueOrFalseAnswerRequest(v TrueOrFalseAnswerRequest) error {
+	v.AnswerType = "true-or-false"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t AnswerRequest) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"answerType"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t AnswerRequest) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "multiple-choice":
+		return t.AsMultipleChoiceAnswerRequest()
+	case "single-choice":
+		return t.AsSingleChoiceAnswerRequest()
+	case "true-or-false":
+		return t.AsTrueOrFalseAnswerRequest()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t AnswerRequest) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *AnswerRequest) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsSingleChoiceQuestion returns the union data inside the Question as a SingleChoiceQuestion
+func (t Question) AsSingleChoiceQuestion() (SingleChoiceQuestion, error) {
+	var body SingleChoiceQuestion
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSingleChoiceQuestion overwrites any union data inside the Question as the provided SingleChoiceQuestion
+func (t *Question) FromSingleChoiceQuestion(v SingleChoiceQuestion) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSingleChoiceQuestion performs a merge with any union data inside the Question, using the provided SingleChoiceQuestion
+func (t *Question) MergeSingleChoiceQuestion(v SingleChoiceQuestion) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsMultipleChoiceQuestion returns the union data inside the Question as a MultipleChoiceQuestion
+func (t Question) AsMultipleChoiceQuestion() (MultipleChoiceQuestion, error) {
+	var body MultipleChoiceQuestion
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMultipleChoiceQuestion overwrites any union data inside the Question as the provided MultipleChoiceQuestion
+func (t *Question) FromMultipleChoiceQuestion(v MultipleChoiceQuestion) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMultipleChoiceQuestion performs a merge with any union data inside the Question, using the provided MultipleChoiceQuestion
+func (t *Question) MergeMultipleChoiceQuestion(v MultipleChoiceQuestion) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTrueOrFalseQuestion returns the union data inside the Question as a TrueOrFalseQuestion
+func (t Question) AsTrueOrFalseQuestion() (TrueOrFalseQuestion, error) {
+	var body TrueOrFalseQuestion
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTrueOrFalseQuestion overwrites any union data inside the Question as the provided TrueOrFalseQuestion
+func (t *Question) FromTrueOrFalseQuestion(v TrueOrFalseQuestion) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTrueOrFalseQuestion performs a merge with any union data inside the Question, using the provided TrueOrFalseQuestion
+func (t *Question) MergeTrueOrFalseQuestion(v TrueOrFalseQuestion) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Question) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Question) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// AdminGetAuditEvents request
+	AdminGetAuditEvents(ctx context.Context, params *AdminGetAuditEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminSearchQuizzes request
+	AdminSearchQuizzes(ctx context.Context, params *AdminSearchQuizzesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminDeleteQuiz request
+	AdminDeleteQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminSearchUsers request
+	AdminSearchUsers(ctx context.Context, params *AdminSearchUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminGetUser request
+	AdminGetUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminDisableUser request
+	AdminDisableUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminEnableUser request
+	AdminEnableUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminImpersonate request
+	AdminImpersonate(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminResendVerification request
+	AdminResendVerification(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminResetTotp request
+	AdminResetTotp(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminSetRoleWithBody request with any body
+	AdminSetRoleWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AdminSetRole(ctx context.Context, id Id, body AdminSetRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AdminVerifyEmail request
+	AdminVerifyEmail(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AuthenticateUserWithBody request with any body
+	AuthenticateUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AuthenticateUser(ctx context.Context, body AuthenticateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AuthenticateTotpWithBody request with any body
+	AuthenticateTotpWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AuthenticateTotp(ctx context.Context, body AuthenticateTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAuthenticated request
+	GetAuthenticated(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateUserWithBody request with any body
+	CreateUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateUser(ctx context.Context, body CreateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ConfirmErasureWithBody request with any body
+	ConfirmErasureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ConfirmErasure(ctx context.Context, body ConfirmErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Liveness request
+	Liveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdentities request
+	GetIdentities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteIdentity request
+	DeleteIdentity(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLearnList request
+	GetLearnList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddQuizToLearnList request
+	AddQuizToLearnList(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveQuizFromLearnList request
+	RemoveQuizFromLearnList(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Logout request
+	Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelErasure request
+	CancelErasure(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetErasure request
+	GetErasure(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RequestErasureWithBody request with any body
+	RequestErasureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RequestErasure(ctx context.Context, body RequestErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportData request
+	ExportData(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsage request
+	GetUsage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOidcProviders request
+	GetOidcProviders(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AuthorizeOidc request
+	AuthorizeOidc(ctx context.Context, provider Provider, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// OidcCallbackWithBody request with any body
+	OidcCallbackWithBody(ctx context.Context, provider Provider, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	OidcCallback(ctx context.Context, provider Provider, body OidcCallbackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateMultipleChoiceQuestionWithBody request with any body
+	CreateMultipleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateMultipleChoiceQuestion(ctx context.Context, body CreateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateManualMultipleChoiceQuestionWithBody request with any body
+	CreateManualMultipleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateManualMultipleChoiceQuestion(ctx context.Context, body CreateManualMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMultipleChoiceQuestion request
+	GetMultipleChoiceQuestion(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateMultipleChoiceQuestionWithBody request with any body
+	UpdateMultipleChoiceQuestionWithBody(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateMultipleChoiceQuestion(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, body UpdateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteMultipleChoiceQuestion request
+	DeleteMultipleChoiceQuestion(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSingleChoiceQuestionWithBody request with any body
+	CreateSingleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSingleChoiceQuestion(ctx context.Context, body CreateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateManualSingleChoiceQuestionWithBody request with any body
+	CreateManualSingleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateManualSingleChoiceQuestion(ctx context.Context, body CreateManualSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSingleChoiceQuestion request
+	GetSingleChoiceQuestion(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateSingleChoiceQuestionWithBody request with any body
+	UpdateSingleChoiceQuestionWithBody(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateSingleChoiceQuestion(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, body UpdateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSingleChoiceQuestion request
+	DeleteSingleChoiceQuestion(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateTrueOrFalseQuestionWithBody request with any body
+	CreateTrueOrFalseQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateTrueOrFalseQuestion(ctx context.Context, body CreateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateManualTrueOrFalseQuestionWithBody request with any body
+	CreateManualTrueOrFalseQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateManualTrueOrFalseQuestion(ctx context.Context, body CreateManualTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTrueOrFalseQuestion request
+	GetTrueOrFalseQuestion(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateTrueOrFalseQuestionWithBody request with any body
+	UpdateTrueOrFalseQuestionWithBody(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateTrueOrFalseQuestion(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, body UpdateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTrueOrFalseQuestion request
+	DeleteTrueOrFalseQuestion(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuestionRevisions request
+	GetQuestionRevisions(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuestionRevisionDiff request
+	GetQuestionRevisionDiff(ctx context.Context, id Id, params *GetQuestionRevisionDiffParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RestoreQuestionRevision request
+	RestoreQuestionRevision(ctx context.Context, id Id, revision Revision, params *RestoreQuestionRevisionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateQuestionStatusWithBody request with any body
+	UpdateQuestionStatusWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateQuestionStatus(ctx context.Context, id Id, body UpdateQuestionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuizHistory request
+	GetQuizHistory(ctx context.Context, params *GetQuizHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuizSessions request
+	GetQuizSessions(ctx context.Context, params *GetQuizSessionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// HasOpenQuizSession request
+	HasOpenQuizSession(ctx context.Context, params *HasOpenQuizSessionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartQuizSessionWithBody request with any body
+	StartQuizSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StartQuizSession(ctx context.Context, body StartQuizSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuizSession request
+	GetQuizSession(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAnswers request
+	GetAnswers(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAnswerWithBody request with any body
+	PutAnswerWithBody(ctx context.Context, quizSessionId QuizSessionId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAnswer(ctx context.Context, quizSessionId QuizSessionId, body PutAnswerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuizResult request
+	GetQuizResult(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubmitQuizSession request
+	SubmitQuizSession(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateQuizWithBody request with any body
+	CreateQuizWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateQuiz(ctx context.Context, body CreateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportQuizWithBody request with any body
+	ImportQuizWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportQuiz(ctx context.Context, body ImportQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuizzesOfUser request
+	GetQuizzesOfUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteQuiz request
+	DeleteQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuiz request
+	GetQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateQuizWithBody request with any body
+	UpdateQuizWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateQuiz(ctx context.Context, id Id, body UpdateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ArchiveQuiz request
+	ArchiveQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PublishQuiz request
+	PublishQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Readiness request
+	Readiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResendVerificationWithBody request with any body
+	ResendVerificationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ResendVerification(ctx context.Context, body ResendVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReviewItemsWithBody request with any body
+	GetReviewItemsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	GetReviewItems(ctx context.Context, body GetReviewItemsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNextReviewItemQuestion request
+	GetNextReviewItemQuestion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReviewItemQuestion request
+	GetReviewItemQuestion(ctx context.Context, reviewItemID ReviewItemId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReviewItemCounts request
+	GetReviewItemCounts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuizOptions request
+	GetQuizOptions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubmitReviewItemWithBody request with any body
+	SubmitReviewItemWithBody(ctx context.Context, reviewItemID ReviewItemId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubmitReviewItem(ctx context.Context, reviewItemID ReviewItemId, body SubmitReviewItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeOtherSessions request
+	RevokeOtherSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSessions request
+	GetSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeSession request
+	RevokeSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiTokens request
+	GetApiTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateApiTokenWithBody request with any body
+	CreateApiTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateApiToken(ctx context.Context, body CreateApiTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeApiToken request
+	RevokeApiToken(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTotpStatus request
+	GetTotpStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ConfirmTotpWithBody request with any body
+	ConfirmTotpWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ConfirmTotp(ctx context.Context, body ConfirmTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DisableTotpWithBody request with any body
+	DisableTotpWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DisableTotp(ctx context.Context, body DisableTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EnrollTotp request
+	EnrollTotp(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegenerateRecoveryCodesWithBody request with any body
+	RegenerateRecoveryCodesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegenerateRecoveryCodes(ctx context.Context, body RegenerateRecoveryCodesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// VerifyEmail request
+	VerifyEmail(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) AdminGetAuditEvents(ctx context.Context, params *AdminGetAuditEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminGetAuditEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminSearchQuizzes(ctx context.Context, params *AdminSearchQuizzesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminSearchQuizzesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminDeleteQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminDeleteQuizRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminSearchUsers(ctx context.Context, params *AdminSearchUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminSearchUsersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminGetUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminGetUserRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminDisableUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminDisableUserRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminEnableUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminEnableUserRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminImpersonate(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminImpersonateRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminResendVerification(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminResendVerificationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminResetTotp(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminResetTotpRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminSetRoleWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminSetRoleRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminSetRole(ctx context.Context, id Id, body AdminSetRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminSetRoleRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AdminVerifyEmail(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminVerifyEmailRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AuthenticateUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthenticateUserRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AuthenticateUser(ctx context.Context, body AuthenticateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthenticateUserRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AuthenticateTotpWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthenticateTotpRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AuthenticateTotp(ctx context.Context, body AuthenticateTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthenticateTotpRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAuthenticated(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthenticatedRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateUserRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateUser(ctx context.Context, body CreateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateUserRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmErasureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmErasureRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmErasure(ctx context.Context, body ConfirmErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmErasureRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Liveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLivenessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetIdentities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdentitiesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteIdentity(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteIdentityRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLearnList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLearnListRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddQuizToLearnList(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddQuizToLearnListRequest(c.Server, quizID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveQuizFromLearnList(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveQuizFromLearnListRequest(c.Server, quizID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogoutRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelErasure(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelErasureRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetErasure(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetErasureRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RequestErasureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRequestErasureRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RequestErasure(ctx context.Context, body RequestErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRequestErasureRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportData(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportDataRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOidcProviders(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOidcProvidersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AuthorizeOidc(ctx context.Context, provider Provider, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthorizeOidcRequest(c.Server, provider)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OidcCallbackWithBody(ctx context.Context, provider Provider, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOidcCallbackRequestWithBody(c.Server, provider, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OidcCallback(ctx context.Context, provider Provider, body OidcCallbackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOidcCallbackRequest(c.Server, provider, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateMultipleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateMultipleChoiceQuestionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateMultipleChoiceQuestion(ctx context.Context, body CreateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateMultipleChoiceQuestionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateManualMultipleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateManualMultipleChoiceQuestionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateManualMultipleChoiceQuestion(ctx context.Context, body CreateManualMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateManualMultipleChoiceQuestionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMultipleChoiceQuestion(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMultipleChoiceQuestionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateMultipleChoiceQuestionWithBody(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateMultipleChoiceQuestionRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateMultipleChoiceQuestion(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, body UpdateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateMultipleChoiceQuestionRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteMultipleChoiceQuestion(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteMultipleChoiceQuestionRequest(c.Server, quizId, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSingleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSingleChoiceQuestionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSingleChoiceQuestion(ctx context.Context, body CreateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSingleChoiceQuestionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateManualSingleChoiceQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateManualSingleChoiceQuestionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateManualSingleChoiceQuestion(ctx context.Context, body CreateManualSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateManualSingleChoiceQuestionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSingleChoiceQuestion(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSingleChoiceQuestionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSingleChoiceQuestionWithBody(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSingleChoiceQuestionRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSingleChoiceQuestion(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, body UpdateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSingleChoiceQuestionRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSingleChoiceQuestion(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSingleChoiceQuestionRequest(c.Server, quizId, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTrueOrFalseQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTrueOrFalseQuestionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTrueOrFalseQuestion(ctx context.Context, body CreateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTrueOrFalseQuestionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateManualTrueOrFalseQuestionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateManualTrueOrFalseQuestionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateManualTrueOrFalseQuestion(ctx context.Context, body CreateManualTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateManualTrueOrFalseQuestionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTrueOrFalseQuestion(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTrueOrFalseQuestionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTrueOrFalseQuestionWithBody(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTrueOrFalseQuestionRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTrueOrFalseQuestion(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, body UpdateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTrueOrFalseQuestionRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTrueOrFalseQuestion(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTrueOrFalseQuestionRequest(c.Server, quizId, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuestionRevisions(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuestionRevisionsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuestionRevisionDiff(ctx context.Context, id Id, params *GetQuestionRevisionDiffParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuestionRevisionDiffRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreQuestionRevision(ctx context.Context, id Id, revision Revision, params *RestoreQuestionRevisionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreQuestionRevisionRequest(c.Server, id, revision, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateQuestionStatusWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateQuestionStatusRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateQuestionStatus(ctx context.Context, id Id, body UpdateQuestionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateQuestionStatusRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuizHistory(ctx context.Context, params *GetQuizHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizHistoryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuizSessions(ctx context.Context, params *GetQuizSessionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizSessionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HasOpenQuizSession(ctx context.Context, params *HasOpenQuizSessionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHasOpenQuizSessionRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartQuizSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartQuizSessionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartQuizSession(ctx context.Context, body StartQuizSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartQuizSessionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuizSession(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizSessionRequest(c.Server, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAnswers(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAnswersRequest(c.Server, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAnswerWithBody(ctx context.Context, quizSessionId QuizSessionId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAnswerRequestWithBody(c.Server, quizSessionId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAnswer(ctx context.Context, quizSessionId QuizSessionId, body PutAnswerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAnswerRequest(c.Server, quizSessionId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuizResult(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizResultRequest(c.Server, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitQuizSession(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitQuizSessionRequest(c.Server, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateQuizWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateQuizRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateQuiz(ctx context.Context, body CreateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateQuizRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportQuizWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportQuizRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportQuiz(ctx context.Context, body ImportQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportQuizRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuizzesOfUser(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizzesOfUserRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteQuizRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateQuizWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateQuizRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateQuiz(ctx context.Context, id Id, body UpdateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateQuizRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ArchiveQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewArchiveQuizRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PublishQuiz(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPublishQuizRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Readiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResendVerificationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResendVerificationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResendVerification(ctx context.Context, body ResendVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResendVerificationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReviewItemsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReviewItemsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReviewItems(ctx context.Context, body GetReviewItemsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReviewItemsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNextReviewItemQuestion(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNextReviewItemQuestionRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReviewItemQuestion(ctx context.Context, reviewItemID ReviewItemId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReviewItemQuestionRequest(c.Server, reviewItemID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReviewItemCounts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReviewItemCountsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetQuizOptions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuizOptionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitReviewItemWithBody(ctx context.Context, reviewItemID ReviewItemId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitReviewItemRequestWithBody(c.Server, reviewItemID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitReviewItem(ctx context.Context, reviewItemID ReviewItemId, body SubmitReviewItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitReviewItemRequest(c.Server, reviewItemID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeOtherSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeOtherSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeSession(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeSessionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiTokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiTokensRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateApiTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateApiTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateApiToken(ctx context.Context, body CreateApiTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateApiTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeApiToken(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeApiTokenRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTotpStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTotpStatusRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmTotpWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmTotpRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ConfirmTotp(ctx context.Context, body ConfirmTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfirmTotpRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DisableTotpWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDisableTotpRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DisableTotp(ctx context.Context, body DisableTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDisableTotpRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EnrollTotp(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEnrollTotpRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegenerateRecoveryCodesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegenerateRecoveryCodesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegenerateRecoveryCodes(ctx context.Context, body RegenerateRecoveryCodesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegenerateRecoveryCodesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) VerifyEmail(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVerifyEmailRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewAdminGetAuditEventsRequest generates requests for AdminGetAuditEvents
+func NewAdminGetAuditEventsRequest(server string, params *AdminGetAuditEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/audit-events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Actor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor", runtime.ParamLocationQuery, *params.Actor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Action != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "action", runtime.ParamLocationQuery, *params.Action); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "targetType", runtime.ParamLocationQuery, *params.TargetType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "targetId", runtime.ParamLocationQuery, *params.TargetId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminSearchQuizzesRequest generates requests for AdminSearchQuizzes
+func NewAdminSearchQuizzesRequest(server string, params *AdminSearchQuizzesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/quizzes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminDeleteQuizRequest generates requests for AdminDeleteQuiz
+func NewAdminDeleteQuizRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/quizzes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminSearchUsersRequest generates requests for AdminSearchUsers
+func NewAdminSearchUsersRequest(server string, params *AdminSearchUsersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminGetUserRequest generates requests for AdminGetUser
+func NewAdminGetUserRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminDisableUserRequest generates requests for AdminDisableUser
+func NewAdminDisableUserRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/disable", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminEnableUserRequest generates requests for AdminEnableUser
+func NewAdminEnableUserRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/enable", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminImpersonateRequest generates requests for AdminImpersonate
+func NewAdminImpersonateRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/impersonate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminResendVerificationRequest generates requests for AdminResendVerification
+func NewAdminResendVerificationRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/resend-verification", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminResetTotpRequest generates requests for AdminResetTotp
+func NewAdminResetTotpRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/reset-totp", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAdminSetRoleRequest calls the generic AdminSetRole builder with application/json body
+func NewAdminSetRoleRequest(server string, id Id, body AdminSetRoleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAdminSetRoleRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAdminSetRoleRequestWithBody generates requests for AdminSetRole with any type of body
+func NewAdminSetRoleRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/role", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAdminVerifyEmailRequest generates requests for AdminVerifyEmail
+func NewAdminVerifyEmailRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/users/%s/verify", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAuthenticateUserRequest calls the generic AuthenticateUser builder with application/json body
+func NewAuthenticateUserRequest(server string, body AuthenticateUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAuthenticateUserRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAuthenticateUserRequestWithBody generates requests for AuthenticateUser with any type of body
+func NewAuthenticateUserRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/authenticate-user")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAuthenticateTotpRequest calls the generic AuthenticateTotp builder with application/json body
+func NewAuthenticateTotpRequest(server string, body AuthenticateTotpJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAuthenticateTotpRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAuthenticateTotpRequestWithBody generates requests for AuthenticateTotp with any type of body
+func NewAuthenticateTotpRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/authenticate-user/totp")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAuthenticatedRequest generates requests for GetAuthenticated
+func NewGetAuthenticatedRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/authenticated")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateUserRequest calls the generic CreateUser builder with application/json body
+func NewCreateUserRequest(server string, body CreateUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateUserRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateUserRequestWithBody generates requests for CreateUser with any type of body
+func NewCreateUserRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/create-user")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewConfirmErasureRequest calls the generic ConfirmErasure builder with application/json body
+func NewConfirmErasureRequest(server string, body ConfirmErasureJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewConfirmErasureRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewConfirmErasureRequestWithBody generates requests for ConfirmErasure with any type of body
+func NewConfirmErasureRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/erasure/confirm")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLivenessRequest generates requests for Liveness
+func NewLivenessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdentitiesRequest generates requests for GetIdentities
+func NewGetIdentitiesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/identities")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteIdentityRequest generates requests for DeleteIdentity
+func NewDeleteIdentityRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/identities/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLearnListRequest generates requests for GetLearnList
+func NewGetLearnListRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/learn-list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddQuizToLearnListRequest generates requests for AddQuizToLearnList
+func NewAddQuizToLearnListRequest(server string, quizID LearnListQuizId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizID", runtime.ParamLocationPath, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/learn-list/%s/add", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRemoveQuizFromLearnListRequest generates requests for RemoveQuizFromLearnList
+func NewRemoveQuizFromLearnListRequest(server string, quizID LearnListQuizId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizID", runtime.ParamLocationPath, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/learn-list/%s/remove", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLogoutRequest generates requests for Logout
+func NewLogoutRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCancelErasureRequest generates requests for CancelErasure
+func NewCancelErasureRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/erasure")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetErasureRequest generates requests for GetErasure
+func NewGetErasureRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/erasure")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRequestErasureRequest calls the generic RequestErasure builder with application/json body
+func NewRequestErasureRequest(server string, body RequestErasureJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRequestErasureRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRequestErasureRequestWithBody generates requests for RequestErasure with any type of body
+func NewRequestErasureRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/erasure")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewExportDataRequest generates requests for ExportData
+func NewExportDataRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUsageRequest generates requests for GetUsage
+func NewGetUsageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/usage")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOidcProvidersRequest generates requests for GetOidcProviders
+func NewGetOidcProvidersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oidc/providers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAuthorizeOidcRequest generates requests for AuthorizeOidc
+func NewAuthorizeOidcRequest(server string, provider Provider) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "provider", runtime.ParamLocationPath, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oidc/%s/authorize", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOidcCallbackRequest calls the generic OidcCallback builder with application/json body
+func NewOidcCallbackRequest(server string, provider Provider, body OidcCallbackJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewOidcCallbackRequestWithBody(server, provider, "application/json", bodyReader)
+}
+
+// NewOidcCallbackRequestWithBody generates requests for OidcCallback with any type of body
+func NewOidcCallbackRequestWithBody(server string, provider Provider, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "provider", runtime.ParamLocationPath, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oidc/%s/callback", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateMultipleChoiceQuestionRequest calls the generic CreateMultipleChoiceQuestion builder with application/json body
+func NewCreateMultipleChoiceQuestionRequest(server string, body CreateMultipleChoiceQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateMultipleChoiceQuestionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateMultipleChoiceQuestionRequestWithBody generates requests for CreateMultipleChoiceQuestion with any type of body
+func NewCreateMultipleChoiceQuestionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/multiple-choice")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateManualMultipleChoiceQuestionRequest calls the generic CreateManualMultipleChoiceQuestion builder with application/json body
+func NewCreateManualMultipleChoiceQuestionRequest(server string, body CreateManualMultipleChoiceQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateManualMultipleChoiceQuestionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateManualMultipleChoiceQuestionRequestWithBody generates requests for CreateManualMultipleChoiceQuestion with any type of body
+func NewCreateManualMultipleChoiceQuestionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/multiple-choice/manual")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetMultipleChoiceQuestionRequest generates requests for GetMultipleChoiceQuestion
+func NewGetMultipleChoiceQuestionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/multiple-choice/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateMultipleChoiceQuestionRequest calls the generic UpdateMultipleChoiceQuestion builder with application/json body
+func NewUpdateMultipleChoiceQuestionRequest(server string, id Id, params *UpdateMultipleChoiceQuestionParams, body UpdateMultipleChoiceQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateMultipleChoiceQuestionRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewUpdateMultipleChoiceQuestionRequestWithBody generates requests for UpdateMultipleChoiceQuestion with any type of body
+func NewUpdateMultipleChoiceQuestionRequestWithBody(server string, id Id, params *UpdateMultipleChoiceQuestionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/multiple-choice/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ResetReviewItems != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "resetReviewItems", runtime.ParamLocationQuery, *params.ResetReviewItems); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteMultipleChoiceQuestionRequest generates requests for DeleteMultipleChoiceQuestion
+func NewDeleteMultipleChoiceQuestionRequest(server string, quizId QuizId, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizId", runtime.ParamLocationPath, quizId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/multiple-choice/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSingleChoiceQuestionRequest calls the generic CreateSingleChoiceQuestion builder with application/json body
+func NewCreateSingleChoiceQuestionRequest(server string, body CreateSingleChoiceQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSingleChoiceQuestionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateSingleChoiceQuestionRequestWithBody generates requests for CreateSingleChoiceQuestion with any type of body
+func NewCreateSingleChoiceQuestionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/single-choice")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateManualSingleChoiceQuestionRequest calls the generic CreateManualSingleChoiceQuestion builder with application/json body
+func NewCreateManualSingleChoiceQuestionRequest(server string, body CreateManualSingleChoiceQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateManualSingleChoiceQuestionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateManualSingleChoiceQuestionRequestWithBody generates requests for CreateManualSingleChoiceQuestion with any type of body
+func NewCreateManualSingleChoiceQuestionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/single-choice/manual")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSingleChoiceQuestionRequest generates requests for GetSingleChoiceQuestion
+func NewGetSingleChoiceQuestionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/single-choice/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateSingleChoiceQuestionRequest calls the generic UpdateSingleChoiceQuestion builder with application/json body
+func NewUpdateSingleChoiceQuestionRequest(server string, id Id, params *UpdateSingleChoiceQuestionParams, body UpdateSingleChoiceQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateSingleChoiceQuestionRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewUpdateSingleChoiceQuestionRequestWithBody generates requests for UpdateSingleChoiceQuestion with any type of body
+func NewUpdateSingleChoiceQuestionRequestWithBody(server string, id Id, params *UpdateSingleChoiceQuestionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/single-choice/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ResetReviewItems != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "resetReviewItems", runtime.ParamLocationQuery, *params.ResetReviewItems); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteSingleChoiceQuestionRequest generates requests for DeleteSingleChoiceQuestion
+func NewDeleteSingleChoiceQuestionRequest(server string, quizId QuizId, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizId", runtime.ParamLocationPath, quizId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/single-choice/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateTrueOrFalseQuestionRequest calls the generic CreateTrueOrFalseQuestion builder with application/json body
+func NewCreateTrueOrFalseQuestionRequest(server string, body CreateTrueOrFalseQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTrueOrFalseQuestionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateTrueOrFalseQuestionRequestWithBody generates requests for CreateTrueOrFalseQuestion with any type of body
+func NewCreateTrueOrFalseQuestionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/true-or-false")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateManualTrueOrFalseQuestionRequest calls the generic CreateManualTrueOrFalseQuestion builder with application/json body
+func NewCreateManualTrueOrFalseQuestionRequest(server string, body CreateManualTrueOrFalseQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateManualTrueOrFalseQuestionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateManualTrueOrFalseQuestionRequestWithBody generates requests for CreateManualTrueOrFalseQuestion with any type of body
+func NewCreateManualTrueOrFalseQuestionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/true-or-false/manual")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTrueOrFalseQuestionRequest generates requests for GetTrueOrFalseQuestion
+func NewGetTrueOrFalseQuestionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/true-or-false/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateTrueOrFalseQuestionRequest calls the generic UpdateTrueOrFalseQuestion builder with application/json body
+func NewUpdateTrueOrFalseQuestionRequest(server string, id Id, params *UpdateTrueOrFalseQuestionParams, body UpdateTrueOrFalseQuestionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTrueOrFalseQuestionRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewUpdateTrueOrFalseQuestionRequestWithBody generates requests for UpdateTrueOrFalseQuestion with any type of body
+func NewUpdateTrueOrFalseQuestionRequestWithBody(server string, id Id, params *UpdateTrueOrFalseQuestionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/true-or-false/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ResetReviewItems != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "resetReviewItems", runtime.ParamLocationQuery, *params.ResetReviewItems); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTrueOrFalseQuestionRequest generates requests for DeleteTrueOrFalseQuestion
+func NewDeleteTrueOrFalseQuestionRequest(server string, quizId QuizId, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizId", runtime.ParamLocationPath, quizId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/true-or-false/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQuestionRevisionsRequest generates requests for GetQuestionRevisions
+func NewGetQuestionRevisionsRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/%s/revisions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQuestionRevisionDiffRequest generates requests for GetQuestionRevisionDiff
+func NewGetQuestionRevisionDiffRequest(server string, id Id, params *GetQuestionRevisionDiffParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/%s/revisions/diff", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, params.From); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRestoreQuestionRevisionRequest generates requests for RestoreQuestionRevision
+func NewRestoreQuestionRevisionRequest(server string, id Id, revision Revision, params *RestoreQuestionRevisionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "revision", runtime.ParamLocationPath, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/%s/revisions/%s/restore", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ResetReviewItems != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "resetReviewItems", runtime.ParamLocationQuery, *params.ResetReviewItems); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateQuestionStatusRequest calls the generic UpdateQuestionStatus builder with application/json body
+func NewUpdateQuestionStatusRequest(server string, id Id, body UpdateQuestionStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateQuestionStatusRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateQuestionStatusRequestWithBody generates requests for UpdateQuestionStatus with any type of body
+func NewUpdateQuestionStatusRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/questions/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQuizHistoryRequest generates requests for GetQuizHistory
+func NewGetQuizHistoryRequest(server string, params *GetQuizHistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "userID", runtime.ParamLocationQuery, params.UserID); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQuizSessionsRequest generates requests for GetQuizSessions
+func NewGetQuizSessionsRequest(server string, params *GetQuizSessionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "quizId", runtime.ParamLocationQuery, params.QuizId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Open != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "open", runtime.ParamLocationQuery, *params.Open); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHasOpenQuizSessionRequest generates requests for HasOpenQuizSession
+func NewHasOpenQuizSessionRequest(server string, params *HasOpenQuizSessionParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/has-open")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "quizId", runtime.ParamLocationQuery, params.QuizId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStartQuizSessionRequest calls the generic StartQuizSession builder with application/json body
+func NewStartQuizSessionRequest(server string, body StartQuizSessionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStartQuizSessionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStartQuizSessionRequestWithBody generates requests for StartQuizSession with any type of body
+func NewStartQuizSessionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/start")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQuizSessionRequest generates requests for GetQuizSession
+func NewGetQuizSessionRequest(server string, quizSessionId QuizSessionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizSessionId", runtime.ParamLocationPath, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAnswersRequest generates requests for GetAnswers
+func NewGetAnswersRequest(server string, quizSessionId QuizSessionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizSessionId", runtime.ParamLocationPath, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/%s/answers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAnswerRequest calls the generic PutAnswer builder with application/json body
+func NewPutAnswerRequest(server string, quizSessionId QuizSessionId, body PutAnswerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAnswerRequestWithBody(server, quizSessionId, "application/json", bodyReader)
+}
+
+// NewPutAnswerRequestWithBody generates requests for PutAnswer with any type of body
+func NewPutAnswerRequestWithBody(server string, quizSessionId QuizSessionId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizSessionId", runtime.ParamLocationPath, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/%s/answers", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQuizResultRequest generates requests for GetQuizResult
+func NewGetQuizResultRequest(server string, quizSessionId QuizSessionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizSessionId", runtime.ParamLocationPath, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/%s/result", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubmitQuizSessionRequest generates requests for SubmitQuizSession
+func NewSubmitQuizSessionRequest(server string, quizSessionId QuizSessionId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "quizSessionId", runtime.ParamLocationPath, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quiz-sessions/%s/submit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateQuizRequest calls the generic CreateQuiz builder with application/json body
+func NewCreateQuizRequest(server string, body CreateQuizJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateQuizRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateQuizRequestWithBody generates requests for CreateQuiz with any type of body
+func NewCreateQuizRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/create")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewImportQuizRequest calls the generic ImportQuiz builder with application/json body
+func NewImportQuizRequest(server string, body ImportQuizJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewImportQuizRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewImportQuizRequestWithBody generates requests for ImportQuiz with any type of body
+func NewImportQuizRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQuizzesOfUserRequest generates requests for GetQuizzesOfUser
+func NewGetQuizzesOfUserRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/user/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteQuizRequest generates requests for DeleteQuiz
+func NewDeleteQuizRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQuizRequest generates requests for GetQuiz
+func NewGetQuizRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateQuizRequest calls the generic UpdateQuiz builder with application/json body
+func NewUpdateQuizRequest(server string, id Id, body UpdateQuizJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateQuizRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateQuizRequestWithBody generates requests for UpdateQuiz with any type of body
+func NewUpdateQuizRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewArchiveQuizRequest generates requests for ArchiveQuiz
+func NewArchiveQuizRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/%s/archive", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPublishQuizRequest generates requests for PublishQuiz
+func NewPublishQuizRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/quizzes/%s/publish", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReadinessRequest generates requests for Readiness
+func NewReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResendVerificationRequest calls the generic ResendVerification builder with application/json body
+func NewResendVerificationRequest(server string, body ResendVerificationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewResendVerificationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewResendVerificationRequestWithBody generates requests for ResendVerification with any type of body
+func NewResendVerificationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resend-verification")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetReviewItemsRequest calls the generic GetReviewItems builder with application/json body
+func NewGetReviewItemsRequest(server string, body GetReviewItemsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewGetReviewItemsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewGetReviewItemsRequestWithBody generates requests for GetReviewItems with any type of body
+func NewGetReviewItemsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/review-items")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetNextReviewItemQuestionRequest generates requests for GetNextReviewItemQuestion
+func NewGetNextReviewItemQuestionRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/review-items/get-question")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReviewItemQuestionRequest generates requests for GetReviewItemQuestion
+func NewGetReviewItemQuestionRequest(server string, reviewItemID ReviewItemId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "reviewItemID", runtime.ParamLocationPath, reviewItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/review-items/get-question/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReviewItemCountsRequest generates requests for GetReviewItemCounts
+func NewGetReviewItemCountsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/review-items/item-counts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQuizOptionsRequest generates requests for GetQuizOptions
+func NewGetQuizOptionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/review-items/quiz-options")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubmitReviewItemRequest calls the generic SubmitReviewItem builder with application/json body
+func NewSubmitReviewItemRequest(server string, reviewItemID ReviewItemId, body SubmitReviewItemJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubmitReviewItemRequestWithBody(server, reviewItemID, "application/json", bodyReader)
+}
+
+// NewSubmitReviewItemRequestWithBody generates requests for SubmitReviewItem with any type of body
+func NewSubmitReviewItemRequestWithBody(server string, reviewItemID ReviewItemId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "reviewItemID", runtime.ParamLocationPath, reviewItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/review-items/%s/submit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeOtherSessionsRequest generates requests for RevokeOtherSessions
+func NewRevokeOtherSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSessionsRequest generates requests for GetSessions
+func NewGetSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRevokeSessionRequest generates requests for RevokeSession
+func NewRevokeSessionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiTokensRequest generates requests for GetApiTokens
+func NewGetApiTokensRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateApiTokenRequest calls the generic CreateApiToken builder with application/json body
+func NewCreateApiTokenRequest(server string, body CreateApiTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateApiTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateApiTokenRequestWithBody generates requests for CreateApiToken with any type of body
+func NewCreateApiTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeApiTokenRequest generates requests for RevokeApiToken
+func NewRevokeApiTokenRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTotpStatusRequest generates requests for GetTotpStatus
+func NewGetTotpStatusRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/totp")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewConfirmTotpRequest calls the generic ConfirmTotp builder with application/json body
+func NewConfirmTotpRequest(server string, body ConfirmTotpJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewConfirmTotpRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewConfirmTotpRequestWithBody generates requests for ConfirmTotp with any type of body
+func NewConfirmTotpRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/totp/confirm")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDisableTotpRequest calls the generic DisableTotp builder with application/json body
+func NewDisableTotpRequest(server string, body DisableTotpJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDisableTotpRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewDisableTotpRequestWithBody generates requests for DisableTotp with any type of body
+func NewDisableTotpRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/totp/disable")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewEnrollTotpRequest generates requests for EnrollTotp
+func NewEnrollTotpRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/totp/enroll")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegenerateRecoveryCodesRequest calls the generic RegenerateRecoveryCodes builder with application/json body
+func NewRegenerateRecoveryCodesRequest(server string, body RegenerateRecoveryCodesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegenerateRecoveryCodesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegenerateRecoveryCodesRequestWithBody generates requests for RegenerateRecoveryCodes with any type of body
+func NewRegenerateRecoveryCodesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/totp/recovery-codes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewVerifyEmailRequest generates requests for VerifyEmail
+func NewVerifyEmailRequest(server string, params *VerifyEmailParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/verify-email")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "token", runtime.ParamLocationQuery, params.Token); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// AdminGetAuditEventsWithResponse request
+	AdminGetAuditEventsWithResponse(ctx context.Context, params *AdminGetAuditEventsParams, reqEditors ...RequestEditorFn) (*AdminGetAuditEventsResponse, error)
+
+	// AdminSearchQuizzesWithResponse request
+	AdminSearchQuizzesWithResponse(ctx context.Context, params *AdminSearchQuizzesParams, reqEditors ...RequestEditorFn) (*AdminSearchQuizzesResponse, error)
+
+	// AdminDeleteQuizWithResponse request
+	AdminDeleteQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminDeleteQuizResponse, error)
+
+	// AdminSearchUsersWithResponse request
+	AdminSearchUsersWithResponse(ctx context.Context, params *AdminSearchUsersParams, reqEditors ...RequestEditorFn) (*AdminSearchUsersResponse, error)
+
+	// AdminGetUserWithResponse request
+	AdminGetUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminGetUserResponse, error)
+
+	// AdminDisableUserWithResponse request
+	AdminDisableUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminDisableUserResponse, error)
+
+	// AdminEnableUserWithResponse request
+	AdminEnableUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminEnableUserResponse, error)
+
+	// AdminImpersonateWithResponse request
+	AdminImpersonateWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminImpersonateResponse, error)
+
+	// AdminResendVerificationWithResponse request
+	AdminResendVerificationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminResendVerificationResponse, error)
+
+	// AdminResetTotpWithResponse request
+	AdminResetTotpWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminResetTotpResponse, error)
+
+	// AdminSetRoleWithBodyWithResponse request with any body
+	AdminSetRoleWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AdminSetRoleResponse, error)
+
+	AdminSetRoleWithResponse(ctx context.Context, id Id, body AdminSetRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*AdminSetRoleResponse, error)
+
+	// AdminVerifyEmailWithResponse request
+	AdminVerifyEmailWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminVerifyEmailResponse, error)
+
+	// AuthenticateUserWithBodyWithResponse request with any body
+	AuthenticateUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AuthenticateUserResponse, error)
+
+	AuthenticateUserWithResponse(ctx context.Context, body AuthenticateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*AuthenticateUserResponse, error)
+
+	// AuthenticateTotpWithBodyWithResponse request with any body
+	AuthenticateTotpWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AuthenticateTotpResponse, error)
+
+	AuthenticateTotpWithResponse(ctx context.Context, body AuthenticateTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*AuthenticateTotpResponse, error)
+
+	// GetAuthenticatedWithResponse request
+	GetAuthenticatedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthenticatedResponse, error)
+
+	// CreateUserWithBodyWithResponse request with any body
+	CreateUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateUserResponse, error)
+
+	CreateUserWithResponse(ctx context.Context, body CreateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateUserResponse, error)
+
+	// ConfirmErasureWithBodyWithResponse request with any body
+	ConfirmErasureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfirmErasureResponse, error)
+
+	ConfirmErasureWithResponse(ctx context.Context, body ConfirmErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*ConfirmErasureResponse, error)
+
+	// LivenessWithResponse request
+	LivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LivenessResponse, error)
+
+	// GetIdentitiesWithResponse request
+	GetIdentitiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIdentitiesResponse, error)
+
+	// DeleteIdentityWithResponse request
+	DeleteIdentityWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteIdentityResponse, error)
+
+	// GetLearnListWithResponse request
+	GetLearnListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLearnListResponse, error)
+
+	// AddQuizToLearnListWithResponse request
+	AddQuizToLearnListWithResponse(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*AddQuizToLearnListResponse, error)
+
+	// RemoveQuizFromLearnListWithResponse request
+	RemoveQuizFromLearnListWithResponse(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*RemoveQuizFromLearnListResponse, error)
+
+	// LogoutWithResponse request
+	LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error)
+
+	// CancelErasureWithResponse request
+	CancelErasureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CancelErasureResponse, error)
+
+	// GetErasureWithResponse request
+	GetErasureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetErasureResponse, error)
+
+	// RequestErasureWithBodyWithResponse request with any body
+	RequestErasureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RequestErasureResponse, error)
+
+	RequestErasureWithResponse(ctx context.Context, body RequestErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*RequestErasureResponse, error)
+
+	// ExportDataWithResponse request
+	ExportDataWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ExportDataResponse, error)
+
+	// GetUsageWithResponse request
+	GetUsageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUsageResponse, error)
+
+	// GetOidcProvidersWithResponse request
+	GetOidcProvidersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOidcProvidersResponse, error)
+
+	// AuthorizeOidcWithResponse request
+	AuthorizeOidcWithResponse(ctx context.Context, provider Provider, reqEditors ...RequestEditorFn) (*AuthorizeOidcResponse, error)
+
+	// OidcCallbackWithBodyWithResponse request with any body
+	OidcCallbackWithBodyWithResponse(ctx context.Context, provider Provider, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OidcCallbackResponse, error)
+
+	OidcCallbackWithResponse(ctx context.Context, provider Provider, body OidcCallbackJSONRequestBody, reqEditors ...RequestEditorFn) (*OidcCallbackResponse, error)
+
+	// CreateMultipleChoiceQuestionWithBodyWithResponse request with any body
+	CreateMultipleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateMultipleChoiceQuestionResponse, error)
+
+	CreateMultipleChoiceQuestionWithResponse(ctx context.Context, body CreateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateMultipleChoiceQuestionResponse, error)
+
+	// CreateManualMultipleChoiceQuestionWithBodyWithResponse request with any body
+	CreateManualMultipleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateManualMultipleChoiceQuestionResponse, error)
+
+	CreateManualMultipleChoiceQuestionWithResponse(ctx context.Context, body CreateManualMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateManualMultipleChoiceQuestionResponse, error)
+
+	// GetMultipleChoiceQuestionWithResponse request
+	GetMultipleChoiceQuestionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetMultipleChoiceQuestionResponse, error)
+
+	// UpdateMultipleChoiceQuestionWithBodyWithResponse request with any body
+	UpdateMultipleChoiceQuestionWithBodyWithResponse(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateMultipleChoiceQuestionResponse, error)
+
+	UpdateMultipleChoiceQuestionWithResponse(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, body UpdateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateMultipleChoiceQuestionResponse, error)
+
+	// DeleteMultipleChoiceQuestionWithResponse request
+	DeleteMultipleChoiceQuestionWithResponse(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*DeleteMultipleChoiceQuestionResponse, error)
+
+	// CreateSingleChoiceQuestionWithBodyWithResponse request with any body
+	CreateSingleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSingleChoiceQuestionResponse, error)
+
+	CreateSingleChoiceQuestionWithResponse(ctx context.Context, body CreateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSingleChoiceQuestionResponse, error)
+
+	// CreateManualSingleChoiceQuestionWithBodyWithResponse request with any body
+	CreateManualSingleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateManualSingleChoiceQuestionResponse, error)
+
+	CreateManualSingleChoiceQuestionWithResponse(ctx context.Context, body CreateManualSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateManualSingleChoiceQuestionResponse, error)
+
+	// GetSingleChoiceQuestionWithResponse request
+	GetSingleChoiceQuestionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetSingleChoiceQuestionResponse, error)
+
+	// UpdateSingleChoiceQuestionWithBodyWithResponse request with any body
+	UpdateSingleChoiceQuestionWithBodyWithResponse(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSingleChoiceQuestionResponse, error)
+
+	UpdateSingleChoiceQuestionWithResponse(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, body UpdateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSingleChoiceQuestionResponse, error)
+
+	// DeleteSingleChoiceQuestionWithResponse request
+	DeleteSingleChoiceQuestionWithResponse(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*DeleteSingleChoiceQuestionResponse, error)
+
+	// CreateTrueOrFalseQuestionWithBodyWithResponse request with any body
+	CreateTrueOrFalseQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTrueOrFalseQuestionResponse, error)
+
+	CreateTrueOrFalseQuestionWithResponse(ctx context.Context, body CreateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTrueOrFalseQuestionResponse, error)
+
+	// CreateManualTrueOrFalseQuestionWithBodyWithResponse request with any body
+	CreateManualTrueOrFalseQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateManualTrueOrFalseQuestionResponse, error)
+
+	CreateManualTrueOrFalseQuestionWithResponse(ctx context.Context, body CreateManualTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateManualTrueOrFalseQuestionResponse, error)
+
+	// GetTrueOrFalseQuestionWithResponse request
+	GetTrueOrFalseQuestionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetTrueOrFalseQuestionResponse, error)
+
+	// UpdateTrueOrFalseQuestionWithBodyWithResponse request with any body
+	UpdateTrueOrFalseQuestionWithBodyWithResponse(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTrueOrFalseQuestionResponse, error)
+
+	UpdateTrueOrFalseQuestionWithResponse(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, body UpdateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTrueOrFalseQuestionResponse, error)
+
+	// DeleteTrueOrFalseQuestionWithResponse request
+	DeleteTrueOrFalseQuestionWithResponse(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*DeleteTrueOrFalseQuestionResponse, error)
+
+	// GetQuestionRevisionsWithResponse request
+	GetQuestionRevisionsWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetQuestionRevisionsResponse, error)
+
+	// GetQuestionRevisionDiffWithResponse request
+	GetQuestionRevisionDiffWithResponse(ctx context.Context, id Id, params *GetQuestionRevisionDiffParams, reqEditors ...RequestEditorFn) (*GetQuestionRevisionDiffResponse, error)
+
+	// RestoreQuestionRevisionWithResponse request
+	RestoreQuestionRevisionWithResponse(ctx context.Context, id Id, revision Revision, params *RestoreQuestionRevisionParams, reqEditors ...RequestEditorFn) (*RestoreQuestionRevisionResponse, error)
+
+	// UpdateQuestionStatusWithBodyWithResponse request with any body
+	UpdateQuestionStatusWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateQuestionStatusResponse, error)
+
+	UpdateQuestionStatusWithResponse(ctx context.Context, id Id, body UpdateQuestionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateQuestionStatusResponse, error)
+
+	// GetQuizHistoryWithResponse request
+	GetQuizHistoryWithResponse(ctx context.Context, params *GetQuizHistoryParams, reqEditors ...RequestEditorFn) (*GetQuizHistoryResponse, error)
+
+	// GetQuizSessionsWithResponse request
+	GetQuizSessionsWithResponse(ctx context.Context, params *GetQuizSessionsParams, reqEditors ...RequestEditorFn) (*GetQuizSessionsResponse, error)
+
+	// HasOpenQuizSessionWithResponse request
+	HasOpenQuizSessionWithResponse(ctx context.Context, params *HasOpenQuizSessionParams, reqEditors ...RequestEditorFn) (*HasOpenQuizSessionResponse, error)
+
+	// StartQuizSessionWithBodyWithResponse request with any body
+	StartQuizSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartQuizSessionResponse, error)
+
+	StartQuizSessionWithResponse(ctx context.Context, body StartQuizSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*StartQuizSessionResponse, error)
+
+	// GetQuizSessionWithResponse request
+	GetQuizSessionWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*GetQuizSessionResponse, error)
+
+	// GetAnswersWithResponse request
+	GetAnswersWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*GetAnswersResponse, error)
+
+	// PutAnswerWithBodyWithResponse request with any body
+	PutAnswerWithBodyWithResponse(ctx context.Context, quizSessionId QuizSessionId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAnswerResponse, error)
+
+	PutAnswerWithResponse(ctx context.Context, quizSessionId QuizSessionId, body PutAnswerJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAnswerResponse, error)
+
+	// GetQuizResultWithResponse request
+	GetQuizResultWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*GetQuizResultResponse, error)
+
+	// SubmitQuizSessionWithResponse request
+	SubmitQuizSessionWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*SubmitQuizSessionResponse, error)
+
+	// CreateQuizWithBodyWithResponse request with any body
+	CreateQuizWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateQuizResponse, error)
+
+	CreateQuizWithResponse(ctx context.Context, body CreateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateQuizResponse, error)
+
+	// ImportQuizWithBodyWithResponse request with any body
+	ImportQuizWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportQuizResponse, error)
+
+	ImportQuizWithResponse(ctx context.Context, body ImportQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportQuizResponse, error)
+
+	// GetQuizzesOfUserWithResponse request
+	GetQuizzesOfUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetQuizzesOfUserResponse, error)
+
+	// DeleteQuizWithResponse request
+	DeleteQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteQuizResponse, error)
+
+	// GetQuizWithResponse request
+	GetQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetQuizResponse, error)
+
+	// UpdateQuizWithBodyWithResponse request with any body
+	UpdateQuizWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateQuizResponse, error)
+
+	UpdateQuizWithResponse(ctx context.Context, id Id, body UpdateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateQuizResponse, error)
+
+	// ArchiveQuizWithResponse request
+	ArchiveQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ArchiveQuizResponse, error)
+
+	// PublishQuizWithResponse request
+	PublishQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*PublishQuizResponse, error)
+
+	// ReadinessWithResponse request
+	ReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadinessResponse, error)
+
+	// ResendVerificationWithBodyWithResponse request with any body
+	ResendVerificationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResendVerificationResponse, error)
+
+	ResendVerificationWithResponse(ctx context.Context, body ResendVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*ResendVerificationResponse, error)
+
+	// GetReviewItemsWithBodyWithResponse request with any body
+	GetReviewItemsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetReviewItemsResponse, error)
+
+	GetReviewItemsWithResponse(ctx context.Context, body GetReviewItemsJSONRequestBody, reqEditors ...RequestEditorFn) (*GetReviewItemsResponse, error)
+
+	// GetNextReviewItemQuestionWithResponse request
+	GetNextReviewItemQuestionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetNextReviewItemQuestionResponse, error)
+
+	// GetReviewItemQuestionWithResponse request
+	GetReviewItemQuestionWithResponse(ctx context.Context, reviewItemID ReviewItemId, reqEditors ...RequestEditorFn) (*GetReviewItemQuestionResponse, error)
+
+	// GetReviewItemCountsWithResponse request
+	GetReviewItemCountsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReviewItemCountsResponse, error)
+
+	// GetQuizOptionsWithResponse request
+	GetQuizOptionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetQuizOptionsResponse, error)
+
+	// SubmitReviewItemWithBodyWithResponse request with any body
+	SubmitReviewItemWithBodyWithResponse(ctx context.Context, reviewItemID ReviewItemId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitReviewItemResponse, error)
+
+	SubmitReviewItemWithResponse(ctx context.Context, reviewItemID ReviewItemId, body SubmitReviewItemJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitReviewItemResponse, error)
+
+	// RevokeOtherSessionsWithResponse request
+	RevokeOtherSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RevokeOtherSessionsResponse, error)
+
+	// GetSessionsWithResponse request
+	GetSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionsResponse, error)
+
+	// RevokeSessionWithResponse request
+	RevokeSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RevokeSessionResponse, error)
+
+	// GetApiTokensWithResponse request
+	GetApiTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiTokensResponse, error)
+
+	// CreateApiTokenWithBodyWithResponse request with any body
+	CreateApiTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateApiTokenResponse, error)
+
+	CreateApiTokenWithResponse(ctx context.Context, body CreateApiTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateApiTokenResponse, error)
+
+	// RevokeApiTokenWithResponse request
+	RevokeApiTokenWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RevokeApiTokenResponse, error)
+
+	// GetTotpStatusWithResponse request
+	GetTotpStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTotpStatusResponse, error)
+
+	// ConfirmTotpWithBodyWithResponse request with any body
+	ConfirmTotpWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfirmTotpResponse, error)
+
+	ConfirmTotpWithResponse(ctx context.Context, body ConfirmTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*ConfirmTotpResponse, error)
+
+	// DisableTotpWithBodyWithResponse request with any body
+	DisableTotpWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DisableTotpResponse, error)
+
+	DisableTotpWithResponse(ctx context.Context, body DisableTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*DisableTotpResponse, error)
+
+	// EnrollTotpWithResponse request
+	EnrollTotpWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EnrollTotpResponse, error)
+
+	// RegenerateRecoveryCodesWithBodyWithResponse request with any body
+	RegenerateRecoveryCodesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegenerateRecoveryCodesResponse, error)
+
+	RegenerateRecoveryCodesWithResponse(ctx context.Context, body RegenerateRecoveryCodesJSONRequestBody, reqEditors ...RequestEditorFn) (*RegenerateRecoveryCodesResponse, error)
+
+	// VerifyEmailWithResponse request
+	VerifyEmailWithResponse(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error)
+}
+
+type AdminGetAuditEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuditEventPage
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminGetAuditEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminGetAuditEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminSearchQuizzesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminQuizPage
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminSearchQuizzesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminSearchQuizzesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminDeleteQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminDeleteQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminDeleteQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminSearchUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUserPage
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminSearchUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminSearchUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminGetUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminGetUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminGetUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminDisableUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminDisableUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminDisableUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminEnableUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminEnableUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminEnableUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminImpersonateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Impersonation
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminImpersonateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminImpersonateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminResendVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EmailVerification
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminResendVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminResendVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminResetTotpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminResetTotpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminResetTotpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminSetRoleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminSetRoleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminSetRoleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AdminVerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AdminUser
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminVerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminVerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AuthenticateUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	JSON202      *LoginChallenge
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AuthenticateUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AuthenticateUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AuthenticateTotpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AuthenticateTotpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AuthenticateTotpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthenticatedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthenticatedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthenticatedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConfirmErasureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Erasure
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ConfirmErasureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConfirmErasureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LivenessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Liveness
+}
+
+// Status returns HTTPResponse.Status
+func (r LivenessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LivenessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdentitiesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Identity
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdentitiesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdentitiesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteIdentityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteIdentityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteIdentityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLearnListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LearnList
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLearnListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLearnListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddQuizToLearnListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LearnList
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AddQuizToLearnListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddQuizToLearnListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveQuizFromLearnListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LearnList
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveQuizFromLearnListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveQuizFromLearnListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelErasureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelErasureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelErasureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetErasureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Erasure
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetErasureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetErasureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RequestErasureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Erasure
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RequestErasureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RequestErasureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Usage
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOidcProvidersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OidcProvider
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOidcProvidersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOidcProvidersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AuthorizeOidcResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OidcAuthorization
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AuthorizeOidcResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AuthorizeOidcResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type OidcCallbackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSON202      *LoginChallenge
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r OidcCallbackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OidcCallbackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateMultipleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MultipleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateMultipleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateMultipleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateManualMultipleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MultipleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateManualMultipleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateManualMultipleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMultipleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MultipleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMultipleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMultipleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateMultipleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MultipleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateMultipleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateMultipleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteMultipleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Deleted
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteMultipleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteMultipleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSingleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SingleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSingleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSingleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateManualSingleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SingleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateManualSingleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateManualSingleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSingleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SingleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSingleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSingleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateSingleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SingleChoiceQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateSingleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateSingleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSingleChoiceQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Deleted
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSingleChoiceQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSingleChoiceQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateTrueOrFalseQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrueOrFalseQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateTrueOrFalseQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateTrueOrFalseQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateManualTrueOrFalseQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrueOrFalseQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateManualTrueOrFalseQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateManualTrueOrFalseQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTrueOrFalseQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrueOrFalseQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTrueOrFalseQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTrueOrFalseQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTrueOrFalseQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TrueOrFalseQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTrueOrFalseQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTrueOrFalseQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTrueOrFalseQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Deleted
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTrueOrFalseQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTrueOrFalseQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuestionRevisionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]QuestionRevision
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuestionRevisionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuestionRevisionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuestionRevisionDiffResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuestionRevisionDiff
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuestionRevisionDiffResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuestionRevisionDiffResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestoreQuestionRevisionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Question
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreQuestionRevisionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreQuestionRevisionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateQuestionStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Question
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateQuestionStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateQuestionStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizHistory
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizSessionList
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HasOpenQuizSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r HasOpenQuizSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HasOpenQuizSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartQuizSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizSession
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StartQuizSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartQuizSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizSession
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAnswersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AnswerLists
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAnswersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAnswersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAnswerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Answer
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAnswerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAnswerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizResultResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizResult
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizResultResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizResultResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubmitQuizSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizResult
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SubmitQuizSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubmitQuizSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizInfo
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizInfo
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizzesOfUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizList
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizzesOfUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizzesOfUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Deleted
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Quiz
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizInfo
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ArchiveQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Quiz
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ArchiveQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ArchiveQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PublishQuizResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Quiz
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PublishQuizResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PublishQuizResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Readiness
+	JSON503      *Readiness
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResendVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EmailVerification
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ResendVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResendVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReviewItemsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReviewItemPage
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReviewItemsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReviewItemsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNextReviewItemQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReviewItemQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNextReviewItemQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNextReviewItemQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReviewItemQuestionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReviewItemQuestion
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReviewItemQuestionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReviewItemQuestionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReviewItemCountsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReviewItemCounts
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReviewItemCountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReviewItemCountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuizOptionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuizOptions
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuizOptionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuizOptionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubmitReviewItemResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReviewItem
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SubmitReviewItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubmitReviewItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeOtherSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeOtherSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeOtherSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ActiveSession
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ApiToken
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateApiTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreatedApiToken
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateApiTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateApiTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeApiTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeApiTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeApiTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTotpStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TotpStatus
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTotpStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTotpStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConfirmTotpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RecoveryCodes
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ConfirmTotpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConfirmTotpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DisableTotpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DisableTotpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DisableTotpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EnrollTotpResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TotpEnrollment
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r EnrollTotpResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EnrollTotpResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegenerateRecoveryCodesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RecoveryCodes
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RegenerateRecoveryCodesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegenerateRecoveryCodesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type VerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EmailVerification
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r VerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r VerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// AdminGetAuditEventsWithResponse request returning *AdminGetAuditEventsResponse
+func (c *ClientWithResponses) AdminGetAuditEventsWithResponse(ctx context.Context, params *AdminGetAuditEventsParams, reqEditors ...RequestEditorFn) (*AdminGetAuditEventsResponse, error) {
+	rsp, err := c.AdminGetAuditEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminGetAuditEventsResponse(rsp)
+}
+
+// AdminSearchQuizzesWithResponse request returning *AdminSearchQuizzesResponse
+func (c *ClientWithResponses) AdminSearchQuizzesWithResponse(ctx context.Context, params *AdminSearchQuizzesParams, reqEditors ...RequestEditorFn) (*AdminSearchQuizzesResponse, error) {
+	rsp, err := c.AdminSearchQuizzes(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminSearchQuizzesResponse(rsp)
+}
+
+// AdminDeleteQuizWithResponse request returning *AdminDeleteQuizResponse
+func (c *ClientWithResponses) AdminDeleteQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminDeleteQuizResponse, error) {
+	rsp, err := c.AdminDeleteQuiz(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminDeleteQuizResponse(rsp)
+}
+
+// AdminSearchUsersWithResponse request returning *AdminSearchUsersResponse
+func (c *ClientWithResponses) AdminSearchUsersWithResponse(ctx context.Context, params *AdminSearchUsersParams, reqEditors ...RequestEditorFn) (*AdminSearchUsersResponse, error) {
+	rsp, err := c.AdminSearchUsers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminSearchUsersResponse(rsp)
+}
+
+// AdminGetUserWithResponse request returning *AdminGetUserResponse
+func (c *ClientWithResponses) AdminGetUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminGetUserResponse, error) {
+	rsp, err := c.AdminGetUser(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminGetUserResponse(rsp)
+}
+
+// AdminDisableUserWithResponse request returning *AdminDisableUserResponse
+func (c *ClientWithResponses) AdminDisableUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminDisableUserResponse, error) {
+	rsp, err := c.AdminDisableUser(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminDisableUserResponse(rsp)
+}
+
+// AdminEnableUserWithResponse request returning *AdminEnableUserResponse
+func (c *ClientWithResponses) AdminEnableUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminEnableUserResponse, error) {
+	rsp, err := c.AdminEnableUser(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminEnableUserResponse(rsp)
+}
+
+// AdminImpersonateWithResponse request returning *AdminImpersonateResponse
+func (c *ClientWithResponses) AdminImpersonateWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminImpersonateResponse, error) {
+	rsp, err := c.AdminImpersonate(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminImpersonateResponse(rsp)
+}
+
+// AdminResendVerificationWithResponse request returning *AdminResendVerificationResponse
+func (c *ClientWithResponses) AdminResendVerificationWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminResendVerificationResponse, error) {
+	rsp, err := c.AdminResendVerification(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminResendVerificationResponse(rsp)
+}
+
+// AdminResetTotpWithResponse request returning *AdminResetTotpResponse
+func (c *ClientWithResponses) AdminResetTotpWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminResetTotpResponse, error) {
+	rsp, err := c.AdminResetTotp(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminResetTotpResponse(rsp)
+}
+
+// AdminSetRoleWithBodyWithResponse request with arbitrary body returning *AdminSetRoleResponse
+func (c *ClientWithResponses) AdminSetRoleWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AdminSetRoleResponse, error) {
+	rsp, err := c.AdminSetRoleWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminSetRoleResponse(rsp)
+}
+
+func (c *ClientWithResponses) AdminSetRoleWithResponse(ctx context.Context, id Id, body AdminSetRoleJSONRequestBody, reqEditors ...RequestEditorFn) (*AdminSetRoleResponse, error) {
+	rsp, err := c.AdminSetRole(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminSetRoleResponse(rsp)
+}
+
+// AdminVerifyEmailWithResponse request returning *AdminVerifyEmailResponse
+func (c *ClientWithResponses) AdminVerifyEmailWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*AdminVerifyEmailResponse, error) {
+	rsp, err := c.AdminVerifyEmail(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminVerifyEmailResponse(rsp)
+}
+
+// AuthenticateUserWithBodyWithResponse request with arbitrary body returning *AuthenticateUserResponse
+func (c *ClientWithResponses) AuthenticateUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AuthenticateUserResponse, error) {
+	rsp, err := c.AuthenticateUserWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthenticateUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) AuthenticateUserWithResponse(ctx context.Context, body AuthenticateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*AuthenticateUserResponse, error) {
+	rsp, err := c.AuthenticateUser(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthenticateUserResponse(rsp)
+}
+
+// AuthenticateTotpWithBodyWithResponse request with arbitrary body returning *AuthenticateTotpResponse
+func (c *ClientWithResponses) AuthenticateTotpWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AuthenticateTotpResponse, error) {
+	rsp, err := c.AuthenticateTotpWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthenticateTotpResponse(rsp)
+}
+
+func (c *ClientWithResponses) AuthenticateTotpWithResponse(ctx context.Context, body AuthenticateTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*AuthenticateTotpResponse, error) {
+	rsp, err := c.AuthenticateTotp(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthenticateTotpResponse(rsp)
+}
+
+// GetAuthenticatedWithResponse request returning *GetAuthenticatedResponse
+func (c *ClientWithResponses) GetAuthenticatedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAuthenticatedResponse, error) {
+	rsp, err := c.GetAuthenticated(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthenticatedResponse(rsp)
+}
+
+// CreateUserWithBodyWithResponse request with arbitrary body returning *CreateUserResponse
+func (c *ClientWithResponses) CreateUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateUserResponse, error) {
+	rsp, err := c.CreateUserWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateUserWithResponse(ctx context.Context, body CreateUserJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateUserResponse, error) {
+	rsp, err := c.CreateUser(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateUserResponse(rsp)
+}
+
+// ConfirmErasureWithBodyWithResponse request with arbitrary body returning *ConfirmErasureResponse
+func (c *ClientWithResponses) ConfirmErasureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfirmErasureResponse, error) {
+	rsp, err := c.ConfirmErasureWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmErasureResponse(rsp)
+}
+
+func (c *ClientWithResponses) ConfirmErasureWithResponse(ctx context.Context, body ConfirmErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*ConfirmErasureResponse, error) {
+	rsp, err := c.ConfirmErasure(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmErasureResponse(rsp)
+}
+
+// LivenessWithResponse request returning *LivenessResponse
+func (c *ClientWithResponses) LivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LivenessResponse, error) {
+	rsp, err := c.Liveness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLivenessResponse(rsp)
+}
+
+// GetIdentitiesWithResponse request returning *GetIdentitiesResponse
+func (c *ClientWithResponses) GetIdentitiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIdentitiesResponse, error) {
+	rsp, err := c.GetIdentities(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdentitiesResponse(rsp)
+}
+
+// DeleteIdentityWithResponse request returning *DeleteIdentityResponse
+func (c *ClientWithResponses) DeleteIdentityWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteIdentityResponse, error) {
+	rsp, err := c.DeleteIdentity(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteIdentityResponse(rsp)
+}
+
+// GetLearnListWithResponse request returning *GetLearnListResponse
+func (c *ClientWithResponses) GetLearnListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLearnListResponse, error) {
+	rsp, err := c.GetLearnList(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLearnListResponse(rsp)
+}
+
+// AddQuizToLearnListWithResponse request returning *AddQuizToLearnListResponse
+func (c *ClientWithResponses) AddQuizToLearnListWithResponse(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*AddQuizToLearnListResponse, error) {
+	rsp, err := c.AddQuizToLearnList(ctx, quizID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddQuizToLearnListResponse(rsp)
+}
+
+// RemoveQuizFromLearnListWithResponse request returning *RemoveQuizFromLearnListResponse
+func (c *ClientWithResponses) RemoveQuizFromLearnListWithResponse(ctx context.Context, quizID LearnListQuizId, reqEditors ...RequestEditorFn) (*RemoveQuizFromLearnListResponse, error) {
+	rsp, err := c.RemoveQuizFromLearnList(ctx, quizID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveQuizFromLearnListResponse(rsp)
+}
+
+// LogoutWithResponse request returning *LogoutResponse
+func (c *ClientWithResponses) LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error) {
+	rsp, err := c.Logout(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogoutResponse(rsp)
+}
+
+// CancelErasureWithResponse request returning *CancelErasureResponse
+func (c *ClientWithResponses) CancelErasureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CancelErasureResponse, error) {
+	rsp, err := c.CancelErasure(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelErasureResponse(rsp)
+}
+
+// GetErasureWithResponse request returning *GetErasureResponse
+func (c *ClientWithResponses) GetErasureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetErasureResponse, error) {
+	rsp, err := c.GetErasure(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetErasureResponse(rsp)
+}
+
+// RequestErasureWithBodyWithResponse request with arbitrary body returning *RequestErasureResponse
+func (c *ClientWithResponses) RequestErasureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RequestErasureResponse, error) {
+	rsp, err := c.RequestErasureWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRequestErasureResponse(rsp)
+}
+
+func (c *ClientWithResponses) RequestErasureWithResponse(ctx context.Context, body RequestErasureJSONRequestBody, reqEditors ...RequestEditorFn) (*RequestErasureResponse, error) {
+	rsp, err := c.RequestErasure(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRequestErasureResponse(rsp)
+}
+
+// ExportDataWithResponse request returning *ExportDataResponse
+func (c *ClientWithResponses) ExportDataWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ExportDataResponse, error) {
+	rsp, err := c.ExportData(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportDataResponse(rsp)
+}
+
+// GetUsageWithResponse request returning *GetUsageResponse
+func (c *ClientWithResponses) GetUsageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUsageResponse, error) {
+	rsp, err := c.GetUsage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsageResponse(rsp)
+}
+
+// GetOidcProvidersWithResponse request returning *GetOidcProvidersResponse
+func (c *ClientWithResponses) GetOidcProvidersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOidcProvidersResponse, error) {
+	rsp, err := c.GetOidcProviders(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOidcProvidersResponse(rsp)
+}
+
+// AuthorizeOidcWithResponse request returning *AuthorizeOidcResponse
+func (c *ClientWithResponses) AuthorizeOidcWithResponse(ctx context.Context, provider Provider, reqEditors ...RequestEditorFn) (*AuthorizeOidcResponse, error) {
+	rsp, err := c.AuthorizeOidc(ctx, provider, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthorizeOidcResponse(rsp)
+}
+
+// OidcCallbackWithBodyWithResponse request with arbitrary body returning *OidcCallbackResponse
+func (c *ClientWithResponses) OidcCallbackWithBodyWithResponse(ctx context.Context, provider Provider, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OidcCallbackResponse, error) {
+	rsp, err := c.OidcCallbackWithBody(ctx, provider, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOidcCallbackResponse(rsp)
+}
+
+func (c *ClientWithResponses) OidcCallbackWithResponse(ctx context.Context, provider Provider, body OidcCallbackJSONRequestBody, reqEditors ...RequestEditorFn) (*OidcCallbackResponse, error) {
+	rsp, err := c.OidcCallback(ctx, provider, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOidcCallbackResponse(rsp)
+}
+
+// CreateMultipleChoiceQuestionWithBodyWithResponse request with arbitrary body returning *CreateMultipleChoiceQuestionResponse
+func (c *ClientWithResponses) CreateMultipleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateMultipleChoiceQuestionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateMultipleChoiceQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateMultipleChoiceQuestionWithResponse(ctx context.Context, body CreateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateMultipleChoiceQuestion(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateMultipleChoiceQuestionResponse(rsp)
+}
+
+// CreateManualMultipleChoiceQuestionWithBodyWithResponse request with arbitrary body returning *CreateManualMultipleChoiceQuestionResponse
+func (c *ClientWithResponses) CreateManualMultipleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateManualMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateManualMultipleChoiceQuestionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateManualMultipleChoiceQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateManualMultipleChoiceQuestionWithResponse(ctx context.Context, body CreateManualMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateManualMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateManualMultipleChoiceQuestion(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateManualMultipleChoiceQuestionResponse(rsp)
+}
+
+// GetMultipleChoiceQuestionWithResponse request returning *GetMultipleChoiceQuestionResponse
+func (c *ClientWithResponses) GetMultipleChoiceQuestionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.GetMultipleChoiceQuestion(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMultipleChoiceQuestionResponse(rsp)
+}
+
+// UpdateMultipleChoiceQuestionWithBodyWithResponse request with arbitrary body returning *UpdateMultipleChoiceQuestionResponse
+func (c *ClientWithResponses) UpdateMultipleChoiceQuestionWithBodyWithResponse(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.UpdateMultipleChoiceQuestionWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateMultipleChoiceQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateMultipleChoiceQuestionWithResponse(ctx context.Context, id Id, params *UpdateMultipleChoiceQuestionParams, body UpdateMultipleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.UpdateMultipleChoiceQuestion(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateMultipleChoiceQuestionResponse(rsp)
+}
+
+// DeleteMultipleChoiceQuestionWithResponse request returning *DeleteMultipleChoiceQuestionResponse
+func (c *ClientWithResponses) DeleteMultipleChoiceQuestionWithResponse(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*DeleteMultipleChoiceQuestionResponse, error) {
+	rsp, err := c.DeleteMultipleChoiceQuestion(ctx, quizId, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteMultipleChoiceQuestionResponse(rsp)
+}
+
+// CreateSingleChoiceQuestionWithBodyWithResponse request with arbitrary body returning *CreateSingleChoiceQuestionResponse
+func (c *ClientWithResponses) CreateSingleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSingleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateSingleChoiceQuestionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSingleChoiceQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSingleChoiceQuestionWithResponse(ctx context.Context, body CreateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSingleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateSingleChoiceQuestion(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSingleChoiceQuestionResponse(rsp)
+}
+
+// CreateManualSingleChoiceQuestionWithBodyWithResponse request with arbitrary body returning *CreateManualSingleChoiceQuestionResponse
+func (c *ClientWithResponses) CreateManualSingleChoiceQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateManualSingleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateManualSingleChoiceQuestionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateManualSingleChoiceQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateManualSingleChoiceQuestionWithResponse(ctx context.Context, body CreateManualSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateManualSingleChoiceQuestionResponse, error) {
+	rsp, err := c.CreateManualSingleChoiceQuestion(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateManualSingleChoiceQuestionResponse(rsp)
+}
+
+// GetSingleChoiceQuestionWithResponse request returning *GetSingleChoiceQuestionResponse
+func (c *ClientWithResponses) GetSingleChoiceQuestionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetSingleChoiceQuestionResponse, error) {
+	rsp, err := c.GetSingleChoiceQuestion(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSingleChoiceQuestionResponse(rsp)
+}
+
+// UpdateSingleChoiceQuestionWithBodyWithResponse request with arbitrary body returning *UpdateSingleChoiceQuestionResponse
+func (c *ClientWithResponses) UpdateSingleChoiceQuestionWithBodyWithResponse(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSingleChoiceQuestionResponse, error) {
+	rsp, err := c.UpdateSingleChoiceQuestionWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSingleChoiceQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateSingleChoiceQuestionWithResponse(ctx context.Context, id Id, params *UpdateSingleChoiceQuestionParams, body UpdateSingleChoiceQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSingleChoiceQuestionResponse, error) {
+	rsp, err := c.UpdateSingleChoiceQuestion(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSingleChoiceQuestionResponse(rsp)
+}
+
+// DeleteSingleChoiceQuestionWithResponse request returning *DeleteSingleChoiceQuestionResponse
+func (c *ClientWithResponses) DeleteSingleChoiceQuestionWithResponse(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*DeleteSingleChoiceQuestionResponse, error) {
+	rsp, err := c.DeleteSingleChoiceQuestion(ctx, quizId, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSingleChoiceQuestionResponse(rsp)
+}
+
+// CreateTrueOrFalseQuestionWithBodyWithResponse request with arbitrary body returning *CreateTrueOrFalseQuestionResponse
+func (c *ClientWithResponses) CreateTrueOrFalseQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.CreateTrueOrFalseQuestionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTrueOrFalseQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateTrueOrFalseQuestionWithResponse(ctx context.Context, body CreateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.CreateTrueOrFalseQuestion(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTrueOrFalseQuestionResponse(rsp)
+}
+
+// CreateManualTrueOrFalseQuestionWithBodyWithResponse request with arbitrary body returning *CreateManualTrueOrFalseQuestionResponse
+func (c *ClientWithResponses) CreateManualTrueOrFalseQuestionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateManualTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.CreateManualTrueOrFalseQuestionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateManualTrueOrFalseQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateManualTrueOrFalseQuestionWithResponse(ctx context.Context, body CreateManualTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateManualTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.CreateManualTrueOrFalseQuestion(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateManualTrueOrFalseQuestionResponse(rsp)
+}
+
+// GetTrueOrFalseQuestionWithResponse request returning *GetTrueOrFalseQuestionResponse
+func (c *ClientWithResponses) GetTrueOrFalseQuestionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.GetTrueOrFalseQuestion(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTrueOrFalseQuestionResponse(rsp)
+}
+
+// UpdateTrueOrFalseQuestionWithBodyWithResponse request with arbitrary body returning *UpdateTrueOrFalseQuestionResponse
+func (c *ClientWithResponses) UpdateTrueOrFalseQuestionWithBodyWithResponse(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.UpdateTrueOrFalseQuestionWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTrueOrFalseQuestionResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTrueOrFalseQuestionWithResponse(ctx context.Context, id Id, params *UpdateTrueOrFalseQuestionParams, body UpdateTrueOrFalseQuestionJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.UpdateTrueOrFalseQuestion(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTrueOrFalseQuestionResponse(rsp)
+}
+
+// DeleteTrueOrFalseQuestionWithResponse request returning *DeleteTrueOrFalseQuestionResponse
+func (c *ClientWithResponses) DeleteTrueOrFalseQuestionWithResponse(ctx context.Context, quizId QuizId, id Id, reqEditors ...RequestEditorFn) (*DeleteTrueOrFalseQuestionResponse, error) {
+	rsp, err := c.DeleteTrueOrFalseQuestion(ctx, quizId, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTrueOrFalseQuestionResponse(rsp)
+}
+
+// GetQuestionRevisionsWithResponse request returning *GetQuestionRevisionsResponse
+func (c *ClientWithResponses) GetQuestionRevisionsWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetQuestionRevisionsResponse, error) {
+	rsp, err := c.GetQuestionRevisions(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuestionRevisionsResponse(rsp)
+}
+
+// GetQuestionRevisionDiffWithResponse request returning *GetQuestionRevisionDiffResponse
+func (c *ClientWithResponses) GetQuestionRevisionDiffWithResponse(ctx context.Context, id Id, params *GetQuestionRevisionDiffParams, reqEditors ...RequestEditorFn) (*GetQuestionRevisionDiffResponse, error) {
+	rsp, err := c.GetQuestionRevisionDiff(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuestionRevisionDiffResponse(rsp)
+}
+
+// RestoreQuestionRevisionWithResponse request returning *RestoreQuestionRevisionResponse
+func (c *ClientWithResponses) RestoreQuestionRevisionWithResponse(ctx context.Context, id Id, revision Revision, params *RestoreQuestionRevisionParams, reqEditors ...RequestEditorFn) (*RestoreQuestionRevisionResponse, error) {
+	rsp, err := c.RestoreQuestionRevision(ctx, id, revision, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreQuestionRevisionResponse(rsp)
+}
+
+// UpdateQuestionStatusWithBodyWithResponse request with arbitrary body returning *UpdateQuestionStatusResponse
+func (c *ClientWithResponses) UpdateQuestionStatusWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateQuestionStatusResponse, error) {
+	rsp, err := c.UpdateQuestionStatusWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateQuestionStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateQuestionStatusWithResponse(ctx context.Context, id Id, body UpdateQuestionStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateQuestionStatusResponse, error) {
+	rsp, err := c.UpdateQuestionStatus(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateQuestionStatusResponse(rsp)
+}
+
+// GetQuizHistoryWithResponse request returning *GetQuizHistoryResponse
+func (c *ClientWithResponses) GetQuizHistoryWithResponse(ctx context.Context, params *GetQuizHistoryParams, reqEditors ...RequestEditorFn) (*GetQuizHistoryResponse, error) {
+	rsp, err := c.GetQuizHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizHistoryResponse(rsp)
+}
+
+// GetQuizSessionsWithResponse request returning *GetQuizSessionsResponse
+func (c *ClientWithResponses) GetQuizSessionsWithResponse(ctx context.Context, params *GetQuizSessionsParams, reqEditors ...RequestEditorFn) (*GetQuizSessionsResponse, error) {
+	rsp, err := c.GetQuizSessions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizSessionsResponse(rsp)
+}
+
+// HasOpenQuizSessionWithResponse request returning *HasOpenQuizSessionResponse
+func (c *ClientWithResponses) HasOpenQuizSessionWithResponse(ctx context.Context, params *HasOpenQuizSessionParams, reqEditors ...RequestEditorFn) (*HasOpenQuizSessionResponse, error) {
+	rsp, err := c.HasOpenQuizSession(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHasOpenQuizSessionResponse(rsp)
+}
+
+// StartQuizSessionWithBodyWithResponse request with arbitrary body returning *StartQuizSessionResponse
+func (c *ClientWithResponses) StartQuizSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartQuizSessionResponse, error) {
+	rsp, err := c.StartQuizSessionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartQuizSessionResponse(rsp)
+}
+
+func (c *ClientWithResponses) StartQuizSessionWithResponse(ctx context.Context, body StartQuizSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*StartQuizSessionResponse, error) {
+	rsp, err := c.StartQuizSession(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartQuizSessionResponse(rsp)
+}
+
+// GetQuizSessionWithResponse request returning *GetQuizSessionResponse
+func (c *ClientWithResponses) GetQuizSessionWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*GetQuizSessionResponse, error) {
+	rsp, err := c.GetQuizSession(ctx, quizSessionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizSessionResponse(rsp)
+}
+
+// GetAnswersWithResponse request returning *GetAnswersResponse
+func (c *ClientWithResponses) GetAnswersWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*GetAnswersResponse, error) {
+	rsp, err := c.GetAnswers(ctx, quizSessionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAnswersResponse(rsp)
+}
+
+// PutAnswerWithBodyWithResponse request with arbitrary body returning *PutAnswerResponse
+func (c *ClientWithResponses) PutAnswerWithBodyWithResponse(ctx context.Context, quizSessionId QuizSessionId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAnswerResponse, error) {
+	rsp, err := c.PutAnswerWithBody(ctx, quizSessionId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAnswerResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAnswerWithResponse(ctx context.Context, quizSessionId QuizSessionId, body PutAnswerJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAnswerResponse, error) {
+	rsp, err := c.PutAnswer(ctx, quizSessionId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAnswerResponse(rsp)
+}
+
+// GetQuizResultWithResponse request returning *GetQuizResultResponse
+func (c *ClientWithResponses) GetQuizResultWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*GetQuizResultResponse, error) {
+	rsp, err := c.GetQuizResult(ctx, quizSessionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizResultResponse(rsp)
+}
+
+// SubmitQuizSessionWithResponse request returning *SubmitQuizSessionResponse
+func (c *ClientWithResponses) SubmitQuizSessionWithResponse(ctx context.Context, quizSessionId QuizSessionId, reqEditors ...RequestEditorFn) (*SubmitQuizSessionResponse, error) {
+	rsp, err := c.SubmitQuizSession(ctx, quizSessionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitQuizSessionResponse(rsp)
+}
+
+// CreateQuizWithBodyWithResponse request with arbitrary body returning *CreateQuizResponse
+func (c *ClientWithResponses) CreateQuizWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateQuizResponse, error) {
+	rsp, err := c.CreateQuizWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateQuizResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateQuizWithResponse(ctx context.Context, body CreateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateQuizResponse, error) {
+	rsp, err := c.CreateQuiz(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateQuizResponse(rsp)
+}
+
+// ImportQuizWithBodyWithResponse request with arbitrary body returning *ImportQuizResponse
+func (c *ClientWithResponses) ImportQuizWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportQuizResponse, error) {
+	rsp, err := c.ImportQuizWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportQuizResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportQuizWithResponse(ctx context.Context, body ImportQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportQuizResponse, error) {
+	rsp, err := c.ImportQuiz(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportQuizResponse(rsp)
+}
+
+// GetQuizzesOfUserWithResponse request returning *GetQuizzesOfUserResponse
+func (c *ClientWithResponses) GetQuizzesOfUserWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetQuizzesOfUserResponse, error) {
+	rsp, err := c.GetQuizzesOfUser(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizzesOfUserResponse(rsp)
+}
+
+// DeleteQuizWithResponse request returning *DeleteQuizResponse
+func (c *ClientWithResponses) DeleteQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*DeleteQuizResponse, error) {
+	rsp, err := c.DeleteQuiz(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteQuizResponse(rsp)
+}
+
+// GetQuizWithResponse request returning *GetQuizResponse
+func (c *ClientWithResponses) GetQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetQuizResponse, error) {
+	rsp, err := c.GetQuiz(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizResponse(rsp)
+}
+
+// UpdateQuizWithBodyWithResponse request with arbitrary body returning *UpdateQuizResponse
+func (c *ClientWithResponses) UpdateQuizWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateQuizResponse, error) {
+	rsp, err := c.UpdateQuizWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateQuizResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateQuizWithResponse(ctx context.Context, id Id, body UpdateQuizJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateQuizResponse, error) {
+	rsp, err := c.UpdateQuiz(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateQuizResponse(rsp)
+}
+
+// ArchiveQuizWithResponse request returning *ArchiveQuizResponse
+func (c *ClientWithResponses) ArchiveQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ArchiveQuizResponse, error) {
+	rsp, err := c.ArchiveQuiz(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseArchiveQuizResponse(rsp)
+}
+
+// PublishQuizWithResponse request returning *PublishQuizResponse
+func (c *ClientWithResponses) PublishQuizWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*PublishQuizResponse, error) {
+	rsp, err := c.PublishQuiz(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishQuizResponse(rsp)
+}
+
+// ReadinessWithResponse request returning *ReadinessResponse
+func (c *ClientWithResponses) ReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadinessResponse, error) {
+	rsp, err := c.Readiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadinessResponse(rsp)
+}
+
+// ResendVerificationWithBodyWithResponse request with arbitrary body returning *ResendVerificationResponse
+func (c *ClientWithResponses) ResendVerificationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResendVerificationResponse, error) {
+	rsp, err := c.ResendVerificationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResendVerificationResponse(rsp)
+}
+
+func (c *ClientWithResponses) ResendVerificationWithResponse(ctx context.Context, body ResendVerificationJSONRequestBody, reqEditors ...RequestEditorFn) (*ResendVerificationResponse, error) {
+	rsp, err := c.ResendVerification(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResendVerificationResponse(rsp)
+}
+
+// GetReviewItemsWithBodyWithResponse request with arbitrary body returning *GetReviewItemsResponse
+func (c *ClientWithResponses) GetReviewItemsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetReviewItemsResponse, error) {
+	rsp, err := c.GetReviewItemsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReviewItemsResponse(rsp)
+}
+
+func (c *ClientWithResponses) GetReviewItemsWithResponse(ctx context.Context, body GetReviewItemsJSONRequestBody, reqEditors ...RequestEditorFn) (*GetReviewItemsResponse, error) {
+	rsp, err := c.GetReviewItems(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReviewItemsResponse(rsp)
+}
+
+// GetNextReviewItemQuestionWithResponse request returning *GetNextReviewItemQuestionResponse
+func (c *ClientWithResponses) GetNextReviewItemQuestionWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetNextReviewItemQuestionResponse, error) {
+	rsp, err := c.GetNextReviewItemQuestion(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNextReviewItemQuestionResponse(rsp)
+}
+
+// GetReviewItemQuestionWithResponse request returning *GetReviewItemQuestionResponse
+func (c *ClientWithResponses) GetReviewItemQuestionWithResponse(ctx context.Context, reviewItemID ReviewItemId, reqEditors ...RequestEditorFn) (*GetReviewItemQuestionResponse, error) {
+	rsp, err := c.GetReviewItemQuestion(ctx, reviewItemID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReviewItemQuestionResponse(rsp)
+}
+
+// GetReviewItemCountsWithResponse request returning *GetReviewItemCountsResponse
+func (c *ClientWithResponses) GetReviewItemCountsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReviewItemCountsResponse, error) {
+	rsp, err := c.GetReviewItemCounts(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReviewItemCountsResponse(rsp)
+}
+
+// GetQuizOptionsWithResponse request returning *GetQuizOptionsResponse
+func (c *ClientWithResponses) GetQuizOptionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetQuizOptionsResponse, error) {
+	rsp, err := c.GetQuizOptions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuizOptionsResponse(rsp)
+}
+
+// SubmitReviewItemWithBodyWithResponse request with arbitrary body returning *SubmitReviewItemResponse
+func (c *ClientWithResponses) SubmitReviewItemWithBodyWithResponse(ctx context.Context, reviewItemID ReviewItemId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitReviewItemResponse, error) {
+	rsp, err := c.SubmitReviewItemWithBody(ctx, reviewItemID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitReviewItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubmitReviewItemWithResponse(ctx context.Context, reviewItemID ReviewItemId, body SubmitReviewItemJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitReviewItemResponse, error) {
+	rsp, err := c.SubmitReviewItem(ctx, reviewItemID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitReviewItemResponse(rsp)
+}
+
+// RevokeOtherSessionsWithResponse request returning *RevokeOtherSessionsResponse
+func (c *ClientWithResponses) RevokeOtherSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RevokeOtherSessionsResponse, error) {
+	rsp, err := c.RevokeOtherSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeOtherSessionsResponse(rsp)
+}
+
+// GetSessionsWithResponse request returning *GetSessionsResponse
+func (c *ClientWithResponses) GetSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionsResponse, error) {
+	rsp, err := c.GetSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSessionsResponse(rsp)
+}
+
+// RevokeSessionWithResponse request returning *RevokeSessionResponse
+func (c *ClientWithResponses) RevokeSessionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RevokeSessionResponse, error) {
+	rsp, err := c.RevokeSession(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeSessionResponse(rsp)
+}
+
+// GetApiTokensWithResponse request returning *GetApiTokensResponse
+func (c *ClientWithResponses) GetApiTokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiTokensResponse, error) {
+	rsp, err := c.GetApiTokens(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiTokensResponse(rsp)
+}
+
+// CreateApiTokenWithBodyWithResponse request with arbitrary body returning *CreateApiTokenResponse
+func (c *ClientWithResponses) CreateApiTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateApiTokenResponse, error) {
+	rsp, err := c.CreateApiTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateApiTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateApiTokenWithResponse(ctx context.Context, body CreateApiTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateApiTokenResponse, error) {
+	rsp, err := c.CreateApiToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateApiTokenResponse(rsp)
+}
+
+// RevokeApiTokenWithResponse request returning *RevokeApiTokenResponse
+func (c *ClientWithResponses) RevokeApiTokenWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RevokeApiTokenResponse, error) {
+	rsp, err := c.RevokeApiToken(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeApiTokenResponse(rsp)
+}
+
+// GetTotpStatusWithResponse request returning *GetTotpStatusResponse
+func (c *ClientWithResponses) GetTotpStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetTotpStatusResponse, error) {
+	rsp, err := c.GetTotpStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTotpStatusResponse(rsp)
+}
+
+// ConfirmTotpWithBodyWithResponse request with arbitrary body returning *ConfirmTotpResponse
+func (c *ClientWithResponses) ConfirmTotpWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfirmTotpResponse, error) {
+	rsp, err := c.ConfirmTotpWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmTotpResponse(rsp)
+}
+
+func (c *ClientWithResponses) ConfirmTotpWithResponse(ctx context.Context, body ConfirmTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*ConfirmTotpResponse, error) {
+	rsp, err := c.ConfirmTotp(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfirmTotpResponse(rsp)
+}
+
+// DisableTotpWithBodyWithResponse request with arbitrary body returning *DisableTotpResponse
+func (c *ClientWithResponses) DisableTotpWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DisableTotpResponse, error) {
+	rsp, err := c.DisableTotpWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDisableTotpResponse(rsp)
+}
+
+func (c *ClientWithResponses) DisableTotpWithResponse(ctx context.Context, body DisableTotpJSONRequestBody, reqEditors ...RequestEditorFn) (*DisableTotpResponse, error) {
+	rsp, err := c.DisableTotp(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDisableTotpResponse(rsp)
+}
+
+// EnrollTotpWithResponse request returning *EnrollTotpResponse
+func (c *ClientWithResponses) EnrollTotpWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EnrollTotpResponse, error) {
+	rsp, err := c.EnrollTotp(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEnrollTotpResponse(rsp)
+}
+
+// RegenerateRecoveryCodesWithBodyWithResponse request with arbitrary body returning *RegenerateRecoveryCodesResponse
+func (c *ClientWithResponses) RegenerateRecoveryCodesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegenerateRecoveryCodesResponse, error) {
+	rsp, err := c.RegenerateRecoveryCodesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegenerateRecoveryCodesResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegenerateRecoveryCodesWithResponse(ctx context.Context, body RegenerateRecoveryCodesJSONRequestBody, reqEditors ...RequestEditorFn) (*RegenerateRecoveryCodesResponse, error) {
+	rsp, err := c.RegenerateRecoveryCodes(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegenerateRecoveryCodesResponse(rsp)
+}
+
+// VerifyEmailWithResponse request returning *VerifyEmailResponse
+func (c *ClientWithResponses) VerifyEmailWithResponse(ctx context.Context, params *VerifyEmailParams, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error) {
+	rsp, err := c.VerifyEmail(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyEmailResponse(rsp)
+}
+
+// ParseAdminGetAuditEventsResponse parses an HTTP response from a AdminGetAuditEventsWithResponse call
+func ParseAdminGetAuditEventsResponse(rsp *http.Response) (*AdminGetAuditEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminGetAuditEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditEventPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminSearchQuizzesResponse parses an HTTP response from a AdminSearchQuizzesWithResponse call
+func ParseAdminSearchQuizzesResponse(rsp *http.Response) (*AdminSearchQuizzesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminSearchQuizzesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminQuizPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminDeleteQuizResponse parses an HTTP response from a AdminDeleteQuizWithResponse call
+func ParseAdminDeleteQuizResponse(rsp *http.Response) (*AdminDeleteQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminDeleteQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminSearchUsersResponse parses an HTTP response from a AdminSearchUsersWithResponse call
+func ParseAdminSearchUsersResponse(rsp *http.Response) (*AdminSearchUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminSearchUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUserPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminGetUserResponse parses an HTTP response from a AdminGetUserWithResponse call
+func ParseAdminGetUserResponse(rsp *http.Response) (*AdminGetUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminGetUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminDisableUserResponse parses an HTTP response from a AdminDisableUserWithResponse call
+func ParseAdminDisableUserResponse(rsp *http.Response) (*AdminDisableUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminDisableUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminEnableUserResponse parses an HTTP response from a AdminEnableUserWithResponse call
+func ParseAdminEnableUserResponse(rsp *http.Response) (*AdminEnableUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminEnableUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminImpersonateResponse parses an HTTP response from a AdminImpersonateWithResponse call
+func ParseAdminImpersonateResponse(rsp *http.Response) (*AdminImpersonateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminImpersonateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Impersonation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminResendVerificationResponse parses an HTTP response from a AdminResendVerificationWithResponse call
+func ParseAdminResendVerificationResponse(rsp *http.Response) (*AdminResendVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminResendVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EmailVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminResetTotpResponse parses an HTTP response from a AdminResetTotpWithResponse call
+func ParseAdminResetTotpResponse(rsp *http.Response) (*AdminResetTotpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminResetTotpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminSetRoleResponse parses an HTTP response from a AdminSetRoleWithResponse call
+func ParseAdminSetRoleResponse(rsp *http.Response) (*AdminSetRoleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminSetRoleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAdminVerifyEmailResponse parses an HTTP response from a AdminVerifyEmailWithResponse call
+func ParseAdminVerifyEmailResponse(rsp *http.Response) (*AdminVerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminVerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AdminUser
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAuthenticateUserResponse parses an HTTP response from a AuthenticateUserWithResponse call
+func ParseAuthenticateUserResponse(rsp *http.Response) (*AuthenticateUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AuthenticateUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest LoginChallenge
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAuthenticateTotpResponse parses an HTTP response from a AuthenticateTotpWithResponse call
+func ParseAuthenticateTotpResponse(rsp *http.Response) (*AuthenticateTotpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AuthenticateTotpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthenticatedResponse parses an HTTP response from a GetAuthenticatedWithResponse call
+func ParseGetAuthenticatedResponse(rsp *http.Response) (*GetAuthenticatedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthenticatedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateUserResponse parses an HTTP response from a CreateUserWithResponse call
+func ParseCreateUserResponse(rsp *http.Response) (*CreateUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseConfirmErasureResponse parses an HTTP response from a ConfirmErasureWithResponse call
+func ParseConfirmErasureResponse(rsp *http.Response) (*ConfirmErasureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ConfirmErasureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Erasure
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLivenessResponse parses an HTTP response from a LivenessWithResponse call
+func ParseLivenessResponse(rsp *http.Response) (*LivenessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LivenessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Liveness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetIdentitiesResponse parses an HTTP response from a GetIdentitiesWithResponse call
+func ParseGetIdentitiesResponse(rsp *http.Response) (*GetIdentitiesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetIdentitiesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Identity
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteIdentityResponse parses an HTTP response from a DeleteIdentityWithResponse call
+func ParseDeleteIdentityResponse(rsp *http.Response) (*DeleteIdentityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteIdentityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLearnListResponse parses an HTTP response from a GetLearnListWithResponse call
+func ParseGetLearnListResponse(rsp *http.Response) (*GetLearnListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLearnListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LearnList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddQuizToLearnListResponse parses an HTTP response from a AddQuizToLearnListWithResponse call
+func ParseAddQuizToLearnListResponse(rsp *http.Response) (*AddQuizToLearnListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddQuizToLearnListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LearnList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveQuizFromLearnListResponse parses an HTTP response from a RemoveQuizFromLearnListWithResponse call
+func ParseRemoveQuizFromLearnListResponse(rsp *http.Response) (*RemoveQuizFromLearnListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveQuizFromLearnListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LearnList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLogoutResponse parses an HTTP response from a LogoutWithResponse call
+func ParseLogoutResponse(rsp *http.Response) (*LogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelErasureResponse parses an HTTP response from a CancelErasureWithResponse call
+func ParseCancelErasureResponse(rsp *http.Response) (*CancelErasureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelErasureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetErasureResponse parses an HTTP response from a GetErasureWithResponse call
+func ParseGetErasureResponse(rsp *http.Response) (*GetErasureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetErasureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Erasure
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRequestErasureResponse parses an HTTP response from a RequestErasureWithResponse call
+func ParseRequestErasureResponse(rsp *http.Response) (*RequestErasureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RequestErasureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Erasure
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportDataResponse parses an HTTP response from a ExportDataWithResponse call
+func ParseExportDataResponse(rsp *http.Response) (*ExportDataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportDataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsageResponse parses an HTTP response from a GetUsageWithResponse call
+func ParseGetUsageResponse(rsp *http.Response) (*GetUsageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Usage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOidcProvidersResponse parses an HTTP response from a GetOidcProvidersWithResponse call
+func ParseGetOidcProvidersResponse(rsp *http.Response) (*GetOidcProvidersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOidcProvidersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OidcProvider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAuthorizeOidcResponse parses an HTTP response from a AuthorizeOidcWithResponse call
+func ParseAuthorizeOidcResponse(rsp *http.Response) (*AuthorizeOidcResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AuthorizeOidcResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OidcAuthorization
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseOidcCallbackResponse parses an HTTP response from a OidcCallbackWithResponse call
+func ParseOidcCallbackResponse(rsp *http.Response) (*OidcCallbackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OidcCallbackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest LoginChallenge
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateMultipleChoiceQuestionResponse parses an HTTP response from a CreateMultipleChoiceQuestionWithResponse call
+func ParseCreateMultipleChoiceQuestionResponse(rsp *http.Response) (*CreateMultipleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateMultipleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MultipleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateManualMultipleChoiceQuestionResponse parses an HTTP response from a CreateManualMultipleChoiceQuestionWithResponse call
+func ParseCreateManualMultipleChoiceQuestionResponse(rsp *http.Response) (*CreateManualMultipleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateManualMultipleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MultipleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMultipleChoiceQuestionResponse parses an HTTP response from a GetMultipleChoiceQuestionWithResponse call
+func ParseGetMultipleChoiceQuestionResponse(rsp *http.Response) (*GetMultipleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMultipleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MultipleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateMultipleChoiceQuestionResponse parses an HTTP response from a UpdateMultipleChoiceQuestionWithResponse call
+func ParseUpdateMultipleChoiceQuestionResponse(rsp *http.Response) (*UpdateMultipleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateMultipleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MultipleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteMultipleChoiceQuestionResponse parses an HTTP response from a DeleteMultipleChoiceQuestionWithResponse call
+func ParseDeleteMultipleChoiceQuestionResponse(rsp *http.Response) (*DeleteMultipleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteMultipleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Deleted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSingleChoiceQuestionResponse parses an HTTP response from a CreateSingleChoiceQuestionWithResponse call
+func ParseCreateSingleChoiceQuestionResponse(rsp *http.Response) (*CreateSingleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSingleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SingleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateManualSingleChoiceQuestionResponse parses an HTTP response from a CreateManualSingleChoiceQuestionWithResponse call
+func ParseCreateManualSingleChoiceQuestionResponse(rsp *http.Response) (*CreateManualSingleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateManualSingleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SingleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSingleChoiceQuestionResponse parses an HTTP response from a GetSingleChoiceQuestionWithResponse call
+func ParseGetSingleChoiceQuestionResponse(rsp *http.Response) (*GetSingleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSingleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SingleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateSingleChoiceQuestionResponse parses an HTTP response from a UpdateSingleChoiceQuestionWithResponse call
+func ParseUpdateSingleChoiceQuestionResponse(rsp *http.Response) (*UpdateSingleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateSingleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SingleChoiceQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSingleChoiceQuestionResponse parses an HTTP response from a DeleteSingleChoiceQuestionWithResponse call
+func ParseDeleteSingleChoiceQuestionResponse(rsp *http.Response) (*DeleteSingleChoiceQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSingleChoiceQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Deleted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateTrueOrFalseQuestionResponse parses an HTTP response from a CreateTrueOrFalseQuestionWithResponse call
+func ParseCreateTrueOrFalseQuestionResponse(rsp *http.Response) (*CreateTrueOrFalseQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateTrueOrFalseQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrueOrFalseQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateManualTrueOrFalseQuestionResponse parses an HTTP response from a CreateManualTrueOrFalseQuestionWithResponse call
+func ParseCreateManualTrueOrFalseQuestionResponse(rsp *http.Response) (*CreateManualTrueOrFalseQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateManualTrueOrFalseQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrueOrFalseQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTrueOrFalseQuestionResponse parses an HTTP response from a GetTrueOrFalseQuestionWithResponse call
+func ParseGetTrueOrFalseQuestionResponse(rsp *http.Response) (*GetTrueOrFalseQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTrueOrFalseQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrueOrFalseQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateTrueOrFalseQuestionResponse parses an HTTP response from a UpdateTrueOrFalseQuestionWithResponse call
+func ParseUpdateTrueOrFalseQuestionResponse(rsp *http.Response) (*UpdateTrueOrFalseQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTrueOrFalseQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TrueOrFalseQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTrueOrFalseQuestionResponse parses an HTTP response from a DeleteTrueOrFalseQuestionWithResponse call
+func ParseDeleteTrueOrFalseQuestionResponse(rsp *http.Response) (*DeleteTrueOrFalseQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTrueOrFalseQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Deleted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuestionRevisionsResponse parses an HTTP response from a GetQuestionRevisionsWithResponse call
+func ParseGetQuestionRevisionsResponse(rsp *http.Response) (*GetQuestionRevisionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuestionRevisionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []QuestionRevision
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuestionRevisionDiffResponse parses an HTTP response from a GetQuestionRevisionDiffWithResponse call
+func ParseGetQuestionRevisionDiffResponse(rsp *http.Response) (*GetQuestionRevisionDiffResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuestionRevisionDiffResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuestionRevisionDiff
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRestoreQuestionRevisionResponse parses an HTTP response from a RestoreQuestionRevisionWithResponse call
+func ParseRestoreQuestionRevisionResponse(rsp *http.Response) (*RestoreQuestionRevisionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreQuestionRevisionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Question
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateQuestionStatusResponse parses an HTTP response from a UpdateQuestionStatusWithResponse call
+func ParseUpdateQuestionStatusResponse(rsp *http.Response) (*UpdateQuestionStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateQuestionStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Question
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizHistoryResponse parses an HTTP response from a GetQuizHistoryWithResponse call
+func ParseGetQuizHistoryResponse(rsp *http.Response) (*GetQuizHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizSessionsResponse parses an HTTP response from a GetQuizSessionsWithResponse call
+func ParseGetQuizSessionsResponse(rsp *http.Response) (*GetQuizSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizSessionList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHasOpenQuizSessionResponse parses an HTTP response from a HasOpenQuizSessionWithResponse call
+func ParseHasOpenQuizSessionResponse(rsp *http.Response) (*HasOpenQuizSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HasOpenQuizSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartQuizSessionResponse parses an HTTP response from a StartQuizSessionWithResponse call
+func ParseStartQuizSessionResponse(rsp *http.Response) (*StartQuizSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartQuizSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizSession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizSessionResponse parses an HTTP response from a GetQuizSessionWithResponse call
+func ParseGetQuizSessionResponse(rsp *http.Response) (*GetQuizSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizSession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAnswersResponse parses an HTTP response from a GetAnswersWithResponse call
+func ParseGetAnswersResponse(rsp *http.Response) (*GetAnswersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAnswersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AnswerLists
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAnswerResponse parses an HTTP response from a PutAnswerWithResponse call
+func ParsePutAnswerResponse(rsp *http.Response) (*PutAnswerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAnswerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Answer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizResultResponse parses an HTTP response from a GetQuizResultWithResponse call
+func ParseGetQuizResultResponse(rsp *http.Response) (*GetQuizResultResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizResultResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubmitQuizSessionResponse parses an HTTP response from a SubmitQuizSessionWithResponse call
+func ParseSubmitQuizSessionResponse(rsp *http.Response) (*SubmitQuizSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubmitQuizSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateQuizResponse parses an HTTP response from a CreateQuizWithResponse call
+func ParseCreateQuizResponse(rsp *http.Response) (*CreateQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportQuizResponse parses an HTTP response from a ImportQuizWithResponse call
+func ParseImportQuizResponse(rsp *http.Response) (*ImportQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizzesOfUserResponse parses an HTTP response from a GetQuizzesOfUserWithResponse call
+func ParseGetQuizzesOfUserResponse(rsp *http.Response) (*GetQuizzesOfUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizzesOfUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteQuizResponse parses an HTTP response from a DeleteQuizWithResponse call
+func ParseDeleteQuizResponse(rsp *http.Response) (*DeleteQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Deleted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizResponse parses an HTTP response from a GetQuizWithResponse call
+func ParseGetQuizResponse(rsp *http.Response) (*GetQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Quiz
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateQuizResponse parses an HTTP response from a UpdateQuizWithResponse call
+func ParseUpdateQuizResponse(rsp *http.Response) (*UpdateQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseArchiveQuizResponse parses an HTTP response from a ArchiveQuizWithResponse call
+func ParseArchiveQuizResponse(rsp *http.Response) (*ArchiveQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ArchiveQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Quiz
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePublishQuizResponse parses an HTTP response from a PublishQuizWithResponse call
+func ParsePublishQuizResponse(rsp *http.Response) (*PublishQuizResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PublishQuizResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Quiz
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReadinessResponse parses an HTTP response from a ReadinessWithResponse call
+func ParseReadinessResponse(rsp *http.Response) (*ReadinessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadinessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Readiness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Readiness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResendVerificationResponse parses an HTTP response from a ResendVerificationWithResponse call
+func ParseResendVerificationResponse(rsp *http.Response) (*ResendVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResendVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EmailVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReviewItemsResponse parses an HTTP response from a GetReviewItemsWithResponse call
+func ParseGetReviewItemsResponse(rsp *http.Response) (*GetReviewItemsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReviewItemsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReviewItemPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNextReviewItemQuestionResponse parses an HTTP response from a GetNextReviewItemQuestionWithResponse call
+func ParseGetNextReviewItemQuestionResponse(rsp *http.Response) (*GetNextReviewItemQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNextReviewItemQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReviewItemQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReviewItemQuestionResponse parses an HTTP response from a GetReviewItemQuestionWithResponse call
+func ParseGetReviewItemQuestionResponse(rsp *http.Response) (*GetReviewItemQuestionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReviewItemQuestionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReviewItemQuestion
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReviewItemCountsResponse parses an HTTP response from a GetReviewItemCountsWithResponse call
+func ParseGetReviewItemCountsResponse(rsp *http.Response) (*GetReviewItemCountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReviewItemCountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReviewItemCounts
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuizOptionsResponse parses an HTTP response from a GetQuizOptionsWithResponse call
+func ParseGetQuizOptionsResponse(rsp *http.Response) (*GetQuizOptionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuizOptionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuizOptions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubmitReviewItemResponse parses an HTTP response from a SubmitReviewItemWithResponse call
+func ParseSubmitReviewItemResponse(rsp *http.Response) (*SubmitReviewItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubmitReviewItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReviewItem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeOtherSessionsResponse parses an HTTP response from a RevokeOtherSessionsWithResponse call
+func ParseRevokeOtherSessionsResponse(rsp *http.Response) (*RevokeOtherSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeOtherSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSessionsResponse parses an HTTP response from a GetSessionsWithResponse call
+func ParseGetSessionsResponse(rsp *http.Response) (*GetSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ActiveSession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeSessionResponse parses an HTTP response from a RevokeSessionWithResponse call
+func ParseRevokeSessionResponse(rsp *http.Response) (*RevokeSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiTokensResponse parses an HTTP response from a GetApiTokensWithResponse call
+func ParseGetApiTokensResponse(rsp *http.Response) (*GetApiTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ApiToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateApiTokenResponse parses an HTTP response from a CreateApiTokenWithResponse call
+func ParseCreateApiTokenResponse(rsp *http.Response) (*CreateApiTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateApiTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreatedApiToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeApiTokenResponse parses an HTTP response from a RevokeApiTokenWithResponse call
+func ParseRevokeApiTokenResponse(rsp *http.Response) (*RevokeApiTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeApiTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTotpStatusResponse parses an HTTP response from a GetTotpStatusWithResponse call
+func ParseGetTotpStatusResponse(rsp *http.Response) (*GetTotpStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTotpStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TotpStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseConfirmTotpResponse parses an HTTP response from a ConfirmTotpWithResponse call
+func ParseConfirmTotpResponse(rsp *http.Response) (*ConfirmTotpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ConfirmTotpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RecoveryCodes
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDisableTotpResponse parses an HTTP response from a DisableTotpWithResponse call
+func ParseDisableTotpResponse(rsp *http.Response) (*DisableTotpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DisableTotpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEnrollTotpResponse parses an HTTP response from a EnrollTotpWithResponse call
+func ParseEnrollTotpResponse(rsp *http.Response) (*EnrollTotpResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EnrollTotpResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TotpEnrollment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegenerateRecoveryCodesResponse parses an HTTP response from a RegenerateRecoveryCodesWithResponse call
+func ParseRegenerateRecoveryCodesResponse(rsp *http.Response) (*RegenerateRecoveryCodesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegenerateRecoveryCodesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RecoveryCodes
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseVerifyEmailResponse parses an HTTP response from a VerifyEmailWithResponse call
+func ParseVerifyEmailResponse(rsp *http.Response) (*VerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EmailVerification
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
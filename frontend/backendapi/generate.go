@@ -0,0 +1,5 @@
+// Package backendapi is the client of the backend, generated from the OpenAPI
+// specification in backend/api/openapi.yaml. Run go generate after changing it.
+package backendapi
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml ../../backend/api/openapi.yaml
@@ -1,33 +1,102 @@
 package main
 
 import (
+	stdcontext "context"
+	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
 	"spaced-ace/api"
-	"spaced-ace/constants"
+	"spaced-ace/auth"
+	"spaced-ace/config"
 	"spaced-ace/context"
+	"spaced-ace/health"
+	"spaced-ace/logging"
+	"spaced-ace/metrics"
 	"spaced-ace/render"
+	"spaced-ace/service"
+	"spaced-ace/tracing"
 	"spaced-ace/views/pages"
+	"syscall"
 )
 
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if len(os.Args) == 2 && os.Args[1] == "healthcheck" {
+		if err := health.Probe(cfg.Server.Port); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	logging.Init(cfg.Logging)
+	log.Printf("Configuration:\n%s", cfg)
+	shutdownTracing, err := tracing.Init(stdcontext.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	service.Init(cfg.Backend)
+	api.Init(cfg.ReviewItems)
+
 	e := echo.New()
+	e.IPExtractor = auth.IPExtractor(cfg.Server.TrustedProxies)
+	e.Use(tracing.Middleware)
+	e.Use(metrics.Middleware)
 
 	// Static files
 	e.Static("/static", "static")
 
-	e.Use(middleware.Logger())
+	// The probes run every few seconds, logging them would drown the requests
+	e.Use(logging.Middleware(func(c echo.Context) bool {
+		return c.Path() == "/healthz" || c.Path() == "/readyz"
+	}))
 	e.Use(context.SessionMiddleware)
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5, // Compression level: 1 (fastest, least compression) to 9 (slowest, max compression)
 	}))
 
+	e.GET("/healthz", health.LivenessEndpoint)
+	e.GET("/readyz", health.ReadinessEndpoint)
 	e.GET("/", func(c echo.Context) error {
 		return render.TemplRender(c, http.StatusOK, pages.IndexPage())
 	})
 
 	api.RegisterRoutes(e)
 
-	e.Logger.Fatal(e.Start("0.0.0.0" + ":" + constants.PORT))
+	ctx, stop := signal.NotifyContext(stdcontext.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		if err := e.Start(fmt.Sprintf("0.0.0.0:%d", cfg.Server.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalln(err)
+		}
+	}()
+	metricsServer := metrics.NewServer(cfg.Metrics.Port)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalln(err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	slog.Info("shutting down, waiting for requests to finish", "timeout", cfg.Server.ShutdownTimeout)
+	health.Drain()
+	shutdownCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down the server", "error", err)
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down the metrics server", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("failed to flush the spans", "error", err)
+	}
 }
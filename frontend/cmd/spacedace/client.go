@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"spaced-ace/backendapi"
+	"time"
+)
+
+// What login stores in the user's config directory. SPACEDACE_URL and
+// SPACEDACE_TOKEN take precedence, e.g. for scripts running in CI.
+type credentials struct {
+	Url   string `json:"url"`
+	Token string `json:"token"`
+}
+
+const defaultUrl = "http://localhost:9000"
+
+func credentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "spacedace", "credentials.json"), nil
+}
+
+func loadCredentials() (credentials, error) {
+	stored := credentials{Url: defaultUrl}
+	path, err := credentialsPath()
+	if err != nil {
+		return stored, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return stored, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(content, &stored); err != nil {
+			return stored, fmt.Errorf("reading %s: %w", path, err)
+		}
+	}
+	if url := os.Getenv("SPACEDACE_URL"); url != "" {
+		stored.Url = url
+	}
+	if token := os.Getenv("SPACEDACE_TOKEN"); token != "" {
+		stored.Token = token
+	}
+	return stored, nil
+}
+
+// Only the owner can read the file, the token grants access to the account
+func saveCredentials(stored credentials) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+// Returned when the backend answers with an error status
+type apiError struct {
+	StatusCode int
+	Message    string
+	RequestId  string
+}
+
+func (e *apiError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	if e.RequestId == "" {
+		return message
+	}
+	return fmt.Sprintf("%s (reference %s)", message, e.RequestId)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Sends the requests of the generated client with the token and turns the
+// error statuses into an *apiError
+type tokenDoer struct {
+	token  string
+	client *http.Client
+}
+
+func (d *tokenDoer) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", "Bearer "+d.token)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result backendapi.Error
+	_ = json.Unmarshal(body, &result)
+	apiErr := &apiError{StatusCode: resp.StatusCode, Message: result.Message}
+	if result.RequestId != nil {
+		apiErr.RequestId = *result.RequestId
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		apiErr.Message = "the token was not accepted, run spacedace login with a new one"
+	}
+	return nil, apiErr
+}
+
+func newClient(stored credentials) (*backendapi.ClientWithResponses, error) {
+	if stored.Token == "" {
+		return nil, errors.New("not logged in, run spacedace login first")
+	}
+	doer := &tokenDoer{token: stored.Token, client: &http.Client{Timeout: 2 * time.Minute}}
+	return backendapi.NewClientWithResponses(stored.Url, backendapi.WithHTTPClient(doer))
+}
+
+// Returns the client of the stored credentials
+func connect() (*backendapi.ClientWithResponses, error) {
+	stored, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return newClient(stored)
+}
+
+// Returns the body of a successful response, which is only missing when the
+// backend answered with something its specification does not describe
+func expect[T any](body *T, status string) (*T, error) {
+	if body == nil {
+		return nil, fmt.Errorf("unexpected response from the backend: %s", status)
+	}
+	return body, nil
+}
+
+// Returns the signed in user of the token
+func currentUser(ctx context.Context, client *backendapi.ClientWithResponses) (*backendapi.User, error) {
+	resp, err := client.GetAuthenticatedWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return &session.User, nil
+}
+
+func printJson(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
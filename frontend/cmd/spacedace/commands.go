@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"spaced-ace/backendapi"
+	"strings"
+	"text/tabwriter"
+)
+
+func runLogin(args []string) error {
+	flags, asJson := newFlagSet("login", "")
+	url := flags.String("url", "", "url of the backend, "+defaultUrl+" when never set")
+	token := flags.String("token", "", "personal api token, read from the terminal when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	stored, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	if *url != "" {
+		stored.Url = strings.TrimSuffix(*url, "/")
+	}
+	stored.Token = *token
+	if stored.Token == "" {
+		fmt.Fprint(os.Stderr, "Personal api token: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		stored.Token = strings.TrimSpace(line)
+	}
+
+	client, err := newClient(stored)
+	if err != nil {
+		return err
+	}
+	user, err := currentUser(context.Background(), client)
+	if err != nil {
+		return err
+	}
+	if err := saveCredentials(stored); err != nil {
+		return err
+	}
+
+	if *asJson {
+		return printJson(user)
+	}
+	fmt.Printf("Logged in to %s as %s (%s)\n", stored.Url, user.Name, user.Email)
+	return nil
+}
+
+// Only forgets the token, it stays valid until it is revoked on the account page
+func runLogout(args []string) error {
+	flags, _ := newFlagSet("logout", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	stored, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	stored.Token = ""
+	return saveCredentials(stored)
+}
+
+func runQuizzes(args []string) error {
+	flags, asJson := newFlagSet("quizzes", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	client, err := connect()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	user, err := currentUser(ctx, client)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetQuizzesOfUserWithResponse(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+	list, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return err
+	}
+	quizzes := []backendapi.QuizInfo{}
+	if list.Quizzes != nil {
+		quizzes = *list.Quizzes
+	}
+
+	if *asJson {
+		return printJson(quizzes)
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tTITLE\tDESCRIPTION")
+	for _, quiz := range quizzes {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", quiz.Id, quiz.Title, quiz.Description)
+	}
+	return table.Flush()
+}
+
+// The exported quiz either is the output or goes to a file, then a line saying
+// what was exported is printed. With --json the quiz is written as JSON or the
+// line is a JSON object.
+func runExport(args []string) error {
+	flags, asJson := newFlagSet("export", "QUIZ_ID")
+	format := flags.String("format", "json", "json or csv")
+	output := flags.String("output", "", "file to write, standard output when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	if *asJson && *output == "" && *format != "json" {
+		return fmt.Errorf("--json writes the quiz as JSON, leave out --format %s or set --output", *format)
+	}
+	write, err := quizWriter(*format)
+	if err != nil {
+		return err
+	}
+	client, err := connect()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetQuizWithResponse(context.Background(), flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	quiz, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return err
+	}
+	exported, err := toExchangeQuiz(*quiz)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return write(os.Stdout, exported)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := write(file, exported); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if *asJson {
+		return printJson(exportResult{QuizId: quiz.Id, Title: quiz.Title, Questions: len(exported.Questions), Output: *output})
+	}
+	fmt.Printf("Exported %d questions of %s to %s\n", len(exported.Questions), quiz.Title, *output)
+	return nil
+}
+
+// What export prints with --json once the quiz is in the file
+type exportResult struct {
+	QuizId    string `json:"quizId"`
+	Title     string `json:"title"`
+	Questions int    `json:"questions"`
+	Output    string `json:"output"`
+}
+
+func runImport(args []string) error {
+	flags, asJson := newFlagSet("import", "FILE")
+	format := flags.String("format", "", "json or csv, taken from the extension of the file when empty")
+	name := flags.String("name", "", "name of the quiz, the name in a JSON file or the file name when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	read, err := quizReader(*format)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	quiz, err := read(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if *name != "" {
+		quiz.Name = *name
+	}
+	if quiz.Name == "" {
+		quiz.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	body, err := toQuizImport(quiz)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	client, err := connect()
+	if err != nil {
+		return err
+	}
+	resp, err := client.ImportQuizWithResponse(context.Background(), body)
+	if err != nil {
+		return err
+	}
+	created, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return err
+	}
+
+	if *asJson {
+		return printJson(created)
+	}
+	fmt.Printf("Imported %d questions into %s (%s)\n", len(body.Questions), created.Title, created.Id)
+	return nil
+}
+
+// Generates the questions from the text of the file into the quiz one request
+// after the other, mixed cycles through the types. Every question is generated
+// from the whole text, so the questions of one run can repeat each other for
+// short texts.
+func runGenerate(args []string) error {
+	flags, asJson := newFlagSet("generate", "FILE")
+	quizId := flags.String("quiz", "", "id of the quiz the questions are added to")
+	questionType := flags.String("type", singleChoiceType, strings.Join([]string{singleChoiceType, multipleChoiceType, trueOrFalseType}, ", ")+" or mixed")
+	count := flags.Int("count", 1, "number of questions")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || *quizId == "" || *count < 1 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	text, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	request := backendapi.GenerateQuestionRequest{QuizId: *quizId, Prompt: strings.TrimSpace(string(text))}
+	if request.Prompt == "" {
+		return fmt.Errorf("%s is empty", flags.Arg(0))
+	}
+	client, err := connect()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	types := []string{singleChoiceType, multipleChoiceType, trueOrFalseType}
+	var generated []any
+	for i := 0; i < *count; i++ {
+		current := *questionType
+		if current == "mixed" {
+			current = types[i%len(types)]
+		}
+		var question any
+		var title string
+		switch current {
+		case singleChoiceType:
+			resp, err := client.CreateSingleChoiceQuestionWithResponse(ctx, request)
+			if err != nil {
+				return err
+			}
+			q, err := expect(resp.JSON200, resp.Status())
+			if err != nil {
+				return err
+			}
+			question, title = q, q.Question
+		case multipleChoiceType:
+			resp, err := client.CreateMultipleChoiceQuestionWithResponse(ctx, request)
+			if err != nil {
+				return err
+			}
+			q, err := expect(resp.JSON200, resp.Status())
+			if err != nil {
+				return err
+			}
+			question, title = q, q.Question
+		case trueOrFalseType:
+			resp, err := client.CreateTrueOrFalseQuestionWithResponse(ctx, request)
+			if err != nil {
+				return err
+			}
+			q, err := expect(resp.JSON200, resp.Status())
+			if err != nil {
+				return err
+			}
+			question, title = q, q.Question
+		default:
+			return fmt.Errorf("unknown question type %q", current)
+		}
+		generated = append(generated, question)
+		if !*asJson {
+			fmt.Printf("%d. %s\n", i+1, title)
+		}
+	}
+
+	if *asJson {
+		return printJson(generated)
+	}
+	return nil
+}
+
+func runLearn(args []string) error {
+	flags, asJson := newFlagSet("learn", "[add|remove QUIZ_ID...]")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	client, err := connect()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var learnList *backendapi.LearnList
+	switch flags.Arg(0) {
+	case "":
+		resp, err := client.GetLearnListWithResponse(ctx)
+		if err != nil {
+			return err
+		}
+		if learnList, err = expect(resp.JSON200, resp.Status()); err != nil {
+			return err
+		}
+	case "add", "remove":
+		if flags.NArg() < 2 {
+			flags.Usage()
+			return flag.ErrHelp
+		}
+		for _, quizId := range flags.Args()[1:] {
+			if flags.Arg(0) == "add" {
+				resp, err := client.AddQuizToLearnListWithResponse(ctx, quizId)
+				if err != nil {
+					return err
+				}
+				if learnList, err = expect(resp.JSON200, resp.Status()); err != nil {
+					return err
+				}
+			} else {
+				resp, err := client.RemoveQuizFromLearnListWithResponse(ctx, quizId)
+				if err != nil {
+					return err
+				}
+				if learnList, err = expect(resp.JSON200, resp.Status()); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	if *asJson {
+		return printJson(learnList.SelectedItems)
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tQUIZ")
+	for _, item := range learnList.SelectedItems {
+		fmt.Fprintf(table, "%s\t%s\n", item.QuizID, item.QuizName)
+	}
+	return table.Flush()
+}
+
+func quizWriter(format string) (func(io.Writer, exchangeQuiz) error, error) {
+	switch format {
+	case "json":
+		return writeJsonQuiz, nil
+	case "csv":
+		return writeCsvQuiz, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, use json or csv", format)
+}
+
+func quizReader(format string) (func(io.Reader) (exchangeQuiz, error), error) {
+	switch format {
+	case "json":
+		return readJsonQuiz, nil
+	case "csv":
+		return readCsvQuiz, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, use json or csv", format)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"spaced-ace/backendapi"
+	"spaced-ace/models"
+	"strconv"
+	"strings"
+)
+
+// The file format of export and import. Questions are written the way they
+// are typed in: the correct answer is a letter, letters like "AC" or
+// true and false.
+type exchangeQuiz struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Questions   []exchangeQuestion `json:"questions"`
+}
+
+type exchangeQuestion struct {
+	Type     string   `json:"type"`
+	Question string   `json:"question"`
+	Answers  []string `json:"answers,omitempty"`
+	Correct  string   `json:"correct"`
+}
+
+const (
+	singleChoiceType   = "single-choice"
+	multipleChoiceType = "multiple-choice"
+	trueOrFalseType    = "true-or-false"
+)
+
+var csvHeader = []string{"type", "question", "answer_a", "answer_b", "answer_c", "answer_d", "correct"}
+
+func toExchangeQuiz(quiz backendapi.Quiz) (exchangeQuiz, error) {
+	exported := exchangeQuiz{Name: quiz.Title, Description: quiz.Description, Questions: []exchangeQuestion{}}
+	for _, q := range quiz.Questions {
+		questionType, err := q.Type()
+		if err != nil {
+			return exported, err
+		}
+		switch models.QuestionType(questionType) {
+		case models.SingleChoice:
+			question, err := q.AsSingleChoiceQuestion()
+			if err != nil {
+				return exported, err
+			}
+			if rejected(question.Status) {
+				continue
+			}
+			exported.Questions = append(exported.Questions, exchangeQuestion{
+				Type:     singleChoiceType,
+				Question: question.Question,
+				Answers:  question.Answers,
+				Correct:  string(question.CorrectAnswer),
+			})
+		case models.MultipleChoice:
+			question, err := q.AsMultipleChoiceQuestion()
+			if err != nil {
+				return exported, err
+			}
+			if rejected(question.Status) {
+				continue
+			}
+			correct := ""
+			for _, answer := range question.CorrectAnswers {
+				correct += string(answer)
+			}
+			exported.Questions = append(exported.Questions, exchangeQuestion{
+				Type:     multipleChoiceType,
+				Question: question.Question,
+				Answers:  question.Answers,
+				Correct:  correct,
+			})
+		case models.TrueOrFalse:
+			question, err := q.AsTrueOrFalseQuestion()
+			if err != nil {
+				return exported, err
+			}
+			if rejected(question.Status) {
+				continue
+			}
+			exported.Questions = append(exported.Questions, exchangeQuestion{
+				Type:     trueOrFalseType,
+				Question: question.Question,
+				Correct:  strconv.FormatBool(question.CorrectAnswer),
+			})
+		default:
+			// Open ended questions cannot be imported again, so they are left out
+			continue
+		}
+	}
+	return exported, nil
+}
+
+// Rejected questions are left out, importing would approve them again
+func rejected(status *backendapi.QuestionStatus) bool {
+	return status != nil && *status == backendapi.QuestionStatusRejected
+}
+
+// The backend checks the answers and the correct letters, only what the file
+// format adds is checked here
+func toQuizImport(quiz exchangeQuiz) (backendapi.QuizImport, error) {
+	imported := backendapi.QuizImport{Name: quiz.Name, Questions: []backendapi.Question{}}
+	if quiz.Description != "" {
+		imported.Description = &quiz.Description
+	}
+	for i, q := range quiz.Questions {
+		var question backendapi.Question
+		var err error
+		switch q.Type {
+		case singleChoiceType:
+			err = question.FromSingleChoiceQuestion(backendapi.SingleChoiceQuestion{
+				QuestionType:  backendapi.QuestionType(models.SingleChoice),
+				Question:      q.Question,
+				Answers:       q.Answers,
+				CorrectAnswer: backendapi.SingleChoiceQuestionCorrectAnswer(strings.ToUpper(q.Correct)),
+			})
+		case multipleChoiceType:
+			var correct []backendapi.MultipleChoiceQuestionCorrectAnswers
+			for _, letter := range strings.ToUpper(q.Correct) {
+				correct = append(correct, backendapi.MultipleChoiceQuestionCorrectAnswers(letter))
+			}
+			err = question.FromMultipleChoiceQuestion(backendapi.MultipleChoiceQuestion{
+				QuestionType:   backendapi.QuestionType(models.MultipleChoice),
+				Question:       q.Question,
+				Answers:        q.Answers,
+				CorrectAnswers: correct,
+			})
+		case trueOrFalseType:
+			var correct bool
+			correct, err = strconv.ParseBool(q.Correct)
+			if err != nil {
+				return imported, fmt.Errorf("question %d: the correct answer has to be true or false", i+1)
+			}
+			err = question.FromTrueOrFalseQuestion(backendapi.TrueOrFalseQuestion{
+				QuestionType:  backendapi.QuestionType(models.TrueOrFalse),
+				Question:      q.Question,
+				CorrectAnswer: correct,
+			})
+		default:
+			return imported, fmt.Errorf("question %d: unknown type %q, use %s, %s or %s", i+1, q.Type, singleChoiceType, multipleChoiceType, trueOrFalseType)
+		}
+		if err != nil {
+			return imported, fmt.Errorf("question %d: %w", i+1, err)
+		}
+		imported.Questions = append(imported.Questions, question)
+	}
+	return imported, nil
+}
+
+func writeJsonQuiz(w io.Writer, quiz exchangeQuiz) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(quiz)
+}
+
+func readJsonQuiz(r io.Reader) (exchangeQuiz, error) {
+	var quiz exchangeQuiz
+	if err := json.NewDecoder(r).Decode(&quiz); err != nil {
+		return quiz, fmt.Errorf("reading the quiz: %w", err)
+	}
+	return quiz, nil
+}
+
+// CSV has no room for the name and description of the quiz, one question is
+// written per row
+func writeCsvQuiz(w io.Writer, quiz exchangeQuiz) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, q := range quiz.Questions {
+		answers := make([]string, 4)
+		copy(answers, q.Answers)
+		record := append([]string{q.Type, q.Question}, answers...)
+		if err := writer.Write(append(record, q.Correct)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func readCsvQuiz(r io.Reader) (exchangeQuiz, error) {
+	quiz := exchangeQuiz{}
+	reader := csv.NewReader(r)
+	// Any header is read, so a wrong one is reported as such
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return quiz, errors.New("the file is empty")
+	}
+	if err != nil {
+		return quiz, err
+	}
+	if strings.Join(header, ",") != strings.Join(csvHeader, ",") {
+		return quiz, fmt.Errorf("the first row has to be the header %s", strings.Join(csvHeader, ","))
+	}
+	reader.FieldsPerRecord = len(csvHeader)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return quiz, nil
+		}
+		if err != nil {
+			return quiz, err
+		}
+		question := exchangeQuestion{Type: record[0], Question: record[1], Correct: record[6]}
+		if question.Type != trueOrFalseType {
+			question.Answers = record[2:6]
+		}
+		quiz.Questions = append(quiz.Questions, question)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExchangeRoundTrip(t *testing.T) {
+	quiz := exchangeQuiz{
+		Name:        "Capitals",
+		Description: "Of Europe",
+		Questions: []exchangeQuestion{
+			{Type: singleChoiceType, Question: "The capital of France?", Answers: []string{"Paris", "Lyon", "Nice", "Lille"}, Correct: "A"},
+			{Type: multipleChoiceType, Question: "Capitals on a river, \"really\"?", Answers: []string{"Berlin", "Vienna", "Madrid", "Oslo"}, Correct: "AB"},
+			{Type: trueOrFalseType, Question: "Bern is the capital of Switzerland,\nofficially?", Correct: "true"},
+		},
+	}
+	for _, tc := range []struct {
+		format string
+		want   exchangeQuiz
+	}{
+		{format: "json", want: quiz},
+		// CSV has no room for the name and the description
+		{format: "csv", want: exchangeQuiz{Questions: quiz.Questions}},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			write, err := quizWriter(tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			read, err := quizReader(tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var file bytes.Buffer
+			if err := write(&file, quiz); err != nil {
+				t.Fatal(err)
+			}
+			got, err := read(&file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadCsvQuiz(t *testing.T) {
+	header := strings.Join(csvHeader, ",") + "\n"
+	for _, tc := range []struct {
+		name    string
+		file    string
+		want    []exchangeQuestion
+		wantErr string
+	}{
+		{name: "empty file", file: "", wantErr: "the file is empty"},
+		{name: "only the header", file: header},
+		{name: "wrong header", file: "type,question,correct\n", wantErr: "the first row has to be the header"},
+		{name: "too few fields", file: header + "single-choice,Capital?,Paris,Lyon,A\n", wantErr: "wrong number of fields"},
+		{name: "too many fields", file: header + "true-or-false,Sky is blue?,,,,,true,extra\n", wantErr: "wrong number of fields"},
+		{name: "unterminated quote", file: header + "single-choice,\"Capital?,Paris,Lyon,Nice,Lille,A\n", wantErr: "extraneous or missing \" in quoted-field"},
+		{
+			name: "spaces after the commas",
+			file: header + "single-choice, Capital?, Paris, Lyon, Nice, Lille, A\n",
+			want: []exchangeQuestion{{Type: singleChoiceType, Question: "Capital?", Answers: []string{"Paris", "Lyon", "Nice", "Lille"}, Correct: "A"}},
+		},
+		{
+			name: "answers of true or false are dropped",
+			file: header + "true-or-false,Sky is blue?,yes,,,,true\n",
+			want: []exchangeQuestion{{Type: trueOrFalseType, Question: "Sky is blue?", Correct: "true"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			quiz, err := readCsvQuiz(strings.NewReader(tc.file))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(quiz.Questions, tc.want) {
+				t.Errorf("got %+v, want %+v", quiz.Questions, tc.want)
+			}
+		})
+	}
+}
+
+func TestToQuizImport(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		question exchangeQuestion
+		wantErr  string
+	}{
+		{name: "lower case letters", question: exchangeQuestion{Type: multipleChoiceType, Question: "Rivers?", Answers: []string{"a", "b", "c", "d"}, Correct: "ac"}},
+		{name: "true or false", question: exchangeQuestion{Type: trueOrFalseType, Question: "Sky is blue?", Correct: "T"}},
+		{name: "true or false not a bool", question: exchangeQuestion{Type: trueOrFalseType, Question: "Sky is blue?", Correct: "yes"}, wantErr: "question 1: the correct answer has to be true or false"},
+		{name: "unknown type", question: exchangeQuestion{Type: "open", Question: "Why?"}, wantErr: "question 1: unknown type \"open\""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			imported, err := toQuizImport(exchangeQuiz{Name: "Quiz", Questions: []exchangeQuestion{tc.question}})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(imported.Questions) != 1 {
+				t.Errorf("got %d questions", len(imported.Questions))
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Command spacedace works with SpacedAce from the terminal. It talks to the
+// backend with a personal api token created on the account page.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: spacedace <command> [flags] [arguments]
+
+Commands:
+  login      store the url of the backend and a personal api token
+  logout     forget the stored token
+  quizzes    list your quizzes
+  export     write a quiz with its questions as JSON or CSV
+  import     create a quiz from a JSON or CSV file
+  generate   generate questions into a quiz from a text file
+  learn      list, add or remove the quizzes of your learn list
+  review     answer the due review items one after the other
+
+Every command takes --json to print machine-readable JSON instead of text.
+Run spacedace <command> --help for the flags of a command.
+`
+
+var commands = map[string]func(args []string) error{
+	"login":    runLogin,
+	"logout":   runLogout,
+	"quizzes":  runQuizzes,
+	"export":   runExport,
+	"import":   runImport,
+	"generate": runGenerate,
+	"learn":    runLearn,
+	"review":   runReview,
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err := run(os.Args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "spacedace:", err)
+		os.Exit(1)
+	}
+}
+
+// Returns the flags of the command with --json already registered
+func newFlagSet(name string, arguments string) (*flag.FlagSet, *bool) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: spacedace %s [flags] %s\n\nFlags:\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	asJson := flags.Bool("json", false, "print JSON instead of text")
+	return flags, asJson
+}
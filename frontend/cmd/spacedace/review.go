@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"spaced-ace/backendapi"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// One answered question, printed as a line of JSON with --json
+type reviewResult struct {
+	Question       string    `json:"question"`
+	Answer         string    `json:"answer"`
+	CorrectAnswer  string    `json:"correctAnswer"`
+	Correct        bool      `json:"correct"`
+	NextReviewDate time.Time `json:"nextReviewDate"`
+}
+
+var optionLetters = []string{"A", "B", "C", "D"}
+
+// Asks the due review items one after the other until none is due, the limit
+// is reached or q is typed. The hints go to standard error, so with --json
+// standard output only has a JSON line per question and per answer.
+func runReview(args []string) error {
+	flags, asJson := newFlagSet("review", "")
+	limit := flags.Int("limit", 0, "stop after this many questions, 0 for every due one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	client, err := connect()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	input := bufio.NewReader(os.Stdin)
+	answered, correct := 0, 0
+	for *limit == 0 || answered < *limit {
+		resp, err := client.GetNextReviewItemQuestionWithResponse(ctx)
+		if isNotFound(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		next, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return err
+		}
+
+		prompt, err := newReviewPrompt(next)
+		if err != nil {
+			return err
+		}
+		if *asJson {
+			if err := printJsonLine(next); err != nil {
+				return err
+			}
+		} else {
+			fmt.Println()
+			fmt.Println(prompt.text)
+		}
+
+		var answer backendapi.ReviewItemAnswer
+		var given string
+		for {
+			fmt.Fprintf(os.Stderr, "%s, q to quit: ", prompt.hint)
+			line, err := input.ReadString('\n')
+			if errors.Is(err, io.EOF) && line == "" {
+				return printReviewSummary(*asJson, answered, correct)
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+			typed := strings.ToUpper(strings.TrimSpace(line))
+			if typed == "Q" {
+				return printReviewSummary(*asJson, answered, correct)
+			}
+			if answer, given, err = prompt.parse(typed); err == nil {
+				break
+			}
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		submitted, err := client.SubmitReviewItemWithResponse(ctx, next.CurrentReviewItemID, answer)
+		if err != nil {
+			return err
+		}
+		item, err := expect(submitted.JSON200, submitted.Status())
+		if err != nil {
+			return err
+		}
+
+		result := reviewResult{
+			Question:      prompt.question,
+			Answer:        given,
+			CorrectAnswer: prompt.correct,
+			Correct:       given == prompt.correct,
+		}
+		if item.NextReviewDate != nil {
+			result.NextReviewDate = *item.NextReviewDate
+		}
+		answered++
+		if result.Correct {
+			correct++
+		}
+		if *asJson {
+			if err := printJsonLine(result); err != nil {
+				return err
+			}
+			continue
+		}
+		if result.Correct {
+			fmt.Print("Correct.")
+		} else {
+			fmt.Printf("Wrong, the answer is %s.", result.CorrectAnswer)
+		}
+		fmt.Printf(" Next review %s\n", result.NextReviewDate.Local().Format("2006-01-02 15:04"))
+	}
+	return printReviewSummary(*asJson, answered, correct)
+}
+
+func printReviewSummary(asJson bool, answered int, correct int) error {
+	if asJson {
+		return nil
+	}
+	if answered == 0 {
+		fmt.Println("Nothing is due for review")
+		return nil
+	}
+	fmt.Printf("\nReviewed %d questions, %d correct\n", answered, correct)
+	return nil
+}
+
+// What is shown for a review question and how the typed answer is read
+type reviewPrompt struct {
+	question string
+	text     string
+	hint     string
+	// In the form parse returns the typed answer in, "AC" for multiple choice
+	correct string
+	parse   func(typed string) (backendapi.ReviewItemAnswer, string, error)
+}
+
+func newReviewPrompt(next *backendapi.ReviewItemQuestion) (reviewPrompt, error) {
+	switch {
+	case next.SingleChoiceQuestion != nil:
+		q := next.SingleChoiceQuestion
+		return reviewPrompt{
+			question: q.Question,
+			text:     formatOptions(q.Question, q.Answers),
+			hint:     "Answer with a letter",
+			correct:  string(q.CorrectAnswer),
+			parse: func(typed string) (backendapi.ReviewItemAnswer, string, error) {
+				if !slices.Contains(optionLetters, typed) {
+					return backendapi.ReviewItemAnswer{}, "", errors.New("type one of A, B, C and D")
+				}
+				return backendapi.ReviewItemAnswer{SingleChoiceValue: &typed}, typed, nil
+			},
+		}, nil
+	case next.MultipleChoiceQuestion != nil:
+		q := next.MultipleChoiceQuestion
+		correct := ""
+		for _, answer := range q.CorrectAnswers {
+			correct += string(answer)
+		}
+		return reviewPrompt{
+			question: q.Question,
+			text:     formatOptions(q.Question, q.Answers),
+			hint:     "Answer with every correct letter, like AC",
+			correct:  sortLetters(correct),
+			parse: func(typed string) (backendapi.ReviewItemAnswer, string, error) {
+				given := sortLetters(typed)
+				if given == "" {
+					return backendapi.ReviewItemAnswer{}, "", errors.New("type at least one letter")
+				}
+				letters := strings.Split(given, "")
+				for _, letter := range letters {
+					if !slices.Contains(optionLetters, letter) {
+						return backendapi.ReviewItemAnswer{}, "", errors.New("type the letters of the correct answers, like AC")
+					}
+				}
+				return backendapi.ReviewItemAnswer{MultipleChoiceValue: &letters}, given, nil
+			},
+		}, nil
+	case next.TrueOrFalseQuestion != nil:
+		q := next.TrueOrFalseQuestion
+		return reviewPrompt{
+			question: q.Question,
+			text:     q.Question,
+			hint:     "Answer with t or f",
+			correct:  strings.ToUpper(strconv.FormatBool(q.CorrectAnswer)),
+			parse: func(typed string) (backendapi.ReviewItemAnswer, string, error) {
+				value, err := strconv.ParseBool(typed)
+				if err != nil {
+					return backendapi.ReviewItemAnswer{}, "", errors.New("type t or f")
+				}
+				return backendapi.ReviewItemAnswer{TrueOrFalseValue: &value}, strings.ToUpper(strconv.FormatBool(value)), nil
+			},
+		}, nil
+	}
+	return reviewPrompt{}, fmt.Errorf("review item %s has no question", next.CurrentReviewItemID)
+}
+
+func formatOptions(question string, answers []string) string {
+	var text strings.Builder
+	text.WriteString(question)
+	for i, answer := range answers {
+		if i < len(optionLetters) {
+			fmt.Fprintf(&text, "\n  %s) %s", optionLetters[i], answer)
+		}
+	}
+	return text.String()
+}
+
+// Letters without duplicates and separators in alphabetical order, so AC,
+// "C A" and CA are the same answer
+func sortLetters(letters string) string {
+	var unique []string
+	for _, letter := range strings.Split(strings.ToUpper(letters), "") {
+		if strings.TrimSpace(letter) == "" || letter == "," {
+			continue
+		}
+		if !slices.Contains(unique, letter) {
+			unique = append(unique, letter)
+		}
+	}
+	slices.Sort(unique)
+	return strings.Join(unique, "")
+}
+
+func printJsonLine(value any) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(string(content))
+	return err
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"spaced-ace/backendapi"
+	"testing"
+)
+
+func TestSortLetters(t *testing.T) {
+	for _, tc := range []struct {
+		typed string
+		want  string
+	}{
+		{typed: "AC", want: "AC"},
+		{typed: "ca", want: "AC"},
+		{typed: "C A", want: "AC"},
+		{typed: "c, a", want: "AC"},
+		{typed: "AAC", want: "AC"},
+		{typed: "DCBA", want: "ABCD"},
+		{typed: " , ", want: ""},
+		{typed: "", want: ""},
+	} {
+		if got := sortLetters(tc.typed); got != tc.want {
+			t.Errorf("sortLetters(%q) = %q, want %q", tc.typed, got, tc.want)
+		}
+	}
+}
+
+func TestReviewPromptParse(t *testing.T) {
+	multiple := &backendapi.ReviewItemQuestion{MultipleChoiceQuestion: &backendapi.MultipleChoiceQuestion{
+		Question:       "Rivers?",
+		Answers:        []string{"a", "b", "c", "d"},
+		CorrectAnswers: []backendapi.MultipleChoiceQuestionCorrectAnswers{"C", "A"},
+	}}
+	trueOrFalse := &backendapi.ReviewItemQuestion{TrueOrFalseQuestion: &backendapi.TrueOrFalseQuestion{Question: "Sky is blue?", CorrectAnswer: true}}
+	for _, tc := range []struct {
+		name    string
+		next    *backendapi.ReviewItemQuestion
+		typed   string
+		want    string
+		correct bool
+		wantErr bool
+	}{
+		{name: "multiple choice in any order", next: multiple, typed: "C A", want: "AC", correct: true},
+		{name: "multiple choice missing a letter", next: multiple, typed: "A", want: "A"},
+		{name: "multiple choice unknown letter", next: multiple, typed: "AE", wantErr: true},
+		{name: "multiple choice nothing", next: multiple, typed: ",", wantErr: true},
+		{name: "true or false short", next: trueOrFalse, typed: "T", want: "TRUE", correct: true},
+		{name: "true or false word", next: trueOrFalse, typed: "FALSE", want: "FALSE"},
+		{name: "true or false other", next: trueOrFalse, typed: "YES", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt, err := newReviewPrompt(tc.next)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, given, err := prompt.parse(tc.typed)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v", err)
+			}
+			if given != tc.want || (given == prompt.correct) != tc.correct {
+				t.Errorf("got %q with correct %q", given, prompt.correct)
+			}
+		})
+	}
+}
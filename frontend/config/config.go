@@ -0,0 +1,254 @@
+// Package config loads the settings of the frontend the same way the backend
+// does: defaults, overridden by the YAML file named by CONFIG_FILE, overridden
+// by the environment.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server      Server      `yaml:"server"`
+	Metrics     Metrics     `yaml:"metrics"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Logging     Logging     `yaml:"logging"`
+	Backend     Backend     `yaml:"backend"`
+	ReviewItems ReviewItems `yaml:"reviewItems"`
+}
+
+type Server struct {
+	Port int `yaml:"port" env:"PORT"`
+	// How long in-flight requests get to finish after SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT"`
+	// The address ranges of reverse proxies in front of the site, whose
+	// X-Forwarded-For is trusted, comma separated in the environment. Without
+	// any the peer of the connection is the address the backend limits logins
+	// and signups by.
+	TrustedProxies []string `yaml:"trustedProxies" env:"TRUSTED_PROXIES"`
+}
+
+// Prometheus scrapes /metrics on its own port, which is not published like the site
+type Metrics struct {
+	Port int `yaml:"port" env:"METRICS_PORT"`
+}
+
+// Traces are exported over OTLP/HTTP, e.g. to a local collector on
+// http://localhost:4318. Tracing is off while the endpoint is empty.
+type Tracing struct {
+	Endpoint string `yaml:"endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	// The share of the traces that are kept, between 0 and 1
+	SampleRatio float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type Logging struct {
+	// debug, info, warn or error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// json for the log collectors, text to read them in a terminal
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+type Backend struct {
+	Url string `yaml:"url" env:"BACKEND_URL"`
+}
+
+type ReviewItems struct {
+	// Has to match the page size of the backend
+	PageSize int `yaml:"pageSize" env:"REVIEW_ITEM_PAGE_SIZE"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server:      Server{Port: 42069, ShutdownTimeout: 30 * time.Second},
+		Metrics:     Metrics{Port: 9101},
+		Tracing:     Tracing{SampleRatio: 1},
+		Logging:     Logging{Level: "info", Format: "json"},
+		Backend:     Backend{Url: "http://localhost:9000"},
+		ReviewItems: ReviewItems{PageSize: 10},
+	}
+}
+
+// Loads and validates the configuration, the errors name every invalid setting
+func Load() (*Config, error) {
+	config := Default()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := config.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.readEnv(); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *Config) readFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	defer file.Close()
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) readEnv() error {
+	problems := []string{}
+	readEnvFields(reflect.ValueOf(c).Elem(), &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid environment:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// Sets the fields with an env tag from the variable when it is set and not empty
+func readEnvFields(value reflect.Value, problems *[]string) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		env := value.Type().Field(i).Tag.Get("env")
+		switch {
+		case field.Kind() == reflect.Struct:
+			readEnvFields(field, problems)
+		case env == "":
+		case field.Type() == reflect.TypeOf(time.Duration(0)):
+			raw, exists := os.LookupEnv(env)
+			if !exists || raw == "" {
+				continue
+			}
+			duration, err := time.ParseDuration(raw)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s must be a duration like 30s, got %q", env, raw))
+				continue
+			}
+			field.SetInt(int64(duration))
+		case field.Kind() == reflect.Float64:
+			raw, exists := os.LookupEnv(env)
+			if !exists || raw == "" {
+				continue
+			}
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s must be a number, got %q", env, raw))
+				continue
+			}
+			field.SetFloat(number)
+		case field.Kind() == reflect.Int:
+			number := int(field.Int())
+			readEnvInt(env, &number, problems)
+			field.SetInt(int64(number))
+		case field.Kind() == reflect.String:
+			readEnvString(env, field.Addr().Interface().(*string))
+		case field.Type() == reflect.TypeOf([]string{}):
+			if raw, exists := os.LookupEnv(env); exists && raw != "" {
+				field.Set(reflect.ValueOf(strings.Split(raw, ",")))
+			}
+		}
+	}
+}
+
+func readEnvString(env string, value *string) {
+	if raw, exists := os.LookupEnv(env); exists && raw != "" {
+		*value = raw
+	}
+}
+
+func readEnvInt(env string, value *int, problems *[]string) {
+	raw, exists := os.LookupEnv(env)
+	if !exists || raw == "" {
+		return
+	}
+	number, err := strconv.Atoi(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s must be a whole number, got %q", env, raw))
+		return
+	}
+	*value = number
+}
+
+// Reports every problem at once, named by the setting of the file and its variable
+func (c *Config) Validate() error {
+	problems := []string{}
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("server.port (PORT) must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("server.shutdownTimeout (SHUTDOWN_TIMEOUT) must be positive, got %s", c.Server.ShutdownTimeout))
+	}
+	for _, cidr := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			problems = append(problems, fmt.Sprintf("server.trustedProxies (TRUSTED_PROXIES) must be address ranges like 10.0.0.0/8, got %q", cidr))
+		}
+	}
+	if c.Metrics.Port <= 0 || c.Metrics.Port > 65535 || c.Metrics.Port == c.Server.Port {
+		problems = append(problems, fmt.Sprintf("metrics.port (METRICS_PORT) must be between 1 and 65535 and differ from the port of the site, got %d", c.Metrics.Port))
+	}
+	if c.Tracing.Endpoint != "" && !validUrl(c.Tracing.Endpoint) {
+		problems = append(problems, fmt.Sprintf("tracing.endpoint (TRACING_OTLP_ENDPOINT) must be an absolute http url, got %q", c.Tracing.Endpoint))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("tracing.sampleRatio (TRACING_SAMPLE_RATIO) must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+	}
+	if !slices.Contains([]string{"debug", "info", "warn", "error"}, c.Logging.Level) {
+		problems = append(problems, fmt.Sprintf("logging.level (LOG_LEVEL) must be debug, info, warn or error, got %q", c.Logging.Level))
+	}
+	if c.Logging.Format != "json" && c.Logging.Format != "text" {
+		problems = append(problems, fmt.Sprintf("logging.format (LOG_FORMAT) must be json or text, got %q", c.Logging.Format))
+	}
+	if !validUrl(c.Backend.Url) {
+		problems = append(problems, fmt.Sprintf("backend.url (BACKEND_URL) must be an absolute http url, got %q", c.Backend.Url))
+	}
+	if c.ReviewItems.PageSize <= 0 {
+		problems = append(problems, fmt.Sprintf("reviewItems.pageSize (REVIEW_ITEM_PAGE_SIZE) must be positive, got %d", c.ReviewItems.PageSize))
+	}
+	if len(problems) > 0 {
+		return errors.New("invalid configuration:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func validUrl(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// Returns the configuration as YAML with the secrets redacted, for the startup log
+func (c *Config) String() string {
+	redacted := *c
+	redact(reflect.ValueOf(&redacted).Elem())
+	out, err := yaml.Marshal(&redacted)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+// Blanks the fields tagged secret, the way the backend logs its configuration
+func redact(value reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if value.Type().Field(i).Tag.Get("secret") == "true" && field.String() != "" {
+			field.SetString("[redacted]")
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			redact(field)
+		}
+	}
+}
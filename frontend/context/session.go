@@ -1,12 +1,15 @@
 package context
 
 import (
+	stdcontext "context"
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"spaced-ace/models/business"
 	"spaced-ace/service"
 )
 
+type sessionContextKey struct{}
+
 type AppContext struct {
 	echo.Context
 	Session    *business.Session
@@ -24,14 +27,20 @@ func SessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return next(cc)
 		}
 
-		cc.ApiService = service.NewApiService(sessionCookie)
+		cc.ApiService = service.NewApiService(c.Request().Context(), sessionCookie)
 		session, err := cc.ApiService.GetSession()
 		if err != nil {
 			return next(cc)
 		}
 
+		if renewedCookie := cc.ApiService.RenewedSessionCookie(); renewedCookie != nil {
+			c.SetCookie(renewedCookie)
+		}
+
 		cc.Session = session
 		cc.Set("cc", cc)
+		// Templates read the session from the request context, e.g. to show the admin menu
+		c.SetRequest(c.Request().WithContext(stdcontext.WithValue(c.Request().Context(), sessionContextKey{}, session)))
 		return next(cc)
 	}
 }
@@ -61,3 +70,31 @@ func RequireSessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// Returns the session of the request for templates, nil for anonymous requests
+func SessionFromContext(ctx stdcontext.Context) *business.Session {
+	session, _ := ctx.Value(sessionContextKey{}).(*business.Session)
+	return session
+}
+
+// Only lets moderators and admins through, must run after RequireSessionMiddleware
+func RequireStaffMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cc := c.(*AppContext)
+		if !cc.Session.User.IsStaff() {
+			return c.Redirect(http.StatusFound, "/not-found")
+		}
+		return next(c)
+	}
+}
+
+// Only lets admins through, must run after RequireSessionMiddleware
+func RequireAdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cc := c.(*AppContext)
+		if !cc.Session.User.IsAdmin() {
+			return c.Redirect(http.StatusFound, "/not-found")
+		}
+		return next(c)
+	}
+}
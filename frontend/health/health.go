@@ -0,0 +1,61 @@
+// Package health serves the liveness and readiness probes of compose and
+// Kubernetes
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"spaced-ace/logging"
+	"spaced-ace/service"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// How long the backend gets to answer before the frontend counts as not ready
+const checkTimeout = 3 * time.Second
+
+var draining atomic.Bool
+
+// Fails the readiness probe from now on, so no new traffic is routed to the
+// server while the in-flight requests finish
+func Drain() {
+	draining.Store(true)
+}
+
+func LivenessEndpoint(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Every page needs the backend, so the frontend is ready when the backend is
+// up. Only the liveness of the backend is checked, the backend reports on its
+// own dependencies.
+func ReadinessEndpoint(c echo.Context) error {
+	if draining.Load() {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"status": "draining"})
+	}
+	ctx, cancel := context.WithTimeout(c.Request().Context(), checkTimeout)
+	defer cancel()
+	if err := service.NewApiService(ctx, nil).CheckLiveness(); err != nil {
+		logging.Logger(c.Request().Context()).Warn("readiness check of the backend failed", "error", err)
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"status": "unavailable"})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Asks the server listening on the port whether it is ready and fails when it
+// is not, the healthcheck of the container image that ships without curl
+func Probe(port int) error {
+	client := http.Client{Timeout: 2 * checkTimeout}
+	res, err := client.Get(fmt.Sprintf("http://localhost:%d/readyz", port))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("not ready, status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package logging sets up the structured logger of the frontend. Every entry
+// logged during a request carries its request id, which is sent on to the
+// backend, so a failed page can be followed through both services.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"regexp"
+	"spaced-ace/config"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIdKey struct{}
+
+// Ids of the caller are only taken over when they cannot break the log lines
+var validRequestId = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Installs the default logger, the log package writes through it as well
+func Init(cfg config.Logging) {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(cfg.Level))
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler = slog.NewJSONHandler(os.Stdout, options)
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(os.Stdout, options)
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+// Returns the id of the request the context belongs to, empty outside of requests
+func RequestId(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
+
+// Returns the logger adding the request and the trace id to the entries
+func Logger(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if id := RequestId(ctx); id != "" {
+		logger = logger.With("requestId", id)
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		logger = logger.With("traceId", span.TraceID().String())
+	}
+	return logger
+}
+
+// Gives every request an id, taken from the X-Request-Id header of a proxy
+// in front of the site when there is one, and logs the request once it is
+// answered.
+// The skipped requests still get an id, they are only not logged.
+func Middleware(skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestId.MatchString(id) {
+				id = uuid.NewString()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			ctx := context.WithValue(req.Context(), requestIdKey{}, id)
+			c.SetRequest(req.WithContext(ctx))
+
+			started := time.Now()
+			if err := next(c); err != nil {
+				// Writes the error response now, so the logged status is the one sent
+				c.Error(err)
+			}
+			if skipper(c) {
+				return nil
+			}
+			status := c.Response().Status
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			}
+			Logger(ctx).LogAttrs(ctx, level, "request",
+				slog.String("method", req.Method),
+				slog.String("route", c.Path()),
+				slog.String("path", req.URL.Path),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(started)),
+				slog.String("remoteIp", c.RealIP()),
+			)
+			return nil
+		}
+	}
+}
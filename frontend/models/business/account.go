@@ -0,0 +1,43 @@
+package business
+
+import "time"
+
+type TotpStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+type TotpEnrollment struct {
+	Secret          string
+	ProvisioningUri string
+}
+
+type ApiToken struct {
+	Id         string
+	Name       string
+	Prefix     string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+}
+
+type ActiveSession struct {
+	Id         string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ValidUntil time.Time
+	RememberMe bool
+	Current    bool
+}
+
+// A requested deletion of the account, EraseAfter is set once it is confirmed
+type Erasure struct {
+	QuizHandling    string
+	TransferToEmail string
+	RequestedAt     time.Time
+	ConfirmedAt     *time.Time
+	EraseAfter      *time.Time
+}
@@ -0,0 +1,35 @@
+package business
+
+import "time"
+
+type AdminUser struct {
+	Id            string
+	Name          string
+	Email         string
+	EmailVerified bool
+	Role          string
+	Plan          string
+	DisabledAt    *time.Time
+}
+
+type AdminUserPage struct {
+	Users   []AdminUser
+	Page    int
+	HasMore bool
+}
+
+type AdminQuiz struct {
+	QuizInfo
+	CreatorEmail string
+}
+
+type AdminQuizPage struct {
+	Quizzes []AdminQuiz
+	Page    int
+	HasMore bool
+}
+
+type Impersonation struct {
+	Session    string
+	ValidUntil time.Time
+}
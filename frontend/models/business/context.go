@@ -5,9 +5,34 @@ type User struct {
 	Name          string `json:"name"`
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
+}
+
+// Moderators can see and delete every quiz
+func (u User) IsStaff() bool {
+	return u.Role == "moderator" || u.Role == "admin"
+}
+
+// Admins can also manage the users
+func (u User) IsAdmin() bool {
+	return u.Role == "admin"
 }
 
 type Session struct {
 	Id   string `json:"session"`
 	User User   `json:"user"`
+	// The admin looking at the account in a read-only session
+	ImpersonatedBy *User `json:"impersonatedBy"`
+}
+
+type OidcProvider struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// Where to send the browser to sign in with a provider, and the state that
+// comes back with it
+type OidcAuthorization struct {
+	Url   string
+	State string
 }
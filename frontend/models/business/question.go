@@ -2,6 +2,7 @@ package business
 
 import (
 	"spaced-ace/models"
+	"time"
 )
 
 type QuestionOption struct {
@@ -15,6 +16,22 @@ type CommonQuestionProperties struct {
 	Order        int                 `json:"order"`
 	QuestionType models.QuestionType `json:"questionType"`
 	Question     string              `json:"question"`
+	// draft, approved or rejected, learners only get the published questions
+	Status    string `json:"status"`
+	Published bool   `json:"published"`
+}
+
+// Returns the properties every question type has, nil for anything else
+func CommonPropertiesOf(question interface{}) *CommonQuestionProperties {
+	switch q := question.(type) {
+	case *SingleChoiceQuestion:
+		return &q.CommonQuestionProperties
+	case *MultipleChoiceQuestion:
+		return &q.CommonQuestionProperties
+	case *TrueOrFalseQuestion:
+		return &q.CommonQuestionProperties
+	}
+	return nil
 }
 
 type SingleChoiceQuestion struct {
@@ -37,3 +54,27 @@ type OpenEndedQuestion struct {
 	Context string `json:"context"`
 	Answer  string `json:"answer"`
 }
+
+// A stored version of a question. AuthorName is empty for the version from
+// before the history was kept, Question is one of the question types.
+type QuestionRevision struct {
+	Revision     int
+	AuthorName   string
+	CreatedAt    time.Time
+	RestoredFrom *int
+	Question     interface{}
+}
+
+type QuestionRevisionDiff struct {
+	From                 int
+	To                   int
+	Changes              []QuestionChange
+	CorrectAnswerChanged bool
+}
+
+// A changed field of the question, the values are formatted for showing them
+type QuestionChange struct {
+	Field string
+	From  string
+	To    string
+}
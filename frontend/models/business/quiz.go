@@ -1,6 +1,10 @@
 package business
 
-import "spaced-ace/utils"
+import (
+	"spaced-ace/models"
+	"spaced-ace/utils"
+	"time"
+)
 
 type QuizInfo struct {
 	Id          string `json:"id"`
@@ -8,12 +12,38 @@ type QuizInfo struct {
 	Description string `json:"description"`
 	CreatorId   string `json:"creatorId"`
 	CreatorName string `json:"creatorName"`
+	// draft, published or archived, learners only take published quizzes
+	Status string `json:"status"`
 }
 
 type Quiz struct {
 	QuizInfo
-	Questions []interface{}
+	PublishedAt *time.Time
+	Questions   []interface{}
 }
+
+// Returns the questions learners get, the owner also gets the ones in review
+func (q *Quiz) PublishedQuestions() []interface{} {
+	var published []interface{}
+	for _, question := range q.Questions {
+		if common := CommonPropertiesOf(question); common != nil && common.Published {
+			published = append(published, question)
+		}
+	}
+	return published
+}
+
+// Returns the number of questions waiting in the review queue
+func (q *Quiz) DraftQuestionCount() int {
+	count := 0
+	for _, question := range q.Questions {
+		if common := CommonPropertiesOf(question); common != nil && common.Status == models.QuestionDraft {
+			count++
+		}
+	}
+	return count
+}
+
 type QuestionWithMetaData struct {
 	EditMode bool
 	Question interface{}
@@ -0,0 +1,17 @@
+package business
+
+import "time"
+
+// A limit of 0 means the period is not limited
+type UsagePeriod struct {
+	Name     string
+	Limit    int
+	Used     int
+	ResetsAt time.Time
+}
+
+type Usage struct {
+	Plan    string
+	Daily   UsagePeriod
+	Monthly UsagePeriod
+}
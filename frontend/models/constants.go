@@ -5,3 +5,24 @@ const (
 	MultipleChoiceQuestion = "multiple-choice"
 	TrueOrFalseQuestion    = "true-or-false"
 )
+
+const (
+	QuestionDraft    = "draft"
+	QuestionApproved = "approved"
+	QuestionRejected = "rejected"
+)
+
+const (
+	QuizDraft     = "draft"
+	QuizPublished = "published"
+	QuizArchived  = "archived"
+)
+
+var ApiTokenScopes = []string{
+	"quizzes:read",
+	"quizzes:write",
+	"sessions:read",
+	"sessions:write",
+	"learn:read",
+	"learn:write",
+}
@@ -0,0 +1,11 @@
+package request
+
+// Bound from the query of the search and pager requests
+type AdminSearchForm struct {
+	Query string `query:"q" form:"q"`
+	Page  int    `query:"page" form:"page"`
+}
+
+type AdminSetRoleForm struct {
+	Role string `form:"role"`
+}
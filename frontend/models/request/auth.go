@@ -1,8 +1,9 @@
 package request
 
 type LoginForm struct {
-	Email    string `form:"email"`
-	Password string `form:"password"`
+	Email      string `form:"email"`
+	Password   string `form:"password"`
+	RememberMe bool   `form:"remember_me"`
 }
 
 type SignupForm struct {
@@ -11,3 +12,29 @@ type SignupForm struct {
 	Password      string `form:"password"`
 	PasswordAgain string `form:"password_again"`
 }
+
+type LoginTotpForm struct {
+	Challenge string `form:"challenge"`
+	Code      string `form:"code"`
+}
+
+type TotpCodeForm struct {
+	Code string `form:"code"`
+}
+
+type TotpConfirmForm struct {
+	Code            string `form:"code"`
+	Secret          string `form:"secret"`
+	ProvisioningUri string `form:"provisioningUri"`
+}
+
+type CreateApiTokenForm struct {
+	Name          string   `form:"name"`
+	Scopes        []string `form:"scopes"`
+	ExpiresInDays int      `form:"expiresInDays"`
+}
+
+type ErasureForm struct {
+	QuizHandling string `form:"quizHandling"`
+	TransferTo   string `form:"transferTo"`
+}
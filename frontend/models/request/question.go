@@ -5,3 +5,18 @@ type GenerateQuestionForm struct {
 	QuestionType string `form:"questionType"`
 	Context      string `form:"context"`
 }
+
+// The fields of the question editor. Answers are the four options of the choice
+// questions and Correct holds the letters of the correct ones, or "true" or
+// "false" for true or false questions. QuestionId is empty for new questions,
+// ResetReviewItems only applies to changed ones.
+type QuestionForm struct {
+	QuizId       string   `query:"quizId" form:"quizId"`
+	QuestionId   string   `query:"questionId" form:"questionId"`
+	QuestionType string   `query:"questionType" form:"questionType"`
+	Question     string   `query:"question" form:"question"`
+	Answers      []string `query:"answers" form:"answers"`
+	Correct      []string `query:"correct" form:"correct"`
+
+	ResetReviewItems bool `query:"resetReviewItems" form:"resetReviewItems"`
+}
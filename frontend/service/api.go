@@ -1,87 +1,194 @@
 package service
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/labstack/echo/v4"
 	"io"
 	"net/http"
-	"slices"
-	"spaced-ace/constants"
+	"spaced-ace/backendapi"
+	"spaced-ace/config"
+	"spaced-ace/logging"
 	"spaced-ace/models"
 	"spaced-ace/models/business"
-	"spaced-ace/models/external"
 	"spaced-ace/models/request"
+	"spaced-ace/tracing"
+	"strconv"
 )
 
+var backendConfig = config.Default().Backend
+
+func Init(cfg config.Backend) {
+	backendConfig = cfg
+}
+
+// Talks to the backend within the trace of the request it serves. The routes
+// and models come from the client generated from the backend's specification,
+// the service only adds the session and maps the results for the pages.
 type ApiService struct {
-	sessionCookie *http.Cookie
-	client        *http.Client
+	ctx                  context.Context
+	sessionCookie        *http.Cookie
+	renewedSessionCookie *http.Cookie
+	httpClient           *http.Client
+	client               *backendapi.ClientWithResponses
 }
 
-func NewApiService(sessionCookie *http.Cookie) *ApiService {
-	return &ApiService{
+func NewApiService(ctx context.Context, sessionCookie *http.Cookie) *ApiService {
+	a := &ApiService{
+		ctx:           ctx,
 		sessionCookie: sessionCookie,
-		client:        &http.Client{},
+		httpClient:    &http.Client{Transport: tracing.NewTransport(http.DefaultTransport)},
 	}
+	// Only the options can fail and setting the doer never does
+	a.client, _ = backendapi.NewClientWithResponses(backendConfig.Url, backendapi.WithHTTPClient(a))
+	return a
 }
 
-func (a *ApiService) getResponse(method, path string, requestBody any, responseBody interface{}) error {
-	data, err := json.Marshal(requestBody)
-	if err != nil {
-		return err
-	}
-
-	req, err := http.NewRequest(method, constants.BACKEND_URL+path, bytes.NewBuffer(data))
-	if err != nil {
-		return err
+// Talks to the backend on behalf of a browser that has no session yet, for the
+// logins and signups. The user agent and the address of the browser are
+// forwarded, so sessions are listed with the device they belong to and the
+// backend limits the attempts of the browser instead of this server.
+func NewBrowserApiService(c echo.Context) *ApiService {
+	a := NewApiService(c.Request().Context(), nil)
+	userAgent, address := c.Request().UserAgent(), c.RealIP()
+	forward := func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set(echo.HeaderXForwardedFor, address)
+		return nil
 	}
+	a.client, _ = backendapi.NewClientWithResponses(backendConfig.Url, backendapi.WithHTTPClient(a), backendapi.WithRequestEditorFn(forward))
+	return a
+}
 
+// Sends the requests of the generated client with the session of the user.
+// Error statuses are returned as an *ApiError, so the methods only have to
+// deal with the successful responses.
+func (a *ApiService) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set(echo.HeaderXRequestID, logging.RequestId(a.ctx))
 	if a.sessionCookie != nil {
 		req.AddCookie(a.sessionCookie)
 	}
 
-	resp, err := a.client.Do(req)
+	resp, err := a.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// The backend slides the session forward and sends the cookie again with the new expiry
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "session" {
+			a.renewedSessionCookie = cookie
+		}
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		return nil, newApiError(resp, bodyBytes)
+	}
 
-		var result map[string]string
-		err = json.Unmarshal(bodyBytes, &result)
-		if err != nil {
-			return err
-		}
+	return resp, nil
+}
 
-		return errors.New("error message: " + result["message"])
+// Returns the body of a successful response, which is only missing when the
+// backend answered with something its specification does not describe
+func expect[T any](body *T, status string) (*T, error) {
+	if body == nil {
+		return nil, fmt.Errorf("unexpected response from the backend: %s", status)
 	}
+	return body, nil
+}
 
-	err = json.NewDecoder(resp.Body).Decode(&responseBody)
-	if err != nil {
-		return err
+// Returned when the backend answers with an error status
+type ApiError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    json.RawMessage
+	// Id of the request in the logs of both services
+	RequestId string
+	// Seconds to wait before trying again, set when the request was rate limited
+	RetryAfter int
+}
+
+// Reads the error model of the backend. A body that is not one, e.g. the page
+// of a proxy in between, still leaves the status to go by.
+func newApiError(resp *http.Response, body []byte) *ApiError {
+	var result struct {
+		backendapi.Error
+		Details json.RawMessage `json:"details"`
+	}
+	_ = json.Unmarshal(body, &result)
+	requestId := ""
+	if result.RequestId != nil {
+		requestId = *result.RequestId
+	}
+	if requestId == "" {
+		requestId = resp.Header.Get(echo.HeaderXRequestID)
+	}
+	retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+	return &ApiError{
+		StatusCode: resp.StatusCode,
+		Code:       string(result.Code),
+		Message:    result.Message,
+		Details:    result.Details,
+		RequestId:  requestId,
+		RetryAfter: retryAfter,
+	}
+}
+
+// Returns the message to show to the user. The messages of the client errors
+// are written for them, for everything else they only get the reference to
+// quote when reporting it.
+func (e *ApiError) Error() string {
+	if e.StatusCode < http.StatusInternalServerError && e.Message != "" {
+		return e.Message
+	}
+	if e.RequestId == "" {
+		return "something went wrong, please try again"
+	}
+	return fmt.Sprintf("something went wrong, please try again (reference %s)", e.RequestId)
+}
+
+// Returns the exhausted period when the request failed because the
+// question generation quota of the user is used up
+func QuotaExceeded(err error) (*business.UsagePeriod, bool) {
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.Code != string(backendapi.ErrorCodeQuotaExceeded) {
+		return nil, false
+	}
+	var details backendapi.QuotaExceededDetails
+	if err := json.Unmarshal(apiErr.Details, &details); err != nil {
+		return nil, false
 	}
+	return details.MapToBusiness(), true
+}
 
-	return nil
+// Returns the session cookie renewed by the backend during this request, if any
+func (a *ApiService) RenewedSessionCookie() *http.Cookie {
+	return a.renewedSessionCookie
 }
 
 func (a *ApiService) GetSession() (*business.Session, error) {
-	session := new(business.Session)
-	if err := a.getResponse("GET", "/authenticated", nil, session); err != nil {
+	resp, err := a.client.GetAuthenticatedWithResponse(a.ctx)
+	if err != nil {
 		return nil, err
 	}
-	return session, nil
+	session, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return session.MapToBusiness(), nil
 }
 
 func (a *ApiService) DeleteSession() error {
-	return a.getResponse("POST", "/logout", nil, nil)
+	_, err := a.client.LogoutWithResponse(a.ctx)
+	return err
 }
 
 func (a *ApiService) GetQuiz(quizId string) (*business.Quiz, error) {
@@ -89,451 +196,1027 @@ func (a *ApiService) GetQuiz(quizId string) (*business.Quiz, error) {
 		return nil, echo.NewHTTPError(400, fmt.Sprintf("Invalid quiz id: %s", quizId))
 	}
 
-	quizDTO := new(external.Quiz)
-	if err := a.getResponse("GET", "/quizzes/"+quizId, nil, quizDTO); err != nil {
+	resp, err := a.client.GetQuizWithResponse(a.ctx, quizId)
+	if err != nil {
 		return nil, err
 	}
-
-	var questions []interface{}
-	for _, rawQuestion := range quizDTO.Questions {
-		var questionDto map[string]interface{}
-		if err := json.Unmarshal(rawQuestion, &questionDto); err != nil {
-			continue
-		}
-		questionType := models.ParseFloatToQuestionType(questionDto["questionType"].(float64))
-
-		if questionType == models.SingleChoice {
-			var questionDto external.SingleChoiceQuestionResponseBody
-			if err := json.Unmarshal(rawQuestion, &questionDto); err != nil {
-				continue
-			}
-			question, err := questionDto.MapToBusiness()
-			if err != nil {
-				continue
-			}
-			questions = append(questions, question)
-		}
-
-		if questionType == models.MultipleChoice {
-			var questionDto external.MultipleChoiceQuestionResponseBody
-			if err := json.Unmarshal(rawQuestion, &questionDto); err != nil {
-				continue
-			}
-			question, err := questionDto.MapToBusiness()
-			if err != nil {
-				continue
-			}
-			questions = append(questions, question)
-		}
-
-		if questionType == models.TrueOrFalse {
-			var questionDto external.TrueOrFalseQuestionResponseBody
-			if err := json.Unmarshal(rawQuestion, &questionDto); err != nil {
-				continue
-			}
-			question, err := questionDto.MapToBusiness()
-			if err != nil {
-				continue
-			}
-			questions = append(questions, question)
-		}
-	}
-
-	// Reverse the order of questions
-	slices.Reverse(questions)
-
-	quiz := &business.Quiz{
-		QuizInfo: business.QuizInfo{
-			Id:          quizDTO.Id,
-			Title:       quizDTO.Title,
-			Description: quizDTO.Description,
-			CreatorId:   quizDTO.CreatorId,
-			CreatorName: quizDTO.CreatorName,
-		},
-		Questions: questions,
+	quiz, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
 	}
-
-	return quiz, nil
+	return quiz.MapToBusiness(), nil
 }
 
 func (a *ApiService) GenerateSingleChoiceQuestion(quizId, context string) (*business.SingleChoiceQuestion, error) {
-	requestBody := external.GenerateQuestionRequestBody{
+	requestBody := backendapi.GenerateQuestionRequest{
 		QuizId: quizId,
 		Prompt: context,
 	}
 
-	questionDTO := new(external.SingleChoiceQuestionResponseBody)
-	if err := a.getResponse("POST", "/questions/single-choice", requestBody, questionDTO); err != nil {
+	resp, err := a.client.CreateSingleChoiceQuestionWithResponse(a.ctx, requestBody)
+	if err != nil {
 		return nil, err
 	}
-
-	return questionDTO.MapToBusiness()
+	question, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return question.MapToBusiness()
 }
 func (a *ApiService) GenerateMultipleChoiceQuestion(quizId, context string) (*business.MultipleChoiceQuestion, error) {
-	requestBody := external.GenerateQuestionRequestBody{
+	requestBody := backendapi.GenerateQuestionRequest{
 		QuizId: quizId,
 		Prompt: context,
 	}
 
-	questionDTO := new(external.MultipleChoiceQuestionResponseBody)
-	if err := a.getResponse("POST", "/questions/multiple-choice", requestBody, questionDTO); err != nil {
+	resp, err := a.client.CreateMultipleChoiceQuestionWithResponse(a.ctx, requestBody)
+	if err != nil {
 		return nil, err
 	}
-
-	return questionDTO.MapToBusiness()
+	question, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return question.MapToBusiness()
 }
 func (a *ApiService) GenerateTrueOrFalseQuestion(quizId, context string) (*business.TrueOrFalseQuestion, error) {
-	requestBody := external.GenerateQuestionRequestBody{
+	requestBody := backendapi.GenerateQuestionRequest{
 		QuizId: quizId,
 		Prompt: context,
 	}
 
-	questionDTO := new(external.TrueOrFalseQuestionResponseBody)
-	if err := a.getResponse("POST", "/questions/true-or-false", requestBody, questionDTO); err != nil {
+	resp, err := a.client.CreateTrueOrFalseQuestionWithResponse(a.ctx, requestBody)
+	if err != nil {
 		return nil, err
 	}
-
-	return questionDTO.MapToBusiness()
+	question, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return question.MapToBusiness(), nil
 }
 func (a *ApiService) DeleteQuestion(questionType, quizId, questionId string) error {
-	return a.getResponse("DELETE", fmt.Sprintf("/questions/%s/%s/%s", questionType, quizId, questionId), nil, nil)
+	var err error
+	switch questionType {
+	case models.SingleChoiceQuestion:
+		_, err = a.client.DeleteSingleChoiceQuestionWithResponse(a.ctx, quizId, questionId)
+	case models.MultipleChoiceQuestion:
+		_, err = a.client.DeleteMultipleChoiceQuestionWithResponse(a.ctx, quizId, questionId)
+	case models.TrueOrFalseQuestion:
+		_, err = a.client.DeleteTrueOrFalseQuestionWithResponse(a.ctx, quizId, questionId)
+	default:
+		err = fmt.Errorf("unknown question type %q", questionType)
+	}
+	return err
 }
 
-func (a *ApiService) GetQuizzesInfos(userId string) ([]business.QuizInfo, error) {
-	quizzesDTO := new(external.QuizInfosResponse)
-	err := a.getResponse("GET", "/quizzes/user/"+userId, nil, quizzesDTO)
+// Returns the question as its business model, the way the questions of
+// business.Quiz are
+func (a *ApiService) GetQuestion(questionType, questionId string) (interface{}, error) {
+	switch questionType {
+	case models.SingleChoiceQuestion:
+		resp, err := a.client.GetSingleChoiceQuestionWithResponse(a.ctx, questionId)
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.MultipleChoiceQuestion:
+		resp, err := a.client.GetMultipleChoiceQuestionWithResponse(a.ctx, questionId)
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.TrueOrFalseQuestion:
+		resp, err := a.client.GetTrueOrFalseQuestionWithResponse(a.ctx, questionId)
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness(), nil
+	}
+	return nil, fmt.Errorf("unknown question type %q", questionType)
+}
+
+// Creates the question written in the editor without the llm
+func (a *ApiService) CreateQuestion(form request.QuestionForm) (interface{}, error) {
+	switch form.QuestionType {
+	case models.SingleChoiceQuestion:
+		resp, err := a.client.CreateManualSingleChoiceQuestionWithResponse(a.ctx, singleChoiceUpdate(form))
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.MultipleChoiceQuestion:
+		resp, err := a.client.CreateManualMultipleChoiceQuestionWithResponse(a.ctx, multipleChoiceUpdate(form))
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.TrueOrFalseQuestion:
+		resp, err := a.client.CreateManualTrueOrFalseQuestionWithResponse(a.ctx, trueOrFalseUpdate(form))
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness(), nil
+	}
+	return nil, fmt.Errorf("unknown question type %q", form.QuestionType)
+}
+
+func (a *ApiService) UpdateQuestion(form request.QuestionForm) (interface{}, error) {
+	switch form.QuestionType {
+	case models.SingleChoiceQuestion:
+		resp, err := a.client.UpdateSingleChoiceQuestionWithResponse(a.ctx, form.QuestionId, &backendapi.UpdateSingleChoiceQuestionParams{ResetReviewItems: &form.ResetReviewItems}, singleChoiceUpdate(form))
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.MultipleChoiceQuestion:
+		resp, err := a.client.UpdateMultipleChoiceQuestionWithResponse(a.ctx, form.QuestionId, &backendapi.UpdateMultipleChoiceQuestionParams{ResetReviewItems: &form.ResetReviewItems}, multipleChoiceUpdate(form))
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness()
+	case models.TrueOrFalseQuestion:
+		resp, err := a.client.UpdateTrueOrFalseQuestionWithResponse(a.ctx, form.QuestionId, &backendapi.UpdateTrueOrFalseQuestionParams{ResetReviewItems: &form.ResetReviewItems}, trueOrFalseUpdate(form))
+		if err != nil {
+			return nil, err
+		}
+		question, err := expect(resp.JSON200, resp.Status())
+		if err != nil {
+			return nil, err
+		}
+		return question.MapToBusiness(), nil
+	}
+	return nil, fmt.Errorf("unknown question type %q", form.QuestionType)
+}
+
+// Returns the revisions of the question newest first
+func (a *ApiService) GetQuestionRevisions(questionId string) ([]business.QuestionRevision, error) {
+	resp, err := a.client.GetQuestionRevisionsWithResponse(a.ctx, questionId)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]business.QuestionRevision, 0, len(*revisions))
+	for _, revision := range *revisions {
+		m, err := revision.MapToBusiness()
+		if err != nil {
+			return nil, err
+		}
+		mapped = append(mapped, m)
+	}
+	return mapped, nil
+}
+
+// Compares the revision with the latest one
+func (a *ApiService) GetQuestionRevisionDiff(questionId string, from int) (*business.QuestionRevisionDiff, error) {
+	resp, err := a.client.GetQuestionRevisionDiffWithResponse(a.ctx, questionId, &backendapi.GetQuestionRevisionDiffParams{From: from})
+	if err != nil {
+		return nil, err
+	}
+	diff, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
 		return nil, err
 	}
+	return diff.MapToBusiness(), nil
+}
 
-	var quizInfos []business.QuizInfo
-	for _, q := range quizzesDTO.Quizzes {
-		quizInfos = append(quizInfos, business.QuizInfo{
-			Id:          q.Id,
-			Title:       q.Title,
-			Description: q.Description,
-			CreatorId:   q.CreatorId,
-			CreatorName: q.CreatorName,
-		})
+func (a *ApiService) RestoreQuestionRevision(questionId string, revision int, resetReviewItems bool) (interface{}, error) {
+	resp, err := a.client.RestoreQuestionRevisionWithResponse(a.ctx, questionId, revision, &backendapi.RestoreQuestionRevisionParams{ResetReviewItems: &resetReviewItems})
+	if err != nil {
+		return nil, err
+	}
+	question, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return question.MapToBusiness()
+}
+
+// Approves or rejects a question of the review queue
+func (a *ApiService) UpdateQuestionStatus(questionId, status string) (interface{}, error) {
+	resp, err := a.client.UpdateQuestionStatusWithResponse(a.ctx, questionId, backendapi.QuestionStatusRequest{Status: backendapi.QuestionStatusRequestStatus(status)})
+	if err != nil {
+		return nil, err
+	}
+	question, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
 	}
+	return question.MapToBusiness()
+}
 
-	return quizInfos, err
+func singleChoiceUpdate(form request.QuestionForm) backendapi.SingleChoiceQuestionUpdate {
+	correctAnswer := ""
+	if len(form.Correct) > 0 {
+		correctAnswer = form.Correct[0]
+	}
+	return backendapi.SingleChoiceQuestionUpdate{
+		QuizId:        form.QuizId,
+		Question:      form.Question,
+		Answers:       form.Answers,
+		CorrectAnswer: correctAnswer,
+	}
+}
+func multipleChoiceUpdate(form request.QuestionForm) backendapi.MultipleChoiceQuestionUpdate {
+	return backendapi.MultipleChoiceQuestionUpdate{
+		QuizId:         form.QuizId,
+		Question:       form.Question,
+		Answers:        form.Answers,
+		CorrectAnswers: form.Correct,
+	}
+}
+func trueOrFalseUpdate(form request.QuestionForm) backendapi.TrueOrFalseQuestionUpdate {
+	return backendapi.TrueOrFalseQuestionUpdate{
+		QuizId:        form.QuizId,
+		Question:      form.Question,
+		CorrectAnswer: len(form.Correct) > 0 && form.Correct[0] == "true",
+	}
+}
+
+func (a *ApiService) GetQuizzesInfos(userId string) ([]business.QuizInfo, error) {
+	resp, err := a.client.GetQuizzesOfUserWithResponse(a.ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	quizzes, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return quizzes.MapToBusiness(), nil
 }
 func (a *ApiService) CreateQuiz(title, description string) (*business.QuizInfo, error) {
-	requestBody := external.CreateQuizRequestBody{
+	requestBody := backendapi.QuizRequest{
 		Name:        title,
-		Description: description,
+		Description: &description,
 	}
 
-	quizInfoDto := new(external.QuizInfo)
-	err := a.getResponse("POST", "/quizzes/create", requestBody, quizInfoDto)
+	resp, err := a.client.CreateQuizWithResponse(a.ctx, requestBody)
 	if err != nil {
 		return nil, err
 	}
-
-	return &business.QuizInfo{
-		Id:          quizInfoDto.Id,
-		Title:       quizInfoDto.Title,
-		Description: quizInfoDto.Description,
-		CreatorId:   quizInfoDto.CreatorId,
-		CreatorName: quizInfoDto.CreatorName,
-	}, nil
+	quizInfo, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	mapped := quizInfo.MapToBusiness()
+	return &mapped, nil
 }
 func (a *ApiService) UpdateQuiz(quizId, title, description string) (*business.QuizInfo, error) {
-	requestBody := &external.UpdateQuizRequestBody{
-		Title:       title,
-		Description: description,
+	requestBody := backendapi.QuizRequest{
+		Name:        title,
+		Description: &description,
 	}
 
-	quizInfoDto := new(external.QuizInfo)
-	if err := a.getResponse("PATCH", "/quizzes/"+quizId, requestBody, quizInfoDto); err != nil {
+	resp, err := a.client.UpdateQuizWithResponse(a.ctx, quizId, requestBody)
+	if err != nil {
 		return nil, err
 	}
-
-	return &business.QuizInfo{
-		Id:          quizInfoDto.Id,
-		Title:       quizInfoDto.Title,
-		Description: quizInfoDto.Description,
-		CreatorId:   quizInfoDto.CreatorId,
-		CreatorName: quizInfoDto.CreatorName,
-	}, nil
+	quizInfo, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	mapped := quizInfo.MapToBusiness()
+	return &mapped, nil
 }
 func (a *ApiService) DeleteQuiz(quizId string) error {
-	return a.getResponse("DELETE", "/quizzes/"+quizId, nil, nil)
+	_, err := a.client.DeleteQuizWithResponse(a.ctx, quizId)
+	return err
 }
 
-func (a *ApiService) CreateQuizSession(userId, quizId string) (*business.QuizSession, error) {
-	requestBody := &external.CreateQuizSessionRequestBody{
-		UserID: userId,
-		QuizID: quizId,
+// Gives learners the approved questions of the quiz
+func (a *ApiService) PublishQuiz(quizId string) (*business.Quiz, error) {
+	resp, err := a.client.PublishQuizWithResponse(a.ctx, quizId)
+	if err != nil {
+		return nil, err
 	}
-
-	quizSessionDto := new(external.QuizSession)
-	if err := a.getResponse("POST", "/quiz-sessions/start", requestBody, quizSessionDto); err != nil {
+	quiz, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
 		return nil, err
 	}
-
-	return quizSessionDto.MapToBusiness()
+	return quiz.MapToBusiness(), nil
 }
-func (a *ApiService) GetQuizSessions(userId, quizId string) ([]*business.QuizSession, error) {
-	quizSessionsResponse := new(external.GetQuizSessionsResponseBody)
-	if err := a.getResponse("GET", fmt.Sprintf("/quiz-sessions?userId=%s&quizId=%s", userId, quizId), nil, quizSessionsResponse); err != nil {
+func (a *ApiService) ArchiveQuiz(quizId string) (*business.Quiz, error) {
+	resp, err := a.client.ArchiveQuizWithResponse(a.ctx, quizId)
+	if err != nil {
+		return nil, err
+	}
+	quiz, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
 		return nil, err
 	}
+	return quiz.MapToBusiness(), nil
+}
 
-	quizSessions := make([]*business.QuizSession, quizSessionsResponse.Length)
-	for i, quizSessionDto := range quizSessionsResponse.QuizSessions {
-		quizSession, err := quizSessionDto.MapToBusiness()
-		if err != nil {
-			return nil, err
-		}
-		quizSessions[i] = quizSession
+// The sessions always belong to the signed in user
+func (a *ApiService) CreateQuizSession(quizId string) (*business.QuizSession, error) {
+	resp, err := a.client.StartQuizSessionWithResponse(a.ctx, backendapi.StartQuizSessionRequest{QuizId: quizId})
+	if err != nil {
+		return nil, err
+	}
+	quizSession, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return quizSession.MapToBusiness(), nil
+}
+func (a *ApiService) GetQuizSessions(quizId string) ([]*business.QuizSession, error) {
+	resp, err := a.client.GetQuizSessionsWithResponse(a.ctx, &backendapi.GetQuizSessionsParams{QuizId: quizId})
+	if err != nil {
+		return nil, err
+	}
+	list, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
 	}
 
+	quizSessions := make([]*business.QuizSession, 0, len(list.QuizSessions))
+	for _, quizSession := range list.QuizSessions {
+		quizSessions = append(quizSessions, quizSession.MapToBusiness())
+	}
 	return quizSessions, nil
 }
 func (a *ApiService) GetQuizSession(quizSessionId string) (*business.QuizSession, error) {
-	quizSessionResponse := new(external.QuizSession)
-	if err := a.getResponse("GET", fmt.Sprintf("/quiz-sessions/%s", quizSessionId), nil, quizSessionResponse); err != nil {
+	resp, err := a.client.GetQuizSessionWithResponse(a.ctx, quizSessionId)
+	if err != nil {
 		return nil, err
 	}
-
-	quizSession, err := quizSessionResponse.MapToBusiness()
+	quizSession, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
 		return nil, err
 	}
-
-	return quizSession, nil
+	return quizSession.MapToBusiness(), nil
 }
-func (a *ApiService) HasQuizSession(userId, quizId string) (bool, error) {
-	if err := a.getResponse("GET", fmt.Sprintf("/quiz-sessions/has-session?userId=%s&quizId=%s", userId, quizId), nil, nil); err != nil {
+func (a *ApiService) HasQuizSession(quizId string) (bool, error) {
+	_, err := a.client.HasOpenQuizSessionWithResponse(a.ctx, &backendapi.HasOpenQuizSessionParams{QuizId: quizId})
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
 		return false, nil
 	}
+	if err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
 func (a *ApiService) SubmitQuiz(quizSessionId string) (*business.QuizResult, error) {
-	quizResultDto := new(external.QuizResult)
-	if err := a.getResponse("POST", fmt.Sprintf("/quiz-sessions/%s/submit", quizSessionId), nil, &quizResultDto); err != nil {
+	resp, err := a.client.SubmitQuizSessionWithResponse(a.ctx, quizSessionId)
+	if err != nil {
 		return nil, fmt.Errorf("error submitting quiz session with ID `%s`: %w", quizSessionId, err)
 	}
-
-	quizResult, err := quizResultDto.MapToBusiness()
+	quizResult, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
-		return nil, fmt.Errorf("error mapping quiz result with ID `%s`: %w", quizResultDto.ID, err)
+		return nil, err
 	}
-
-	return quizResult, err
+	return quizResult.MapToBusiness(), nil
 }
 func (a *ApiService) GetQuizResult(quizSessionId string) (*business.QuizResult, error) {
-	quizResultDto := new(external.QuizResult)
-	if err := a.getResponse("GET", fmt.Sprintf("/quiz-sessions/%s/result", quizSessionId), nil, &quizResultDto); err != nil {
+	resp, err := a.client.GetQuizResultWithResponse(a.ctx, quizSessionId)
+	if err != nil {
 		return nil, fmt.Errorf("error getting quiz result for session with ID `%s`: %w", quizSessionId, err)
 	}
-
-	quizResult, err := quizResultDto.MapToBusiness()
+	quizResult, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
-		return nil, fmt.Errorf("error mapping quiz result with ID `%s`: %w", quizResultDto.ID, err)
+		return nil, err
 	}
-
-	return quizResult, err
+	return quizResult.MapToBusiness(), nil
 }
 
 func (a *ApiService) GetAnswers(quizSessionId string) (*business.AnswerLists, error) {
-	answersResponse := new(external.AnswersResponse)
-	if err := a.getResponse("GET", fmt.Sprintf("/quiz-sessions/%s/answers", quizSessionId), nil, &answersResponse); err != nil {
+	resp, err := a.client.GetAnswersWithResponse(a.ctx, quizSessionId)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
 		return nil, err
 	}
+	return answers.MapToBusiness(), nil
+}
 
-	answerLists, err := answersResponse.MapToBusiness()
+// Stores the answer of the question in the session, the answer of the
+// backend is the stored one in the model of its type
+func (a *ApiService) putAnswer(quizSessionId string, requestBody backendapi.AnswerRequest) (*backendapi.Answer, error) {
+	resp, err := a.client.PutAnswerWithResponse(a.ctx, quizSessionId, requestBody)
 	if err != nil {
 		return nil, err
 	}
-	return answerLists, nil
+	return expect(resp.JSON200, resp.Status())
 }
 func (a *ApiService) CreateOrUpdateSingleChoiceAnswer(quizSessionId, questionId string, answer string) (*business.SingleChoiceAnswer, error) {
-	requestBody := external.NewSingleChoiceAnswerRequestBody(questionId, answer)
-
-	responseBody := new(external.SingleChoiceAnswer)
-	if err := a.getResponse("PUT", fmt.Sprintf("/quiz-sessions/%s/answers", quizSessionId), requestBody, responseBody); err != nil {
+	var requestBody backendapi.AnswerRequest
+	if err := requestBody.FromSingleChoiceAnswerRequest(backendapi.SingleChoiceAnswerRequest{QuestionId: questionId, Answer: answer}); err != nil {
 		return nil, err
 	}
 
-	singleChoiceAnswer, err := responseBody.MapToBusiness()
+	stored, err := a.putAnswer(quizSessionId, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	singleChoiceAnswer, err := stored.AsSingleChoiceAnswer()
 	if err != nil {
 		return nil, err
 	}
-	return singleChoiceAnswer, nil
+	return singleChoiceAnswer.MapToBusiness(), nil
 }
 func (a *ApiService) CreateOrUpdateMultipleChoiceAnswer(quizSessionId, questionId string, answers []string) (*business.MultipleChoiceAnswer, error) {
-	requestBody := external.NewMultipleChoiceAnswerRequestBody(questionId, answers)
-
-	responseBody := new(external.MultipleChoiceAnswer)
-	if err := a.getResponse("PUT", fmt.Sprintf("/quiz-sessions/%s/answers", quizSessionId), requestBody, responseBody); err != nil {
+	var requestBody backendapi.AnswerRequest
+	if err := requestBody.FromMultipleChoiceAnswerRequest(backendapi.MultipleChoiceAnswerRequest{QuestionId: questionId, Answers: answers}); err != nil {
 		return nil, err
 	}
 
-	multipleChoiceAnswer, err := responseBody.MapToBusiness()
+	stored, err := a.putAnswer(quizSessionId, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	multipleChoiceAnswer, err := stored.AsMultipleChoiceAnswer()
 	if err != nil {
 		return nil, err
 	}
-	return multipleChoiceAnswer, nil
+	return multipleChoiceAnswer.MapToBusiness(), nil
 }
 func (a *ApiService) CreateOrUpdateTrueOrFalseAnswer(quizSessionId, questionId string, answer bool) (*business.TrueOrFalseAnswer, error) {
-	requestBody := external.NewTrueOrFalseAnswerRequestBody(questionId, answer)
-
-	responseBody := new(external.TrueOrFalseAnswer)
-	if err := a.getResponse("PUT", fmt.Sprintf("/quiz-sessions/%s/answers", quizSessionId), requestBody, responseBody); err != nil {
+	var requestBody backendapi.AnswerRequest
+	if err := requestBody.FromTrueOrFalseAnswerRequest(backendapi.TrueOrFalseAnswerRequest{QuestionId: questionId, Answer: &answer}); err != nil {
 		return nil, err
 	}
 
-	trueOrFalseAnswer, err := responseBody.MapToBusiness()
+	stored, err := a.putAnswer(quizSessionId, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	trueOrFalseAnswer, err := stored.AsTrueOrFalseAnswer()
 	if err != nil {
 		return nil, err
 	}
-	return trueOrFalseAnswer, nil
+	return trueOrFalseAnswer.MapToBusiness(), nil
 }
 
 func (a *ApiService) GetQuizHistory(userID string) ([]business.QuizHistoryEntry, error) {
-	responseBody := new(external.QuizHistoryEntriesResponseBody)
-	if err := a.getResponse("GET", fmt.Sprintf("/quiz-history?userID=%s", userID), nil, responseBody); err != nil {
+	resp, err := a.client.GetQuizHistoryWithResponse(a.ctx, &backendapi.GetQuizHistoryParams{UserID: userID})
+	if err != nil {
 		return []business.QuizHistoryEntry{}, err
 	}
-
-	entries := make([]business.QuizHistoryEntry, 0, responseBody.Length)
-	for _, e := range responseBody.QuizHistoryEntries {
-		entry, err := e.MapToBusiness()
-		if err != nil {
-			return []business.QuizHistoryEntry{}, err
-		}
-		entries = append(entries, *entry)
+	history, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return []business.QuizHistoryEntry{}, err
 	}
 
+	entries := make([]business.QuizHistoryEntry, 0, len(history.QuizHistoryEntries))
+	for _, entry := range history.QuizHistoryEntries {
+		entries = append(entries, entry.MapToBusiness())
+	}
 	return entries, nil
 }
 
 func (a *ApiService) GetLearnList() (*business.LearnList, error) {
-	responseBody := new(external.LearnListResponseBody)
-	if err := a.getResponse("GET", "/learn-list", nil, responseBody); err != nil {
+	resp, err := a.client.GetLearnListWithResponse(a.ctx)
+	if err != nil {
 		return nil, err
 	}
-
-	learnList, err := responseBody.MapToBusiness()
+	learnList, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
 		return nil, err
 	}
-
-	return learnList, nil
+	return learnList.MapToBusiness(), nil
 }
 func (a *ApiService) AddQuizToLearnList(quizID string) (*business.LearnList, error) {
-	responseBody := new(external.LearnListResponseBody)
-	if err := a.getResponse("POST", fmt.Sprintf("/learn-list/%s/add", quizID), nil, responseBody); err != nil {
+	resp, err := a.client.AddQuizToLearnListWithResponse(a.ctx, quizID)
+	if err != nil {
 		return nil, err
 	}
-
-	learnList, err := responseBody.MapToBusiness()
+	learnList, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
 		return nil, err
 	}
-
-	return learnList, nil
+	return learnList.MapToBusiness(), nil
 }
 func (a *ApiService) RemoveQuizFromLearnList(quizID string) (*business.LearnList, error) {
-	responseBody := new(external.LearnListResponseBody)
-	if err := a.getResponse("POST", fmt.Sprintf("/learn-list/%s/remove", quizID), nil, responseBody); err != nil {
+	resp, err := a.client.RemoveQuizFromLearnListWithResponse(a.ctx, quizID)
+	if err != nil {
 		return nil, err
 	}
-
-	learnList, err := responseBody.MapToBusiness()
+	learnList, err := expect(resp.JSON200, resp.Status())
 	if err != nil {
 		return nil, err
 	}
-
-	return learnList, nil
+	return learnList.MapToBusiness(), nil
 }
 
 func (a *ApiService) GetReviewItemListData(quizID, difficulty, status, query string, page int) (reviewItems []business.ReviewItem, quizOptions []business.Option, maxReviewItemCount int, err error) {
-	reviewItemsRequestBody := external.ReviewItemsRequestBody{
-		QuizID:     quizID,
-		Difficulty: difficulty,
-		Status:     status,
-		Page:       page,
-		Query:      query,
+	filterDifficulty := backendapi.ReviewItemFilterDifficulty(difficulty)
+	filterStatus := backendapi.ReviewItemFilterStatus(status)
+	filter := backendapi.ReviewItemFilter{
+		Quiz:       &quizID,
+		Difficulty: &filterDifficulty,
+		Status:     &filterStatus,
+		Page:       &page,
+		Query:      &query,
 	}
 
-	reviewItemsResponseBody := new(external.ReviewItemResponseBody)
-	if err = a.getResponse("GET", "/review-items", reviewItemsRequestBody, reviewItemsResponseBody); err != nil {
+	reviewItemsResp, err := a.client.GetReviewItemsWithResponse(a.ctx, filter)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("getting review items: %w\n", err)
+	}
+	reviewItemPage, err := expect(reviewItemsResp.JSON200, reviewItemsResp.Status())
+	if err != nil {
 		return nil, nil, 0, fmt.Errorf("getting review items: %w\n", err)
 	}
 
-	reviewItems = make([]business.ReviewItem, 0, len(reviewItemsResponseBody.ReviewItems))
-	for _, item := range reviewItemsResponseBody.ReviewItems {
+	reviewItems = make([]business.ReviewItem, 0, len(reviewItemPage.ReviewItems))
+	for _, item := range reviewItemPage.ReviewItems {
 		reviewItem, err := item.MapToBusiness()
 		if err != nil {
-			return nil, nil, 0, fmt.Errorf("mapping review item %+v: %w\n", reviewItem, err)
+			return nil, nil, 0, fmt.Errorf("mapping review item %+v: %w\n", item, err)
 		}
 		reviewItems = append(reviewItems, *reviewItem)
 	}
 
-	maxReviewItemCount = reviewItemsResponseBody.ReviewItemCountForFilter
+	maxReviewItemCount = reviewItemPage.ReviewItemCountForFilter
 
-	quizOptionsResponseBody := new(external.QuizOptionsResponseBody)
-	if err = a.getResponse("GET", "/review-items/quiz-options", nil, quizOptionsResponseBody); err != nil {
+	quizOptionsResp, err := a.client.GetQuizOptionsWithResponse(a.ctx)
+	if err != nil {
 		return nil, nil, 0, fmt.Errorf("getting quiz options: %w\n", err)
 	}
-
-	quizOptions = make([]business.Option, 0, len(quizOptionsResponseBody.QuizOptions))
-	for _, option := range quizOptionsResponseBody.QuizOptions {
-		quizOption, err := option.MapToBusiness()
-		if err != nil {
-			return nil, nil, 0, fmt.Errorf("mapping quiz options: %w\n", err)
-		}
-		quizOptions = append(quizOptions, *quizOption)
+	options, err := expect(quizOptionsResp.JSON200, quizOptionsResp.Status())
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("getting quiz options: %w\n", err)
 	}
 
-	return reviewItems, quizOptions, maxReviewItemCount, nil
+	return reviewItems, options.MapToBusiness(), maxReviewItemCount, nil
 }
 func (a *ApiService) GetReviewItemCounts() (total int, dueToReview int, err error) {
-	response := new(external.ReviewItemCountsResponseBody)
-	if err := a.getResponse("GET", "/review-items/item-counts", nil, response); err != nil {
+	resp, err := a.client.GetReviewItemCountsWithResponse(a.ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	counts, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
 		return 0, 0, err
 	}
-	return response.Total, response.DueToReview, nil
+	return counts.Total, counts.DueToReview, nil
 }
+
+// Returns the question of the review item, or of the next one due when the id is empty
 func (a *ApiService) GetReviewItemQuestion(reviewItemID string) (*business.ReviewItemQuestionData, error) {
-	url := "/review-items/get-question"
-	if reviewItemID != "" {
-		url = fmt.Sprintf("%s/%s", url, reviewItemID)
+	var question *backendapi.ReviewItemQuestion
+	if reviewItemID == "" {
+		resp, err := a.client.GetNextReviewItemQuestionWithResponse(a.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting question for the next review item: %w\n", err)
+		}
+		question = resp.JSON200
+	} else {
+		resp, err := a.client.GetReviewItemQuestionWithResponse(a.ctx, reviewItemID)
+		if err != nil {
+			return nil, fmt.Errorf("getting question for review item with ID %q: %w\n", reviewItemID, err)
+		}
+		question = resp.JSON200
 	}
-
-	requestBody := new(external.ReviewItemQuestionResponseBody)
-	if err := a.getResponse("GET", url, nil, requestBody); err != nil {
-		return nil, fmt.Errorf("getting question for review item with ID %q: %w\n", reviewItemID, err)
+	if question == nil {
+		return nil, fmt.Errorf("getting question for review item with ID %q: unexpected response from the backend\n", reviewItemID)
 	}
 
-	reviewItemPageData, err := requestBody.MapToBusiness()
+	reviewItemPageData, err := question.MapToBusiness()
 	if err != nil {
 		return nil, fmt.Errorf("mapping review item page data: %w\n", err)
 	}
-
 	return reviewItemPageData, nil
 }
 func (a *ApiService) SubmitReviewItemQuestion(reviewItemID string, form request.SubmitReviewItemQuestionForm) (*business.ReviewItem, error) {
-	requestBody := external.SubmitReviewItemQuestionRequestBody{
-		SingleChoiceValue:   form.SingleChoiceValue,
-		MultipleChoiceValue: form.MultipleChoiceValue,
-		TrueOrFalseValue:    form.TrueOrFalseValue,
+	requestBody := backendapi.ReviewItemAnswer{
+		SingleChoiceValue:   &form.SingleChoiceValue,
+		MultipleChoiceValue: &form.MultipleChoiceValue,
+		TrueOrFalseValue:    &form.TrueOrFalseValue,
+	}
+
+	resp, err := a.client.SubmitReviewItemWithResponse(a.ctx, reviewItemID, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	reviewItem, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return reviewItem.MapToBusiness()
+}
+
+func (a *ApiService) GetTotpStatus() (*business.TotpStatus, error) {
+	resp, err := a.client.GetTotpStatusWithResponse(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return status.MapToBusiness(), nil
+}
+func (a *ApiService) EnrollTotp() (*business.TotpEnrollment, error) {
+	resp, err := a.client.EnrollTotpWithResponse(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return enrollment.MapToBusiness(), nil
+}
+func (a *ApiService) ConfirmTotp(code string) ([]string, error) {
+	resp, err := a.client.ConfirmTotpWithResponse(a.ctx, backendapi.TotpCodeRequest{Code: code})
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes.RecoveryCodes, nil
+}
+func (a *ApiService) DisableTotp(code string) error {
+	_, err := a.client.DisableTotpWithResponse(a.ctx, backendapi.TotpCodeRequest{Code: code})
+	return err
+}
+func (a *ApiService) RegenerateRecoveryCodes(code string) ([]string, error) {
+	resp, err := a.client.RegenerateRecoveryCodesWithResponse(a.ctx, backendapi.TotpCodeRequest{Code: code})
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes.RecoveryCodes, nil
+}
+func (a *ApiService) GetApiTokens() ([]business.ApiToken, error) {
+	resp, err := a.client.GetApiTokensWithResponse(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiTokens, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]business.ApiToken, 0, len(*apiTokens))
+	for _, token := range *apiTokens {
+		tokens = append(tokens, token.MapToBusiness())
+	}
+	return tokens, nil
+}
+func (a *ApiService) CreateApiToken(form request.CreateApiTokenForm) (string, error) {
+	requestBody := backendapi.CreateApiTokenRequest{
+		Name:          form.Name,
+		Scopes:        form.Scopes,
+		ExpiresInDays: &form.ExpiresInDays,
+	}
+	resp, err := a.client.CreateApiTokenWithResponse(a.ctx, requestBody)
+	if err != nil {
+		return "", err
+	}
+	created, err := expect(resp.JSON201, resp.Status())
+	if err != nil {
+		return "", err
+	}
+	return created.Token, nil
+}
+func (a *ApiService) RevokeApiToken(tokenId string) error {
+	_, err := a.client.RevokeApiTokenWithResponse(a.ctx, tokenId)
+	return err
+}
+func (a *ApiService) GetActiveSessions() ([]business.ActiveSession, error) {
+	resp, err := a.client.GetSessionsWithResponse(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	activeSessions, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]business.ActiveSession, 0, len(*activeSessions))
+	for _, session := range *activeSessions {
+		sessions = append(sessions, session.MapToBusiness())
+	}
+	return sessions, nil
+}
+func (a *ApiService) RevokeSession(sessionId string) error {
+	_, err := a.client.RevokeSessionWithResponse(a.ctx, sessionId)
+	return err
+}
+func (a *ApiService) RevokeOtherSessions() error {
+	_, err := a.client.RevokeOtherSessionsWithResponse(a.ctx)
+	return err
+}
+
+// Returns the zip of the user's data with the file name suggested by the backend
+func (a *ApiService) DownloadDataExport() ([]byte, string, error) {
+	resp, err := a.client.ExportDataWithResponse(a.ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Body, resp.HTTPResponse.Header.Get(echo.HeaderContentDisposition), nil
+}
+
+// Returns nil when the user has not asked for the deletion of the account
+func (a *ApiService) GetErasure() (*business.Erasure, error) {
+	resp, err := a.client.GetErasureWithResponse(a.ctx)
+	if err != nil {
+		var apiErr *ApiError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	erasure, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return erasure.MapToBusiness(), nil
+}
+func (a *ApiService) RequestErasure(form request.ErasureForm) (*business.Erasure, error) {
+	requestBody := backendapi.ErasureRequest{
+		QuizHandling: backendapi.ErasureRequestQuizHandling(form.QuizHandling),
+		TransferTo:   &form.TransferTo,
+	}
+	resp, err := a.client.RequestErasureWithResponse(a.ctx, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	erasure, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return erasure.MapToBusiness(), nil
+}
+func (a *ApiService) CancelErasure() error {
+	_, err := a.client.CancelErasureWithResponse(a.ctx)
+	return err
+}
+func (a *ApiService) ConfirmErasure(token string) (*business.Erasure, error) {
+	resp, err := a.client.ConfirmErasureWithResponse(a.ctx, backendapi.ConfirmErasureRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	erasure, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return erasure.MapToBusiness(), nil
+}
+func (a *ApiService) GetUsage() (*business.Usage, error) {
+	resp, err := a.client.GetUsageWithResponse(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return usage.MapToBusiness(), nil
+}
+func (a *ApiService) AdminSearchUsers(query string, page int) (*business.AdminUserPage, error) {
+	resp, err := a.client.AdminSearchUsersWithResponse(a.ctx, &backendapi.AdminSearchUsersParams{Q: &query, Page: &page})
+	if err != nil {
+		return nil, err
+	}
+	users, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return users.MapToBusiness(), nil
+}
+func (a *ApiService) AdminGetUser(userId string) (*business.AdminUser, error) {
+	resp, err := a.client.AdminGetUserWithResponse(a.ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	user, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return user.MapToBusiness(), nil
+}
+func (a *ApiService) AdminResendVerification(userId string) error {
+	_, err := a.client.AdminResendVerificationWithResponse(a.ctx, userId)
+	return err
+}
+func (a *ApiService) AdminVerifyEmail(userId string) error {
+	_, err := a.client.AdminVerifyEmailWithResponse(a.ctx, userId)
+	return err
+}
+func (a *ApiService) AdminDisableUser(userId string) error {
+	_, err := a.client.AdminDisableUserWithResponse(a.ctx, userId)
+	return err
+}
+func (a *ApiService) AdminEnableUser(userId string) error {
+	_, err := a.client.AdminEnableUserWithResponse(a.ctx, userId)
+	return err
+}
+func (a *ApiService) AdminResetTotp(userId string) error {
+	_, err := a.client.AdminResetTotpWithResponse(a.ctx, userId)
+	return err
+}
+func (a *ApiService) AdminSetRole(userId, role string) error {
+	requestBody := backendapi.SetRoleRequest{Role: backendapi.SetRoleRequestRole(role)}
+	_, err := a.client.AdminSetRoleWithResponse(a.ctx, userId, requestBody)
+	return err
+}
+func (a *ApiService) AdminImpersonate(userId string) (*business.Impersonation, error) {
+	resp, err := a.client.AdminImpersonateWithResponse(a.ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	impersonation, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
 	}
+	return impersonation.MapToBusiness(), nil
+}
+func (a *ApiService) AdminSearchQuizzes(query string, page int) (*business.AdminQuizPage, error) {
+	resp, err := a.client.AdminSearchQuizzesWithResponse(a.ctx, &backendapi.AdminSearchQuizzesParams{Q: &query, Page: &page})
+	if err != nil {
+		return nil, err
+	}
+	quizzes, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return quizzes.MapToBusiness(), nil
+}
+func (a *ApiService) AdminDeleteQuiz(quizId string) error {
+	_, err := a.client.AdminDeleteQuizWithResponse(a.ctx, quizId)
+	return err
+}
 
-	responseBody := new(external.ReviewItem)
-	if err := a.getResponse("POST", fmt.Sprintf("/review-items/%s/submit", reviewItemID), requestBody, responseBody); err != nil {
+// A login either creates the session, or returns the challenge to send the
+// code with when two-factor authentication is enabled
+type LoginResult struct {
+	SessionCookie *http.Cookie
+	TotpChallenge string
+}
+
+// Returns the session the backend created with a login or signup, to be set
+// in the browser
+func createdSessionCookie(resp *http.Response) (*http.Cookie, error) {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "session" {
+			return cookie, nil
+		}
+	}
+	return nil, errors.New("session cookie not found")
+}
+
+func (a *ApiService) Login(email, password string, rememberMe bool) (*LoginResult, error) {
+	resp, err := a.client.AuthenticateUserWithResponse(a.ctx, backendapi.LoginRequest{
+		Email:      email,
+		Password:   password,
+		RememberMe: &rememberMe,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON202 != nil {
+		return &LoginResult{TotpChallenge: resp.JSON202.Challenge}, nil
+	}
+	if _, err := expect(resp.JSON200, resp.Status()); err != nil {
+		return nil, err
+	}
+	sessionCookie, err := createdSessionCookie(resp.HTTPResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{SessionCookie: sessionCookie}, nil
+}
+
+func (a *ApiService) LoginTotp(challenge, code string) (*http.Cookie, error) {
+	resp, err := a.client.AuthenticateTotpWithResponse(a.ctx, backendapi.LoginTotpRequest{
+		Challenge: challenge,
+		Code:      code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := expect(resp.JSON200, resp.Status()); err != nil {
 		return nil, err
 	}
+	return createdSessionCookie(resp.HTTPResponse)
+}
 
-	reviewItem, err := responseBody.MapToBusiness()
+func (a *ApiService) SignUp(name, email, password, passwordAgain string) (*http.Cookie, error) {
+	resp, err := a.client.CreateUserWithResponse(a.ctx, backendapi.SignupRequest{
+		Name:          name,
+		Email:         email,
+		Password:      password,
+		PasswordAgain: passwordAgain,
+	})
 	if err != nil {
 		return nil, err
 	}
+	if _, err := expect(resp.JSON200, resp.Status()); err != nil {
+		return nil, err
+	}
+	return createdSessionCookie(resp.HTTPResponse)
+}
+
+func (a *ApiService) VerifyEmail(token string) error {
+	_, err := a.client.VerifyEmailWithResponse(a.ctx, &backendapi.VerifyEmailParams{Token: token})
+	return err
+}
 
-	return reviewItem, nil
+func (a *ApiService) ResendVerification(email string) error {
+	_, err := a.client.ResendVerificationWithResponse(a.ctx, backendapi.ResendVerificationRequest{Email: email})
+	return err
+}
+
+func (a *ApiService) GetOidcProviders() ([]business.OidcProvider, error) {
+	resp, err := a.client.GetOidcProvidersWithResponse(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]business.OidcProvider, len(*providers))
+	for i, provider := range *providers {
+		result[i] = provider.MapToBusiness()
+	}
+	return result, nil
+}
+
+func (a *ApiService) AuthorizeOidc(provider string) (*business.OidcAuthorization, error) {
+	resp, err := a.client.AuthorizeOidcWithResponse(a.ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := expect(resp.JSON200, resp.Status())
+	if err != nil {
+		return nil, err
+	}
+	return authorization.MapToBusiness(), nil
+}
+
+func (a *ApiService) OidcCallback(provider, code, state string) (*LoginResult, error) {
+	resp, err := a.client.OidcCallbackWithResponse(a.ctx, provider, backendapi.OidcCallbackRequest{
+		Code:  code,
+		State: state,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON202 != nil {
+		return &LoginResult{TotpChallenge: resp.JSON202.Challenge}, nil
+	}
+	if _, err := expect(resp.JSON200, resp.Status()); err != nil {
+		return nil, err
+	}
+	sessionCookie, err := createdSessionCookie(resp.HTTPResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{SessionCookie: sessionCookie}, nil
+}
+
+// Returns an error unless the backend is alive
+func (a *ApiService) CheckLiveness() error {
+	resp, err := a.client.LivenessWithResponse(a.ctx)
+	if err != nil {
+		return err
+	}
+	_, err = expect(resp.JSON200, resp.Status())
+	return err
 }
@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"encoding/base64"
+	"github.com/skip2/go-qrcode"
+)
+
+// QRCodeDataURI encodes the content as a PNG QR code that can be used as an image source
+func QRCodeDataURI(content string) (string, error) {
+	png, err := qrcode.Encode(content, qrcode.Medium, 256)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
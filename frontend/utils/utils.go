@@ -3,7 +3,6 @@ package utils
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"net/url"
 	"spaced-ace/models"
 	"strconv"
@@ -74,7 +73,6 @@ func GenerateColors(title string, id string) (string, string) {
 }
 
 func FindInFormData(formData url.Values, name, value string) bool {
-	fmt.Printf("FindInFormData data: %+v, name: %s, value:%s\n", formData, name, value)
 	for key, values := range formData {
 		for _, v := range values {
 			if key == name && v == value {
@@ -87,6 +87,19 @@ async def close_resources():
     await database.close_pool()
 
 
+@app.middleware('http')
+async def add_provider_header(request, call_next):
+    # The backend labels its llm metrics with the provider
+    response = await call_next(request)
+    response.headers['X-LLM-Provider'] = get_provider()
+    return response
+
+
+@app.get('/healthz')
+async def healthz() -> dict:
+    return {'status': 'ok'}
+
+
 @app.post('/multiple-choice/create')
 async def multiple_choice_create(context: Prompt) -> MulipleChoice:
     if MOCK_RESPONSE: